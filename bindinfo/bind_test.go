@@ -828,6 +828,43 @@ func (s *testSuite) TestNotEvolvePlanForReadStorageHint(c *C) {
 	c.Assert(rows[0][3], Equals, "using")
 }
 
+func (s *testSuite) TestLeadingHintBinding(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	s.cleanBindingEnv(tk)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("create table t3(a int, b int)")
+	tk.MustExec("create global binding for select * from t1, t2, t3 where t1.a = t2.a and t2.b = t3.b using select /*+ leading(t3, (t2, t1)) */ * from t1, t2, t3 where t1.a = t2.a and t2.b = t3.b")
+	bindHandle := s.domain.BindHandle()
+	sql, hash := normalizeWithDefaultDB(c, "select * from t1, t2, t3 where t1.a = t2.a and t2.b = t3.b", "test")
+	bindData := bindHandle.GetBindRecord(hash, sql, "test")
+	c.Check(bindData, NotNil)
+	c.Assert(len(bindData.Bindings), Equals, 1)
+	// The nested tables are filled with the default database as well.
+	c.Assert(bindData.Bindings[0].ID, Equals, "leading(@`sel_1` `test`.`t3`, (`test`.`t2`, `test`.`t1`))")
+	rows := tk.MustQuery("show global bindings").Rows()
+	c.Assert(rows, HasLen, 1)
+	c.Assert(rows[0][1], Equals, "SELECT /*+ leading(`t3`, (`t2`, `t1`))*/ * FROM ((`test`.`t1`) JOIN `test`.`t2`) JOIN `test`.`t3` WHERE `t1`.`a` = `t2`.`a` AND `t2`.`b` = `t3`.`b`")
+
+	// t2 and t1 are joined first, then they are joined with t3.
+	tk.MustQuery("explain format = 'brief' select * from t1, t2, t3 where t1.a = t2.a and t2.b = t3.b").Check(testkit.Rows(
+		"Projection 15593.77 root  test.t1.a, test.t1.b, test.t2.a, test.t2.b, test.t3.a, test.t3.b",
+		"└─HashJoin 15593.77 root  inner join, equal:[eq(test.t3.b, test.t2.b)]",
+		"  ├─TableReader(Build) 9990.00 root  data:Selection",
+		"  │ └─Selection 9990.00 cop[tikv]  not(isnull(test.t3.b))",
+		"  │   └─TableFullScan 10000.00 cop[tikv] table:t3 keep order:false, stats:pseudo",
+		"  └─HashJoin(Probe) 12475.01 root  inner join, equal:[eq(test.t2.a, test.t1.a)]",
+		"    ├─TableReader(Build) 9980.01 root  data:Selection",
+		"    │ └─Selection 9980.01 cop[tikv]  not(isnull(test.t2.a)), not(isnull(test.t2.b))",
+		"    │   └─TableFullScan 10000.00 cop[tikv] table:t2 keep order:false, stats:pseudo",
+		"    └─TableReader(Probe) 9990.00 root  data:Selection",
+		"      └─Selection 9990.00 cop[tikv]  not(isnull(test.t1.a))",
+		"        └─TableFullScan 10000.00 cop[tikv] table:t1 keep order:false, stats:pseudo"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+}
+
 func (s *testSuite) TestBindingWithIsolationRead(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	s.cleanBindingEnv(tk)
@@ -43,6 +43,8 @@ const (
 	Capture = "capture"
 	// Evolve indicates the binding is evolved by TiDB from old bindings.
 	Evolve = "evolve"
+	// Guard indicates the binding is created by TiDB to fix the regressed plan of a SQL.
+	Guard = "guard"
 	// Builtin indicates the binding is a builtin record for internal locking purpose. It is also the status for the builtin binding.
 	Builtin = "builtin"
 )
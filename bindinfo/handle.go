@@ -83,6 +83,11 @@ type BindHandle struct {
 
 	// pendingVerifyBindRecordMap indicates the pending verify bind records that found during query.
 	pendingVerifyBindRecordMap tmpBindRecordMap
+
+	// lastPlanHistoryCapture is the last time of capturing the plan history.
+	lastPlanHistoryCapture time.Time
+	// lastPlanRegressionGuard is the last time of guarding the plan regressions.
+	lastPlanRegressionGuard time.Time
 }
 
 // Lease influences the duration of loading bind info and handling invalid bind.
@@ -0,0 +1,298 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/domain/infosync"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	utilparser "github.com/pingcap/tidb/util/parser"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stmtsummary"
+	"go.uber.org/zap"
+)
+
+const (
+	// planHistoryMinExecCount is the minimum execution count of a plan to be recorded in the plan history,
+	// the average latency of a plan executed fewer times is too noisy to be compared.
+	planHistoryMinExecCount = 3
+
+	// guardApplied means the guard binding is in use.
+	guardApplied = "applied"
+	// guardReverted means the guard binding has been dropped or replaced by others, the regressed
+	// plan won't be guarded any more.
+	guardReverted = "reverted"
+)
+
+// historicPlan is a row of mysql.plan_history.
+type historicPlan struct {
+	sqlDigest   string
+	planDigest  string
+	originalSQL string
+	db          string
+	sampleSQL   string
+	planHint    string
+	charset     string
+	collation   string
+	avgLatency  uint64
+}
+
+// CapturePlanHistory records the execution statistics of the plans in the statement summary to
+// mysql.plan_history, so the historic plans of a SQL are still known after they are evicted.
+// The statistics are summarized on each TiDB instance, so every instance records its own rows
+// and GuardPlanRegressions sums them up.
+func (h *BindHandle) CapturePlanHistory() {
+	serverInfo, err := infosync.GetServerInfo()
+	if err != nil {
+		logutil.BgLogger().Warn("[sql-bind] get server info failed in plan history capture", zap.Error(err))
+		return
+	}
+	instance := serverInfo.IP + ":" + strconv.FormatUint(uint64(serverInfo.Port), 10)
+	parser4Capture := parser.New()
+	lastCaptureTime := h.lastPlanHistoryCapture
+	h.lastPlanHistoryCapture = time.Now()
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	for _, plan := range stmtsummary.StmtSummaryByDigestMap.GetBindablePlans(planHistoryMinExecCount) {
+		// The plan is not executed since the last capture, so its statistics are not changed.
+		if plan.PlanHint == "" || plan.LastSeen.Before(lastCaptureTime) {
+			continue
+		}
+		stmt, err := parser4Capture.ParseOneStmt(plan.Query, plan.Charset, plan.Collation)
+		if err != nil {
+			logutil.BgLogger().Debug("[sql-bind] parse SQL failed in plan history capture", zap.String("SQL", plan.Query), zap.Error(err))
+			continue
+		}
+		if insertStmt, ok := stmt.(*ast.InsertStmt); ok && insertStmt.Select == nil {
+			continue
+		}
+		dbName := utilparser.GetDefaultDB(stmt, plan.Schema)
+		normalizedSQL, digest := parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(stmt, dbName, plan.Query))
+		firstSeen := types.NewTime(types.FromGoTime(plan.FirstSeen), mysql.TypeTimestamp, 3)
+		lastSeen := types.NewTime(types.FromGoTime(plan.LastSeen), mysql.TypeTimestamp, 3)
+		stmtNode, err := exec.ParseWithParams(context.TODO(), `INSERT INTO mysql.plan_history VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)
+			ON DUPLICATE KEY UPDATE sample_sql = VALUES(sample_sql), plan_hint = VALUES(plan_hint), exec_count = VALUES(exec_count),
+			avg_latency = VALUES(avg_latency), last_seen = VALUES(last_seen)`,
+			digest.String(),
+			plan.PlanDigest,
+			instance,
+			normalizedSQL,
+			dbName,
+			plan.Query,
+			plan.PlanHint,
+			plan.Charset,
+			plan.Collation,
+			plan.ExecCount,
+			int64(plan.SumLatency)/plan.ExecCount,
+			firstSeen.String(),
+			lastSeen.String(),
+		)
+		if err == nil {
+			_, _, err = exec.ExecRestrictedStmt(context.TODO(), stmtNode)
+		}
+		if err != nil {
+			logutil.BgLogger().Warn("[sql-bind] record plan history failed", zap.String("digest", digest.String()), zap.Error(err))
+		}
+	}
+}
+
+// GuardPlanRegressions compares the latest plan of every SQL executed since the last run with its historic
+// plans in mysql.plan_history. If the latest plan is `tidb_plan_regression_ratio` times slower than the best
+// historic plan, the SQL is bound to the historic plan and the binding is recorded in mysql.plan_regression_guard.
+// Users can review the records and revert one by dropping the binding, the regressed plan won't be guarded again.
+func (h *BindHandle) GuardPlanRegressions() error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ratio, err := getPlanRegressionRatio(exec)
+	if err != nil {
+		return err
+	}
+	if err = h.revertDroppedGuards(exec); err != nil {
+		return err
+	}
+	lastGuardTime := time.Now()
+	sql := new(strings.Builder)
+	sqlexec.MustFormatSQL(sql, `SELECT sql_digest, plan_digest, ANY_VALUE(original_sql), ANY_VALUE(default_db), ANY_VALUE(sample_sql),
+		ANY_VALUE(plan_hint), ANY_VALUE(charset), ANY_VALUE(collation),
+		CAST(SUM(CAST(exec_count AS DECIMAL(20, 0)) * avg_latency) / SUM(exec_count) AS UNSIGNED), MAX(last_seen) AS max_last_seen
+		FROM mysql.plan_history`)
+	if !h.lastPlanRegressionGuard.IsZero() {
+		// The plans are captured every lease on each instance, so the rows written since the last run
+		// may be seen a little earlier than it.
+		since := types.NewTime(types.FromGoTime(h.lastPlanRegressionGuard.Add(-2*Lease)), mysql.TypeTimestamp, 3)
+		sqlexec.MustFormatSQL(sql, " WHERE sql_digest IN (SELECT sql_digest FROM mysql.plan_history WHERE last_seen >= %?)", since.String())
+	}
+	sqlexec.MustFormatSQL(sql, " GROUP BY sql_digest, plan_digest ORDER BY sql_digest, max_last_seen DESC")
+	rows, err := execRestrictedSQL(exec, sql.String())
+	if err != nil {
+		return err
+	}
+	h.lastPlanRegressionGuard = lastGuardTime
+	for i := 0; i < len(rows); {
+		latest := newHistoricPlan(rows[i])
+		var best *historicPlan
+		for i++; i < len(rows) && rows[i].GetString(0) == latest.sqlDigest; i++ {
+			plan := newHistoricPlan(rows[i])
+			if plan.planDigest != latest.planDigest && (best == nil || plan.avgLatency < best.avgLatency) {
+				best = plan
+			}
+		}
+		if best == nil || float64(latest.avgLatency) <= float64(best.avgLatency)*ratio {
+			continue
+		}
+		if err = h.guardPlan(exec, latest, best); err != nil {
+			logutil.BgLogger().Warn("[sql-bind] guard the regressed plan failed", zap.String("digest", latest.sqlDigest), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// guardPlan binds the SQL to the good plan if the regressed plan has not been guarded yet.
+func (h *BindHandle) guardPlan(exec sqlexec.RestrictedSQLExecutor, regressed, good *historicPlan) error {
+	rows, err := execRestrictedSQL(exec, `SELECT id FROM mysql.plan_regression_guard WHERE sql_digest = %? AND regressed_plan_digest = %?`,
+		regressed.sqlDigest, regressed.planDigest)
+	if err != nil || len(rows) > 0 {
+		return err
+	}
+	// Respect the bindings created by users or other features.
+	if r := h.GetBindRecord(regressed.sqlDigest, regressed.originalSQL, regressed.db); r != nil && r.HasUsingBinding() {
+		return nil
+	}
+	stmt, err := parser.New().ParseOneStmt(good.sampleSQL, good.charset, good.collation)
+	if err != nil {
+		return err
+	}
+	bindSQL := GenerateBindSQL(context.TODO(), stmt, good.planHint, true, good.db)
+	if bindSQL == "" {
+		return nil
+	}
+	binding := Binding{
+		BindSQL:   bindSQL,
+		Status:    Using,
+		Charset:   good.charset,
+		Collation: good.collation,
+		Source:    Guard,
+	}
+	// We don't need to pass the `sctx` because the BindSQL is generated from an executed plan.
+	err = h.CreateBindRecord(nil, &BindRecord{OriginalSQL: regressed.originalSQL, Db: regressed.db, Bindings: []Binding{binding}})
+	if err != nil {
+		return err
+	}
+	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+	_, err = execRestrictedSQL(exec, `INSERT INTO mysql.plan_regression_guard (sql_digest, original_sql, default_db, regressed_plan_digest,
+		regressed_avg_latency, good_plan_digest, good_avg_latency, bind_sql, status, create_time, update_time) VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		regressed.sqlDigest, regressed.originalSQL, regressed.db, regressed.planDigest, regressed.avgLatency,
+		good.planDigest, good.avgLatency, bindSQL, guardApplied, now, now)
+	if err != nil {
+		return err
+	}
+	logutil.BgLogger().Info("[sql-bind] bind the regressed SQL to the historic plan",
+		zap.String("digest", regressed.sqlDigest),
+		zap.String("regressedPlanDigest", regressed.planDigest),
+		zap.Uint64("regressedAvgLatency", regressed.avgLatency),
+		zap.String("goodPlanDigest", good.planDigest),
+		zap.Uint64("goodAvgLatency", good.avgLatency),
+	)
+	return nil
+}
+
+// revertDroppedGuards marks the guard records whose bindings are not in use anymore as reverted.
+func (h *BindHandle) revertDroppedGuards(exec sqlexec.RestrictedSQLExecutor) error {
+	rows, err := execRestrictedSQL(exec, `SELECT id, sql_digest, original_sql, default_db, bind_sql FROM mysql.plan_regression_guard WHERE status = %?`, guardApplied)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if r := h.GetBindRecord(row.GetString(1), row.GetString(2), row.GetString(3)); r != nil {
+			inUse := false
+			for _, binding := range r.Bindings {
+				inUse = inUse || (binding.Status == Using && binding.Source == Guard && binding.BindSQL == row.GetString(4))
+			}
+			if inUse {
+				continue
+			}
+		}
+		now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String()
+		_, err = execRestrictedSQL(exec, `UPDATE mysql.plan_regression_guard SET status = %?, update_time = %? WHERE id = %?`, guardReverted, now, row.GetUint64(0))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execRestrictedSQL(exec sqlexec.RestrictedSQLExecutor, sql string, args ...interface{}) ([]chunk.Row, error) {
+	stmt, err := exec.ParseWithParams(context.TODO(), sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	// No need to acquire the session context lock for ExecRestrictedStmt, it
+	// uses another background session.
+	rows, _, err := exec.ExecRestrictedStmt(context.TODO(), stmt)
+	return rows, err
+}
+
+func newHistoricPlan(row chunk.Row) *historicPlan {
+	return &historicPlan{
+		sqlDigest:   row.GetString(0),
+		planDigest:  row.GetString(1),
+		originalSQL: row.GetString(2),
+		db:          row.GetString(3),
+		sampleSQL:   row.GetString(4),
+		planHint:    row.GetString(5),
+		charset:     row.GetString(6),
+		collation:   row.GetString(7),
+		avgLatency:  row.GetUint64(8),
+	}
+}
+
+// GCPlanHistory deletes the historic plans which are not executed in `tidb_plan_history_retention`.
+func (h *BindHandle) GCPlanHistory() error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	retention, err := getPlanHistoryRetention(exec)
+	if err != nil {
+		return err
+	}
+	lastSeen := types.NewTime(types.FromGoTime(time.Now().Add(-retention)), mysql.TypeTimestamp, 3)
+	_, err = execRestrictedSQL(exec, "DELETE FROM mysql.plan_history WHERE last_seen < %?", lastSeen.String())
+	return err
+}
+
+func getPlanHistoryRetention(exec sqlexec.RestrictedSQLExecutor) (time.Duration, error) {
+	rows, err := execRestrictedSQL(exec, "SELECT variable_value FROM mysql.global_variables WHERE variable_name = %?", variable.TiDBPlanHistoryRetention)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return time.ParseDuration(variable.DefTiDBPlanHistoryRetention)
+	}
+	return time.ParseDuration(rows[0].GetString(0))
+}
+
+func getPlanRegressionRatio(exec sqlexec.RestrictedSQLExecutor) (float64, error) {
+	rows, err := execRestrictedSQL(exec, "SELECT variable_value FROM mysql.global_variables WHERE variable_name = %?", variable.TiDBPlanRegressionRatio)
+	if err != nil || len(rows) == 0 {
+		return variable.DefTiDBPlanRegressionRatio, err
+	}
+	return strconv.ParseFloat(rows[0].GetString(0), 64)
+}
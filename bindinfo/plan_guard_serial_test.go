@@ -0,0 +1,111 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/stmtsummary"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRegressionGuard(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int, key idx_b(b), key idx_c(c))")
+
+	// Run the SQL with two plans, the plan using idx_c is the latest one.
+	for _, idx := range []string{"idx_b", "idx_c"} {
+		tk.MustExec("create session binding for select * from t where b = 1 and c > 1 using select /*+ use_index(t, " + idx + ") */ * from t where b = 1 and c > 1")
+		for i := 0; i < 3; i++ {
+			tk.MustQuery("select * from t where b = 1 and c > 1")
+		}
+		tk.MustExec("drop session binding for select * from t where b = 1 and c > 1")
+	}
+	dom.BindHandle().CapturePlanHistory()
+	originalSQL := "select * from `test` . `t` where `b` = ? and `c` > ?"
+	tk.MustQuery("select plan_hint, exec_count from mysql.plan_history where original_sql = ? order by last_seen", originalSQL).Check(testkit.Rows(
+		"use_index(@`sel_1` `test`.`t` `idx_b`) 3",
+		"use_index(@`sel_1` `test`.`t` `idx_c`) 3",
+	))
+
+	// The latest plan is not slow enough to be regarded as a regression.
+	tk.MustExec("update mysql.plan_history set avg_latency = 1000, last_seen = '2021-01-01 00:00:00' where plan_hint like '%idx_b%'")
+	tk.MustExec("update mysql.plan_history set avg_latency = 10000, last_seen = '2021-01-02 00:00:00' where plan_hint like '%idx_c%'")
+	tk.MustExec("set @@global.tidb_plan_regression_ratio = 20")
+	require.NoError(t, dom.BindHandle().GuardPlanRegressions())
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	tk.MustQuery("select * from mysql.plan_regression_guard").Check(testkit.Rows())
+
+	// Only the SQLs executed since the last run are checked again.
+	tk.MustExec("set @@global.tidb_plan_regression_ratio = default")
+	require.NoError(t, dom.BindHandle().GuardPlanRegressions())
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	// The latest plan is executed on another instance, the average latency of all instances is compared.
+	tk.MustExec(`insert into mysql.plan_history select sql_digest, plan_digest, '127.0.0.2:4000', original_sql, default_db, sample_sql,
+		plan_hint, charset, collation, exec_count, 30000, first_seen, now(3) from mysql.plan_history where plan_hint like '%idx_c%'`)
+	require.NoError(t, dom.BindHandle().GuardPlanRegressions())
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, originalSQL, rows[0][0])
+	require.Equal(t, "SELECT /*+ use_index(@`sel_1` `test`.`t` `idx_b`)*/ * FROM `test`.`t` WHERE `b` = 1 AND `c` > 1", rows[0][1])
+	require.Equal(t, "guard", rows[0][8])
+	// The dropped session binding hides the global one, so check it in another session.
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk1.MustQuery("select * from t where b = 1 and c > 1")
+	tk1.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustQuery("select regressed_avg_latency, good_avg_latency, status from mysql.plan_regression_guard").Check(testkit.Rows("20000 1000 applied"))
+
+	// The regressed plan is guarded only once.
+	tk.MustExec("update mysql.plan_history set last_seen = now(3) where plan_hint like '%idx_c%'")
+	require.NoError(t, dom.BindHandle().GuardPlanRegressions())
+	tk.MustQuery("select count(*) from mysql.plan_regression_guard").Check(testkit.Rows("1"))
+
+	// Revert the guard by dropping the binding.
+	tk.MustExec("drop global binding for select * from t where b = 1 and c > 1")
+	require.NoError(t, dom.BindHandle().GuardPlanRegressions())
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	tk.MustQuery("select status from mysql.plan_regression_guard").Check(testkit.Rows("reverted"))
+}
+
+func TestGCPlanHistory(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	insertSQL := "insert into mysql.plan_history values (?, ?, '127.0.0.1:4000', 'select ?', 'test', 'select 1', '', 'utf8mb4', 'utf8mb4_bin', 3, 1000, ?, ?)"
+	tk.MustExec(insertSQL, "d1", "p1", "2021-01-01 00:00:00", "2021-01-01 00:00:00")
+	tk.MustExec(insertSQL, "d1", "p2", "2021-01-01 00:00:00", time.Now().Add(-2*time.Hour).Format("2006-01-02 15:04:05"))
+	tk.MustExec(insertSQL, "d2", "p3", "2021-01-01 00:00:00", time.Now().Format("2006-01-02 15:04:05"))
+
+	// The plans are kept for 7 days by default.
+	require.NoError(t, dom.BindHandle().GCPlanHistory())
+	tk.MustQuery("select plan_digest from mysql.plan_history order by plan_digest").Check(testkit.Rows("p2", "p3"))
+
+	tk.MustExec("set @@global.tidb_plan_history_retention = '1h'")
+	defer tk.MustExec("set @@global.tidb_plan_history_retention = default")
+	require.NoError(t, dom.BindHandle().GCPlanHistory())
+	tk.MustQuery("select plan_digest from mysql.plan_history order by plan_digest").Check(testkit.Rows("p3"))
+}
@@ -16,6 +16,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -32,8 +33,11 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/checkpoints"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/glue"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/br/pkg/lightning/restore"
 	"github.com/pingcap/tidb/br/pkg/lightning/tikv"
+	"github.com/pingcap/tidb/br/pkg/storage"
 )
 
 func main() {
@@ -52,6 +56,8 @@ func run() error {
 		mode, flagImportEngine, flagCleanupEngine   *string
 		cpRemove, cpErrIgnore, cpErrDestroy, cpDump *string
 		localStoringTables                          *bool
+		flagPreCheck                                *bool
+		preCheckFormat                              *string
 
 		fsUsage func()
 	)
@@ -78,6 +84,9 @@ func run() error {
 
 		localStoringTables = fs.Bool("check-local-storage", false, "show tables that are missing local intermediate files (value can be 'all' or '`db`.`table`')")
 
+		flagPreCheck = fs.Bool("precheck", false, "only run the pre-checks against the config without importing any data, and print the report")
+		preCheckFormat = fs.String("precheck-format", "json", "format of the pre-check report, values can be ['json', 'markdown']")
+
 		fsUsage = fs.Usage
 	}))
 
@@ -130,6 +139,9 @@ func run() error {
 	if *localStoringTables {
 		return errors.Trace(getLocalStoringTables(ctx, cfg))
 	}
+	if *flagPreCheck {
+		return errors.Trace(preCheck(ctx, cfg, *preCheckFormat))
+	}
 
 	fsUsage()
 	return nil
@@ -458,3 +470,54 @@ func cleanupEngine(ctx context.Context, cfg *config.Config, tls *common.TLS, eng
 
 	return errors.Trace(ce.Cleanup(ctx))
 }
+
+func preCheck(ctx context.Context, cfg *config.Config, format string) error {
+	if format != "json" && format != "markdown" {
+		return errors.Errorf("invalid pre-check report format %s, must use json or markdown", format)
+	}
+	// all checks are needed in the pre-check.
+	cfg.App.CheckRequirements = true
+
+	db, err := restore.DBFromConfig(ctx, cfg.TiDB)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	g := glue.NewExternalTiDBGlue(db, cfg.TiDB.SQLMode)
+
+	u, err := storage.ParseBackend(cfg.Mydumper.SourceDir, nil)
+	if err != nil {
+		return errors.Annotate(err, "parse backend failed")
+	}
+	s, err := storage.New(ctx, u, &storage.ExternalStorageOptions{})
+	if err != nil {
+		return errors.Annotate(err, "create storage failed")
+	}
+	mdl, err := mydump.NewMyDumpLoaderWithStore(ctx, cfg, s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	rc, err := restore.NewRestoreController(ctx, mdl.GetDatabases(), cfg, &restore.LightningStatus{}, s, g)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer rc.Close()
+
+	report, err := rc.PreCheck(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if format == "json" {
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fmt.Println(string(content))
+	} else {
+		fmt.Print(report.Markdown())
+	}
+	if !report.Passed {
+		return errors.New("tidb-lightning pre-check failed")
+	}
+	return nil
+}
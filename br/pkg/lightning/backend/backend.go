@@ -247,6 +247,11 @@ func MakeBackend(ab AbstractBackend) Backend {
 	return Backend{abstract: ab}
 }
 
+// Inner returns the AbstractBackend behind the Backend.
+func (be Backend) Inner() AbstractBackend {
+	return be.abstract
+}
+
 func (be Backend) Close() {
 	be.abstract.Close()
 }
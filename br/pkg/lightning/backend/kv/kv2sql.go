@@ -17,7 +17,9 @@ package kv
 import (
 	"fmt"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/lightning/metric"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/table"
@@ -64,6 +66,31 @@ func (t *TableKVDecoder) DecodeRawRowDataAsStr(h kv.Handle, value []byte) (res s
 	return fmt.Sprintf("/* ERROR: %s */", err)
 }
 
+// EncodeRawRow re-encodes the raw row data into all KV pairs belonging to the
+// row, i.e. the record and all its index entries.
+func (t *TableKVDecoder) EncodeRawRow(h kv.Handle, value []byte) ([]common.KvPair, error) {
+	row, _, err := t.DecodeRawRowData(h, value)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if common.TableHasAutoRowID(t.tbl.Meta()) {
+		row = append(row, types.NewIntDatum(h.IntValue()))
+	}
+	if _, err = t.tbl.AddRecord(t.se, row); err != nil {
+		return nil, errors.Trace(err)
+	}
+	kvPairs := t.se.takeKvPairs()
+	defer kvPairs.Clear()
+	pairs := make([]common.KvPair, 0, len(kvPairs.pairs))
+	for _, pair := range kvPairs.pairs {
+		pairs = append(pairs, common.KvPair{
+			Key: append([]byte{}, pair.Key...),
+			Val: append([]byte{}, pair.Val...),
+		})
+	}
+	return pairs, nil
+}
+
 func NewTableKVDecoder(tbl table.Table, options *SessionOptions) (*TableKVDecoder, error) {
 	metric.KvEncoderCounter.WithLabelValues("open").Inc()
 	se := newSession(options)
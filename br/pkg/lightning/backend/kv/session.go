@@ -236,6 +236,11 @@ type SessionOptions struct {
 	SysVars   map[string]string
 	// a seed used for tableKvEncoder's auto random bits value
 	AutoRandomSeed int64
+	// FillNullAutoID fills an explicit NULL in an auto-increment or an
+	// auto-random primary key column with a generated ID, like INSERT does.
+	// It is only set for the formats which can't tell a NULL from a missing
+	// value, the NULL is rejected in strict mode otherwise.
+	FillNullAutoID bool
 }
 
 // NewSession creates a new trimmed down Session matching the options.
@@ -62,6 +62,8 @@ type tableKVEncoder struct {
 	genCols     []genCol
 	// convert auto id for shard rowid or auto random id base on row id generated by lightning
 	autoIDFn autoIDConverter
+	// fillNullAutoID is SessionOptions.FillNullAutoID.
+	fillNullAutoID bool
 }
 
 func NewTableKVEncoder(tbl table.Table, options *SessionOptions) (Encoder, error) {
@@ -103,10 +105,11 @@ func NewTableKVEncoder(tbl table.Table, options *SessionOptions) (Encoder, error
 	}
 
 	return &tableKVEncoder{
-		tbl:      tbl,
-		se:       se,
-		genCols:  genCols,
-		autoIDFn: autoIDFn,
+		tbl:            tbl,
+		se:             se,
+		genCols:        genCols,
+		autoIDFn:       autoIDFn,
+		fillNullAutoID: options.FillNullAutoID,
 	}, nil
 }
 
@@ -342,8 +345,13 @@ func (kvcodec *tableKVEncoder) Encode(
 		j := columnPermutation[i]
 		isAutoIncCol := mysql.HasAutoIncrementFlag(col.Flag)
 		isPk := mysql.HasPriKeyFlag(col.Flag)
+		hasValue := j >= 0 && j < len(row)
+		// like INSERT, a NULL auto-increment or auto-random column is filled with a generated ID.
+		if kvcodec.fillNullAutoID && hasValue && row[j].IsNull() && (isAutoIncCol || isAutoRandom && isPk) {
+			hasValue = false
+		}
 		switch {
-		case j >= 0 && j < len(row):
+		case hasValue:
 			value, err = table.CastValue(kvcodec.se, row[j], col.ToInfo(), false, false)
 			if err == nil {
 				err = col.HandleBadNull(&value, kvcodec.se.vars.StmtCtx)
@@ -224,6 +224,14 @@ func (s *kvSuite) TestDecodeIndex(c *C) {
 	rawData, _, err := decoder.DecodeRawRowData(h1, data.pairs[0].Val)
 	c.Assert(err, IsNil)
 	c.Assert(rawData, DeepEquals, rows)
+
+	encoded, err := decoder.EncodeRawRow(h1, data.pairs[0].Val)
+	c.Assert(err, IsNil)
+	c.Assert(encoded, HasLen, len(data.pairs))
+	for i, pair := range encoded {
+		c.Assert(pair.Key, DeepEquals, data.pairs[i].Key)
+		c.Assert(pair.Val, DeepEquals, data.pairs[i].Val)
+	}
 }
 
 func (s *kvSuite) TestEncodeRowFormatV2(c *C) {
@@ -342,6 +350,38 @@ func (s *kvSuite) TestEncodeDoubleAutoIncrement(c *C) {
 	c.Assert(tbl.Allocators(encoder.(*tableKVEncoder).se).Get(autoid.AutoIncrementType).Base(), Equals, int64(70))
 }
 
+func (s *kvSuite) TestEncodeNullAutoIncrement(c *C) {
+	tblInfo := mockTableInfo(c, "create table t (id int not null auto_increment primary key, a int);")
+	tbl, err := tables.TableFromMeta(NewPanickingAllocators(0), tblInfo)
+	c.Assert(err, IsNil)
+
+	logger := log.Logger{Logger: zap.NewNop()}
+	encoder, err := NewTableKVEncoder(tbl, &SessionOptions{
+		SQLMode:        mysql.ModeStrictAllTables,
+		SysVars:        map[string]string{"tidb_row_format_version": "2"},
+		FillNullAutoID: true,
+	})
+	c.Assert(err, IsNil)
+
+	// a NULL id is filled with the row ID, the same as an omitted id.
+	pairs, err := encoder.Encode(logger, []types.Datum{types.NewDatum(nil), types.NewIntDatum(1)}, 70, []int{0, 1, -1}, "1.jsonl", 1234)
+	c.Assert(err, IsNil)
+	expected, err := encoder.Encode(logger, []types.Datum{types.NewIntDatum(1)}, 70, []int{-1, 0, -1}, "1.jsonl", 1234)
+	c.Assert(err, IsNil)
+	c.Assert(pairs, DeepEquals, expected)
+	c.Assert(tbl.Allocators(encoder.(*tableKVEncoder).se).Get(autoid.AutoIncrementType).Base(), Equals, int64(70))
+
+	// CSV and SQL files can omit a column, so an explicit NULL is kept and
+	// rejected in strict mode.
+	encoder, err = NewTableKVEncoder(tbl, &SessionOptions{
+		SQLMode: mysql.ModeStrictAllTables,
+		SysVars: map[string]string{"tidb_row_format_version": "2"},
+	})
+	c.Assert(err, IsNil)
+	_, err = encoder.Encode(logger, []types.Datum{types.NewDatum(nil), types.NewIntDatum(1)}, 71, []int{0, 1, -1}, "1.csv", 1234)
+	c.Assert(err, ErrorMatches, ".*Column 'id' cannot be null.*")
+}
+
 func mockTableInfo(c *C, createSQL string) *model.TableInfo {
 	parser := parser.New()
 	node, err := parser.ParseOneStmt(createSQL, "", "")
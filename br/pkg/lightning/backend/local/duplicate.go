@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/kv"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/br/pkg/lightning/errormanager"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
 	"github.com/pingcap/tidb/br/pkg/logutil"
@@ -53,6 +54,9 @@ import (
 
 const (
 	maxGetRequestKeyCount = 1024
+	// maxResolveBatchCount is the maximum number of conflicts resolved in a
+	// single transaction.
+	maxResolveBatchCount = 256
 )
 
 type DuplicateRequest struct {
@@ -71,6 +75,12 @@ type DuplicateManager struct {
 	ts                uint64
 	keyAdapter        KeyAdapter
 	remoteWorkerPool  *utils.WorkerPool
+	// resolution is the algorithm to resolve the duplicates, see
+	// `tikv-importer.duplicate-resolution`.
+	resolution string
+	// store is used to remove the rows losing the conflicts, it is nil if the
+	// duplicates are not resolved.
+	store tidbkv.Storage
 }
 
 type pendingIndexHandles struct {
@@ -180,14 +190,28 @@ func physicalTableIDs(tableInfo *model.TableInfo) []int64 {
 // NewDuplicateManager creates a new *DuplicateManager.
 //
 // This object provides methods to collect and decode duplicated KV pairs into row data. The results
-// are stored into the errorMgr.
+// are stored into the errorMgr. If the resolution is config.ReplaceOnDup or config.IgnoreOnDup, the
+// rows losing the conflicts are also removed from TiKV through the store.
 func NewDuplicateManager(
 	errorMgr *errormanager.ErrorManager,
 	splitCli restore.SplitClient,
 	ts uint64,
 	tls *common.TLS,
-	regionConcurrency int) (*DuplicateManager, error) {
+	regionConcurrency int,
+	resolution string,
+	store tidbkv.Storage,
+) (*DuplicateManager, error) {
+	switch resolution {
+	case config.ReplaceOnDup, config.IgnoreOnDup:
+		if store == nil {
+			return nil, errors.Errorf("a storage is required to resolve the duplicates by '%s'", resolution)
+		}
+	default:
+		store = nil
+	}
 	return &DuplicateManager{
+		resolution:        resolution,
+		store:             store,
 		errorMgr:          errorMgr,
 		tls:               tls,
 		regionConcurrency: regionConcurrency,
@@ -251,6 +275,9 @@ func (manager *DuplicateManager) sendRequestToTiKV(ctx context.Context,
 	}
 	tryTimes := 0
 	indexHandles := makePendingIndexHandlesWithCapacity(0)
+	// the keys in TiKV may have been overwritten by the imported data, so the
+	// winners of the conflicts should be written back.
+	resolver := manager.newConflictResolver(decoder, true)
 	for len(regions) > 0 {
 		if tryTimes > maxRetryTimes {
 			return errors.Errorf("retry time exceed limit")
@@ -350,6 +377,9 @@ func (manager *DuplicateManager) sendRequestToTiKV(ctx context.Context,
 				if err != nil {
 					return err
 				}
+				if err := manager.resolveRemoteDuplicates(ctx, resolver, resp.Pairs, req.indexInfo); err != nil {
+					return err
+				}
 				if handles.Len() > 0 {
 					indexHandles.extend(&handles)
 				}
@@ -363,7 +393,7 @@ func (manager *DuplicateManager) sendRequestToTiKV(ctx context.Context,
 		}
 		regions = unfinishedRegions
 	}
-	return nil
+	return resolver.flush(ctx)
 }
 
 func (manager *DuplicateManager) storeDuplicateData(
@@ -435,6 +465,9 @@ func (manager *DuplicateManager) CollectDuplicateRowsFromLocalIndex(
 
 	allRanges := make([]tidbkv.KeyRange, 0)
 	tableIDs := physicalTableIDs(tbl.Meta())
+	// the winners of the local conflicts are the ones ingested, so only the
+	// losers need to be removed.
+	resolver := manager.newConflictResolver(decoder, false)
 	// Collect row handle duplicates.
 	var dataConflictInfos []errormanager.DataConflictInfo
 	hasDataConflict := false
@@ -492,6 +525,9 @@ func (manager *DuplicateManager) CollectDuplicateRowsFromLocalIndex(
 			}(); err != nil {
 				return false, errors.Trace(err)
 			}
+			if err := manager.resolveLocalDuplicates(ctx, resolver, db, opts, nil); err != nil {
+				return false, errors.Trace(err)
+			}
 			db.DeleteRange(startKey, endKey, &pebble.WriteOptions{Sync: false})
 		}
 	}
@@ -560,6 +596,9 @@ func (manager *DuplicateManager) CollectDuplicateRowsFromLocalIndex(
 				if handles.Len() > 0 {
 					handles = manager.getValues(ctx, decoder, handles)
 				}
+				if err := manager.resolveLocalDuplicates(ctx, resolver, db, opts, indexInfo); err != nil {
+					return err
+				}
 				if handles.Len() == 0 {
 					db.DeleteRange(startKey, endKey, &pebble.WriteOptions{Sync: false})
 				}
@@ -576,6 +615,9 @@ func (manager *DuplicateManager) CollectDuplicateRowsFromLocalIndex(
 	if handles.Len() > 0 {
 		return false, errors.Errorf("retry getValues time exceed limit")
 	}
+	if err := resolver.flush(ctx); err != nil {
+		return false, errors.Trace(err)
+	}
 	for _, r := range allRanges {
 		startKey := codec.EncodeBytes([]byte{}, r.StartKey)
 		endKey := codec.EncodeBytes([]byte{}, r.EndKey)
@@ -584,6 +626,221 @@ func (manager *DuplicateManager) CollectDuplicateRowsFromLocalIndex(
 	return hasDataConflict, nil
 }
 
+// conflictRow is a row involved in a conflict of the primary key or an unique key.
+type conflictRow struct {
+	handle tidbkv.Handle
+	// rawRow is the record value of the row, it is nil if the row should be
+	// read from TiKV.
+	rawRow []byte
+}
+
+// conflictResolver removes the rows losing the conflicts from TiKV.
+type conflictResolver struct {
+	store    tidbkv.Storage
+	decoder  *kv.TableKVDecoder
+	keepLast bool
+	// writeWinner indicates whether to write back the KV pairs of the winner,
+	// which is needed if they could have been overwritten by the losers.
+	writeWinner bool
+
+	txn     tidbkv.Transaction
+	pending int
+}
+
+// newConflictResolver creates a conflictResolver, it returns nil if the
+// duplicates are not resolved.
+func (manager *DuplicateManager) newConflictResolver(decoder *kv.TableKVDecoder, writeWinner bool) *conflictResolver {
+	if manager.store == nil {
+		return nil
+	}
+	return &conflictResolver{
+		store:       manager.store,
+		decoder:     decoder,
+		keepLast:    manager.resolution == config.ReplaceOnDup,
+		writeWinner: writeWinner,
+	}
+}
+
+// resolve keeps the first or the last row in rows, which should be in the
+// order of precedence, and removes the KV pairs of the other rows not shared
+// with the kept one.
+func (r *conflictResolver) resolve(ctx context.Context, rows []conflictRow) error {
+	if r.txn == nil {
+		txn, err := r.store.Begin()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		r.txn = txn
+	}
+
+	candidates := make([]conflictRow, 0, len(rows))
+	for _, row := range rows {
+		if row.rawRow == nil {
+			value, err := r.txn.Get(ctx, r.decoder.EncodeHandleKey(row.handle))
+			if tidbkv.IsErrNotFound(err) {
+				// the row has already been removed by another conflict.
+				continue
+			}
+			if err != nil {
+				return errors.Trace(err)
+			}
+			row.rawRow = value
+		}
+		candidates = append(candidates, row)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	winnerIdx := 0
+	if r.keepLast {
+		winnerIdx = len(candidates) - 1
+	}
+	winner := candidates[winnerIdx]
+	winnerPairs, err := r.decoder.EncodeRawRow(winner.handle, winner.rawRow)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	winnerKeys := make(map[string]struct{}, len(winnerPairs))
+	for _, pair := range winnerPairs {
+		winnerKeys[string(pair.Key)] = struct{}{}
+	}
+	for i, row := range candidates {
+		if i == winnerIdx {
+			continue
+		}
+		pairs, err := r.decoder.EncodeRawRow(row.handle, row.rawRow)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, pair := range pairs {
+			if _, ok := winnerKeys[string(pair.Key)]; ok {
+				continue
+			}
+			if err := r.txn.Delete(pair.Key); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if r.writeWinner {
+		for _, pair := range winnerPairs {
+			if err := r.txn.Set(pair.Key, pair.Val); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	r.pending++
+	if r.pending >= maxResolveBatchCount {
+		return r.flush(ctx)
+	}
+	return nil
+}
+
+// flush commits the pending changes.
+func (r *conflictResolver) flush(ctx context.Context) error {
+	if r == nil || r.txn == nil {
+		return nil
+	}
+	txn := r.txn
+	r.txn = nil
+	r.pending = 0
+	return errors.Trace(txn.Commit(ctx))
+}
+
+// decodeConflictRow decodes the row a conflicting KV pair belongs to.
+func decodeConflictRow(decoder *kv.TableKVDecoder, indexInfo *model.IndexInfo, key, value []byte) (conflictRow, error) {
+	if indexInfo != nil {
+		h, err := decoder.DecodeHandleFromIndex(indexInfo, key, value)
+		return conflictRow{handle: h}, errors.Trace(err)
+	}
+	h, err := decoder.DecodeHandleFromTable(key)
+	return conflictRow{handle: h, rawRow: value}, errors.Trace(err)
+}
+
+// resolveLocalDuplicates resolves the duplicates recorded in the duplicate db
+// within the range. The versions of the same key are sorted in the source
+// order by the duplicateKeyAdapter.
+func (manager *DuplicateManager) resolveLocalDuplicates(
+	ctx context.Context,
+	resolver *conflictResolver,
+	db *pebble.DB,
+	opts *pebble.IterOptions,
+	indexInfo *model.IndexInfo,
+) error {
+	if resolver == nil {
+		return nil
+	}
+	iter := db.NewIter(opts)
+	defer iter.Close()
+
+	var curKey []byte
+	var rows []conflictRow
+	for iter.First(); iter.Valid(); iter.Next() {
+		rawKey, _, _, err := manager.keyAdapter.Decode(nil, iter.Key())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(rows) > 0 && !bytes.Equal(rawKey, curKey) {
+			if err := resolver.resolve(ctx, rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+		curKey = rawKey
+		row, err := decodeConflictRow(resolver.decoder, indexInfo, rawKey, append([]byte{}, iter.Value()...))
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	if err := iter.Error(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) > 0 {
+		return resolver.resolve(ctx, rows)
+	}
+	return nil
+}
+
+// resolveRemoteDuplicates resolves the duplicates found in TiKV. The versions
+// of the same key are ordered by their commit TS, so the existing row precedes
+// the imported one.
+func (manager *DuplicateManager) resolveRemoteDuplicates(
+	ctx context.Context,
+	resolver *conflictResolver,
+	pairs []*import_sstpb.KvPair,
+	indexInfo *model.IndexInfo,
+) error {
+	if resolver == nil || len(pairs) == 0 {
+		return nil
+	}
+	sorted := make([]*import_sstpb.KvPair, len(pairs))
+	copy(sorted, pairs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if cmp := bytes.Compare(sorted[i].Key, sorted[j].Key); cmp != 0 {
+			return cmp < 0
+		}
+		return sorted[i].CommitTs < sorted[j].CommitTs
+	})
+
+	var rows []conflictRow
+	for i, pair := range sorted {
+		if i > 0 && !bytes.Equal(pair.Key, sorted[i-1].Key) {
+			if err := resolver.resolve(ctx, rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
+		}
+		row, err := decodeConflictRow(resolver.decoder, indexInfo, pair.Key, pair.Value)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+	return resolver.resolve(ctx, rows)
+}
+
 func (manager *DuplicateManager) getValues(
 	ctx context.Context,
 	decoder *kv.TableKVDecoder,
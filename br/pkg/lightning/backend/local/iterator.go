@@ -21,6 +21,7 @@ import (
 	"github.com/cockroachdb/pebble"
 	sst "github.com/pingcap/kvproto/pkg/import_sstpb"
 	"github.com/pingcap/tidb/br/pkg/kv"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
 	"github.com/pingcap/tidb/br/pkg/logutil"
 	"github.com/pingcap/tidb/util/codec"
@@ -50,43 +51,81 @@ type duplicateIter struct {
 	curRawKey []byte
 	curVal    []byte
 	nextKey   []byte
+	valid     bool
 	err       error
 
 	engineFile     *File
 	keyAdapter     KeyAdapter
 	writeBatch     *pebble.Batch
 	writeBatchSize int64
+	// keepLast indicates to return the last duplicated pair in source order
+	// instead of the first one.
+	keepLast bool
 }
 
 func (d *duplicateIter) Seek(key []byte) bool {
 	encodedKey := d.keyAdapter.Encode(nil, key, 0, 0)
 	if d.err != nil || !d.iter.SeekGE(encodedKey) {
+		d.valid = false
 		return false
 	}
 	d.fill()
-	return d.err == nil
+	return d.Valid()
 }
 
 func (d *duplicateIter) First() bool {
 	if d.err != nil || !d.iter.First() {
+		d.valid = false
 		return false
 	}
 	d.fill()
-	return d.err == nil
+	return d.Valid()
 }
 
 func (d *duplicateIter) Last() bool {
 	if d.err != nil || !d.iter.Last() {
+		d.valid = false
 		return false
 	}
 	d.fill()
-	return d.err == nil
+	return d.Valid()
 }
 
+// fill reads the pair at the current position, and then consumes all pairs
+// with the same key, recording them as duplicates. After that, the underlying
+// iterator is positioned at the next key.
 func (d *duplicateIter) fill() {
+	d.valid = true
 	d.curKey, _, _, d.err = d.keyAdapter.Decode(d.curKey[:0], d.iter.Key())
+	if d.err != nil {
+		return
+	}
 	d.curRawKey = append(d.curRawKey[:0], d.iter.Key()...)
 	d.curVal = append(d.curVal[:0], d.iter.Value()...)
+
+	recordFirst := false
+	for d.err == nil && d.ctx.Err() == nil && d.iter.Next() {
+		d.nextKey, _, _, d.err = d.keyAdapter.Decode(d.nextKey[:0], d.iter.Key())
+		if d.err != nil {
+			return
+		}
+		if !bytes.Equal(d.nextKey, d.curKey) {
+			return
+		}
+		log.L().Debug("duplicate key detected", logutil.Key("key", d.curKey))
+		if !recordFirst {
+			d.record(d.curRawKey, d.curVal)
+			recordFirst = true
+		}
+		d.record(d.iter.Key(), d.iter.Value())
+		if d.keepLast {
+			d.curRawKey = append(d.curRawKey[:0], d.iter.Key()...)
+			d.curVal = append(d.curVal[:0], d.iter.Value()...)
+		}
+	}
+	if d.err == nil {
+		d.err = d.ctx.Err()
+	}
 }
 
 func (d *duplicateIter) flush() {
@@ -108,29 +147,12 @@ func (d *duplicateIter) record(key []byte, val []byte) {
 }
 
 func (d *duplicateIter) Next() bool {
-	recordFirst := false
-	for d.err == nil && d.ctx.Err() == nil && d.iter.Next() {
-		d.nextKey, _, _, d.err = d.keyAdapter.Decode(d.nextKey[:0], d.iter.Key())
-		if d.err != nil {
-			return false
-		}
-		if !bytes.Equal(d.nextKey, d.curKey) {
-			d.curKey, d.nextKey = d.nextKey, d.curKey[:0]
-			d.curRawKey = append(d.curRawKey[:0], d.iter.Key()...)
-			d.curVal = append(d.curVal[:0], d.iter.Value()...)
-			return true
-		}
-		log.L().Debug("duplicate key detected", logutil.Key("key", d.curKey))
-		if !recordFirst {
-			d.record(d.curRawKey, d.curVal)
-			recordFirst = true
-		}
-		d.record(d.iter.Key(), d.iter.Value())
-	}
-	if d.err == nil {
-		d.err = d.ctx.Err()
+	if d.err != nil || !d.iter.Valid() {
+		d.valid = false
+		return false
 	}
-	return false
+	d.fill()
+	return d.Valid()
 }
 
 func (d *duplicateIter) Key() []byte {
@@ -142,7 +164,7 @@ func (d *duplicateIter) Value() []byte {
 }
 
 func (d *duplicateIter) Valid() bool {
-	return d.err == nil && d.iter.Valid()
+	return d.err == nil && d.valid
 }
 
 func (d *duplicateIter) Error() error {
@@ -177,6 +199,7 @@ func newDuplicateIter(ctx context.Context, engineFile *File, opts *pebble.IterOp
 		engineFile: engineFile,
 		keyAdapter: engineFile.keyAdapter,
 		writeBatch: engineFile.duplicateDB.NewBatch(),
+		keepLast:   engineFile.duplicateResolution == config.ReplaceOnDup,
 	}
 }
 
@@ -24,7 +24,9 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
 )
 
 type iteratorSuite struct{}
@@ -252,3 +254,127 @@ func (s *iteratorSuite) TestDuplicateIterSeek(c *C) {
 	c.Assert(engineFile.Close(), IsNil)
 	c.Assert(duplicateDB.Close(), IsNil)
 }
+
+func (s *iteratorSuite) TestFirstLocalDuplicateKey(c *C) {
+	keys := [][]byte{{1, 2, 3, 0}, {1, 2, 3, 1}, {1, 2, 3, 1}, {1, 2, 3, 2}, {1, 2, 4, 0}, {1, 2, 4, 0}}
+
+	storeDir := c.MkDir()
+	db, err := pebble.Open(filepath.Join(storeDir, "kv"), &pebble.Options{})
+	c.Assert(err, IsNil)
+
+	keyAdapter := duplicateKeyAdapter{}
+	wb := db.NewBatch()
+	for i, key := range keys {
+		c.Assert(wb.Set(keyAdapter.Encode(nil, key, int64(i), 0), randBytes(128), nil), IsNil)
+	}
+	c.Assert(wb.Commit(pebble.Sync), IsNil)
+
+	duplicateDB, err := pebble.Open(filepath.Join(storeDir, "duplicates"), &pebble.Options{})
+	c.Assert(err, IsNil)
+	engineFile := &File{
+		ctx:         context.Background(),
+		db:          db,
+		keyAdapter:  keyAdapter,
+		duplicateDB: duplicateDB,
+	}
+	iter := newDuplicateIter(context.Background(), engineFile, &pebble.IterOptions{})
+	for iter.First(); iter.Valid(); iter.Next() {
+	}
+	c.Assert(iter.Error(), IsNil)
+	c.Assert(iter.Close(), IsNil)
+
+	be := backend.MakeBackend(&local{duplicateDB: duplicateDB})
+	key, err := FirstLocalDuplicateKey(be, []byte{1, 2, 3}, []byte{1, 2, 4})
+	c.Assert(err, IsNil)
+	c.Assert(key, BytesEquals, []byte{1, 2, 3, 1})
+	key, err = FirstLocalDuplicateKey(be, []byte{1, 2, 3, 2}, []byte{1, 2, 4})
+	c.Assert(err, IsNil)
+	c.Assert(key, IsNil)
+	key, err = FirstLocalDuplicateKey(be, []byte{1, 2, 4}, []byte{1, 2, 5})
+	c.Assert(err, IsNil)
+	c.Assert(key, BytesEquals, []byte{1, 2, 4, 0})
+
+	_, err = FirstLocalDuplicateKey(backend.MakeBackend(&local{}), []byte{1}, []byte{2})
+	c.Assert(err, ErrorMatches, "duplicate detection of the local backend is not enabled")
+
+	c.Assert(engineFile.Close(), IsNil)
+	c.Assert(duplicateDB.Close(), IsNil)
+}
+
+func (s *iteratorSuite) TestDuplicateIterKeepLast(c *C) {
+	pairs := []common.KvPair{
+		{
+			Key:    []byte{1, 2, 3, 0},
+			Val:    randBytes(128),
+			RowID:  1,
+			Offset: 0,
+		},
+		{
+			Key:    []byte{1, 2, 3, 1},
+			Val:    randBytes(128),
+			RowID:  2,
+			Offset: 100,
+		},
+		{
+			Key:    []byte{1, 2, 3, 1},
+			Val:    randBytes(128),
+			RowID:  3,
+			Offset: 200,
+		},
+		{
+			Key:    []byte{1, 2, 3, 1},
+			Val:    randBytes(128),
+			RowID:  4,
+			Offset: 300,
+		},
+		{
+			Key:    []byte{1, 2, 3, 2},
+			Val:    randBytes(128),
+			RowID:  5,
+			Offset: 400,
+		},
+	}
+
+	storeDir := c.MkDir()
+	db, err := pebble.Open(filepath.Join(storeDir, "kv"), &pebble.Options{})
+	c.Assert(err, IsNil)
+
+	keyAdapter := duplicateKeyAdapter{}
+	wb := db.NewBatch()
+	for _, p := range pairs {
+		key := keyAdapter.Encode(nil, p.Key, p.RowID, p.Offset)
+		c.Assert(wb.Set(key, p.Val, nil), IsNil)
+	}
+	c.Assert(wb.Commit(pebble.Sync), IsNil)
+
+	duplicateDB, err := pebble.Open(filepath.Join(storeDir, "duplicates"), &pebble.Options{})
+	c.Assert(err, IsNil)
+	engineFile := &File{
+		ctx:                 context.Background(),
+		db:                  db,
+		keyAdapter:          keyAdapter,
+		duplicateDB:         duplicateDB,
+		duplicateResolution: config.ReplaceOnDup,
+	}
+	iter := newDuplicateIter(context.Background(), engineFile, &pebble.IterOptions{})
+
+	// the last version of the duplicated key is kept.
+	var values [][]byte
+	for iter.First(); iter.Valid(); iter.Next() {
+		values = append(values, append([]byte{}, iter.Value()...))
+	}
+	c.Assert(iter.Error(), IsNil)
+	c.Assert(values, DeepEquals, [][]byte{pairs[0].Val, pairs[3].Val, pairs[4].Val})
+	c.Assert(iter.Close(), IsNil)
+	c.Assert(engineFile.Close(), IsNil)
+
+	// all versions of the duplicated key are recorded.
+	dupIter := duplicateDB.NewIter(&pebble.IterOptions{})
+	var detected [][]byte
+	for dupIter.First(); dupIter.Valid(); dupIter.Next() {
+		detected = append(detected, append([]byte{}, dupIter.Value()...))
+	}
+	c.Assert(dupIter.Close(), IsNil)
+	c.Assert(detected, DeepEquals, [][]byte{pairs[1].Val, pairs[2].Val, pairs[3].Val})
+	c.Assert(duplicateDB.Close(), IsNil)
+}
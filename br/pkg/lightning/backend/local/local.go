@@ -61,10 +61,13 @@ import (
 	split "github.com/pingcap/tidb/br/pkg/restore"
 	"github.com/pingcap/tidb/br/pkg/utils"
 	"github.com/pingcap/tidb/br/pkg/version"
+	tidbkv "github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/store/driver"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/hack"
+	tikvconfig "github.com/tikv/client-go/v2/config"
 	"github.com/tikv/client-go/v2/oracle"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/atomic"
@@ -200,10 +203,11 @@ type File struct {
 	importedKVSize  atomic.Int64
 	importedKVCount atomic.Int64
 
-	keyAdapter         KeyAdapter
-	duplicateDetection bool
-	duplicateDB        *pebble.DB
-	errorMgr           *errormanager.ErrorManager
+	keyAdapter          KeyAdapter
+	duplicateDetection  bool
+	duplicateResolution string
+	duplicateDB         *pebble.DB
+	errorMgr            *errormanager.ErrorManager
 }
 
 func (e *File) setError(err error) {
@@ -819,9 +823,13 @@ type local struct {
 	localWriterMemCacheSize int64
 	supportMultiIngest      bool
 
-	duplicateDetection bool
-	duplicateDB        *pebble.DB
-	errorMgr           *errormanager.ErrorManager
+	duplicateDetection  bool
+	duplicateResolution string
+	duplicateDB         *pebble.DB
+	errorMgr            *errormanager.ErrorManager
+	// kvStore is used to remove the conflicting rows, it is nil unless the
+	// duplicates are resolved by replace or ignore.
+	kvStore tidbkv.Storage
 }
 
 // connPool is a lazy pool of gRPC channels.
@@ -937,6 +945,14 @@ func NewLocalBackend(
 		}
 	}
 
+	var kvStore tidbkv.Storage
+	if cfg.DuplicateResolution == config.ReplaceOnDup || cfg.DuplicateResolution == config.IgnoreOnDup {
+		kvStore, err = openKVStore(tls, pdAddr)
+		if err != nil {
+			return backend.MakeBackend(nil), errors.Annotate(err, "open tikv storage failed")
+		}
+	}
+
 	local := &local{
 		engines:  sync.Map{},
 		pdCtl:    pdCtl,
@@ -956,8 +972,10 @@ func NewLocalBackend(
 		engineMemCacheSize:      int(cfg.EngineMemCacheSize),
 		localWriterMemCacheSize: int64(cfg.LocalWriterMemCacheSize),
 		duplicateDetection:      cfg.DuplicateDetection,
+		duplicateResolution:     cfg.DuplicateResolution,
 		duplicateDB:             duplicateDB,
 		errorMgr:                errorMgr,
+		kvStore:                 kvStore,
 	}
 	local.conns = common.NewGRPCConns()
 	if err = local.checkMultiIngestSupport(ctx, pdCtl); err != nil {
@@ -967,6 +985,14 @@ func NewLocalBackend(
 	return backend.MakeBackend(local), nil
 }
 
+// openKVStore opens the TiKV storage of the cluster.
+func openKVStore(tls *common.TLS, pdAddr string) (tidbkv.Storage, error) {
+	tlsOpt := tls.ToPDSecurityOption()
+	security := tikvconfig.NewSecurity(tlsOpt.CAPath, tlsOpt.CertPath, tlsOpt.KeyPath, nil)
+	store, err := driver.TiKVDriver{}.OpenWithOptions(fmt.Sprintf("tikv://%s?disableGC=true", pdAddr), driver.WithSecurity(security))
+	return store, errors.Trace(err)
+}
+
 func (local *local) checkMultiIngestSupport(ctx context.Context, pdCtl *pdutil.PdController) error {
 	stores, err := pdCtl.GetPDClient().GetAllStores(ctx, pd.WithExcludeTombstone())
 	if err != nil {
@@ -1165,6 +1191,13 @@ func (local *local) Close() {
 		local.duplicateDB = nil
 	}
 
+	if local.kvStore != nil {
+		if err := local.kvStore.Close(); err != nil {
+			log.L().Warn("close tikv storage failed", zap.Error(err))
+		}
+		local.kvStore = nil
+	}
+
 	// if checkpoint is disable or we finish load all data successfully, then files in this
 	// dir will be useless, so we clean up this dir and all files in it.
 	if !local.checkpointEnabled || common.IsEmptyDir(local.localStoreDir) {
@@ -1272,17 +1305,18 @@ func (local *local) OpenEngine(ctx context.Context, cfg *backend.EngineConfig, e
 		keyAdapter = duplicateKeyAdapter{}
 	}
 	e, _ := local.engines.LoadOrStore(engineUUID, &File{
-		UUID:               engineUUID,
-		sstDir:             sstDir,
-		sstMetasChan:       make(chan metaOrFlush, 64),
-		ctx:                engineCtx,
-		cancel:             cancel,
-		config:             engineCfg,
-		tableInfo:          cfg.TableInfo,
-		duplicateDetection: local.duplicateDetection,
-		duplicateDB:        local.duplicateDB,
-		errorMgr:           local.errorMgr,
-		keyAdapter:         keyAdapter,
+		UUID:                engineUUID,
+		sstDir:              sstDir,
+		sstMetasChan:        make(chan metaOrFlush, 64),
+		ctx:                 engineCtx,
+		cancel:              cancel,
+		config:              engineCfg,
+		tableInfo:           cfg.TableInfo,
+		duplicateDetection:  local.duplicateDetection,
+		duplicateResolution: local.duplicateResolution,
+		duplicateDB:         local.duplicateDB,
+		errorMgr:            local.errorMgr,
+		keyAdapter:          keyAdapter,
 	})
 	engine := e.(*File)
 	engine.db = db
@@ -2076,7 +2110,8 @@ func (local *local) CollectLocalDuplicateRows(ctx context.Context, tbl table.Tab
 		return false, err
 	}
 	ts := oracle.ComposeTS(physicalTS, logicalTS)
-	duplicateManager, err := NewDuplicateManager(local.errorMgr, local.splitCli, ts, local.tls, local.tcpConcurrency)
+	duplicateManager, err := NewDuplicateManager(local.errorMgr, local.splitCli, ts, local.tls, local.tcpConcurrency,
+		local.duplicateResolution, local.kvStore)
 	if err != nil {
 		return false, errors.Annotate(err, "open duplicatemanager failed")
 	}
@@ -2084,7 +2119,27 @@ func (local *local) CollectLocalDuplicateRows(ctx context.Context, tbl table.Tab
 	if err != nil {
 		return false, errors.Annotate(err, "collect local duplicate rows failed")
 	}
-	return hasDupe, nil
+	return hasDupe, local.checkDuplicateResolution(hasDupe, tbl)
+}
+
+// FirstLocalDuplicateKey returns the first duplicate key in [startKey, endKey) recorded by the engines of
+// the local backend when they are imported, or nil if there is none. The backend must be created with
+// DuplicateDetection enabled.
+func FirstLocalDuplicateKey(be backend.Backend, startKey, endKey []byte) ([]byte, error) {
+	local, ok := be.Inner().(*local)
+	if !ok || local.duplicateDB == nil {
+		return nil, errors.New("duplicate detection of the local backend is not enabled")
+	}
+	iter := local.duplicateDB.NewIter(&pebble.IterOptions{
+		LowerBound: codec.EncodeBytes(nil, startKey),
+		UpperBound: codec.EncodeBytes(nil, endKey),
+	})
+	defer iter.Close()
+	if !iter.First() {
+		return nil, errors.Trace(iter.Error())
+	}
+	key, _, _, err := duplicateKeyAdapter{}.Decode(nil, iter.Key())
+	return key, errors.Trace(err)
 }
 
 func (local *local) CollectRemoteDuplicateRows(ctx context.Context, tbl table.Table) (bool, error) {
@@ -2095,7 +2150,8 @@ func (local *local) CollectRemoteDuplicateRows(ctx context.Context, tbl table.Ta
 	}
 	ts := oracle.ComposeTS(physicalTS, logicalTS)
 
-	duplicateManager, err := NewDuplicateManager(local.errorMgr, local.splitCli, ts, local.tls, local.tcpConcurrency)
+	duplicateManager, err := NewDuplicateManager(local.errorMgr, local.splitCli, ts, local.tls, local.tcpConcurrency,
+		local.duplicateResolution, local.kvStore)
 	if err != nil {
 		return false, errors.Annotate(err, "open duplicatemanager failed")
 	}
@@ -2103,7 +2159,17 @@ func (local *local) CollectRemoteDuplicateRows(ctx context.Context, tbl table.Ta
 	if err != nil {
 		return false, errors.Annotate(err, "collect remote duplicate rows failed")
 	}
-	return hasDupe, nil
+	return hasDupe, local.checkDuplicateResolution(hasDupe, tbl)
+}
+
+// checkDuplicateResolution returns an error if duplicates are detected while
+// the duplicate-resolution is error.
+func (local *local) checkDuplicateResolution(hasDupe bool, tbl table.Table) error {
+	if !hasDupe || local.duplicateResolution != config.ErrorOnDup {
+		return nil
+	}
+	return errors.Errorf("duplicate rows are detected in table %s, please check the conflict_error_v1 table in the task info schema",
+		tbl.Meta().Name.O)
 }
 
 func (e *File) unfinishedRanges(ranges []Range) []Range {
@@ -70,6 +70,12 @@ const (
 	// ErrorOnDup indicates using INSERT INTO to insert data, which would violate PK or UNIQUE constraint
 	ErrorOnDup = "error"
 
+	// DupeResAlgNone only records the duplicated rows in the local backend, without resolving them.
+	// The other algorithms of `tikv-importer.duplicate-resolution` are ReplaceOnDup, IgnoreOnDup
+	// and ErrorOnDup, which keep the latest row in source order, keep the earliest row, and fail
+	// the task respectively.
+	DupeResAlgNone = "none"
+
 	defaultDistSQLScanConcurrency     = 15
 	distSQLScanConcurrencyPerStore    = 4
 	defaultBuildStatsConcurrency      = 20
@@ -448,16 +454,18 @@ type FileRouteRule struct {
 }
 
 type TikvImporter struct {
-	Addr               string   `toml:"addr" json:"addr"`
-	Backend            string   `toml:"backend" json:"backend"`
-	OnDuplicate        string   `toml:"on-duplicate" json:"on-duplicate"`
-	MaxKVPairs         int      `toml:"max-kv-pairs" json:"max-kv-pairs"`
-	SendKVPairs        int      `toml:"send-kv-pairs" json:"send-kv-pairs"`
-	RegionSplitSize    ByteSize `toml:"region-split-size" json:"region-split-size"`
-	SortedKVDir        string   `toml:"sorted-kv-dir" json:"sorted-kv-dir"`
-	DiskQuota          ByteSize `toml:"disk-quota" json:"disk-quota"`
-	RangeConcurrency   int      `toml:"range-concurrency" json:"range-concurrency"`
-	DuplicateDetection bool     `toml:"duplicate-detection" json:"duplicate-detection"`
+	Addr                string   `toml:"addr" json:"addr"`
+	Backend             string   `toml:"backend" json:"backend"`
+	OnDuplicate         string   `toml:"on-duplicate" json:"on-duplicate"`
+	MaxKVPairs          int      `toml:"max-kv-pairs" json:"max-kv-pairs"`
+	SendKVPairs         int      `toml:"send-kv-pairs" json:"send-kv-pairs"`
+	RegionSplitSize     ByteSize `toml:"region-split-size" json:"region-split-size"`
+	SortedKVDir         string   `toml:"sorted-kv-dir" json:"sorted-kv-dir"`
+	DiskQuota           ByteSize `toml:"disk-quota" json:"disk-quota"`
+	RangeConcurrency    int      `toml:"range-concurrency" json:"range-concurrency"`
+	DuplicateDetection  bool     `toml:"duplicate-detection" json:"duplicate-detection"`
+	DuplicateResolution string   `toml:"duplicate-resolution" json:"duplicate-resolution"`
+	IncrementalImport   bool     `toml:"incremental-import" json:"incremental-import"`
 
 	EngineMemCacheSize      ByteSize `toml:"engine-mem-cache-size" json:"engine-mem-cache-size"`
 	LocalWriterMemCacheSize ByteSize `toml:"local-writer-mem-cache-size" json:"local-writer-mem-cache-size"`
@@ -575,12 +583,13 @@ func NewConfig() *Config {
 			DataInvalidCharReplace: string(defaultCSVDataInvalidCharReplace),
 		},
 		TikvImporter: TikvImporter{
-			Backend:         "",
-			OnDuplicate:     ReplaceOnDup,
-			MaxKVPairs:      4096,
-			SendKVPairs:     32768,
-			RegionSplitSize: 0,
-			DiskQuota:       ByteSize(math.MaxInt64),
+			Backend:             "",
+			OnDuplicate:         ReplaceOnDup,
+			MaxKVPairs:          4096,
+			SendKVPairs:         32768,
+			RegionSplitSize:     0,
+			DiskQuota:           ByteSize(math.MaxInt64),
+			DuplicateResolution: DupeResAlgNone,
 		},
 		PostRestore: PostRestore{
 			Checksum:          OpLevelRequired,
@@ -757,12 +766,29 @@ func (cfg *Config) Adjust(ctx context.Context) error {
 		cfg.TikvImporter.LocalWriterMemCacheSize = defaultLocalWriterMemCacheSize
 	}
 
+	cfg.TikvImporter.DuplicateResolution = strings.ToLower(cfg.TikvImporter.DuplicateResolution)
+	switch cfg.TikvImporter.DuplicateResolution {
+	case "":
+		cfg.TikvImporter.DuplicateResolution = DupeResAlgNone
+	case DupeResAlgNone, ReplaceOnDup, IgnoreOnDup, ErrorOnDup:
+	default:
+		return errors.Errorf("invalid config: unsupported `tikv-importer.duplicate-resolution` (%s)", cfg.TikvImporter.DuplicateResolution)
+	}
+
 	if cfg.TikvImporter.Backend == BackendLocal {
 		if err := cfg.CheckAndAdjustForLocalBackend(); err != nil {
 			return err
 		}
+		// the imported rows may conflict with the existing ones in the incremental import.
+		if cfg.TikvImporter.DuplicateResolution != DupeResAlgNone || cfg.TikvImporter.IncrementalImport {
+			cfg.TikvImporter.DuplicateDetection = true
+		}
+	} else if cfg.TikvImporter.IncrementalImport {
+		return errors.Errorf("invalid config: unsupported backend (%s) for incremental-import", cfg.TikvImporter.Backend)
 	} else if cfg.TikvImporter.DuplicateDetection {
 		return errors.Errorf("invalid config: unsupported backend (%s) for duplicate-detection", cfg.TikvImporter.Backend)
+	} else if cfg.TikvImporter.DuplicateResolution != DupeResAlgNone {
+		return errors.Errorf("invalid config: unsupported backend (%s) for duplicate-resolution", cfg.TikvImporter.Backend)
 	}
 
 	if cfg.TikvImporter.Backend == BackendTiDB {
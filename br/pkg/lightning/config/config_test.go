@@ -711,6 +711,52 @@ func (s *configTestSuite) TestAdjustDiskQuota(c *C) {
 	c.Assert(int64(cfg.TikvImporter.DiskQuota), Equals, int64(0))
 }
 
+func (s *configTestSuite) TestAdjustDuplicateResolution(c *C) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	assignMinimalLegalValue(cfg)
+	cfg.TikvImporter.Backend = config.BackendLocal
+	cfg.TikvImporter.SortedKVDir = c.MkDir()
+	cfg.TiDB.DistSQLScanConcurrency = 1
+	c.Assert(cfg.Adjust(ctx), IsNil)
+	c.Assert(cfg.TikvImporter.DuplicateResolution, Equals, config.DupeResAlgNone)
+	c.Assert(cfg.TikvImporter.DuplicateDetection, IsFalse)
+
+	cfg.TikvImporter.DuplicateResolution = "REPLACE"
+	c.Assert(cfg.Adjust(ctx), IsNil)
+	c.Assert(cfg.TikvImporter.DuplicateResolution, Equals, config.ReplaceOnDup)
+	c.Assert(cfg.TikvImporter.DuplicateDetection, IsTrue)
+
+	cfg.TikvImporter.DuplicateResolution = "no_such_strategy"
+	c.Assert(cfg.Adjust(ctx), ErrorMatches, "invalid config: unsupported `tikv-importer\\.duplicate-resolution` \\(no_such_strategy\\)")
+
+	cfg = config.NewConfig()
+	assignMinimalLegalValue(cfg)
+	cfg.TikvImporter.Backend = config.BackendTiDB
+	cfg.TikvImporter.DuplicateResolution = config.IgnoreOnDup
+	cfg.TiDB.DistSQLScanConcurrency = 1
+	c.Assert(cfg.Adjust(ctx), ErrorMatches, "invalid config: unsupported backend \\(tidb\\) for duplicate-resolution")
+}
+
+func (s *configTestSuite) TestAdjustIncrementalImport(c *C) {
+	ctx := context.Background()
+	cfg := config.NewConfig()
+	assignMinimalLegalValue(cfg)
+	cfg.TikvImporter.Backend = config.BackendLocal
+	cfg.TikvImporter.SortedKVDir = c.MkDir()
+	cfg.TikvImporter.IncrementalImport = true
+	cfg.TiDB.DistSQLScanConcurrency = 1
+	c.Assert(cfg.Adjust(ctx), IsNil)
+	c.Assert(cfg.TikvImporter.DuplicateDetection, IsTrue)
+
+	cfg = config.NewConfig()
+	assignMinimalLegalValue(cfg)
+	cfg.TikvImporter.Backend = config.BackendTiDB
+	cfg.TikvImporter.IncrementalImport = true
+	cfg.TiDB.DistSQLScanConcurrency = 1
+	c.Assert(cfg.Adjust(ctx), ErrorMatches, "invalid config: unsupported backend \\(tidb\\) for incremental-import")
+}
+
 func (s *configTestSuite) TestDataCharacterSet(c *C) {
 	testCases := []struct {
 		input string
@@ -0,0 +1,829 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/types"
+)
+
+// Avro object container files are described in
+// https://avro.apache.org/docs/1.10.2/spec.html#Object+Container+Files
+
+const (
+	avroSyncSize = 16
+	// avroMaxBlockSize is the maximum size of a block before and after
+	// decompression, and of a value in the file header. The sizes read from
+	// the file are checked against it before allocating any buffer, so a
+	// corrupted file can't exhaust the memory.
+	avroMaxBlockSize = 256 << 20
+
+	avroCodecNull    = "null"
+	avroCodecDeflate = "deflate"
+	avroCodecSnappy  = "snappy"
+)
+
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// AvroParser is a parser of Avro object container files. The schema embedded
+// in the file must be a record. By default the rows are in the order of the
+// fields of the record. After SetColumns, each field is mapped to the column
+// with the same name, and fields missing from the file are NULL, so files
+// written with different versions of the schema can be imported into the
+// same table. Nested records, arrays and maps are converted to JSON text.
+//
+// Like parquet files, Avro files can't seek to a row efficiently, the offset
+// of the parser is the row number instead of the byte offset.
+type AvroParser struct {
+	reader  *bufio.Reader
+	closer  io.Closer
+	codec   string
+	sync    [avroSyncSize]byte
+	schema  *avroSchema
+	columns []string
+	// fieldIndexes maps the fields of the schema to their indexes in the
+	// columns, it is nil if the columns are the fields of the schema.
+	fieldIndexes []int
+	// unknownField is the first field not in the columns set by SetColumns.
+	unknownField string
+
+	// block is the decompressed content of the current block.
+	block *bytes.Reader
+	// blockRemain is the number of rows not read in the current block.
+	blockRemain int64
+	// pos is the number of rows read.
+	pos int64
+
+	lastRow Row
+	logger  log.Logger
+}
+
+type avroFileHeader struct {
+	codec  string
+	schema *avroSchema
+	sync   [avroSyncSize]byte
+}
+
+// NewAvroParser creates an Avro parser.
+func NewAvroParser(r storage.ReadSeekCloser) (*AvroParser, error) {
+	reader := bufio.NewReader(r)
+	header, err := readAvroFileHeader(reader)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if header.schema.kind != avroRecord {
+		return nil, errors.Errorf("the schema of an Avro data file must be a record, got '%s'", header.schema.kind)
+	}
+	columns := make([]string, 0, len(header.schema.fields))
+	for _, f := range header.schema.fields {
+		columns = append(columns, strings.ToLower(f.name))
+	}
+	return &AvroParser{
+		reader:  reader,
+		closer:  r,
+		codec:   header.codec,
+		sync:    header.sync,
+		schema:  header.schema,
+		columns: columns,
+		logger:  log.L(),
+	}, nil
+}
+
+// ReadAvroFileRowCount reads the row count of an Avro file by walking through
+// the block headers, the content of the blocks is skipped.
+func ReadAvroFileRowCount(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	path string,
+) (int64, error) {
+	r, err := store.Open(ctx, path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	defer r.Close()
+	reader := bufio.NewReader(r)
+	if _, err = readAvroFileHeader(reader); err != nil {
+		return 0, errors.Trace(err)
+	}
+	var rows int64
+	for {
+		count, size, err := readAvroBlockHeader(reader)
+		if err != nil {
+			if errors.Cause(err) == io.EOF {
+				return rows, nil
+			}
+			return 0, errors.Trace(err)
+		}
+		rows += count
+		if _, err = reader.Discard(int(size) + avroSyncSize); err != nil {
+			return 0, errors.Annotatef(err, "truncated Avro file '%s'", path)
+		}
+	}
+}
+
+func readAvroFileHeader(r *bufio.Reader) (*avroFileHeader, error) {
+	magic := make([]byte, len(avroMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return nil, errors.Annotate(err, "failed to read the Avro file header")
+	}
+	if !bytes.Equal(magic, avroMagic) {
+		return nil, errors.New("not an Avro object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		count, err := readAvroLong(r)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			break
+		}
+		if count < 0 {
+			count = -count
+			// skip the block size
+			if _, err = readAvroLong(r); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		for i := int64(0); i < count; i++ {
+			key, err := readAvroBytes(r, avroMaxBlockSize)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value, err := readAvroBytes(r, avroMaxBlockSize)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			meta[string(key)] = value
+		}
+	}
+
+	header := &avroFileHeader{codec: avroCodecNull}
+	if _, err := io.ReadFull(r, header.sync[:]); err != nil {
+		return nil, errors.Annotate(err, "failed to read the Avro file header")
+	}
+	if codec, ok := meta["avro.codec"]; ok && len(codec) > 0 {
+		header.codec = string(codec)
+	}
+	switch header.codec {
+	case avroCodecNull, avroCodecDeflate, avroCodecSnappy:
+	default:
+		return nil, errors.Errorf("unsupported Avro codec '%s'", header.codec)
+	}
+	rawSchema, ok := meta["avro.schema"]
+	if !ok {
+		return nil, errors.New("the Avro file header doesn't contain a schema")
+	}
+	schema, err := parseAvroSchema(rawSchema)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	header.schema = schema
+	return header, nil
+}
+
+// readAvroBlockHeader reads the row count and the byte size of the next block.
+func readAvroBlockHeader(r *bufio.Reader) (count int64, size int64, err error) {
+	if _, err = r.Peek(1); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if count, err = readAvroLong(r); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if size, err = readAvroLong(r); err != nil {
+		return 0, 0, errors.Trace(err)
+	}
+	if count < 0 || size < 0 {
+		return 0, 0, errors.Errorf("invalid Avro block with %d rows and %d bytes", count, size)
+	}
+	return count, size, nil
+}
+
+func (pp *AvroParser) readBlock() error {
+	for {
+		count, size, err := readAvroBlockHeader(pp.reader)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if size > avroMaxBlockSize {
+			return errors.Errorf("Avro block size %d exceeds the limit %d", size, avroMaxBlockSize)
+		}
+		data := make([]byte, size)
+		if _, err = io.ReadFull(pp.reader, data); err != nil {
+			return errors.Annotate(err, "truncated Avro block")
+		}
+		var sync [avroSyncSize]byte
+		if _, err = io.ReadFull(pp.reader, sync[:]); err != nil {
+			return errors.Annotate(err, "truncated Avro block")
+		}
+		if sync != pp.sync {
+			return errors.New("invalid sync marker of Avro block")
+		}
+		if count == 0 {
+			continue
+		}
+
+		switch pp.codec {
+		case avroCodecDeflate:
+			fr := io.LimitReader(flate.NewReader(bytes.NewReader(data)), avroMaxBlockSize+1)
+			if data, err = ioutil.ReadAll(fr); err != nil {
+				return errors.Annotate(err, "failed to decompress Avro block")
+			}
+			if len(data) > avroMaxBlockSize {
+				return errors.Errorf("decompressed Avro block exceeds the limit %d", avroMaxBlockSize)
+			}
+		case avroCodecSnappy:
+			// the compressed data is followed by the 4-byte, big-endian CRC32
+			// checksum of the uncompressed data.
+			if len(data) < 4 {
+				return errors.New("invalid snappy compressed Avro block")
+			}
+			checksum := binary.BigEndian.Uint32(data[len(data)-4:])
+			n, err := snappy.DecodedLen(data[:len(data)-4])
+			if err != nil {
+				return errors.Annotate(err, "failed to decompress Avro block")
+			}
+			if n > avroMaxBlockSize {
+				return errors.Errorf("decompressed Avro block size %d exceeds the limit %d", n, avroMaxBlockSize)
+			}
+			if data, err = snappy.Decode(nil, data[:len(data)-4]); err != nil {
+				return errors.Annotate(err, "failed to decompress Avro block")
+			}
+			if crc32.ChecksumIEEE(data) != checksum {
+				return errors.New("checksum mismatch of snappy compressed Avro block")
+			}
+		}
+		pp.block = bytes.NewReader(data)
+		pp.blockRemain = count
+		return nil
+	}
+}
+
+// Pos returns the currently row number of the Avro file.
+func (pp *AvroParser) Pos() (pos int64, rowID int64) {
+	return pp.pos, pp.lastRow.RowID
+}
+
+// SetPos skips to the row pos of the file.
+func (pp *AvroParser) SetPos(pos int64, rowID int64) error {
+	if pos < pp.pos {
+		return errors.Errorf("can't seek back in Avro file from row %d to %d", pp.pos, pos)
+	}
+	for pp.pos < pos {
+		if pp.blockRemain == 0 {
+			if err := pp.readBlock(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if pp.pos+pp.blockRemain <= pos {
+			pp.pos += pp.blockRemain
+			pp.blockRemain = 0
+			continue
+		}
+		if _, err := pp.schema.decode(pp.block); err != nil {
+			return errors.Trace(err)
+		}
+		pp.blockRemain--
+		pp.pos++
+	}
+	pp.lastRow.RowID = rowID
+	return nil
+}
+
+// Close closes the parser.
+func (pp *AvroParser) Close() error {
+	return pp.closer.Close()
+}
+
+// ReadRow reads a row from the datafile.
+func (pp *AvroParser) ReadRow() error {
+	pp.lastRow.RowID++
+	pp.lastRow.Length = 0
+	if pp.blockRemain == 0 {
+		if err := pp.readBlock(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if pp.unknownField != "" {
+		return errors.Errorf("unknown field '%s' in the Avro schema", pp.unknownField)
+	}
+
+	startLen := pp.block.Len()
+	if cap(pp.lastRow.Row) < len(pp.columns) {
+		pp.lastRow.Row = make([]types.Datum, len(pp.columns))
+	} else {
+		pp.lastRow.Row = pp.lastRow.Row[:len(pp.columns)]
+	}
+	if pp.fieldIndexes != nil {
+		for i := range pp.lastRow.Row {
+			pp.lastRow.Row[i].SetNull()
+		}
+	}
+	for i, f := range pp.schema.fields {
+		idx := i
+		if pp.fieldIndexes != nil {
+			idx = pp.fieldIndexes[i]
+		}
+		if err := f.schema.decodeDatum(pp.block, &pp.lastRow.Row[idx]); err != nil {
+			return errors.Annotatef(err, "failed to decode field '%s' of row %d", f.name, pp.pos)
+		}
+	}
+	pp.lastRow.Length = startLen - pp.block.Len()
+	pp.blockRemain--
+	pp.pos++
+	return nil
+}
+
+// LastRow gets the last row parsed by the parser.
+func (pp *AvroParser) LastRow() Row {
+	return pp.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (pp *AvroParser) RecycleRow(row Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (pp *AvroParser) Columns() []string {
+	return pp.columns
+}
+
+// SetColumns set restored column names to parser. The fields of the schema
+// are mapped to the columns by their case-insensitive names, the columns
+// without a field are NULL, and a field without a column makes ReadRow fail.
+func (pp *AvroParser) SetColumns(cols []string) {
+	columnIndexes := make(map[string]int, len(cols))
+	for i, c := range cols {
+		columnIndexes[strings.ToLower(c)] = i
+	}
+	pp.columns = cols
+	pp.fieldIndexes = make([]int, len(pp.schema.fields))
+	pp.unknownField = ""
+	for i, f := range pp.schema.fields {
+		idx, ok := columnIndexes[strings.ToLower(f.name)]
+		if !ok {
+			if pp.unknownField == "" {
+				pp.unknownField = f.name
+			}
+			continue
+		}
+		pp.fieldIndexes[i] = idx
+	}
+}
+
+// SetLogger sets the logger of the parser.
+func (pp *AvroParser) SetLogger(l log.Logger) {
+	pp.logger = l
+}
+
+// Avro schema and binary encoding.
+
+type avroKind string
+
+const (
+	avroNull    avroKind = "null"
+	avroBoolean avroKind = "boolean"
+	avroInt     avroKind = "int"
+	avroLong    avroKind = "long"
+	avroFloat   avroKind = "float"
+	avroDouble  avroKind = "double"
+	avroBytes   avroKind = "bytes"
+	avroString  avroKind = "string"
+	avroRecord  avroKind = "record"
+	avroEnum    avroKind = "enum"
+	avroArray   avroKind = "array"
+	avroMap     avroKind = "map"
+	avroFixed   avroKind = "fixed"
+	avroUnion   avroKind = "union"
+)
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+type avroSchema struct {
+	kind        avroKind
+	logicalType string
+	scale       int
+	// size of fixed
+	size int
+	// symbols of enum
+	symbols []string
+	// fields of record
+	fields []avroField
+	// items of array, values of map
+	items *avroSchema
+	// branches of union
+	branches []*avroSchema
+}
+
+type avroSchemaParser struct {
+	// named types, indexed by both full names and short names
+	named map[string]*avroSchema
+}
+
+func parseAvroSchema(raw []byte) (*avroSchema, error) {
+	var schema interface{}
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, errors.Annotate(err, "invalid Avro schema")
+	}
+	p := &avroSchemaParser{named: make(map[string]*avroSchema)}
+	return p.parse(schema, "")
+}
+
+func (p *avroSchemaParser) parse(schema interface{}, namespace string) (*avroSchema, error) {
+	switch s := schema.(type) {
+	case string:
+		switch kind := avroKind(s); kind {
+		case avroNull, avroBoolean, avroInt, avroLong, avroFloat, avroDouble, avroBytes, avroString:
+			return &avroSchema{kind: kind}, nil
+		}
+		if named, ok := p.named[s]; ok {
+			return named, nil
+		}
+		if named, ok := p.named[namespace+"."+s]; ok {
+			return named, nil
+		}
+		return nil, errors.Errorf("unknown Avro type '%s'", s)
+	case []interface{}:
+		union := &avroSchema{kind: avroUnion}
+		for _, b := range s {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union.branches = append(union.branches, branch)
+		}
+		return union, nil
+	case map[string]interface{}:
+		return p.parseComplex(s, namespace)
+	default:
+		return nil, errors.Errorf("invalid Avro schema %v", schema)
+	}
+}
+
+func (p *avroSchemaParser) parseComplex(s map[string]interface{}, namespace string) (*avroSchema, error) {
+	typ, ok := s["type"]
+	if !ok {
+		return nil, errors.Errorf("Avro schema %v has no type", s)
+	}
+	typeName, ok := typ.(string)
+	if !ok {
+		// e.g. {"type": {"type": "array", ...}}
+		return p.parse(typ, namespace)
+	}
+
+	result := &avroSchema{kind: avroKind(typeName)}
+	if logicalType, ok := s["logicalType"].(string); ok {
+		result.logicalType = logicalType
+	}
+	if scale, ok := s["scale"].(float64); ok {
+		result.scale = int(scale)
+	}
+
+	switch result.kind {
+	case avroRecord, avroEnum, avroFixed:
+		name, _ := s["name"].(string)
+		if name == "" {
+			return nil, errors.Errorf("Avro %s has no name", result.kind)
+		}
+		if ns, ok := s["namespace"].(string); ok {
+			namespace = ns
+		}
+		fullName := name
+		if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+			namespace = name[:idx]
+			name = name[idx+1:]
+		} else if namespace != "" {
+			fullName = namespace + "." + name
+		}
+		// register before parsing the fields to support recursive types.
+		p.named[fullName] = result
+		p.named[name] = result
+	}
+
+	switch result.kind {
+	case avroNull, avroBoolean, avroInt, avroLong, avroFloat, avroDouble, avroBytes, avroString:
+	case avroRecord:
+		fields, _ := s["fields"].([]interface{})
+		for _, f := range fields {
+			field, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("invalid Avro record field %v", f)
+			}
+			name, _ := field["name"].(string)
+			fieldSchema, err := p.parse(field["type"], namespace)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid type of Avro record field '%s'", name)
+			}
+			result.fields = append(result.fields, avroField{name: name, schema: fieldSchema})
+		}
+	case avroEnum:
+		symbols, _ := s["symbols"].([]interface{})
+		for _, symbol := range symbols {
+			str, _ := symbol.(string)
+			result.symbols = append(result.symbols, str)
+		}
+	case avroFixed:
+		size, ok := s["size"].(float64)
+		if !ok {
+			return nil, errors.New("Avro fixed has no size")
+		}
+		result.size = int(size)
+	case avroArray, avroMap:
+		key := "items"
+		if result.kind == avroMap {
+			key = "values"
+		}
+		items, err := p.parse(s[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		result.items = items
+	default:
+		return nil, errors.Errorf("unknown Avro type '%s'", typeName)
+	}
+	return result, nil
+}
+
+func readAvroLong(r io.ByteReader) (int64, error) {
+	v, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid Avro long")
+	}
+	return v, nil
+}
+
+// readAvroBytes reads a length-prefixed byte sequence, the length must not
+// exceed maxSize, which is the remaining size of the input if known.
+func readAvroBytes(r interface {
+	io.Reader
+	io.ByteReader
+}, maxSize int64) ([]byte, error) {
+	size, err := readAvroLong(r)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 || size > maxSize {
+		return nil, errors.Errorf("invalid Avro bytes length %d", size)
+	}
+	b := make([]byte, size)
+	if _, err = io.ReadFull(r, b); err != nil {
+		return nil, errors.Annotate(err, "truncated Avro bytes")
+	}
+	return b, nil
+}
+
+// decodeDatum decodes a value from r into a Datum.
+func (s *avroSchema) decodeDatum(r *bytes.Reader, d *types.Datum) error {
+	switch s.kind {
+	case avroNull:
+		d.SetNull()
+	case avroBoolean:
+		b, err := r.ReadByte()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetInt64(int64(b))
+	case avroInt, avroLong:
+		v, err := readAvroLong(r)
+		if err != nil {
+			return err
+		}
+		setDatumByAvroLong(d, v, s.logicalType)
+	case avroFloat:
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetFloat32(math.Float32frombits(binary.LittleEndian.Uint32(b[:])))
+	case avroDouble:
+		var b [8]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetFloat64(math.Float64frombits(binary.LittleEndian.Uint64(b[:])))
+	case avroString:
+		b, err := readAvroBytes(r, int64(r.Len()))
+		if err != nil {
+			return err
+		}
+		d.SetString(string(b), "")
+	case avroBytes, avroFixed:
+		b, err := s.readBytes(r)
+		if err != nil {
+			return err
+		}
+		switch {
+		case s.logicalType == "decimal" && len(b) > 0:
+			d.SetString(binaryToDecimalStr(b, s.scale), "")
+		case s.logicalType == "decimal":
+			d.SetString("0", "")
+		default:
+			d.SetBytes(b)
+		}
+	case avroEnum:
+		v, err := readAvroLong(r)
+		if err != nil {
+			return err
+		}
+		if v < 0 || v >= int64(len(s.symbols)) {
+			return errors.Errorf("invalid Avro enum index %d", v)
+		}
+		d.SetString(s.symbols[v], "")
+	case avroUnion:
+		branch, err := s.readBranch(r)
+		if err != nil {
+			return err
+		}
+		return branch.decodeDatum(r, d)
+	default:
+		// nested types are converted to JSON.
+		v, err := s.decode(r)
+		if err != nil {
+			return err
+		}
+		content, err := json.Marshal(v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(string(content), "")
+	}
+	return nil
+}
+
+func (s *avroSchema) readBytes(r *bytes.Reader) ([]byte, error) {
+	if s.kind == avroFixed {
+		if s.size > r.Len() {
+			return nil, errors.New("truncated Avro fixed")
+		}
+		b := make([]byte, s.size)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, errors.Annotate(err, "truncated Avro fixed")
+		}
+		return b, nil
+	}
+	return readAvroBytes(r, int64(r.Len()))
+}
+
+func (s *avroSchema) readBranch(r *bytes.Reader) (*avroSchema, error) {
+	index, err := readAvroLong(r)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= int64(len(s.branches)) {
+		return nil, errors.Errorf("invalid Avro union index %d", index)
+	}
+	return s.branches[index], nil
+}
+
+// decode decodes a value from r into a value which can be marshaled to JSON.
+func (s *avroSchema) decode(r *bytes.Reader) (interface{}, error) {
+	switch s.kind {
+	case avroRecord:
+		record := make(map[string]interface{}, len(s.fields))
+		for _, f := range s.fields {
+			v, err := f.schema.decode(r)
+			if err != nil {
+				return nil, err
+			}
+			record[f.name] = v
+		}
+		return record, nil
+	case avroArray, avroMap:
+		var (
+			array  []interface{}
+			object map[string]interface{}
+		)
+		if s.kind == avroArray {
+			array = make([]interface{}, 0)
+		} else {
+			object = make(map[string]interface{})
+		}
+		for {
+			count, err := readAvroLong(r)
+			if err != nil {
+				return nil, err
+			}
+			if count == 0 {
+				break
+			}
+			if count < 0 {
+				count = -count
+				if _, err = readAvroLong(r); err != nil {
+					return nil, err
+				}
+			}
+			for i := int64(0); i < count; i++ {
+				var key []byte
+				if s.kind == avroMap {
+					if key, err = readAvroBytes(r, int64(r.Len())); err != nil {
+						return nil, err
+					}
+				}
+				v, err := s.items.decode(r)
+				if err != nil {
+					return nil, err
+				}
+				if s.kind == avroArray {
+					array = append(array, v)
+				} else {
+					object[string(key)] = v
+				}
+			}
+		}
+		if s.kind == avroArray {
+			return array, nil
+		}
+		return object, nil
+	case avroUnion:
+		branch, err := s.readBranch(r)
+		if err != nil {
+			return nil, err
+		}
+		return branch.decode(r)
+	case avroBytes, avroFixed:
+		b, err := s.readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+		if s.logicalType == "decimal" && len(b) > 0 {
+			return json.Number(binaryToDecimalStr(b, s.scale)), nil
+		}
+		return base64.StdEncoding.EncodeToString(b), nil
+	default:
+		var d types.Datum
+		if err := s.decodeDatum(r, &d); err != nil {
+			return nil, err
+		}
+		switch d.Kind() {
+		case types.KindNull:
+			return nil, nil
+		case types.KindInt64:
+			if s.kind == avroBoolean {
+				return d.GetInt64() != 0, nil
+			}
+			return d.GetInt64(), nil
+		case types.KindFloat32:
+			return d.GetFloat32(), nil
+		case types.KindFloat64:
+			return d.GetFloat64(), nil
+		default:
+			return d.GetString(), nil
+		}
+	}
+}
+
+func setDatumByAvroLong(d *types.Datum, v int64, logicalType string) {
+	switch logicalType {
+	case "date":
+		d.SetString(time.Unix(v*86400, 0).UTC().Format("2006-01-02"), "")
+	case "time-millis":
+		d.SetString(time.Unix(0, v*int64(time.Millisecond)).UTC().Format("15:04:05.999999"), "")
+	case "time-micros":
+		d.SetString(time.Unix(0, v*int64(time.Microsecond)).UTC().Format("15:04:05.999999"), "")
+	case "timestamp-millis":
+		d.SetString(time.Unix(0, v*int64(time.Millisecond)).UTC().Format("2006-01-02 15:04:05.999999Z"), "")
+	case "timestamp-micros":
+		d.SetString(time.Unix(0, v*int64(time.Microsecond)).UTC().Format("2006-01-02 15:04:05.999999Z"), "")
+	case "local-timestamp-millis":
+		d.SetString(time.Unix(0, v*int64(time.Millisecond)).UTC().Format("2006-01-02 15:04:05.999999"), "")
+	case "local-timestamp-micros":
+		d.SetString(time.Unix(0, v*int64(time.Microsecond)).UTC().Format("2006-01-02 15:04:05.999999"), "")
+	default:
+		d.SetInt64(v)
+	}
+}
@@ -0,0 +1,271 @@
+package mydump
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/types"
+)
+
+type testAvroParserSuite struct{}
+
+var _ = Suite(testAvroParserSuite{})
+
+const testAvroSchema = `{
+	"type": "record",
+	"name": "Test",
+	"namespace": "lightning",
+	"fields": [
+		{"name": "ID", "type": "long"},
+		{"name": "name", "type": ["null", "string"]},
+		{"name": "score", "type": "double"},
+		{"name": "birthday", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}}
+	]
+}`
+
+type avroWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *avroWriter) writeLong(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *avroWriter) writeBytes(b []byte) {
+	w.writeLong(int64(len(b)))
+	w.buf.Write(b)
+}
+
+func (w *avroWriter) writeDouble(v float64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+	w.buf.Write(b[:])
+}
+
+// writeTestAvroRow encodes the i-th row of the test schema.
+func writeTestAvroRow(w *avroWriter, i int) {
+	w.writeLong(int64(i))
+	if i%2 == 0 {
+		w.writeLong(0)
+	} else {
+		w.writeLong(1)
+		w.writeBytes([]byte("row"))
+	}
+	w.writeDouble(float64(i) / 2)
+	// 1970-01-02 + i days
+	w.writeLong(int64(i + 1))
+	// -1.23 * (i + 1)
+	var price [2]byte
+	binary.BigEndian.PutUint16(price[:], uint16(int16(-123*(i+1))))
+	w.writeBytes(price[:])
+	w.writeLong(1)
+	w.writeBytes([]byte("a"))
+	w.writeLong(0)
+}
+
+// makeTestAvroFile creates an Avro file with rowsPerBlock rows in each block.
+func makeTestAvroFile(c *C, codec string, rowsPerBlock ...int) []byte {
+	sync := []byte("0123456789abcdef")
+	file := &avroWriter{}
+	file.buf.Write(avroMagic)
+	file.writeLong(2)
+	file.writeBytes([]byte("avro.schema"))
+	file.writeBytes([]byte(testAvroSchema))
+	file.writeBytes([]byte("avro.codec"))
+	file.writeBytes([]byte(codec))
+	file.writeLong(0)
+	file.buf.Write(sync)
+
+	row := 0
+	for _, n := range rowsPerBlock {
+		block := &avroWriter{}
+		for i := 0; i < n; i++ {
+			writeTestAvroRow(block, row)
+			row++
+		}
+		data := block.buf.Bytes()
+		if codec == avroCodecDeflate {
+			var compressed bytes.Buffer
+			fw, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+			c.Assert(err, IsNil)
+			_, err = fw.Write(data)
+			c.Assert(err, IsNil)
+			c.Assert(fw.Close(), IsNil)
+			data = compressed.Bytes()
+		}
+		file.writeLong(int64(n))
+		file.writeBytes(data)
+		file.buf.Write(sync)
+	}
+	return file.buf.Bytes()
+}
+
+func (s testAvroParserSuite) TestAvroParser(c *C) {
+	for _, codec := range []string{avroCodecNull, avroCodecDeflate} {
+		dir := c.MkDir()
+		name := "test.avro"
+		c.Assert(ioutil.WriteFile(filepath.Join(dir, name), makeTestAvroFile(c, codec, 3, 0, 4), 0o644), IsNil)
+
+		store, err := storage.NewLocalStorage(dir)
+		c.Assert(err, IsNil)
+		count, err := ReadAvroFileRowCount(context.Background(), store, name)
+		c.Assert(err, IsNil)
+		c.Assert(count, Equals, int64(7))
+
+		r, err := store.Open(context.Background(), name)
+		c.Assert(err, IsNil)
+		parser, err := NewAvroParser(r)
+		c.Assert(err, IsNil)
+		c.Assert(parser.Columns(), DeepEquals, []string{"id", "name", "score", "birthday", "price", "tags"})
+
+		verifyRow := func(i int) {
+			row := parser.LastRow()
+			c.Assert(row.RowID, Equals, int64(i+1))
+			c.Assert(row.Row, HasLen, 6)
+			c.Assert(row.Row[0], DeepEquals, types.NewIntDatum(int64(i)))
+			if i%2 == 0 {
+				c.Assert(row.Row[1].IsNull(), IsTrue)
+			} else {
+				c.Assert(row.Row[1].GetString(), Equals, "row")
+			}
+			c.Assert(row.Row[2], DeepEquals, types.NewFloat64Datum(float64(i)/2))
+			c.Assert(row.Row[3].GetString(), Equals, fmt.Sprintf("1970-01-%02d", i+2))
+			c.Assert(row.Row[4].GetString(), Equals, fmt.Sprintf("-%d.%02d", 123*(i+1)/100, 123*(i+1)%100))
+			c.Assert(row.Row[5].GetString(), Equals, `["a"]`)
+		}
+
+		c.Assert(parser.ReadRow(), IsNil)
+		verifyRow(0)
+		c.Assert(parser.ReadRow(), IsNil)
+		verifyRow(1)
+
+		// skip to the middle of the last block.
+		c.Assert(parser.SetPos(5, 5), IsNil)
+		c.Assert(parser.SetPos(4, 4), NotNil)
+		for i := 5; i < 7; i++ {
+			c.Assert(parser.ReadRow(), IsNil)
+			verifyRow(i)
+		}
+		c.Assert(errors.Cause(parser.ReadRow()), Equals, io.EOF)
+		c.Assert(parser.Close(), IsNil)
+	}
+}
+
+func (s testAvroParserSuite) TestAvroParserInvalidFile(c *C) {
+	dir := c.MkDir()
+	store, err := storage.NewLocalStorage(dir)
+	c.Assert(err, IsNil)
+
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "invalid.avro"), []byte("not avro"), 0o644), IsNil)
+	r, err := store.Open(context.Background(), "invalid.avro")
+	c.Assert(err, IsNil)
+	_, err = NewAvroParser(r)
+	c.Assert(err, ErrorMatches, "not an Avro object container file")
+
+	content := makeTestAvroFile(c, "zstandard", 1)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "codec.avro"), content, 0o644), IsNil)
+	r, err = store.Open(context.Background(), "codec.avro")
+	c.Assert(err, IsNil)
+	_, err = NewAvroParser(r)
+	c.Assert(err, ErrorMatches, "unsupported Avro codec 'zstandard'")
+
+	// the sync marker of the block is broken.
+	content = makeTestAvroFile(c, avroCodecNull, 1)
+	content[len(content)-1] = 'x'
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "sync.avro"), content, 0o644), IsNil)
+	r, err = store.Open(context.Background(), "sync.avro")
+	c.Assert(err, IsNil)
+	parser, err := NewAvroParser(r)
+	c.Assert(err, IsNil)
+	c.Assert(parser.ReadRow(), ErrorMatches, "invalid sync marker of Avro block")
+	c.Assert(parser.Close(), IsNil)
+}
+
+func (s testAvroParserSuite) TestAvroParserSetColumns(c *C) {
+	dir := c.MkDir()
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "test.avro"), makeTestAvroFile(c, avroCodecNull, 2), 0o644), IsNil)
+	store, err := storage.NewLocalStorage(dir)
+	c.Assert(err, IsNil)
+
+	r, err := store.Open(context.Background(), "test.avro")
+	c.Assert(err, IsNil)
+	parser, err := NewAvroParser(r)
+	c.Assert(err, IsNil)
+	columns := []string{"tags", "extra", "Price", "birthday", "score", "name", "id"}
+	parser.SetColumns(columns)
+	c.Assert(parser.Columns(), DeepEquals, columns)
+	for i := 0; i < 2; i++ {
+		c.Assert(parser.ReadRow(), IsNil)
+		row := parser.LastRow().Row
+		c.Assert(row, HasLen, 7)
+		c.Assert(row[0].GetString(), Equals, `["a"]`)
+		c.Assert(row[1].IsNull(), IsTrue)
+		c.Assert(row[2].GetString(), Equals, fmt.Sprintf("-%d.%02d", 123*(i+1)/100, 123*(i+1)%100))
+		c.Assert(row[3].GetString(), Equals, fmt.Sprintf("1970-01-%02d", i+2))
+		c.Assert(row[4], DeepEquals, types.NewFloat64Datum(float64(i)/2))
+		c.Assert(row[5].IsNull(), Equals, i%2 == 0)
+		c.Assert(row[6], DeepEquals, types.NewIntDatum(int64(i)))
+	}
+	c.Assert(parser.Close(), IsNil)
+
+	// the fields without a column are rejected.
+	r, err = store.Open(context.Background(), "test.avro")
+	c.Assert(err, IsNil)
+	parser, err = NewAvroParser(r)
+	c.Assert(err, IsNil)
+	parser.SetColumns([]string{"id", "name", "score", "birthday", "price"})
+	c.Assert(parser.ReadRow(), ErrorMatches, "unknown field 'tags' in the Avro schema")
+	c.Assert(parser.Close(), IsNil)
+}
+
+func (s testAvroParserSuite) TestAvroParserSizeLimit(c *C) {
+	dir := c.MkDir()
+	store, err := storage.NewLocalStorage(dir)
+	c.Assert(err, IsNil)
+
+	// the block claims to be larger than the limit.
+	content := makeTestAvroFile(c, avroCodecNull)
+	file := &avroWriter{}
+	file.buf.Write(content)
+	file.writeLong(1)
+	file.writeLong(avroMaxBlockSize + 1)
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "block.avro"), file.buf.Bytes(), 0o644), IsNil)
+	r, err := store.Open(context.Background(), "block.avro")
+	c.Assert(err, IsNil)
+	parser, err := NewAvroParser(r)
+	c.Assert(err, IsNil)
+	c.Assert(parser.ReadRow(), ErrorMatches, "Avro block size .* exceeds the limit .*")
+	c.Assert(parser.Close(), IsNil)
+
+	// a string claims to be longer than the rest of the block.
+	block := &avroWriter{}
+	block.writeLong(0)
+	block.writeLong(1)
+	block.writeLong(math.MaxInt32)
+	file = &avroWriter{}
+	file.buf.Write(content)
+	file.writeLong(1)
+	file.writeBytes(block.buf.Bytes())
+	file.buf.WriteString("0123456789abcdef")
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "string.avro"), file.buf.Bytes(), 0o644), IsNil)
+	r, err = store.Open(context.Background(), "string.avro")
+	c.Assert(err, IsNil)
+	parser, err = NewAvroParser(r)
+	c.Assert(err, IsNil)
+	c.Assert(parser.ReadRow(), ErrorMatches, ".*invalid Avro bytes length 2147483647")
+	c.Assert(parser.Close(), IsNil)
+}
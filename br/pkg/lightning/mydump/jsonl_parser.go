@@ -0,0 +1,272 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/types"
+	binaryJSON "github.com/pingcap/tidb/types/json"
+	"go.uber.org/zap"
+)
+
+// jsonlMinRowSize is the size of the shortest possible row "{}\n", it is used
+// to estimate the upper bound of the row count of a JSON Lines file.
+const jsonlMinRowSize = 3
+
+// JSONLParser is a parser of JSON Lines files, where every non-empty line is
+// a JSON object mapping the column names to the values.
+//
+// The keys are resolved against the columns set by SetColumns, which are the
+// columns of the target table when importing. Keys missing from an object are
+// treated as NULL, and unknown keys are rejected. If no columns are set, the
+// keys of the first object of a chunk determine the columns of the chunk.
+// Nested objects and arrays are kept as JSON text, so they can be imported
+// into JSON columns.
+type JSONLParser struct {
+	blockParser
+
+	// columnIndexes maps the lower-case column names to their indexes in
+	// the columns.
+	columnIndexes map[string]int
+	// seen marks the columns assigned in the current row.
+	seen []bool
+}
+
+// NewJSONLParser creates a JSON Lines parser.
+func NewJSONLParser(
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+) *JSONLParser {
+	return &JSONLParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers),
+	}
+}
+
+// SetColumns set restored column names to parser.
+func (parser *JSONLParser) SetColumns(columns []string) {
+	parser.columns = columns
+	parser.columnIndexes = make(map[string]int, len(columns))
+	for i, c := range columns {
+		parser.columnIndexes[strings.ToLower(c)] = i
+	}
+}
+
+// readLine reads the next line without the line terminator.
+func (parser *JSONLParser) readLine() ([]byte, error) {
+	if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+		line := parser.buf[:index]
+		parser.buf = parser.buf[index+1:]
+		parser.pos += int64(index + 1)
+		return bytes.TrimSuffix(line, []byte{'\r'}), nil
+	}
+
+	// not found in parser.buf, need allocate and loop.
+	var line []byte
+	for {
+		line = append(line, parser.buf...)
+		parser.buf = nil
+		if err := parser.readBlock(); err != nil || len(parser.buf) == 0 {
+			parser.pos += int64(len(line))
+			if err == nil && len(line) == 0 {
+				err = io.EOF
+			}
+			return line, errors.Trace(err)
+		}
+		if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+			line = append(line, parser.buf[:index]...)
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(len(line) + 1)
+			return bytes.TrimSuffix(line, []byte{'\r'}), nil
+		}
+	}
+}
+
+// ReadUntilTerminator seeks the file to the beginning of the next line.
+func (parser *JSONLParser) ReadUntilTerminator() (int64, error) {
+	for {
+		if index := bytes.IndexByte(parser.buf, '\n'); index >= 0 {
+			parser.buf = parser.buf[index+1:]
+			parser.pos += int64(index + 1)
+			return parser.pos, nil
+		}
+		parser.pos += int64(len(parser.buf))
+		parser.buf = nil
+		if err := parser.readBlock(); err != nil {
+			return parser.pos, errors.Trace(err)
+		}
+		if len(parser.buf) == 0 {
+			return parser.pos, io.EOF
+		}
+	}
+}
+
+// ReadRow reads a row from the datafile.
+func (parser *JSONLParser) ReadRow() error {
+	row := &parser.lastRow
+	row.Length = 0
+	row.RowID++
+
+	var line []byte
+	for {
+		var err error
+		line, err = parser.readLine()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			break
+		}
+	}
+	row.Length = len(line)
+
+	if err := parser.parseObject(line); err != nil {
+		parser.logInvalidLine(line)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (parser *JSONLParser) parseObject(line []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	if tok, err := decoder.Token(); err != nil {
+		return errors.Annotate(err, "syntax error")
+	} else if tok != json.Delim('{') {
+		return errors.Errorf("syntax error: expect a JSON object, got %v", tok)
+	}
+
+	initColumns := parser.columns == nil
+	if initColumns {
+		parser.SetColumns([]string{})
+		parser.seen = parser.seen[:0]
+	}
+	row := &parser.lastRow
+	row.Row = parser.acquireDatumSlice()[:0]
+	if !initColumns {
+		parser.resetRow()
+	}
+
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return errors.Annotate(err, "syntax error")
+		}
+		key := strings.ToLower(tok.(string))
+		var value json.RawMessage
+		if err = decoder.Decode(&value); err != nil {
+			return errors.Annotatef(err, "syntax error: invalid value of key '%s'", key)
+		}
+
+		index, ok := parser.columnIndexes[key]
+		if !ok {
+			if !initColumns {
+				return errors.Errorf("unknown key '%s', the columns are %v", key, parser.columns)
+			}
+			index = len(parser.columns)
+			parser.columns = append(parser.columns, key)
+			parser.columnIndexes[key] = index
+			row.Row = append(row.Row, types.Datum{})
+			parser.seen = append(parser.seen, false)
+		}
+		if parser.seen[index] {
+			return errors.Errorf("duplicated key '%s'", key)
+		}
+		parser.seen[index] = true
+		if err = setDatumByJSON(&row.Row[index], value); err != nil {
+			return errors.Annotatef(err, "invalid value of key '%s'", key)
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return errors.Annotate(err, "syntax error")
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("syntax error: unexpected content after the JSON object")
+	}
+	return nil
+}
+
+// resetRow fills the row with NULLs.
+func (parser *JSONLParser) resetRow() {
+	row := &parser.lastRow
+	if cap(row.Row) >= len(parser.columns) {
+		row.Row = row.Row[:len(parser.columns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.columns))
+	}
+	for i := range row.Row {
+		row.Row[i].SetNull()
+	}
+	if len(parser.seen) != len(parser.columns) {
+		parser.seen = make([]bool, len(parser.columns))
+	}
+	for i := range parser.seen {
+		parser.seen[i] = false
+	}
+}
+
+// setDatumByJSON converts a JSON value to a Datum. Numbers are kept in their
+// textual form to avoid losing precision, they are converted to the column
+// type by the encoder. Booleans are kept as JSON literals, which are converted
+// to 1 and 0 for numeric columns.
+func setDatumByJSON(d *types.Datum, value json.RawMessage) error {
+	switch value[0] {
+	case 'n':
+		d.SetNull()
+	case 't':
+		d.SetMysqlJSON(binaryJSON.CreateBinary(true))
+	case 'f':
+		d.SetMysqlJSON(binaryJSON.CreateBinary(false))
+	case '"':
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(s, "utf8mb4_bin")
+	case '{', '[':
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(compacted.String(), "utf8mb4_bin")
+	default:
+		d.SetString(string(value), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// estimateJSONLRows returns the upper bound of the row count of a range of a
+// JSON Lines file.
+func estimateJSONLRows(size int64) int64 {
+	return (size + jsonlMinRowSize - 1) / jsonlMinRowSize
+}
+
+func (parser *JSONLParser) logInvalidLine(line []byte) {
+	content := line
+	if len(content) > 256 {
+		content = content[:256]
+	}
+	parser.Logger.Error("syntax error",
+		zap.Int64("pos", parser.pos),
+		zap.ByteString("content", content),
+	)
+}
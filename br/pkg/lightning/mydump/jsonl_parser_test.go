@@ -0,0 +1,148 @@
+package mydump_test
+
+import (
+	"context"
+	"io"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+)
+
+var _ = Suite(&testMydumpJSONLParserSuite{})
+
+type testMydumpJSONLParserSuite struct {
+	ioWorkers *worker.Pool
+}
+
+func (s *testMydumpJSONLParserSuite) SetUpSuite(c *C) {
+	s.ioWorkers = worker.NewPool(context.Background(), 5, "test_jsonl")
+}
+func (s *testMydumpJSONLParserSuite) TearDownSuite(c *C) {}
+
+func (s *testMydumpJSONLParserSuite) TestReadRow(c *C) {
+	input := `{"ID": 1, "name": "a\"b", "price": 1.50, "ok": true, "extra": {"x": [1, 2]}}
+{"id": 2, "name": null, "ok": false}
+
+{"ok": true, "id": 123456789012345678901234567890}` + "\r\n" + `{"id": 4, "extra": [ "s" ]}`
+
+	parser := mydump.NewJSONLParser(mydump.NewStringReader(input), 16, s.ioWorkers)
+
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.Columns(), DeepEquals, []string{"id", "name", "price", "ok", "extra"})
+	c.Assert(parser.LastRow(), DeepEquals, mydump.Row{
+		RowID: 1,
+		Row: []types.Datum{
+			types.NewStringDatum("1"),
+			types.NewStringDatum(`a"b`),
+			types.NewStringDatum("1.50"),
+			types.NewJSONDatum(json.CreateBinary(true)),
+			types.NewStringDatum(`{"x":[1,2]}`),
+		},
+		Length: 76,
+	})
+	c.Assert(parser, posEq, 77, 1)
+
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.LastRow(), DeepEquals, mydump.Row{
+		RowID: 2,
+		Row: []types.Datum{
+			types.NewStringDatum("2"),
+			nullDatum,
+			nullDatum,
+			types.NewJSONDatum(json.CreateBinary(false)),
+			nullDatum,
+		},
+		Length: 36,
+	})
+	c.Assert(parser, posEq, 114, 2)
+
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.LastRow().Row, DeepEquals, []types.Datum{
+		types.NewStringDatum("123456789012345678901234567890"),
+		nullDatum,
+		nullDatum,
+		types.NewJSONDatum(json.CreateBinary(true)),
+		nullDatum,
+	})
+	c.Assert(parser, posEq, 167, 3)
+
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.LastRow().Row, DeepEquals, []types.Datum{
+		types.NewStringDatum("4"),
+		nullDatum,
+		nullDatum,
+		nullDatum,
+		types.NewStringDatum(`["s"]`),
+	})
+	c.Assert(parser, posEq, 194, 4)
+
+	c.Assert(errors.Cause(parser.ReadRow()), Equals, io.EOF)
+}
+
+func (s *testMydumpJSONLParserSuite) TestSetColumns(c *C) {
+	input := `{"b": 2, "A": 1}` + "\n" + `{"c": 3}` + "\n" + `{"d": 4}`
+	parser := mydump.NewJSONLParser(mydump.NewStringReader(input), 16, s.ioWorkers)
+	parser.SetColumns([]string{"a", "B", "c"})
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.LastRow().Row, DeepEquals, []types.Datum{
+		types.NewStringDatum("1"),
+		types.NewStringDatum("2"),
+		nullDatum,
+	})
+
+	// keys missing from the first row are still accepted.
+	c.Assert(parser.ReadRow(), IsNil)
+	c.Assert(parser.LastRow().Row, DeepEquals, []types.Datum{
+		nullDatum,
+		nullDatum,
+		types.NewStringDatum("3"),
+	})
+
+	c.Assert(parser.ReadRow(), ErrorMatches, "unknown key 'd'.*")
+}
+
+func (s *testMydumpJSONLParserSuite) TestReadUntilTerminator(c *C) {
+	input := "{\"a\": 1}\n{\"a\": 22}\n{\"a\": 333}"
+	cases := []struct {
+		pos      int64
+		expected int64
+		err      error
+	}{
+		{3, 9, nil},
+		{9, 19, nil},
+		{12, 19, nil},
+		{22, int64(len(input)), io.EOF},
+	}
+	for _, tc := range cases {
+		parser := mydump.NewJSONLParser(mydump.NewStringReader(input), 4, s.ioWorkers)
+		c.Assert(parser.SetPos(tc.pos, 0), IsNil)
+		pos, err := parser.ReadUntilTerminator()
+		c.Assert(errors.Cause(err), Equals, tc.err, Commentf("pos = %d", tc.pos))
+		c.Assert(pos, Equals, tc.expected, Commentf("pos = %d", tc.pos))
+	}
+}
+
+func (s *testMydumpJSONLParserSuite) TestInvalidRows(c *C) {
+	cases := []struct {
+		input string
+		err   string
+	}{
+		{`[1, 2]`, "syntax error: expect a JSON object.*"},
+		{`{"a": 1`, "syntax error.*"},
+		{`{"a": 1} {"a": 2}`, "syntax error: unexpected content after the JSON object"},
+		{`{"a": 1, "A": 2}`, "duplicated key 'a'"},
+		{"{\"a\": 1}\n{\"b\": 2}", "unknown key 'b'.*"},
+	}
+	for _, tc := range cases {
+		parser := mydump.NewJSONLParser(mydump.NewStringReader(tc.input), 16, s.ioWorkers)
+		var err error
+		for err == nil {
+			err = parser.ReadRow()
+		}
+		c.Assert(err, ErrorMatches, tc.err, Commentf("input = %q", tc.input))
+	}
+}
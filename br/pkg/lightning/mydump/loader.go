@@ -308,7 +308,7 @@ func (s *mdLoaderSetup) listFiles(ctx context.Context, store storage.ExternalSto
 			s.tableSchemas = append(s.tableSchemas, info)
 		case SourceTypeViewSchema:
 			s.viewSchemas = append(s.viewSchemas, info)
-		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet:
+		case SourceTypeSQL, SourceTypeCSV, SourceTypeParquet, SourceTypeJSONL, SourceTypeAvro:
 			s.tableDatas = append(s.tableDatas, info)
 		}
 
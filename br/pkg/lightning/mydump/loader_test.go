@@ -285,6 +285,23 @@ func (s *testMydumpLoaderSuite) TestDataWithoutSchema(c *C) {
 	}})
 }
 
+func (s *testMydumpLoaderSuite) TestJSONLAndAvroFiles(c *C) {
+	s.touch(c, "db.tbl-schema.sql")
+	s.touch(c, "db.tbl.001.jsonl")
+	s.touch(c, "db.tbl.002.avro")
+
+	mdl, err := md.NewMyDumpLoader(context.Background(), s.cfg)
+	c.Assert(err, IsNil)
+	dbs := mdl.GetDatabases()
+	c.Assert(dbs, HasLen, 1)
+	c.Assert(dbs[0].Tables, HasLen, 1)
+	tableName := filter.Table{Schema: "db", Name: "tbl"}
+	c.Assert(dbs[0].Tables[0].DataFiles, DeepEquals, []md.FileInfo{
+		{TableName: tableName, FileMeta: md.SourceFileMeta{Path: "db.tbl.001.jsonl", Type: md.SourceTypeJSONL, SortKey: "001"}},
+		{TableName: tableName, FileMeta: md.SourceFileMeta{Path: "db.tbl.002.avro", Type: md.SourceTypeAvro, SortKey: "002"}},
+	})
+}
+
 func (s *testMydumpLoaderSuite) TestTablesWithDots(c *C) {
 	s.touch(c, "db-schema-create.sql")
 	s.touch(c, "db.tbl.with.dots-schema.sql")
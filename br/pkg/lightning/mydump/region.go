@@ -256,12 +256,26 @@ func makeSourceFileRegion(
 	ioWorkers *worker.Pool,
 	store storage.ExternalStorage,
 ) ([]*TableRegion, []float64, error) {
-	if fi.FileMeta.Type == SourceTypeParquet {
+	switch fi.FileMeta.Type {
+	case SourceTypeParquet:
 		_, region, err := makeParquetFileRegion(ctx, store, meta, fi, 0)
 		if err != nil {
 			return nil, nil, err
 		}
 		return []*TableRegion{region}, []float64{float64(fi.FileMeta.FileSize)}, nil
+	case SourceTypeAvro:
+		_, region, err := makeAvroFileRegion(ctx, store, meta, fi, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []*TableRegion{region}, []float64{float64(fi.FileMeta.FileSize)}, nil
+	case SourceTypeJSONL:
+		// every row of a JSON Lines file ends with a new line, so it can always
+		// be split without requiring the strict format.
+		if fi.FileMeta.FileSize > int64(cfg.Mydumper.MaxRegionSize+cfg.Mydumper.MaxRegionSize/largeCSVLowerThresholdRation) {
+			_, regions, subFileSizes, err := SplitLargeFile(ctx, meta, cfg, fi, jsonlMinRowSize, 0, ioWorkers, store)
+			return regions, subFileSizes, err
+		}
 	}
 
 	dataFileSize := fi.FileMeta.FileSize
@@ -270,6 +284,10 @@ func makeSourceFileRegion(
 	if !isCsvFile {
 		divisor += 2
 	}
+	rowIDMax := fi.FileMeta.FileSize / divisor
+	if fi.FileMeta.Type == SourceTypeJSONL {
+		rowIDMax = estimateJSONLRows(fi.FileMeta.FileSize)
+	}
 	// If a csv file is overlarge, we need to split it into multiple regions.
 	// Note: We can only split a csv file whose format is strict.
 	// We increase the check threshold by 1/10 of the `max-region-size` because the source file size dumped by tools
@@ -288,7 +306,7 @@ func makeSourceFileRegion(
 			Offset:       0,
 			EndOffset:    fi.FileMeta.FileSize,
 			PrevRowIDMax: 0,
-			RowIDMax:     fi.FileMeta.FileSize / divisor,
+			RowIDMax:     rowIDMax,
 		},
 	}
 
@@ -333,8 +351,43 @@ func makeParquetFileRegion(
 	return rowIDMax, region, nil
 }
 
-// SplitLargeFile splits a large csv file into multiple regions, the size of
-// each regions is specified by `config.MaxRegionSize`.
+// makeAvroFileRegion makes a region for the whole Avro file. Like parquet
+// files, the offset is the row number.
+func makeAvroFileRegion(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	meta *MDTableMeta,
+	dataFile FileInfo,
+	prevRowIdxMax int64,
+) (int64, *TableRegion, error) {
+	numberRows, err := ReadAvroFileRowCount(ctx, store, dataFile.FileMeta.Path)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	rowIDMax := prevRowIdxMax + numberRows
+	region := &TableRegion{
+		DB:       meta.DB,
+		Table:    meta.Name,
+		FileMeta: dataFile.FileMeta,
+		Chunk: Chunk{
+			Offset:       0,
+			EndOffset:    numberRows,
+			PrevRowIDMax: prevRowIdxMax,
+			RowIDMax:     rowIDMax,
+		},
+	}
+	return rowIDMax, region, nil
+}
+
+// lineSplitter is a parser which can skip to the beginning of the next row.
+type lineSplitter interface {
+	SetPos(pos int64, rowID int64) error
+	ReadUntilTerminator() (int64, error)
+	Close() error
+}
+
+// SplitLargeFile splits a large csv or JSON Lines file into multiple regions,
+// the size of each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
 // strict.
 // e.g.
@@ -354,7 +407,8 @@ func SplitLargeFile(
 	dataFileSizes = make([]float64, 0, dataFile.FileMeta.FileSize/maxRegionSize+1)
 	startOffset, endOffset := int64(0), maxRegionSize
 	var columns []string
-	if cfg.Mydumper.CSV.Header {
+	isJSONLFile := dataFile.FileMeta.Type == SourceTypeJSONL
+	if cfg.Mydumper.CSV.Header && !isJSONLFile {
 		r, err := store.Open(ctx, dataFile.FileMeta.Path)
 		if err != nil {
 			return 0, nil, nil, err
@@ -380,20 +434,28 @@ func SplitLargeFile(
 	}
 	for {
 		curRowsCnt := (endOffset - startOffset) / divisor
+		if isJSONLFile {
+			curRowsCnt = estimateJSONLRows(endOffset - startOffset)
+		}
 		rowIDMax := prevRowIdxMax + curRowsCnt
 		if endOffset != dataFile.FileMeta.FileSize {
 			r, err := store.Open(ctx, dataFile.FileMeta.Path)
 			if err != nil {
 				return 0, nil, nil, err
 			}
-			// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
-			charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
-			if err != nil {
-				return 0, nil, nil, err
-			}
-			parser, err := NewCSVParser(&cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
-			if err != nil {
-				return 0, nil, nil, err
+			var parser lineSplitter
+			if isJSONLFile {
+				parser = NewJSONLParser(r, int64(cfg.Mydumper.ReadBlockSize), ioWorker)
+			} else {
+				// Create a utf8mb4 convertor to encode and decode data with the charset of CSV files.
+				charsetConvertor, err := NewCharsetConvertor(cfg.Mydumper.DataCharacterSet, cfg.Mydumper.DataInvalidCharReplace)
+				if err != nil {
+					return 0, nil, nil, err
+				}
+				parser, err = NewCSVParser(&cfg.Mydumper.CSV, r, int64(cfg.Mydumper.ReadBlockSize), ioWorker, false, charsetConvertor)
+				if err != nil {
+					return 0, nil, nil, err
+				}
 			}
 			if err = parser.SetPos(endOffset, prevRowIDMax); err != nil {
 				return 0, nil, nil, err
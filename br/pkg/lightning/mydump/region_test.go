@@ -227,6 +227,49 @@ func (s *testMydumpRegionSuite) TestSplitLargeFile(c *C) {
 	}
 }
 
+func (s *testMydumpRegionSuite) TestSplitLargeJSONLFile(c *C) {
+	meta := &MDTableMeta{
+		DB:   "jsonl",
+		Name: "large_jsonl_file",
+	}
+	cfg := &config.Config{
+		Mydumper: config.MydumperRuntime{
+			ReadBlockSize: config.ReadBlockSize,
+			CSV: config.CSVConfig{
+				// the CSV header setting doesn't apply to JSON Lines files.
+				Header: true,
+			},
+			MaxRegionSize: 10,
+			Filter:        []string{"*.*"},
+		},
+	}
+
+	dir := c.MkDir()
+	fileName := "test.jsonl"
+	content := []byte("{\"a\": 1}\n{\"a\": 22}\n{\"a\": 333}\n{\"a\": 4}")
+	c.Assert(os.WriteFile(filepath.Join(dir, fileName), content, 0o644), IsNil)
+	fileInfo := FileInfo{FileMeta: SourceFileMeta{Path: fileName, Type: SourceTypeJSONL, FileSize: int64(len(content))}}
+
+	ioWorker := worker.NewPool(context.Background(), 4, "io")
+	store, err := storage.NewLocalStorage(dir)
+	c.Assert(err, IsNil)
+
+	_, regions, sizes, err := SplitLargeFile(context.Background(), meta, cfg, fileInfo, 1, 0, ioWorker, store)
+	c.Assert(err, IsNil)
+	offsets := [][]int64{{0, 19}, {19, 30}, {30, 38}}
+	c.Assert(regions, HasLen, len(offsets))
+	c.Assert(sizes, HasLen, len(offsets))
+	prevRowIDMax := int64(0)
+	for i := range offsets {
+		c.Assert(regions[i].Chunk.Offset, Equals, offsets[i][0])
+		c.Assert(regions[i].Chunk.EndOffset, Equals, offsets[i][1])
+		c.Assert(regions[i].Chunk.PrevRowIDMax, Equals, prevRowIDMax)
+		c.Assert(regions[i].Chunk.RowIDMax > prevRowIDMax, IsTrue)
+		c.Assert(regions[i].Chunk.Columns, IsNil)
+		prevRowIDMax = regions[i].Chunk.RowIDMax
+	}
+}
+
 func (s *testMydumpRegionSuite) TestSplitLargeFileNoNewLineAtEOF(c *C) {
 	meta := &MDTableMeta{
 		DB:   "csv",
@@ -21,6 +21,8 @@ const (
 	SourceTypeCSV
 	SourceTypeParquet
 	SourceTypeViewSchema
+	SourceTypeJSONL
+	SourceTypeAvro
 )
 
 const (
@@ -30,6 +32,8 @@ const (
 	TypeSQL      = "sql"
 	TypeCSV      = "csv"
 	TypeParquet  = "parquet"
+	TypeJSONL    = "jsonl"
+	TypeAvro     = "avro"
 	TypeIgnore   = "ignore"
 )
 
@@ -55,6 +59,10 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSONL:
+		return SourceTypeJSONL, nil
+	case TypeAvro:
+		return SourceTypeAvro, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -76,6 +84,10 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSONL:
+		return TypeJSONL
+	case SourceTypeAvro:
+		return TypeAvro
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -111,8 +123,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema\.sql$`, Schema: "$1", Table: "$2", Type: TableSchema},
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql$`, Schema: "$1", Table: "$2", Type: ViewSchema},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3"},
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|jsonl|avro}'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|jsonl|avro)$`, Schema: "$1", Table: "$2", Type: "$4", Key: "$3"},
 }
 
 // // RouteRule is a rule to route file path to target schema/table
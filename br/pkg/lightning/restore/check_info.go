@@ -17,6 +17,7 @@ package restore
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -24,6 +25,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
@@ -67,6 +69,16 @@ const (
 	checkRegionCntRatioThreshold = 1000
 )
 
+// importSpeeds are the rough import speeds (bytes of source files per hour) of the backends,
+// which are used to estimate the import duration in the pre-check report. They are typical
+// speeds rather than measurements of the cluster, so the report labels the duration as a
+// rough estimate.
+var importSpeeds = map[string]float64{
+	config.BackendLocal:    300 * units.GiB,
+	config.BackendImporter: 100 * units.GiB,
+	config.BackendTiDB:     30 * units.GiB,
+}
+
 func (rc *Controller) isSourceInLocal() bool {
 	return strings.HasPrefix(rc.store.URI(), storage.LocalURIPrefix)
 }
@@ -359,6 +371,24 @@ func (rc *Controller) StoragePermission(ctx context.Context) error {
 	return nil
 }
 
+// CheckTableLock checks whether `enable-table-lock` is set in TiDB, which is required to pause the
+// writes to the target tables during the incremental import.
+func (rc *Controller) CheckTableLock(ctx context.Context) error {
+	enabled, err := TableLockEnabled(ctx, rc.tidbGlue.GetSQLExecutor())
+	if err != nil {
+		rc.checkTemplate.Collect(Warn, false, fmt.Sprintf("failed to check `enable-table-lock` of TiDB, "+
+			"the target tables can't be set to read-only during the incremental import without it: %s", err.Error()))
+		return nil
+	}
+	if !enabled {
+		rc.checkTemplate.Collect(Critical, false, "`enable-table-lock` is not set in TiDB, "+
+			"which is required to set the target tables to read-only during the incremental import")
+		return nil
+	}
+	rc.checkTemplate.Collect(Critical, true, "`enable-table-lock` is set in TiDB")
+	return nil
+}
+
 // HasLargeCSV checks whether input csvs is fit for Lightning import.
 // If strictFormat is false, and csv file is large. Lightning will have performance issue.
 // this test cannot be skipped.
@@ -399,7 +429,10 @@ func (rc *Controller) estimateSourceData(ctx context.Context) (int64, error) {
 		for _, tbl := range db.Tables {
 			originSource += tbl.TotalSize
 			tableInfo, ok := info.Tables[tbl.Name]
-			if ok {
+			if !ok {
+				// the table hasn't been created yet in the pre-check, so it can't be sampled.
+				sourceSize += tbl.TotalSize
+			} else {
 				// Do not sample small table because there may a large number of small table and it will take a long
 				// time to sample data for all of them.
 				if rc.cfg.TikvImporter.Backend == config.BackendTiDB || tbl.TotalSize < int64(config.SplitRegionSize) {
@@ -486,6 +519,140 @@ func (rc *Controller) localResource(sourceSize int64) error {
 	return nil
 }
 
+// PreCheck runs all the checks against the config without importing any data, and returns the report.
+// The tables not created yet are considered valid if their schema files are provided.
+func (rc *Controller) PreCheck(ctx context.Context) (*PreCheckReport, error) {
+	if err := rc.ClusterIsAvailable(ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := rc.StoragePermission(ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	getTableFunc := rc.backend.FetchRemoteTableModels
+	if !rc.tidbGlue.OwnsSQLExecutor() {
+		getTableFunc = rc.tidbGlue.GetTables
+	}
+	dbInfos, err := loadExistingSchemaInfo(ctx, rc.dbMetas, getTableFunc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rc.dbInfos = dbInfos
+	if err := rc.DataCheck(ctx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	report := &PreCheckReport{}
+	for _, db := range rc.dbMetas {
+		for _, tbl := range db.Tables {
+			report.SourceSize += tbl.TotalSize
+		}
+	}
+	estimatedSize, err := rc.estimateSourceData(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rc.isLocalBackend() {
+		report.EstimatedLocalSize = estimatedSize
+		if err := rc.localResource(estimatedSize); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := rc.clusterResource(ctx, estimatedSize); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := rc.checkClusterRegion(ctx); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if !rc.isTiDBBackend() {
+		replicaCount, err := rc.getReplicaCount(ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		report.EstimatedClusterSize = estimatedSize * int64(replicaCount)
+	}
+	if speed, ok := importSpeeds[rc.cfg.TikvImporter.Backend]; ok {
+		report.EstimatedDuration = time.Duration(float64(report.SourceSize) / speed * float64(time.Hour))
+	}
+
+	report.Passed = rc.checkTemplate.Success()
+	report.CriticalFailedCount = rc.checkTemplate.FailedCount(Critical)
+	report.WarnFailedCount = rc.checkTemplate.FailedCount(Warn)
+	report.Checks = rc.checkTemplate.Results()
+	return report, nil
+}
+
+// loadExistingSchemaInfo is like LoadSchemaInfo, but skips the databases and tables not existing in TiDB.
+// The missing databases are tolerated only if their schema files are provided.
+func loadExistingSchemaInfo(
+	ctx context.Context,
+	schemas []*mydump.MDDatabaseMeta,
+	getTables func(context.Context, string) ([]*model.TableInfo, error),
+) (map[string]*checkpoints.TidbDBInfo, error) {
+	result := make(map[string]*checkpoints.TidbDBInfo, len(schemas))
+	for _, schema := range schemas {
+		dbInfo := &checkpoints.TidbDBInfo{
+			Name:   schema.Name,
+			Tables: make(map[string]*checkpoints.TidbTableInfo),
+		}
+		result[schema.Name] = dbInfo
+
+		tables, err := getTables(ctx, schema.Name)
+		if err != nil {
+			if schema.SchemaFile == "" {
+				return nil, errors.Trace(err)
+			}
+			log.L().Info("database not found, it will be created from the schema file",
+				zap.String("db", schema.Name), log.ShortError(err))
+			continue
+		}
+		tableMap := make(map[string]*model.TableInfo, len(tables))
+		for _, tbl := range tables {
+			tableMap[tbl.Name.L] = tbl
+		}
+		for _, tbl := range schema.Tables {
+			tblInfo, ok := tableMap[strings.ToLower(tbl.Name)]
+			if !ok {
+				continue
+			}
+			dbInfo.Tables[tblInfo.Name.String()] = &checkpoints.TidbTableInfo{
+				ID:   tblInfo.ID,
+				DB:   schema.Name,
+				Name: tblInfo.Name.String(),
+				Core: tblInfo,
+			}
+		}
+	}
+	return result, nil
+}
+
+// TableHasDataInCluster checks whether the target table already contains data.
+func (rc *Controller) TableHasDataInCluster(ctx context.Context, tableInfo *mydump.MDTableMeta) (bool, error) {
+	if _, ok := rc.dbInfos[tableInfo.DB].Tables[tableInfo.Name]; !ok {
+		// the table doesn't exist, which is reported by SchemaIsValid.
+		return false, nil
+	}
+	db, err := rc.tidbGlue.GetDB()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	exec := common.SQLWithRetry{
+		DB:     db,
+		Logger: log.L(),
+	}
+	var dummy int
+	query := fmt.Sprintf("SELECT 1 FROM %s LIMIT 1", common.UniqueTable(tableInfo.DB, tableInfo.Name))
+	err = exec.QueryRow(ctx, "check table empty", query, &dummy)
+	switch {
+	case errors.ErrorEqual(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, errors.Trace(err)
+	default:
+		return true, nil
+	}
+}
+
 // CheckpointIsValid checks whether we can start this import with this checkpoint.
 func (rc *Controller) CheckpointIsValid(ctx context.Context, tableInfo *mydump.MDTableMeta) ([]string, bool, error) {
 	msgs := make([]string, 0)
@@ -609,6 +776,13 @@ func (rc *Controller) readColumnsAndCount(ctx context.Context, dataFileMeta mydu
 		if err != nil {
 			return nil, 0, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONL:
+		parser = mydump.NewJSONLParser(reader, blockBufSize, rc.ioWorkers)
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(reader)
+		if err != nil {
+			return nil, 0, errors.Trace(err)
+		}
 	default:
 		panic(fmt.Sprintf("unknown file type '%s'", dataFileMeta.Type))
 	}
@@ -665,7 +839,9 @@ func (rc *Controller) SchemaIsValid(ctx context.Context, tableInfo *mydump.MDTab
 		// get columns name from data file.
 		dataFileMeta := dataFile.FileMeta
 
-		if tp := dataFileMeta.Type; tp != mydump.SourceTypeCSV && tp != mydump.SourceTypeSQL && tp != mydump.SourceTypeParquet {
+		switch dataFileMeta.Type {
+		case mydump.SourceTypeCSV, mydump.SourceTypeSQL, mydump.SourceTypeParquet, mydump.SourceTypeJSONL, mydump.SourceTypeAvro:
+		default:
 			msgs = append(msgs, fmt.Sprintf("file '%s' with unknown source type '%s'", dataFileMeta.Path, dataFileMeta.Type.String()))
 			return msgs, nil
 		}
@@ -763,6 +939,7 @@ func (rc *Controller) sampleDataFromTable(ctx context.Context, dbName string, ta
 		Timestamp:      0,
 		SysVars:        rc.sysVars,
 		AutoRandomSeed: 0,
+		FillNullAutoID: fillNullAutoID(tableMeta.DataFiles[0].FileMeta.Type),
 	})
 	blockBufSize := int64(rc.cfg.Mydumper.ReadBlockSize)
 
@@ -786,6 +963,17 @@ func (rc *Controller) sampleDataFromTable(ctx context.Context, dbName string, ta
 		if err != nil {
 			return errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONL:
+		jsonlParser := mydump.NewJSONLParser(reader, blockBufSize, rc.ioWorkers)
+		jsonlParser.SetColumns(getNonGeneratedColumnNames(tableInfo))
+		parser = jsonlParser
+	case mydump.SourceTypeAvro:
+		avroParser, err := mydump.NewAvroParser(reader)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		avroParser.SetColumns(getNonGeneratedColumnNames(tableInfo))
+		parser = avroParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", sampleFile.Path, sampleFile.Type.String()))
 	}
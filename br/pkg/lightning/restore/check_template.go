@@ -15,7 +15,12 @@
 package restore
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -41,6 +46,16 @@ type Template interface {
 
 	// Output print all checks results.
 	Output() string
+
+	// Results returns all checks results in the collected order.
+	Results() []CheckResult
+}
+
+// CheckResult is the result of a single check.
+type CheckResult struct {
+	Item   string    `json:"item"`
+	Type   CheckType `json:"type"`
+	Passed bool      `json:"passed"`
 }
 
 type SimpleTemplate struct {
@@ -48,6 +63,7 @@ type SimpleTemplate struct {
 	warnFailedCount     int
 	criticalFailedCount int
 	t                   table.Writer
+	results             []CheckResult
 }
 
 func NewSimpleTemplate() Template {
@@ -64,6 +80,7 @@ func NewSimpleTemplate() Template {
 		0,
 		0,
 		t,
+		nil,
 	}
 }
 
@@ -79,6 +96,11 @@ func (c *SimpleTemplate) Collect(t CheckType, passed bool, msg string) {
 	}
 	c.t.AppendRow(table.Row{c.count, msg, t, passed})
 	c.t.AppendSeparator()
+	c.results = append(c.results, CheckResult{Item: msg, Type: t, Passed: passed})
+}
+
+func (c *SimpleTemplate) Results() []CheckResult {
+	return c.results
 }
 
 func (c *SimpleTemplate) Success() bool {
@@ -126,3 +148,58 @@ func (c *SimpleTemplate) Output() string {
 	}
 	return res + summary
 }
+
+// PreCheckReport is the report of the pre-checks, which can be rendered as JSON or Markdown.
+type PreCheckReport struct {
+	Passed              bool          `json:"passed"`
+	CriticalFailedCount int           `json:"critical-failed-count"`
+	WarnFailedCount     int           `json:"warn-failed-count"`
+	Checks              []CheckResult `json:"checks"`
+	// SourceSize is the total size of the source files.
+	SourceSize int64 `json:"source-size"`
+	// EstimatedLocalSize is the estimated size of the sorted KV pairs, which is the local disk
+	// requirement of the local backend.
+	EstimatedLocalSize int64 `json:"estimated-local-size"`
+	// EstimatedClusterSize is the estimated size of the imported data in TiKV including all replicas.
+	EstimatedClusterSize int64 `json:"estimated-cluster-size"`
+	// EstimatedDuration is a rough estimate of the import duration, which assumes a fixed
+	// import speed of the backend regardless of the cluster and the data.
+	EstimatedDuration time.Duration `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, which reports the duration in seconds.
+func (r *PreCheckReport) MarshalJSON() ([]byte, error) {
+	type report PreCheckReport
+	return json.Marshal(&struct {
+		*report
+		EstimatedDuration int64 `json:"estimated-duration-seconds"`
+	}{
+		report:            (*report)(r),
+		EstimatedDuration: int64(r.EstimatedDuration.Seconds()),
+	})
+}
+
+// Markdown renders the report as a Markdown document.
+func (r *PreCheckReport) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString("# TiDB Lightning Pre-check Report\n\n")
+	if r.Passed {
+		sb.WriteString("**Result**: passed\n\n")
+	} else {
+		sb.WriteString("**Result**: failed\n\n")
+	}
+	fmt.Fprintf(&sb, "- Critical checks failed: %d\n", r.CriticalFailedCount)
+	fmt.Fprintf(&sb, "- Performance checks failed: %d\n", r.WarnFailedCount)
+	fmt.Fprintf(&sb, "- Source size: %s\n", units.BytesSize(float64(r.SourceSize)))
+	fmt.Fprintf(&sb, "- Estimated local disk size: %s\n", units.BytesSize(float64(r.EstimatedLocalSize)))
+	fmt.Fprintf(&sb, "- Estimated cluster size: %s\n", units.BytesSize(float64(r.EstimatedClusterSize)))
+	fmt.Fprintf(&sb, "- Estimated duration: %s (rough estimate assuming a fixed import speed of the backend)\n\n", r.EstimatedDuration)
+
+	sb.WriteString("| # | Check Item | Type | Passed |\n")
+	sb.WriteString("|---|---|---|---|\n")
+	escaper := strings.NewReplacer("|", "\\|", "\n", "<br>")
+	for i, check := range r.Checks {
+		fmt.Fprintf(&sb, "| %d | %s | %s | %t |\n", i+1, escaper.Replace(check.Item), check.Type, check.Passed)
+	}
+	return sb.String()
+}
@@ -0,0 +1,105 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "github.com/pingcap/check"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+)
+
+var _ = Suite(&checkTemplateSuite{})
+
+type checkTemplateSuite struct{}
+
+func (s *checkTemplateSuite) TestPreCheckReport(c *C) {
+	template := NewSimpleTemplate()
+	template.Collect(Critical, true, "Cluster is available")
+	template.Collect(Warn, false, "large csv: a|b.csv file exists\nand it will slow down import performance")
+	template.Collect(Critical, false, "table(s) [`db`.`t`] are not empty")
+	c.Assert(template.Results(), DeepEquals, []CheckResult{
+		{Item: "Cluster is available", Type: Critical, Passed: true},
+		{Item: "large csv: a|b.csv file exists\nand it will slow down import performance", Type: Warn, Passed: false},
+		{Item: "table(s) [`db`.`t`] are not empty", Type: Critical, Passed: false},
+	})
+
+	report := &PreCheckReport{
+		Passed:               template.Success(),
+		CriticalFailedCount:  template.FailedCount(Critical),
+		WarnFailedCount:      template.FailedCount(Warn),
+		Checks:               template.Results(),
+		SourceSize:           1024,
+		EstimatedLocalSize:   2048,
+		EstimatedClusterSize: 6144,
+		EstimatedDuration:    90 * time.Second,
+	}
+
+	content, err := json.Marshal(report)
+	c.Assert(err, IsNil)
+	var decoded map[string]interface{}
+	c.Assert(json.Unmarshal(content, &decoded), IsNil)
+	c.Assert(decoded["passed"], Equals, false)
+	c.Assert(decoded["critical-failed-count"], Equals, float64(1))
+	c.Assert(decoded["warn-failed-count"], Equals, float64(1))
+	c.Assert(decoded["estimated-cluster-size"], Equals, float64(6144))
+	c.Assert(decoded["estimated-duration-seconds"], Equals, float64(90))
+	c.Assert(decoded["checks"], HasLen, 3)
+
+	markdown := report.Markdown()
+	c.Assert(strings.Contains(markdown, "**Result**: failed"), IsTrue)
+	c.Assert(strings.Contains(markdown, "- Estimated duration: 1m30s (rough estimate"), IsTrue)
+	c.Assert(strings.Contains(markdown, "| 2 | large csv: a\\|b.csv file exists<br>and it will slow down import performance | performance | false |"), IsTrue)
+}
+
+func (s *checkTemplateSuite) TestLoadExistingSchemaInfo(c *C) {
+	metas := []*mydump.MDDatabaseMeta{
+		{
+			Name: "db1",
+			Tables: []*mydump.MDTableMeta{
+				{DB: "db1", Name: "t1"},
+				{DB: "db1", Name: "t2"},
+			},
+		},
+		{
+			Name:       "db2",
+			SchemaFile: "db2-schema-create.sql",
+			Tables:     []*mydump.MDTableMeta{{DB: "db2", Name: "t3"}},
+		},
+	}
+	getTables := func(ctx context.Context, schema string) ([]*model.TableInfo, error) {
+		if schema == "db1" {
+			return []*model.TableInfo{{ID: 100, Name: model.NewCIStr("T1"), State: model.StatePublic}}, nil
+		}
+		return nil, errors.New("schema not found")
+	}
+
+	dbInfos, err := loadExistingSchemaInfo(context.Background(), metas, getTables)
+	c.Assert(err, IsNil)
+	c.Assert(dbInfos, HasLen, 2)
+	c.Assert(dbInfos["db1"].Tables, HasLen, 1)
+	c.Assert(dbInfos["db1"].Tables["T1"].ID, Equals, int64(100))
+	c.Assert(dbInfos["db2"].Tables, HasLen, 0)
+
+	// the missing database without schema file is an error.
+	metas[1].SchemaFile = ""
+	_, err = loadExistingSchemaInfo(context.Background(), metas, getTables)
+	c.Assert(err, ErrorMatches, "schema not found")
+}
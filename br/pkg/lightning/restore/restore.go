@@ -1429,7 +1429,7 @@ func (tr *TableRestore) restoreTable(
 	ctx context.Context,
 	rc *Controller,
 	cp *checkpoints.TableCheckpoint,
-) (bool, error) {
+) (needPostProcess bool, err error) {
 	// 1. Load the table info.
 
 	select {
@@ -1438,6 +1438,24 @@ func (tr *TableRestore) restoreTable(
 	default:
 	}
 
+	// pause the writes to the table during the incremental import, so that the existing data
+	// won't change until the imported data are merged. The writes are resumed in postProcess,
+	// or when the import of the table fails, and paused again when the import is resumed.
+	if rc.cfg.TikvImporter.IncrementalImport && cp.Status < checkpoints.CheckpointStatusAlteredAutoInc {
+		if err := AlterTableWritable(ctx, rc.tidbGlue.GetSQLExecutor(), tr.tableName, false); err != nil {
+			return false, errors.Trace(err)
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			// ctx may be canceled already.
+			if resumeErr := AlterTableWritable(context.Background(), rc.tidbGlue.GetSQLExecutor(), tr.tableName, true); resumeErr != nil {
+				tr.logger.Warn("failed to resume the writes to the table", log.ShortError(resumeErr))
+			}
+		}()
+	}
+
 	metaMgr := rc.metaMgrBuilder.TableMetaMgr(tr)
 	// no need to do anything if the chunks are already populated
 	if len(cp.Engines) > 0 {
@@ -1518,7 +1536,7 @@ func (tr *TableRestore) restoreTable(
 	}
 
 	// 2. Restore engines (if still needed)
-	err := tr.restoreEngines(ctx, rc, cp)
+	err = tr.restoreEngines(ctx, rc, cp)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
@@ -1752,6 +1770,12 @@ func (rc *Controller) preCheckRequirements(ctx context.Context) error {
 		if err := rc.StoragePermission(ctx); err != nil {
 			return errors.Trace(err)
 		}
+
+		if rc.cfg.TikvImporter.IncrementalImport {
+			if err := rc.CheckTableLock(ctx); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 
 	if err := rc.metaMgrBuilder.Init(ctx); err != nil {
@@ -1822,6 +1846,9 @@ func (rc *Controller) DataCheck(ctx context.Context) error {
 	}
 	checkPointCriticalMsgs := make([]string, 0, len(rc.dbMetas))
 	schemaCriticalMsgs := make([]string, 0, len(rc.dbMetas))
+	// the physical import overwrites the existing data unless it's an incremental import.
+	checkTableEmpty := rc.isLocalBackend() && !rc.cfg.TikvImporter.IncrementalImport
+	var nonEmptyTables []string
 	var msgs []string
 	for _, dbInfo := range rc.dbMetas {
 		for _, tableInfo := range dbInfo.Tables {
@@ -1837,6 +1864,12 @@ func (rc *Controller) DataCheck(ctx context.Context) error {
 				}
 			}
 
+			// the missing table will be created from the schema file, which only happens in the pre-check.
+			_, tableExists := rc.dbInfos[tableInfo.DB].Tables[tableInfo.Name]
+			if !tableExists && tableInfo.SchemaFile.FileMeta.Path != "" {
+				continue
+			}
+
 			if rc.cfg.App.CheckRequirements && noCheckpoint && rc.cfg.TikvImporter.Backend != config.BackendTiDB {
 				if msgs, err = rc.SchemaIsValid(ctx, tableInfo); err != nil {
 					return errors.Trace(err)
@@ -1845,6 +1878,16 @@ func (rc *Controller) DataCheck(ctx context.Context) error {
 					schemaCriticalMsgs = append(schemaCriticalMsgs, msgs...)
 				}
 			}
+
+			if rc.cfg.App.CheckRequirements && noCheckpoint && checkTableEmpty {
+				hasData, err := rc.TableHasDataInCluster(ctx, tableInfo)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				if hasData {
+					nonEmptyTables = append(nonEmptyTables, common.UniqueTable(tableInfo.DB, tableInfo.Name))
+				}
+			}
 		}
 	}
 	if len(checkPointCriticalMsgs) != 0 {
@@ -1857,6 +1900,12 @@ func (rc *Controller) DataCheck(ctx context.Context) error {
 	} else {
 		rc.checkTemplate.Collect(Critical, true, "table schemas are valid")
 	}
+	if len(nonEmptyTables) != 0 {
+		rc.checkTemplate.Collect(Critical, false, fmt.Sprintf("table(s) [%s] are not empty, "+
+			"please truncate them or set `tikv-importer.incremental-import` to true", strings.Join(nonEmptyTables, ", ")))
+	} else if rc.cfg.App.CheckRequirements && checkTableEmpty {
+		rc.checkTemplate.Collect(Critical, true, "target tables are empty")
+	}
 	return nil
 }
 
@@ -1908,6 +1957,21 @@ func newChunkRestore(
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+	case mydump.SourceTypeJSONL:
+		jsonlParser := mydump.NewJSONLParser(reader, blockBufSize, ioWorkers)
+		if len(chunk.ColumnPermutation) == 0 {
+			jsonlParser.SetColumns(getNonGeneratedColumnNames(tableInfo.Core))
+		}
+		parser = jsonlParser
+	case mydump.SourceTypeAvro:
+		avroParser, err := mydump.NewAvroParser(reader)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// the fields are always mapped to the table columns, so the column
+		// permutation in the checkpoint stays valid when resuming.
+		avroParser.SetColumns(getNonGeneratedColumnNames(tableInfo.Core))
+		parser = avroParser
 	default:
 		panic(fmt.Sprintf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String()))
 	}
@@ -1915,7 +1979,7 @@ func newChunkRestore(
 	if err = parser.SetPos(chunk.Chunk.Offset, chunk.Chunk.PrevRowIDMax); err != nil {
 		return nil, errors.Trace(err)
 	}
-	if len(chunk.ColumnPermutation) > 0 {
+	if len(chunk.ColumnPermutation) > 0 && chunk.FileMeta.Type != mydump.SourceTypeAvro {
 		parser.SetColumns(getColumnNames(tableInfo.Core, chunk.ColumnPermutation))
 	}
 
@@ -1930,6 +1994,26 @@ func (cr *chunkRestore) close() {
 	cr.parser.Close()
 }
 
+// fillNullAutoID returns whether a NULL auto ID is filled with a generated ID
+// for the source type. Missing keys of JSON Lines files and missing fields of
+// Avro files are decoded as NULL, so the NULL can't be an explicit value.
+func fillNullAutoID(sourceType mydump.SourceType) bool {
+	return sourceType == mydump.SourceTypeJSONL || sourceType == mydump.SourceTypeAvro
+}
+
+// getNonGeneratedColumnNames returns the columns which the keys of JSON Lines
+// files and the fields of Avro files are resolved against. Generated columns
+// are computed by the encoder, so they can't be provided by the files.
+func getNonGeneratedColumnNames(tableInfo *model.TableInfo) []string {
+	names := make([]string, 0, len(tableInfo.Columns))
+	for _, col := range tableInfo.Columns {
+		if !col.IsGenerated() {
+			names = append(names, col.Name.O)
+		}
+	}
+	return names
+}
+
 func getColumnNames(tableInfo *model.TableInfo, permutation []int) []string {
 	colIndexes := make([]int, 0, len(permutation))
 	for i := 0; i < len(permutation); i++ {
@@ -2289,6 +2373,7 @@ func (cr *chunkRestore) restore(
 		SysVars:   rc.sysVars,
 		// use chunk.PrevRowIDMax as the auto random seed, so it can stay the same value after recover from checkpoint.
 		AutoRandomSeed: cr.chunk.Chunk.PrevRowIDMax,
+		FillNullAutoID: fillNullAutoID(cr.chunk.FileMeta.Type),
 	})
 	if err != nil {
 		return err
@@ -2473,6 +2473,48 @@ func (s *tableRestoreSuite) TestGBKEncodedSchemaIsValid(c *C) {
 	c.Assert(msgs, HasLen, 0)
 }
 
+func (s *tableRestoreSuite) TestTableHasDataInCluster(c *C) {
+	db, mock, err := sqlmock.New()
+	c.Assert(err, IsNil)
+
+	mock.ExpectQuery("\\QSELECT 1 FROM `db1`.`t1` LIMIT 1\\E").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}).AddRow(1))
+	mock.ExpectQuery("\\QSELECT 1 FROM `db1`.`t2` LIMIT 1\\E").
+		WillReturnRows(sqlmock.NewRows([]string{"1"}))
+	mock.ExpectClose()
+
+	ctx := context.Background()
+	defaultSQLMode, err := mysql.GetSQLMode(mysql.DefaultSQLMode)
+	c.Assert(err, IsNil)
+	rc := &Controller{
+		cfg:      config.NewConfig(),
+		tidbGlue: glue.NewExternalTiDBGlue(db, defaultSQLMode),
+		dbInfos: map[string]*checkpoints.TidbDBInfo{
+			"db1": {
+				Name: "db1",
+				Tables: map[string]*checkpoints.TidbTableInfo{
+					"t1": {ID: 1, DB: "db1", Name: "t1"},
+					"t2": {ID: 2, DB: "db1", Name: "t2"},
+				},
+			},
+		},
+	}
+
+	hasData, err := rc.TableHasDataInCluster(ctx, &mydump.MDTableMeta{DB: "db1", Name: "t1"})
+	c.Assert(err, IsNil)
+	c.Assert(hasData, IsTrue)
+	hasData, err = rc.TableHasDataInCluster(ctx, &mydump.MDTableMeta{DB: "db1", Name: "t2"})
+	c.Assert(err, IsNil)
+	c.Assert(hasData, IsFalse)
+	// the missing table is not queried.
+	hasData, err = rc.TableHasDataInCluster(ctx, &mydump.MDTableMeta{DB: "db1", Name: "t3"})
+	c.Assert(err, IsNil)
+	c.Assert(hasData, IsFalse)
+
+	c.Assert(db.Close(), IsNil)
+	c.Assert(mock.ExpectationsWereMet(), IsNil)
+}
+
 type testChecksumMgr struct {
 	checksum RemoteChecksum
 	callCnt  int
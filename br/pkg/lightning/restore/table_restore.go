@@ -667,6 +667,13 @@ func (tr *TableRestore) postProcess(
 	forcePostProcess bool,
 	metaMgr tableMetaMgr,
 ) (bool, error) {
+	// resume the writes paused in restoreTable, which would block altering the auto id.
+	if rc.cfg.TikvImporter.IncrementalImport && cp.Status < checkpoints.CheckpointStatusAlteredAutoInc {
+		if err := AlterTableWritable(ctx, rc.tidbGlue.GetSQLExecutor(), tr.tableName, true); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
+
 	// there are no data in this table, no need to do post process
 	// this is important for tables that are just the dump table of views
 	// because at this stage, the table was already deleted and replaced by the related view
@@ -715,11 +722,17 @@ func (tr *TableRestore) postProcess(
 		tr.logger.Info("local checksum", zap.Object("checksum", &localChecksum))
 
 		// 4.5. do duplicate detection.
+		// the failure of the duplicate detection is only logged unless the
+		// duplicates should be resolved.
+		resolveDupe := rc.cfg.TikvImporter.DuplicateResolution != config.DupeResAlgNone
 		hasDupe := false
 		if rc.cfg.TikvImporter.DuplicateDetection {
 			var err error
 			hasDupe, err = rc.backend.CollectLocalDuplicateRows(ctx, tr.encTable)
 			if err != nil {
+				if resolveDupe {
+					return false, errors.Trace(err)
+				}
 				tr.logger.Error("collect local duplicate keys failed", log.ShortError(err))
 			}
 		}
@@ -732,6 +745,9 @@ func (tr *TableRestore) postProcess(
 		if needRemoteDupe && rc.cfg.TikvImporter.DuplicateDetection {
 			hasRemoteDupe, err := rc.backend.CollectRemoteDuplicateRows(ctx, tr.encTable)
 			if err != nil {
+				if resolveDupe {
+					return false, errors.Trace(err)
+				}
 				tr.logger.Error("collect remote duplicate keys failed", log.ShortError(err))
 				err = nil
 			}
@@ -960,8 +976,8 @@ func estimateCompactionThreshold(cp *checkpoints.TableCheckpoint, factor int64)
 				continue
 			}
 			size := chunk.FileMeta.FileSize
-			if chunk.FileMeta.Type == mydump.SourceTypeParquet {
-				// parquet file is compressed, thus estimates with a factor of 2
+			if tp := chunk.FileMeta.Type; tp == mydump.SourceTypeParquet || tp == mydump.SourceTypeAvro {
+				// parquet and avro files are compressed, thus estimates with a factor of 2
 				size *= 2
 			}
 			totalRawFileSize += size
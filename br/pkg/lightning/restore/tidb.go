@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
 	"github.com/pingcap/tidb/br/pkg/lightning/metric"
 	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/errno"
 	"go.uber.org/zap"
 )
 
@@ -398,6 +399,53 @@ func AlterAutoIncrement(ctx context.Context, g glue.SQLExecutor, tableName strin
 	return errors.Annotatef(err, "%s", query)
 }
 
+// AlterTableWritable pauses or resumes the writes to the table.
+//
+// NOTE: the statement takes effect only if `enable-table-lock` is set in TiDB, otherwise it's a no-op.
+// Pausing the writes to a table which is already read-only, e.g. left by a previous run which exited
+// unexpectedly, is not an error.
+func AlterTableWritable(ctx context.Context, g glue.SQLExecutor, tableName string, writable bool) error {
+	logger := log.With(zap.String("table", tableName), zap.Bool("writable", writable))
+	mode := "READ ONLY"
+	if writable {
+		mode = "READ WRITE"
+	}
+	query := fmt.Sprintf("ALTER TABLE %s %s", tableName, mode)
+	task := logger.Begin(zap.InfoLevel, "alter table writable")
+	err := g.ExecuteWithLog(ctx, query, "alter table writable", logger)
+	if err != nil && !writable && isTableReadOnlyErr(err) {
+		logger.Info("table is already read-only", log.ShortError(err))
+		err = nil
+	}
+	task.End(zap.ErrorLevel, err)
+	return errors.Annotatef(err, "%s", query)
+}
+
+// isTableReadOnlyErr checks whether the error is caused by the table being locked as READ ONLY.
+func isTableReadOnlyErr(err error) bool {
+	code, ok := getSQLErrCode(err)
+	return ok && code == errno.ErrTableLocked && strings.Contains(err.Error(), model.TableLockReadOnly.String())
+}
+
+// TableLockEnabled checks whether `enable-table-lock` is set on all TiDB instances.
+func TableLockEnabled(ctx context.Context, g glue.SQLExecutor) (bool, error) {
+	query := "SHOW CONFIG WHERE type = 'tidb' AND name = 'enable-table-lock'"
+	rows, err := g.QueryStringsWithLog(ctx, query, "check enable-table-lock", log.L())
+	if err != nil {
+		return false, errors.Annotatef(err, "%s", query)
+	}
+	if len(rows) == 0 {
+		return false, nil
+	}
+	// the columns are type, instance, name and value.
+	for _, row := range rows {
+		if len(row) < 4 || row[3] != "true" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func AlterAutoRandom(ctx context.Context, g glue.SQLExecutor, tableName string, randomBase int64) error {
 	logger := log.With(zap.String("table", tableName), zap.Int64("auto_random", randomBase))
 	query := fmt.Sprintf("ALTER TABLE %s AUTO_RANDOM_BASE=%d", tableName, randomBase)
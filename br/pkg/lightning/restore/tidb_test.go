@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/metric"
 	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
 	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/util/mock"
 )
 
@@ -428,6 +429,76 @@ func (s *tidbSuite) TestAlterAutoInc(c *C) {
 	c.Assert(err, IsNil)
 }
 
+func (s *tidbSuite) TestAlterTableWritable(c *C) {
+	ctx := context.Background()
+
+	s.mockDB.
+		ExpectExec("\\QALTER TABLE `db`.`table` READ ONLY\\E").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.
+		ExpectExec("\\QALTER TABLE `db`.`table` READ WRITE\\E").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	s.mockDB.
+		ExpectClose()
+
+	err := AlterTableWritable(ctx, s.tiGlue.GetSQLExecutor(), "`db`.`table`", false)
+	c.Assert(err, IsNil)
+	err = AlterTableWritable(ctx, s.tiGlue.GetSQLExecutor(), "`db`.`table`", true)
+	c.Assert(err, IsNil)
+}
+
+func (s *tidbSuite) TestAlterTableWritableAlreadyLocked(c *C) {
+	ctx := context.Background()
+
+	s.mockDB.
+		ExpectExec("\\QALTER TABLE `db`.`table` READ ONLY\\E").
+		WillReturnError(&mysql.MySQLError{
+			Number:  errno.ErrTableLocked,
+			Message: "Table 'table' was locked in READ ONLY by server: 1_session: 2",
+		})
+	s.mockDB.
+		ExpectExec("\\QALTER TABLE `db`.`table` READ ONLY\\E").
+		WillReturnError(&mysql.MySQLError{
+			Number:  errno.ErrTableLocked,
+			Message: "Table 'table' was locked in WRITE by server: 1_session: 2",
+		})
+	s.mockDB.
+		ExpectClose()
+
+	// a table which is already read-only is fine.
+	err := AlterTableWritable(ctx, s.tiGlue.GetSQLExecutor(), "`db`.`table`", false)
+	c.Assert(err, IsNil)
+	err = AlterTableWritable(ctx, s.tiGlue.GetSQLExecutor(), "`db`.`table`", false)
+	c.Assert(err, ErrorMatches, ".*was locked in WRITE.*")
+}
+
+func (s *tidbSuite) TestTableLockEnabled(c *C) {
+	ctx := context.Background()
+
+	columns := []string{"Type", "Instance", "Name", "Value"}
+	query := "\\QSHOW CONFIG WHERE type = 'tidb' AND name = 'enable-table-lock'\\E"
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("tidb", "127.0.0.1:4000", "enable-table-lock", "true").
+			AddRow("tidb", "127.0.0.1:4001", "enable-table-lock", "true"))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectBegin()
+	s.mockDB.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("tidb", "127.0.0.1:4000", "enable-table-lock", "true").
+			AddRow("tidb", "127.0.0.1:4001", "enable-table-lock", "false"))
+	s.mockDB.ExpectCommit()
+	s.mockDB.ExpectClose()
+
+	enabled, err := TableLockEnabled(ctx, s.tiGlue.GetSQLExecutor())
+	c.Assert(err, IsNil)
+	c.Assert(enabled, IsTrue)
+	enabled, err = TableLockEnabled(ctx, s.tiGlue.GetSQLExecutor())
+	c.Assert(err, IsNil)
+	c.Assert(enabled, IsFalse)
+}
+
 func (s *tidbSuite) TestAlterAutoRandom(c *C) {
 	ctx := context.Background()
 
@@ -0,0 +1,379 @@
+// Copyright 2021 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/parser"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/format"
+	"github.com/pingcap/parser/model"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/utils"
+	_ "github.com/pingcap/tidb/types/parser_driver" // for parsing view definitions and default values
+	"go.uber.org/zap"
+)
+
+const nameWildcard = "*"
+
+// NameRewriteRule maps a database, or a single table of a database, in the
+// backup to a new name in the restored cluster.
+// An empty (wildcard) SrcTable maps every table of SrcDB into DstDB and keeps
+// the table names.
+type NameRewriteRule struct {
+	SrcDB    string
+	SrcTable string
+	DstDB    string
+	DstTable string
+}
+
+// String implements fmt.Stringer.
+func (r NameRewriteRule) String() string {
+	src, dst := nameWildcard, nameWildcard
+	if r.SrcTable != "" {
+		src = utils.EncloseName(r.SrcTable)
+		dst = utils.EncloseName(r.DstTable)
+	}
+	return fmt.Sprintf("%s.%s:%s.%s", utils.EncloseName(r.SrcDB), src, utils.EncloseName(r.DstDB), dst)
+}
+
+// ParseNameRewriteRule parses a rule in one of the following forms:
+//
+//	src_db.*:dst_db.*
+//	src_db.src_table:dst_db.dst_table
+//	src_db:dst_db
+//
+// Names may be quoted with backticks, e.g. "`a.b`.*:`c:d`.*".
+func ParseNameRewriteRule(rule string) (NameRewriteRule, error) {
+	invalid := func(reason string) error {
+		return errors.Annotatef(berrors.ErrInvalidArgument, "invalid rewrite rule %q: %s", rule, reason)
+	}
+
+	parseSide := func(s string) (db, table string, rest string, err error) {
+		db, rest, err = parseRewriteIdent(s)
+		if err != nil {
+			return "", "", "", invalid(err.Error())
+		}
+		if db == nameWildcard {
+			return "", "", "", invalid("the database name cannot be a wildcard")
+		}
+		if !strings.HasPrefix(rest, ".") {
+			return db, nameWildcard, rest, nil
+		}
+		table, rest, err = parseRewriteIdent(rest[1:])
+		if err != nil {
+			return "", "", "", invalid(err.Error())
+		}
+		return db, table, rest, nil
+	}
+
+	srcDB, srcTable, rest, err := parseSide(rule)
+	if err != nil {
+		return NameRewriteRule{}, err
+	}
+	if !strings.HasPrefix(rest, ":") {
+		return NameRewriteRule{}, invalid("expect ':' between the source and the target")
+	}
+	dstDB, dstTable, rest, err := parseSide(rest[1:])
+	if err != nil {
+		return NameRewriteRule{}, err
+	}
+	if len(rest) != 0 {
+		return NameRewriteRule{}, invalid(fmt.Sprintf("unexpected trailing characters %q", rest))
+	}
+	if (srcTable == nameWildcard) != (dstTable == nameWildcard) {
+		return NameRewriteRule{}, invalid("the source and the target must both be tables or both be databases")
+	}
+	if utils.IsSysDB(strings.ToLower(srcDB)) || utils.IsSysDB(strings.ToLower(dstDB)) {
+		return NameRewriteRule{}, invalid("system databases cannot be renamed")
+	}
+
+	r := NameRewriteRule{SrcDB: srcDB, DstDB: dstDB}
+	if srcTable != nameWildcard {
+		r.SrcTable, r.DstTable = srcTable, dstTable
+	}
+	return r, nil
+}
+
+// parseRewriteIdent reads one (optionally backtick quoted) identifier from
+// the beginning of s, and returns it together with the unparsed remainder.
+func parseRewriteIdent(s string) (ident string, rest string, err error) {
+	if strings.HasPrefix(s, "`") {
+		var sb strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '`' {
+				sb.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '`' {
+				sb.WriteByte('`')
+				i++
+				continue
+			}
+			if sb.Len() == 0 {
+				return "", "", errors.New("empty name")
+			}
+			return sb.String(), s[i+1:], nil
+		}
+		return "", "", errors.New("unterminated quoted name")
+	}
+	end := strings.IndexAny(s, ".:")
+	if end < 0 {
+		end = len(s)
+	}
+	ident = strings.TrimSpace(s[:end])
+	if len(ident) == 0 {
+		return "", "", errors.New("empty name")
+	}
+	return ident, s[end:], nil
+}
+
+// NameRewriter renames databases and tables from the backup before they are
+// created in the restored cluster. Table IDs, and therefore the key prefixes
+// of the restored data, are always assigned by the restored cluster, so only
+// the names, and the references to the renamed objects inside views and
+// sequence defaults, need to be rewritten.
+type NameRewriter struct {
+	rules []NameRewriteRule
+}
+
+// NewNameRewriter creates a NameRewriter from the textual rules.
+func NewNameRewriter(rules []string) (*NameRewriter, error) {
+	r := &NameRewriter{rules: make([]NameRewriteRule, 0, len(rules))}
+	seen := make(map[string]string, len(rules))
+	for _, s := range rules {
+		rule, err := ParseNameRewriteRule(s)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		key := strings.ToLower(rule.SrcDB) + "." + strings.ToLower(rule.SrcTable)
+		if prev, ok := seen[key]; ok {
+			return nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"rewrite rules %q and %q have the same source", prev, s)
+		}
+		seen[key] = s
+		r.rules = append(r.rules, rule)
+	}
+	return r, nil
+}
+
+// Rules returns the parsed rules.
+func (r *NameRewriter) Rules() []NameRewriteRule {
+	return r.rules
+}
+
+// RewriteName returns the new name of the table `db`.`table`.
+// A table level rule takes precedence over a database level rule.
+// If table is empty, only the database level rules are considered.
+func (r *NameRewriter) RewriteName(db, table string) (newDB, newTable string, ok bool) {
+	var dbRule *NameRewriteRule
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if !strings.EqualFold(rule.SrcDB, db) {
+			continue
+		}
+		if rule.SrcTable == "" {
+			dbRule = rule
+			continue
+		}
+		if table != "" && strings.EqualFold(rule.SrcTable, table) {
+			return rule.DstDB, rule.DstTable, true
+		}
+	}
+	if dbRule != nil {
+		return dbRule.DstDB, table, true
+	}
+	return db, table, false
+}
+
+// RewriteSchemas renames the databases and tables to be restored. The
+// backup metadata is left untouched: renamed databases and tables are
+// replaced by modified copies. The returned databases are the ones which
+// need to be created in the restored cluster.
+func (r *NameRewriter) RewriteSchemas(
+	dbs []*utils.Database,
+	tables []*metautil.Table,
+) ([]*utils.Database, []*metautil.Table, error) {
+	newDBs := make([]*utils.Database, 0, len(dbs))
+	dbByName := make(map[string]*utils.Database, len(dbs))
+	getDB := func(origin *model.DBInfo, name string) *utils.Database {
+		lowerName := strings.ToLower(name)
+		if db, ok := dbByName[lowerName]; ok {
+			return db
+		}
+		info := origin
+		if origin.Name.O != name {
+			info = origin.Clone()
+			info.Name = model.NewCIStr(name)
+		}
+		db := &utils.Database{Info: info}
+		dbByName[lowerName] = db
+		newDBs = append(newDBs, db)
+		return db
+	}
+
+	// databases are created even if they have no tables to restore.
+	for _, db := range dbs {
+		name := db.Info.Name.O
+		if _, isSysDB := utils.GetSysDBName(db.Info.Name); !isSysDB {
+			name, _, _ = r.RewriteName(name, "")
+		}
+		getDB(db.Info, name)
+	}
+
+	type namePair struct {
+		db    string
+		table string
+	}
+	sources := make(map[namePair]*metautil.Table, len(tables))
+	newTables := make([]*metautil.Table, 0, len(tables))
+	for _, table := range tables {
+		if _, isSysDB := utils.GetSysDBName(table.DB.Name); isSysDB {
+			// system tables are restored into a temporary database and then
+			// moved into the system database, they can never be renamed.
+			newTables = append(newTables, table)
+			db := getDB(table.DB, table.DB.Name.O)
+			db.Tables = append(db.Tables, table)
+			continue
+		}
+
+		dbName, tableName, renamed := r.RewriteName(table.DB.Name.O, table.Info.Name.O)
+		newName := namePair{strings.ToLower(dbName), strings.ToLower(tableName)}
+		if prev, ok := sources[newName]; ok {
+			return nil, nil, errors.Annotatef(berrors.ErrInvalidArgument,
+				"both %s and %s would be restored as %s",
+				utils.EncloseDBAndTable(prev.DB.Name.O, prev.Info.Name.O),
+				utils.EncloseDBAndTable(table.DB.Name.O, table.Info.Name.O),
+				utils.EncloseDBAndTable(dbName, tableName))
+		}
+		sources[newName] = table
+
+		db := getDB(table.DB, dbName)
+		newTable := table
+		if renamed || r.referencesRenamedObject(table.Info) {
+			info, err := r.rewriteTableInfo(table.Info, tableName)
+			if err != nil {
+				return nil, nil, errors.Annotatef(err, "failed to rewrite %s",
+					utils.EncloseDBAndTable(table.DB.Name.O, table.Info.Name.O))
+			}
+			clone := *table
+			clone.DB = db.Info
+			clone.Info = info
+			newTable = &clone
+			log.Info("table will be restored with a new name",
+				zap.Stringer("db", table.DB.Name),
+				zap.Stringer("table", table.Info.Name),
+				zap.Stringer("new db", clone.DB.Name),
+				zap.Stringer("new table", clone.Info.Name))
+		}
+		db.Tables = append(db.Tables, newTable)
+		newTables = append(newTables, newTable)
+	}
+	return newDBs, newTables, nil
+}
+
+// referencesRenamedObject checks whether the table is a view or has columns
+// whose default value uses a sequence, which may reference renamed objects.
+func (r *NameRewriter) referencesRenamedObject(info *model.TableInfo) bool {
+	if info.IsView() {
+		return true
+	}
+	for _, col := range info.Columns {
+		if col.DefaultIsExpr {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *NameRewriter) rewriteTableInfo(info *model.TableInfo, newName string) (*model.TableInfo, error) {
+	newInfo := info.Clone()
+	newInfo.Name = model.NewCIStr(newName)
+	if newInfo.Partition != nil {
+		newPartition := *newInfo.Partition
+		newPartition.Definitions = append([]model.PartitionDefinition{}, info.Partition.Definitions...)
+		newInfo.Partition = &newPartition
+	}
+
+	if newInfo.View != nil {
+		view := *newInfo.View
+		// keep the same format with `buildViewInfo` in the ddl package.
+		flags := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+		stmt, err := r.rewriteSQL(view.SelectStmt, flags)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		view.SelectStmt = stmt
+		newInfo.View = &view
+	}
+
+	for _, col := range newInfo.Columns {
+		defaultExpr, ok := col.DefaultValue.(string)
+		if !col.DefaultIsExpr || !ok {
+			continue
+		}
+		// keep the same format with `columnDefToCol` in the ddl package.
+		flags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+			format.RestoreSpacesAroundBinaryOperation
+		stmt, err := r.rewriteSQL("SELECT "+defaultExpr, flags)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// strip the "select " prefix.
+		if err = col.SetDefaultValue(stmt[len("select "):]); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return newInfo, nil
+}
+
+// rewriteSQL renames the qualified table references in the statement.
+func (r *NameRewriter) rewriteSQL(sql string, flags format.RestoreFlags) (string, error) {
+	node, err := parser.New().ParseOneStmt(sql, "", "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	node.Accept(&nameRewriteVisitor{rewriter: r})
+	var sb strings.Builder
+	if err = node.Restore(format.NewRestoreCtx(flags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+type nameRewriteVisitor struct {
+	rewriter *NameRewriter
+}
+
+// Enter implements ast.Visitor.
+func (v *nameRewriteVisitor) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.TableName:
+		if x.Schema.L == "" {
+			break
+		}
+		if db, table, ok := v.rewriter.RewriteName(x.Schema.O, x.Name.O); ok {
+			x.Schema, x.Name = model.NewCIStr(db), model.NewCIStr(table)
+		}
+	case *ast.ColumnName:
+		// only a fully qualified column name references a table directly,
+		// `t`.`c` may refer to an alias.
+		if x.Schema.L == "" {
+			break
+		}
+		if db, table, ok := v.rewriter.RewriteName(x.Schema.O, x.Table.O); ok {
+			x.Schema, x.Table = model.NewCIStr(db), model.NewCIStr(table)
+		}
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor.
+func (v *nameRewriteVisitor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
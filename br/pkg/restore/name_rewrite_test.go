@@ -0,0 +1,158 @@
+// Copyright 2021 PingCAP, Inc. Licensed under Apache-2.0.
+
+package restore_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/br/pkg/metautil"
+	"github.com/pingcap/tidb/br/pkg/restore"
+	"github.com/pingcap/tidb/br/pkg/utils"
+)
+
+var _ = Suite(&testNameRewriteSuite{})
+
+type testNameRewriteSuite struct{}
+
+func (s *testNameRewriteSuite) TestParseNameRewriteRule(c *C) {
+	cases := []struct {
+		rule     string
+		expected restore.NameRewriteRule
+	}{
+		{"a.*:b.*", restore.NameRewriteRule{SrcDB: "a", DstDB: "b"}},
+		{"a:b", restore.NameRewriteRule{SrcDB: "a", DstDB: "b"}},
+		{"a.t1:b.t2", restore.NameRewriteRule{SrcDB: "a", SrcTable: "t1", DstDB: "b", DstTable: "t2"}},
+		{"`a.b`.`c``d`:`e:f`.g", restore.NameRewriteRule{SrcDB: "a.b", SrcTable: "c`d", DstDB: "e:f", DstTable: "g"}},
+		{"`a`.`*`:b.`*`", restore.NameRewriteRule{SrcDB: "a", DstDB: "b"}},
+	}
+	for _, ca := range cases {
+		rule, err := restore.ParseNameRewriteRule(ca.rule)
+		c.Assert(err, IsNil, Commentf("rule %s", ca.rule))
+		c.Assert(rule, DeepEquals, ca.expected, Commentf("rule %s", ca.rule))
+	}
+
+	invalidRules := []string{
+		"",
+		"a",
+		"a.*",
+		"a.*:",
+		"*.*:b.*",
+		"a.*:b.t",
+		"a.t:b.*",
+		"a.t:b.t.x",
+		"`a:b.*",
+		"``.*:b.*",
+		"mysql.*:b.*",
+		"a.*:MySQL.*",
+	}
+	for _, r := range invalidRules {
+		_, err := restore.ParseNameRewriteRule(r)
+		c.Assert(err, ErrorMatches, ".*invalid rewrite rule.*", Commentf("rule %s", r))
+	}
+
+	_, err := restore.NewNameRewriter([]string{"a.*:b.*", "A:c"})
+	c.Assert(err, ErrorMatches, ".*have the same source.*")
+}
+
+func (s *testNameRewriteSuite) TestRewriteName(c *C) {
+	rewriter, err := restore.NewNameRewriter([]string{"a.*:b.*", "a.t1:c.t2"})
+	c.Assert(err, IsNil)
+
+	db, table, ok := rewriter.RewriteName("A", "T0")
+	c.Assert(ok, IsTrue)
+	c.Assert(db, Equals, "b")
+	c.Assert(table, Equals, "T0")
+
+	db, table, ok = rewriter.RewriteName("a", "T1")
+	c.Assert(ok, IsTrue)
+	c.Assert(db, Equals, "c")
+	c.Assert(table, Equals, "t2")
+
+	db, table, ok = rewriter.RewriteName("x", "t1")
+	c.Assert(ok, IsFalse)
+	c.Assert(db, Equals, "x")
+	c.Assert(table, Equals, "t1")
+}
+
+func (s *testNameRewriteSuite) TestRewriteSchemas(c *C) {
+	dbA := &model.DBInfo{ID: 1, Name: model.NewCIStr("a")}
+	dbX := &model.DBInfo{ID: 2, Name: model.NewCIStr("x")}
+	sysDB := &model.DBInfo{ID: 3, Name: utils.TemporaryDBName("mysql")}
+	emptyDB := &model.DBInfo{ID: 4, Name: model.NewCIStr("e")}
+	t1 := &metautil.Table{DB: dbA, Info: &model.TableInfo{ID: 11, Name: model.NewCIStr("t1")}}
+	t2 := &metautil.Table{DB: dbA, Info: &model.TableInfo{ID: 12, Name: model.NewCIStr("t2")}}
+	seq := &metautil.Table{DB: dbA, Info: &model.TableInfo{
+		ID:       13,
+		Name:     model.NewCIStr("seq"),
+		Sequence: &model.SequenceInfo{Start: 1, Increment: 1},
+	}}
+	useSeq := &metautil.Table{DB: dbX, Info: &model.TableInfo{
+		ID:   21,
+		Name: model.NewCIStr("use_seq"),
+		Columns: []*model.ColumnInfo{{
+			Name:          model.NewCIStr("id"),
+			DefaultValue:  "nextval(`a`.`seq`)",
+			DefaultIsExpr: true,
+		}},
+	}}
+	view := &metautil.Table{DB: dbX, Info: &model.TableInfo{
+		ID:   22,
+		Name: model.NewCIStr("v"),
+		View: &model.ViewInfo{
+			SelectStmt: "SELECT `a`.`t1`.`c` AS `c`,`t`.`d` AS `d` FROM ((`a`.`t1`) JOIN `a`.`t2` AS `t`) JOIN `x`.`y`",
+		},
+	}}
+	user := &metautil.Table{DB: sysDB, Info: &model.TableInfo{ID: 31, Name: model.NewCIStr("user")}}
+
+	dbs := []*utils.Database{
+		{Info: dbA, Tables: []*metautil.Table{t1, t2, seq}},
+		{Info: dbX, Tables: []*metautil.Table{useSeq, view}},
+		{Info: sysDB, Tables: []*metautil.Table{user}},
+		{Info: emptyDB},
+	}
+	tables := []*metautil.Table{t1, t2, seq, useSeq, view, user}
+
+	rewriter, err := restore.NewNameRewriter([]string{"a.*:b.*", "a.t1:c.t1_new", "e.*:f.*"})
+	c.Assert(err, IsNil)
+	newDBs, newTables, err := rewriter.RewriteSchemas(dbs, tables)
+	c.Assert(err, IsNil)
+
+	dbNames := make([]string, 0, len(newDBs))
+	for _, db := range newDBs {
+		dbNames = append(dbNames, db.Info.Name.O)
+	}
+	c.Assert(dbNames, DeepEquals, []string{"b", "x", sysDB.Name.O, "f", "c"})
+	c.Assert(newDBs[3].Info.ID, Equals, emptyDB.ID)
+	c.Assert(newDBs[3].Tables, HasLen, 0)
+
+	names := make([]string, 0, len(newTables))
+	for _, t := range newTables {
+		names = append(names, utils.EncloseDBAndTable(t.DB.Name.O, t.Info.Name.O))
+	}
+	c.Assert(names, DeepEquals, []string{
+		"`c`.`t1_new`",
+		"`b`.`t2`",
+		"`b`.`seq`",
+		"`x`.`use_seq`",
+		"`x`.`v`",
+		"`" + sysDB.Name.O + "`.`user`",
+	})
+	// the IDs are kept so that the files can still be mapped to the tables.
+	c.Assert(newTables[0].Info.ID, Equals, t1.Info.ID)
+	c.Assert(newTables[0].DB.ID, Equals, dbA.ID)
+	c.Assert(newTables[3].Info.Columns[0].GetDefaultValue(), Equals, "nextval(`b`.`seq`)")
+	c.Assert(newTables[4].Info.View.SelectStmt, Equals,
+		"SELECT `c`.`t1_new`.`c` AS `c`,`t`.`d` AS `d` FROM ((`c`.`t1_new`) JOIN `b`.`t2` AS `t`) JOIN `x`.`y`")
+	c.Assert(newTables[5], Equals, user)
+
+	// the backup meta is untouched.
+	c.Assert(t1.DB.Name.O, Equals, "a")
+	c.Assert(t1.Info.Name.O, Equals, "t1")
+	c.Assert(useSeq.Info.Columns[0].GetDefaultValue(), Equals, "nextval(`a`.`seq`)")
+	c.Assert(view.Info.View.SelectStmt, Matches, "SELECT `a`.`t1`.*")
+
+	rewriter, err = restore.NewNameRewriter([]string{"a.t1:x.v"})
+	c.Assert(err, IsNil)
+	_, _, err = rewriter.RewriteSchemas(dbs, tables)
+	c.Assert(err, ErrorMatches, ".*both `a`.`t1` and `x`.`v` would be restored as `x`.`v`.*")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/pingcap/failpoint"
 	backuppb "github.com/pingcap/kvproto/pkg/brpb"
 	"github.com/pingcap/log"
+	"github.com/pingcap/parser/model"
 	"github.com/pingcap/tidb/br/pkg/conn"
 	berrors "github.com/pingcap/tidb/br/pkg/errors"
 	"github.com/pingcap/tidb/br/pkg/glue"
@@ -30,6 +31,7 @@ import (
 const (
 	flagOnline   = "online"
 	flagNoSchema = "no-schema"
+	flagRewrite  = "rewrite"
 
 	// FlagMergeRegionSizeBytes is the flag name of merge small regions by size
 	FlagMergeRegionSizeBytes = "merge-region-size-bytes"
@@ -114,6 +116,9 @@ type RestoreConfig struct {
 	NoSchema           bool          `json:"no-schema" toml:"no-schema"`
 	PDConcurrency      uint          `json:"pd-concurrency" toml:"pd-concurrency"`
 	BatchFlushInterval time.Duration `json:"batch-flush-interval" toml:"batch-flush-interval"`
+	// Rewrites are the rules to restore databases and tables under new names,
+	// e.g. "src_db.*:dst_db.*". See restore.ParseNameRewriteRule for the format.
+	Rewrites []string `json:"rewrite" toml:"rewrite"`
 }
 
 // DefineRestoreFlags defines common flags for the restore tidb command.
@@ -121,6 +126,9 @@ func DefineRestoreFlags(flags *pflag.FlagSet) {
 	flags.Bool(flagNoSchema, false, "skip creating schemas and tables, reuse existing empty ones")
 	// Do not expose this flag
 	_ = flags.MarkHidden(flagNoSchema)
+	flags.StringArray(flagRewrite, nil,
+		"restore databases or tables under new names, can be specified multiple times, "+
+			"e.g. --rewrite 'src_db.*:dst_db.*' or --rewrite 'src_db.src_table:dst_db.dst_table'")
 
 	DefineRestoreCommonFlags(flags)
 }
@@ -132,6 +140,10 @@ func (cfg *RestoreConfig) ParseFromFlags(flags *pflag.FlagSet) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	cfg.Rewrites, err = flags.GetStringArray(flagRewrite)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	err = cfg.Config.ParseFromFlags(flags)
 	if err != nil {
 		return errors.Trace(err)
@@ -289,10 +301,19 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 	if err = CheckRestoreDBAndTable(client, cfg); err != nil {
 		return err
 	}
-	files, tables, dbs := filterRestoreFiles(client, cfg)
+	files, tables, dbs := filterRestoreFiles(client.GetDatabases(), cfg)
 	if len(dbs) == 0 && len(tables) != 0 {
 		return errors.Annotate(berrors.ErrRestoreInvalidBackup, "contain tables but no databases")
 	}
+	// DDL jobs are replayed by their original queries, so they must be filtered
+	// by the original names.
+	ddlJobs := restore.FilterDDLJobs(client.GetDDLJobs(), tables)
+	if len(cfg.Rewrites) > 0 {
+		dbs, tables, err = rewriteRestoreNames(client, cfg, dbs, tables, ddlJobs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
 	archiveSize := reader.ArchiveSize(ctx, files)
 	g.Record(summary.RestoreDataSize, archiveSize)
 	//restore from tidb will fetch a general Size issue https://github.com/pingcap/tidb/issues/27247
@@ -321,7 +342,6 @@ func RunRestore(c context.Context, g glue.Glue, cmdName string, cfg *RestoreConf
 	if client.IsIncremental() {
 		newTS = restoreTS
 	}
-	ddlJobs := restore.FilterDDLJobs(client.GetDDLJobs(), tables)
 
 	err = client.PreCheckTableTiFlashReplica(ctx, tables)
 	if err != nil {
@@ -498,10 +518,10 @@ func dropToBlackhole(
 }
 
 func filterRestoreFiles(
-	client *restore.Client,
+	databases []*utils.Database,
 	cfg *RestoreConfig,
 ) (files []*backuppb.File, tables []*metautil.Table, dbs []*utils.Database) {
-	for _, db := range client.GetDatabases() {
+	for _, db := range databases {
 		createdDatabase := false
 		dbName := db.Info.Name.O
 		if name, ok := utils.GetSysDBName(db.Info.Name); utils.IsSysDB(name) && ok {
@@ -518,10 +538,46 @@ func filterRestoreFiles(
 			files = append(files, table.Files...)
 			tables = append(tables, table)
 		}
+		// empty databases are only restored when they are renamed by the
+		// rewrite rules, so a plain restore keeps its original behavior.
+		if len(db.Tables) == 0 && len(cfg.Rewrites) > 0 && cfg.TableFilter.MatchSchema(dbName) {
+			dbs = append(dbs, db)
+		}
 	}
 	return
 }
 
+// rewriteRestoreNames renames the databases and tables to be restored by the
+// rewrite rules in the config.
+func rewriteRestoreNames(
+	client *restore.Client,
+	cfg *RestoreConfig,
+	dbs []*utils.Database,
+	tables []*metautil.Table,
+	ddlJobs []*model.Job,
+) ([]*utils.Database, []*metautil.Table, error) {
+	if client.IsIncremental() {
+		return nil, nil, errors.Annotate(berrors.ErrInvalidArgument,
+			"restoring with rewrite rules is not supported for incremental backups")
+	}
+	if len(ddlJobs) > 0 {
+		return nil, nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"restoring with rewrite rules is not supported for backups containing DDL jobs, found %d jobs", len(ddlJobs))
+	}
+	if cfg.NoSchema {
+		return nil, nil, errors.Annotatef(berrors.ErrInvalidArgument,
+			"--%s cannot be used together with --%s", flagRewrite, flagNoSchema)
+	}
+	rewriter, err := restore.NewNameRewriter(cfg.Rewrites)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for _, rule := range rewriter.Rules() {
+		log.Info("restore with rewrite rule", zap.Stringer("rule", rule))
+	}
+	return rewriter.RewriteSchemas(dbs, tables)
+}
+
 // restorePreWork executes some prepare work before restore.
 // TODO make this function returns a restore post work.
 func restorePreWork(ctx context.Context, client *restore.Client, mgr *conn.Mgr) (pdutil.UndoFunc, error) {
@@ -4,7 +4,11 @@ package task
 
 import (
 	. "github.com/pingcap/check"
+	"github.com/pingcap/parser/model"
+	filter "github.com/pingcap/tidb-tools/pkg/table-filter"
+	"github.com/pingcap/tidb/br/pkg/metautil"
 	"github.com/pingcap/tidb/br/pkg/restore"
+	"github.com/pingcap/tidb/br/pkg/utils"
 )
 
 type testRestoreSuite struct{}
@@ -20,3 +24,32 @@ func (s *testRestoreSuite) TestRestoreConfigAdjust(c *C) {
 	c.Assert(cfg.MergeSmallRegionKeyCount, Equals, restore.DefaultMergeRegionKeyCount)
 	c.Assert(cfg.MergeSmallRegionSizeBytes, Equals, restore.DefaultMergeRegionSizeBytes)
 }
+
+func (s *testRestoreSuite) TestFilterRestoreFilesEmptyDatabase(c *C) {
+	tableFilter, err := filter.Parse([]string{"*.*"})
+	c.Assert(err, IsNil)
+	dbA := &model.DBInfo{Name: model.NewCIStr("a")}
+	dbs := []*utils.Database{
+		{
+			Info: dbA,
+			Tables: []*metautil.Table{{
+				DB:   dbA,
+				Info: &model.TableInfo{Name: model.NewCIStr("t")},
+			}},
+		},
+		{Info: &model.DBInfo{Name: model.NewCIStr("empty")}},
+	}
+
+	cfg := &RestoreConfig{}
+	cfg.TableFilter = tableFilter
+	_, tables, restoreDBs := filterRestoreFiles(dbs, cfg)
+	c.Assert(tables, HasLen, 1)
+	c.Assert(restoreDBs, HasLen, 1)
+	c.Assert(restoreDBs[0].Info.Name.O, Equals, "a")
+
+	cfg.Rewrites = []string{"empty:empty_new"}
+	_, tables, restoreDBs = filterRestoreFiles(dbs, cfg)
+	c.Assert(tables, HasLen, 1)
+	c.Assert(restoreDBs, HasLen, 2)
+	c.Assert(restoreDBs[1].Info.Name.O, Equals, "empty")
+}
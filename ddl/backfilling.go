@@ -29,6 +29,7 @@ import (
 	ddlutil "github.com/pingcap/tidb/ddl/util"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -181,6 +182,8 @@ type reorgBackfillTask struct {
 	physicalTableID int64
 	startKey        kv.Key
 	endKey          kv.Key
+	// ingestWriter is set if the index is backfilled by ingesting SST files.
+	ingestWriter IngestWriter
 }
 
 func (r *reorgBackfillTask) String() string {
@@ -310,7 +313,7 @@ func (w *backfillWorker) run(d *ddlCtx, bf backfiller, job *model.Job) {
 		})
 
 		// Dynamic change batch size.
-		w.batchCnt = int(variable.GetDDLReorgBatchSize())
+		w.batchCnt = w.ddlWorker.reorgCtx.getBackfillBatchSize()
 		result := w.handleBackfillTask(d, task, bf)
 		w.resultCh <- result
 	}
@@ -379,7 +382,9 @@ func (w *worker) waitTaskResults(workers []*backfillWorker, taskCnt int,
 
 // handleReorgTasks sends tasks to workers, and waits for all the running workers to return results,
 // there are taskCnt running workers.
-func (w *worker) handleReorgTasks(reorgInfo *reorgInfo, totalAddedCount *int64, workers []*backfillWorker, batchTasks []*reorgBackfillTask) error {
+func (w *worker) handleReorgTasks(reorgInfo *reorgInfo, totalAddedCount *int64, workers []*backfillWorker, batchTasks []*reorgBackfillTask,
+	ib *ingestBackfill) error {
+	engine := ib.prepare(w.ddlJobCtx, reorgInfo, batchTasks)
 	for i, task := range batchTasks {
 		workers[i].taskCh <- task
 	}
@@ -388,6 +393,13 @@ func (w *worker) handleReorgTasks(reorgInfo *reorgInfo, totalAddedCount *int64,
 	taskCnt := len(batchTasks)
 	startTime := time.Now()
 	nextKey, taskAddedCount, err := w.waitTaskResults(workers, taskCnt, totalAddedCount, startKey)
+	if engine != nil {
+		err = w.ingestReorgTasks(ib, engine, workers, batchTasks, err)
+		if err != nil {
+			// The index KV pairs of the batch are not ingested, they should be backfilled again.
+			nextKey = startKey
+		}
+	}
 	elapsedTime := time.Since(startTime)
 	if err == nil {
 		err = w.isReorgRunnable(reorgInfo.d)
@@ -446,7 +458,7 @@ func tryDecodeToHandleString(key kv.Key) string {
 
 // sendRangeTaskToWorkers sends tasks to workers, and returns remaining kvRanges that is not handled.
 func (w *worker) sendRangeTaskToWorkers(t table.Table, workers []*backfillWorker, reorgInfo *reorgInfo,
-	totalAddedCount *int64, kvRanges []kv.KeyRange) ([]kv.KeyRange, error) {
+	totalAddedCount *int64, kvRanges []kv.KeyRange, ib *ingestBackfill) ([]kv.KeyRange, error) {
 	batchTasks := make([]*reorgBackfillTask, 0, len(workers))
 	physicalTableID := reorgInfo.PhysicalTableID
 
@@ -478,7 +490,7 @@ func (w *worker) sendRangeTaskToWorkers(t table.Table, workers []*backfillWorker
 	}
 
 	// Wait tasks finish.
-	err := w.handleReorgTasks(reorgInfo, totalAddedCount, workers, batchTasks)
+	err := w.handleReorgTasks(reorgInfo, totalAddedCount, workers, batchTasks, ib)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -512,6 +524,21 @@ func loadDDLReorgVars(w *worker) error {
 	return ddlutil.LoadDDLReorgVars(w.ddlJobCtx, ctx)
 }
 
+// loadDDLJobControl reloads the control options of the job set by users.
+func (w *worker) loadDDLJobControl(store kv.Storage, jobID int64) error {
+	var ctl *meta.DDLJobControl
+	err := kv.RunInNewTxn(w.ddlJobCtx, store, false, func(ctx context.Context, txn kv.Transaction) error {
+		var err error
+		ctl, err = meta.NewMeta(txn).GetDDLJobControl(jobID)
+		return err
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.reorgCtx.setJobControl(ctl)
+	return nil
+}
+
 func makeupDecodeColMap(sessCtx sessionctx.Context, t table.Table) (map[int64]decoder.Column, error) {
 	dbName := model.NewCIStr(sessCtx.GetSessionVars().CurrentDB)
 	writableColInfos := make([]*model.ColumnInfo, 0, len(t.WritableCols()))
@@ -554,6 +581,9 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 		return errors.Trace(err)
 	}
 
+	if err := w.loadDDLJobControl(reorgInfo.d.store, job.ID); err != nil {
+		return errors.Trace(err)
+	}
 	if err := w.isReorgRunnable(reorgInfo.d); err != nil {
 		return errors.Trace(err)
 	}
@@ -568,11 +598,12 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 	})
 
 	// variable.ddlReorgWorkerCounter can be modified by system variable "tidb_ddl_reorg_worker_cnt".
-	workerCnt := variable.GetDDLReorgWorkerCounter()
+	workerCnt := w.reorgCtx.getBackfillWorkerCount()
 	backfillWorkers := make([]*backfillWorker, 0, workerCnt)
 	defer func() {
 		closeBackfillWorkers(backfillWorkers)
 	}()
+	ib := newIngestBackfill(bfWorkerType, t.Meta(), indexInfo)
 
 	for {
 		kvRanges, err := splitTableRanges(t, reorgInfo.d.store, startKey, endKey)
@@ -584,7 +615,14 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 		if err := loadDDLReorgVars(w); err != nil {
 			logutil.BgLogger().Error("[ddl] load DDL reorganization variable failed", zap.Error(err))
 		}
-		workerCnt = variable.GetDDLReorgWorkerCounter()
+		// The job may be paused or throttled by users.
+		if err := w.loadDDLJobControl(reorgInfo.d.store, job.ID); err != nil {
+			logutil.BgLogger().Error("[ddl] load DDL job control failed", zap.Error(err))
+		}
+		if err := w.isReorgRunnable(reorgInfo.d); err != nil {
+			return errors.Trace(err)
+		}
+		workerCnt = w.reorgCtx.getBackfillWorkerCount()
 		rowFormat := variable.GetDDLReorgRowFormat()
 		// If only have 1 range, we can only start 1 worker.
 		if len(kvRanges) < int(workerCnt) {
@@ -654,7 +692,7 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 			zap.Int("regionCnt", len(kvRanges)),
 			zap.String("startHandle", tryDecodeToHandleString(startKey)),
 			zap.String("endHandle", tryDecodeToHandleString(endKey)))
-		remains, err := w.sendRangeTaskToWorkers(t, backfillWorkers, reorgInfo, &totalAddedCount, kvRanges)
+		remains, err := w.sendRangeTaskToWorkers(t, backfillWorkers, reorgInfo, &totalAddedCount, kvRanges, ib)
 		if err != nil {
 			return errors.Trace(err)
 		}
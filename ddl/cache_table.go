@@ -0,0 +1,182 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// checkCacheTableValid checks whether the table can be cached.
+func checkCacheTableValid(schema model.CIStr, tblInfo *model.TableInfo) error {
+	if util.IsMemOrSysDB(schema.L) {
+		return errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("system table"))
+	}
+	if tblInfo.TempTableType != model.TempTableNone {
+		return errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("temporary table"))
+	}
+	if tblInfo.GetPartitionInfo() != nil {
+		return errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("partition mode"))
+	}
+	return nil
+}
+
+// checkTableNotCached returns an error if the table is cached, or is switching between cached
+// and not cached. The DDL on cached tables isn't supported, so the cache never follows a schema change.
+func checkTableNotCached(tb table.Table, op string) error {
+	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
+		return errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs(op))
+	}
+	return nil
+}
+
+// AlterTableCache caches the data of the table in the memory of every TiDB instance.
+func (d *ddl) AlterTableCache(ctx sessionctx.Context, ti ast.Ident) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tb.Meta().TableCacheStatusType == model.TableCacheStatusEnable {
+		return nil
+	}
+	if err = checkCacheTableValid(schema.Name, tb.Meta()); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The lock of the table is reset before the job, the writes in the switching state take it.
+	exec := ctx.(sqlexec.RestrictedSQLExecutor)
+	stmt, err := exec.ParseWithParams(context.Background(), "REPLACE INTO mysql.table_cache_meta VALUES (%?, 'NONE', 0, 0)", tb.Meta().ID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, _, err = exec.ExecRestrictedStmt(context.Background(), stmt); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCacheTable,
+		BinlogInfo: &model.HistoryInfo{},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterTableNoCache stops caching the data of the table.
+func (d *ddl) AlterTableNoCache(ctx sessionctx.Context, ti ast.Ident) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tb.Meta().TableCacheStatusType == model.TableCacheStatusDisable {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterNoCacheTable,
+		BinlogInfo: &model.HistoryInfo{},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// onAlterCacheTable caches the table in two steps, disable -> switching -> enable. All the
+// instances write the table with the lease in the switching state, before any of them caches it.
+func onAlterCacheTable(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.TableCacheStatusType == model.TableCacheStatusEnable {
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+	if tblInfo.TempTableType != model.TempTableNone || tblInfo.GetPartitionInfo() != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("partition mode or temporary table"))
+	}
+
+	switch tblInfo.TableCacheStatusType {
+	case model.TableCacheStatusDisable:
+		// disable -> switching
+		tblInfo.TableCacheStatusType = model.TableCacheStatusSwitching
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.TableCacheStatusSwitching:
+		// switching -> enable
+		tblInfo.TableCacheStatusType = model.TableCacheStatusEnable
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		job.State = model.JobStateCancelled
+		err = ErrInvalidDDLState.GenWithStackByArgs("table cache", tblInfo.TableCacheStatusType.String())
+	}
+	return ver, errors.Trace(err)
+}
+
+// onAlterNoCacheTable stops caching the table in two steps, enable -> switching -> disable. No
+// instance reads the cache any more before the writes stop taking the lease.
+func onAlterNoCacheTable(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	switch tblInfo.TableCacheStatusType {
+	case model.TableCacheStatusDisable:
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	case model.TableCacheStatusEnable:
+		// enable -> switching
+		tblInfo.TableCacheStatusType = model.TableCacheStatusSwitching
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.TableCacheStatusSwitching:
+		// switching -> disable
+		tblInfo.TableCacheStatusType = model.TableCacheStatusDisable
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		job.State = model.JobStateCancelled
+		err = ErrInvalidDDLState.GenWithStackByArgs("table cache", tblInfo.TableCacheStatusType.String())
+	}
+	return ver, errors.Trace(err)
+}
@@ -3406,3 +3406,188 @@ func (s *testIntegrationSuite3) TestTruncateLocalTemporaryTable(c *C) {
 	tk.MustExec("truncate table testt.t3")
 	tk.MustQuery("select * from testt.t3").Check(testkit.Rows())
 }
+
+func (s *testIntegrationSuite3) TestAlterLocalTemporaryTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1 (id int)")
+	tk.MustExec("insert into t1 values(100)")
+	tk.MustExec("create temporary table t1 (id int primary key, a int, b varchar(10))")
+	tk.MustExec("insert into t1 values(1, 10, 'a'), (2, 20, 'b'), (3, 30, 'c')")
+
+	// The records are rebuilt by the new columns.
+	tk.MustExec("alter table t1 add column c int default 5 after id, drop column a")
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1 5 a", "2 5 b", "3 5 c"))
+	tk.MustExec("alter table t1 modify column b varchar(20) not null")
+	tk.MustExec("alter table t1 change column c d bigint")
+	tk.MustExec("alter table t1 alter column d set default 7")
+	tk.MustExec("insert into t1(id, b) values(4, 'd')")
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1 5 a", "2 5 b", "3 5 c", "4 7 d"))
+	tk.MustExec("alter table t1 rename column d to c")
+	tk.MustQuery("select c from t1 where id = 4").Check(testkit.Rows("7"))
+	tk.MustExec("alter table t1 modify column id bigint")
+	tk.MustGetErrCode("alter table t1 modify column id varchar(10)", errno.ErrUnsupportedDDLOperation)
+
+	// The index data is rebuilt as well.
+	tk.MustExec("alter table t1 add index idx_c(c)")
+	tk.MustQuery("select id from t1 use index(idx_c) where c = 5").Check(testkit.Rows("1", "2", "3"))
+	tk.MustGetErrCode("alter table t1 add unique index uk_c(c)", errno.ErrDupEntry)
+	tk.MustExec("create unique index uk_b on t1(b)")
+	tk.MustGetErrCode("insert into t1 values(5, 1, 'a')", errno.ErrDupEntry)
+	tk.MustQuery("select id from t1 use index(uk_b) where b = 'c'").Check(testkit.Rows("3"))
+	tk.MustExec("alter table t1 rename index uk_b to uk_b2")
+	tk.MustExec("drop index uk_b2 on t1")
+	tk.MustExec("insert into t1 values(5, 1, 'a')")
+	tk.MustGetErrCode("create index idx_c on t1(b)", errno.ErrDupKeyName)
+	tk.MustExec("create index if not exists idx_c on t1(b)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 index already exist idx_c"))
+
+	// Adding a not null column without default value fails on existing records.
+	tk.MustExec("alter table t1 add column e int")
+	tk.MustGetErrCode("alter table t1 modify column e int not null", errno.ErrBadNull)
+	tk.MustQuery("select count(*) from t1").Check(testkit.Rows("5"))
+
+	// Altering in a transaction commits it first.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t1(id, b) values(6, 'f')")
+	tk.MustExec("alter table t1 drop column e")
+	tk.MustExec("rollback")
+	tk.MustQuery("select id, c, b from t1 where id = 6").Check(testkit.Rows("6 7 f"))
+
+	tk.MustExec("alter table t1 rename to t2")
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("6"))
+	tk.MustQuery("select * from t1").Check(testkit.Rows("100"))
+	tk.MustGetErrCode("alter table t2 shard_row_id_bits = 4", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("drop table t1, t2")
+}
+
+func (s *testIntegrationSuite3) TestRenameLocalTemporaryTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create database if not exists testt")
+	tk.MustExec("create temporary table t1 (id int primary key auto_increment)")
+	tk.MustExec("create temporary table t2 (id int)")
+	tk.MustExec("insert into t1 values(), ()")
+	tk.MustExec("insert into t2 values(1)")
+
+	tk.MustExec("rename table t1 to t3, t2 to t1, t3 to t2")
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1"))
+	tk.MustExec("insert into t2 values()")
+	tk.MustQuery("select * from t2").Check(testkit.Rows("1", "2", "3"))
+
+	// The statement is atomic.
+	tk.MustGetErrCode("rename table t1 to t3, t2 to t3", errno.ErrTableExists)
+	tk.MustGetErrCode("rename table t1 to t3, t2 to notexist.t4", errno.ErrBadDB)
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("3"))
+
+	tk.MustExec("rename table t2 to testt.t2")
+	tk.MustQuery("select count(*) from testt.t2").Check(testkit.Rows("3"))
+	tk.MustGetErrCode("select * from t2", errno.ErrNoSuchTable)
+	tk.MustExec("drop table t1, testt.t2")
+	tk.MustExec("drop database testt")
+}
+
+func (s *testIntegrationSuite3) TestTTLTableOption(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t_like, t_view")
+	showTTL := func() string {
+		createSQL := tk.MustQuery("show create table t").Rows()[0][1].(string)
+		if i := strings.Index(createSQL, " /*T![ttl]"); i >= 0 {
+			return createSQL[i+1:]
+		}
+		return ""
+	}
+
+	tk.MustExec("create table t (id int primary key, created_at datetime, c int) ttl = `created_at` + interval 30 day")
+	c.Assert(showTTL(), Equals, "/*T![ttl] TTL=`created_at` + INTERVAL 30 DAY */ /*T![ttl] TTL_ENABLE='ON' */")
+	tk.MustExec("alter table t ttl_enable = 'off'")
+	c.Assert(showTTL(), Equals, "/*T![ttl] TTL=`created_at` + INTERVAL 30 DAY */ /*T![ttl] TTL_ENABLE='OFF' */")
+	// Changing the TTL keeps TTL_ENABLE.
+	tk.MustExec("alter table t ttl = created_at + interval 12 hour")
+	c.Assert(showTTL(), Equals, "/*T![ttl] TTL=`created_at` + INTERVAL 12 HOUR */ /*T![ttl] TTL_ENABLE='OFF' */")
+	tk.MustExec("alter table t ttl = created_at + interval 1 + 1 month ttl_enable = 'on'")
+	c.Assert(showTTL(), Equals, "/*T![ttl] TTL=`created_at` + INTERVAL 2 MONTH */ /*T![ttl] TTL_ENABLE='ON' */")
+	tk.MustExec("create table t_like like t")
+	c.Assert(tk.MustQuery("show create table t_like").Rows()[0][1], Matches, "(?s).*TTL=`created_at` \\+ INTERVAL 2 MONTH.*")
+
+	// The TTL column can't be dropped, renamed or changed to a type without time.
+	tk.MustGetErrCode("alter table t drop column created_at", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t rename column created_at to c1", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t change column created_at c1 datetime", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t modify column created_at int", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t modify column created_at timestamp")
+	tk.MustExec("alter table t drop column c")
+
+	tk.MustExec("alter table t remove ttl")
+	c.Assert(showTTL(), Equals, "")
+	tk.MustExec("alter table t remove ttl")
+	tk.MustGetErrCode("alter table t ttl_enable = 'on'", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t drop column created_at")
+
+	// Invalid TTL.
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl_enable = 'on'", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl = created_at + interval 0 day", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl = created_at + interval -1 day", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl = created_at + interval 1.5 day", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl = created_at + interval '1:1' hour_minute", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t1 (id int, created_at datetime) ttl = updated_at + interval 1 day", errno.ErrBadField)
+	tk.MustGetErrCode("create table t1 (id int, created_at varchar(20)) ttl = created_at + interval 1 day", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t ttl = id + interval 1 day", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create view t_view as select * from t_like")
+	tk.MustGetErrCode("alter table t_view ttl = created_at + interval 1 day", errno.ErrWrongObject)
+	tk.MustExec("set tidb_enable_global_temporary_table=true")
+	tk.MustGetErrCode("create global temporary table t1 (id int, created_at datetime) ttl = created_at + interval 1 day on commit delete rows", errno.ErrOptOnTemporaryTable)
+	tk.MustGetErrCode("create temporary table t1 (id int, created_at datetime) ttl = created_at + interval 1 day", errno.ErrOptOnTemporaryTable)
+	tk.MustExec("create temporary table t1 like t_like")
+	c.Assert(tk.MustQuery("show create table t1").Rows()[0][1], Not(Matches), "(?s).*TTL.*")
+	tk.MustExec("drop table t1, t, t_like")
+	tk.MustExec("drop view t_view")
+}
+
+func (s *testIntegrationSuite3) TestCacheTable(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, t_part")
+	cacheStatus := func() model.TableCacheStatusType {
+		tbl, err := s.dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+		c.Assert(err, IsNil)
+		return tbl.Meta().TableCacheStatusType
+	}
+
+	tk.MustExec("create table t (id int primary key, v int, key idx_v (v))")
+	tk.MustExec("alter table t cache")
+	c.Assert(cacheStatus(), Equals, model.TableCacheStatusEnable)
+	tk.MustQuery("select lock_type from mysql.table_cache_meta where tid = (select tidb_table_id from information_schema.tables where table_schema = 'test' and table_name = 't')").
+		Check(testkit.Rows("NONE"))
+	// CACHE on a cached table is a no-op.
+	tk.MustExec("alter table t cache")
+	c.Assert(cacheStatus(), Equals, model.TableCacheStatusEnable)
+
+	// The DDLs which change the cached tables are rejected.
+	tk.MustGetErrCode("alter table t add column c int", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("alter table t cache, add column c int", errno.ErrParse)
+	tk.MustGetErrCode("create index idx on t (v)", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("drop index idx_v on t", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("truncate table t", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("rename table t to t1", errno.ErrOptOnCacheTable)
+	tk.MustGetErrCode("drop table t", errno.ErrOptOnCacheTable)
+
+	tk.MustExec("alter table t nocache")
+	c.Assert(cacheStatus(), Equals, model.TableCacheStatusDisable)
+	tk.MustExec("alter table t nocache")
+	tk.MustExec("alter table t add column c int")
+
+	// The system, temporary and partitioned tables can't be cached.
+	tk.MustGetErrCode("alter table mysql.user cache", errno.ErrOptOnCacheTable)
+	tk.MustExec("create table t_part (id int) partition by hash(id) partitions 4")
+	tk.MustGetErrCode("alter table t_part cache", errno.ErrOptOnCacheTable)
+	tk.MustExec("set tidb_enable_global_temporary_table=true")
+	tk.MustExec("create global temporary table t_tmp (id int) on commit delete rows")
+	tk.MustGetErrCode("alter table t_tmp cache", errno.ErrOptOnCacheTable)
+	tk.MustExec("drop table t, t_part, t_tmp")
+}
+
@@ -32,8 +32,10 @@ import (
 	"github.com/pingcap/parser/terror"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/ddl/placement"
 	"github.com/pingcap/tidb/ddl/testutil"
 	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/errno"
 	tmysql "github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/kv"
@@ -41,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
@@ -1615,6 +1618,134 @@ func (s *testIntegrationSuite5) TestMultiPartitionDropAndTruncate(c *C) {
 	result.Check(testkit.Rows(`2010`))
 }
 
+func (s *testIntegrationSuite5) TestIntervalPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3, t4, t5, t6")
+	partitionsSQL := "select partition_name, partition_description from information_schema.partitions where table_schema = 'test' and table_name = '%s' order by partition_ordinal_position"
+
+	tk.MustExec("create table t1 (id int) partition by range (id) interval (100) first partition less than (100) last partition less than (300) null partition maxvalue partition")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t1")).Check(testkit.Rows(
+		"P_NULL 0", "P_LT_100 100", "P_LT_200 200", "P_LT_300 300", "P_MAXVALUE MAXVALUE"))
+	tk.MustGetErrCode("alter table t1 last partition less than (400)", tmysql.ErrPartitionMaxvalue)
+	tk.MustExec("insert into t1 values (null), (-1), (50), (150), (250), (1000)")
+	tk.MustExec("alter table t1 first partition less than (300)")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t1")).Check(testkit.Rows("P_NULL 0", "P_LT_300 300", "P_MAXVALUE MAXVALUE"))
+	c.Assert(tk.MustQuery("admin show ddl jobs 1").Rows()[0][3], Equals, "drop partition")
+	tk.MustQuery("select * from t1 order by id").Check(testkit.Rows("<nil>", "-1", "250", "1000"))
+
+	// The new partitions are added in one ADD PARTITION job, and the old ones are dropped in one DROP PARTITION job.
+	tk.MustExec("create table t2 (id int) partition by range (id) interval (100) first partition less than (100) last partition less than (300)")
+	tk.MustExec("insert into t2 values (50), (150), (250)")
+	tk.MustGetErrCode("insert into t2 values (350)", tmysql.ErrNoPartitionForGivenValue)
+	tk.MustExec("alter table t2 last partition less than (500)")
+	c.Assert(tk.MustQuery("admin show ddl jobs 1").Rows()[0][3], Equals, "add partition")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t2")).Check(testkit.Rows(
+		"P_LT_100 100", "P_LT_200 200", "P_LT_300 300", "P_LT_400 400", "P_LT_500 500"))
+	tk.MustExec("insert into t2 values (350), (450)")
+	tk.MustGetErrCode("alter table t2 last partition less than (550)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t2 last partition less than (500)", tmysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode("alter table t2 first partition less than (250)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t2 first partition less than (300)")
+	c.Assert(tk.MustQuery("admin show ddl jobs 1").Rows()[0][3], Equals, "drop partition")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t2")).Check(testkit.Rows("P_LT_300 300", "P_LT_400 400", "P_LT_500 500"))
+	tk.MustQuery("select * from t2 order by id").Check(testkit.Rows("250", "350", "450"))
+	// Moving the first bound to the current first partition does nothing.
+	tk.MustExec("alter table t2 first partition less than (300)")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t2")).Check(testkit.Rows("P_LT_300 300", "P_LT_400 400", "P_LT_500 500"))
+
+	// The month bounds are computed from the first bound, so they don't drift from the end of month.
+	tk.MustExec("create table t3 (d date) partition by range columns (d) interval (1 month) first partition less than ('2021-01-31') last partition less than ('2021-04-30') null partition")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t3")).Check(testkit.Rows(
+		`P_NULL "2020-12-31"`, `P_LT_2021-01-31 "2021-01-31"`, `P_LT_2021-02-28 "2021-02-28"`, `P_LT_2021-03-31 "2021-03-31"`, `P_LT_2021-04-30 "2021-04-30"`))
+	tk.MustGetErrCode("alter table t3 last partition less than ('2021-06-15')", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t3 last partition less than ('2021-06-30')")
+	tk.MustExec("alter table t3 first partition less than ('2021-03-31')")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t3")).Check(testkit.Rows(
+		`P_NULL "2020-12-31"`, `P_LT_2021-03-31 "2021-03-31"`, `P_LT_2021-04-30 "2021-04-30"`, `P_LT_2021-05-31 "2021-05-31"`, `P_LT_2021-06-30 "2021-06-30"`))
+	tk.MustExec("insert into t3 values ('2021-06-29'), (null)")
+
+	// SHOW CREATE TABLE lists the partitions and prints the interval in a feature comment, the output creates the same table.
+	showCreateTable := tk.MustQuery("show create table t3").Rows()[0][1].(string)
+	c.Assert(showCreateTable, Equals, "CREATE TABLE `t3` (\n"+
+		"  `d` date DEFAULT NULL\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n"+
+		"PARTITION BY RANGE COLUMNS(d) /*T![interval_partition] INTERVAL (1 MONTH) FIRST PARTITION LESS THAN (\"2021-01-31\") LAST PARTITION LESS THAN (\"2021-06-30\") NULL PARTITION */ (\n"+
+		"  PARTITION `P_NULL` VALUES LESS THAN (\"2020-12-31\"),\n"+
+		"  PARTITION `P_LT_2021-03-31` VALUES LESS THAN (\"2021-03-31\"),\n"+
+		"  PARTITION `P_LT_2021-04-30` VALUES LESS THAN (\"2021-04-30\"),\n"+
+		"  PARTITION `P_LT_2021-05-31` VALUES LESS THAN (\"2021-05-31\"),\n"+
+		"  PARTITION `P_LT_2021-06-30` VALUES LESS THAN (\"2021-06-30\")\n"+
+		")")
+	tk.MustExec(strings.Replace(showCreateTable, "`t3`", "`t6`", 1))
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t6")).Check(tk.MustQuery(fmt.Sprintf(partitionsSQL, "t3")).Rows())
+	tk.MustExec("alter table t6 last partition less than ('2021-07-31')")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t6") + " desc limit 1").Check(testkit.Rows(`P_LT_2021-07-31 "2021-07-31"`))
+
+	tk.MustExec("create table t4 (d datetime) partition by range columns (d) interval (12 hour) first partition less than ('2021-01-01') last partition less than ('2021-01-02')")
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t4")).Check(testkit.Rows(
+		`P_LT_2021-01-01 00:00:00 "2021-01-01 00:00:00"`, `P_LT_2021-01-01 12:00:00 "2021-01-01 12:00:00"`, `P_LT_2021-01-02 00:00:00 "2021-01-02 00:00:00"`))
+
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (0) first partition less than (0) last partition less than (10)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (1 day) first partition less than (0) last partition less than (10)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (3) first partition less than (0) last partition less than (10)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (1) first partition less than (0) last partition less than (8192)", tmysql.ErrTooManyPartitions)
+	tk.MustGetErrCode("create table t5 (id int) partition by range columns (id) interval (1) first partition less than (0) last partition less than (10)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (d date) partition by range columns (d) interval (1) first partition less than ('2021-01-01') last partition less than ('2021-01-10')", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (10) first partition less than (0) last partition less than (10) (partition p0 values less than (15))", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (10) first partition less than (0) last partition less than (10) (partition p0 values less than (maxvalue))", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (id int) partition by range (id) interval (10) first partition less than (0) last partition less than (10) premake 1", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t5 (d date) partition by range columns (d) interval (1 day) first partition less than ('2021-01-01') last partition less than ('2021-01-02') premake 8193", tmysql.ErrTooManyPartitions)
+	tk.MustExec("create table t5 (id int) partition by range (id) (partition p0 values less than (10))")
+	tk.MustGetErrCode("alter table t5 last partition less than (20)", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t5 first partition less than (10)", tmysql.ErrUnsupportedDDLOperation)
+}
+
+func (s *testSerialDBSuite1) TestIntervalPartitionPremake(c *C) {
+	// The check interval is read when the DDL owner starts, so the test bootstraps its own store.
+	c.Assert(failpoint.Enable("github.com/pingcap/tidb/ddl/mockPremakeIntervalPartitionsCheckInterval", `return(50)`), IsNil)
+	defer func() {
+		c.Assert(failpoint.Disable("github.com/pingcap/tidb/ddl/mockPremakeIntervalPartitionsCheckInterval"), IsNil)
+	}()
+	store, err := mockstore.NewMockStore()
+	c.Assert(err, IsNil)
+	dom, err := session.BootstrapSession(store)
+	c.Assert(err, IsNil)
+	defer func() {
+		dom.Close()
+		c.Assert(store.Close(), IsNil)
+	}()
+	tk := testkit.NewTestKit(c, store)
+	tk.MustExec("use test")
+	partitionsSQL := "select partition_name from information_schema.partitions where table_schema = 'test' and table_name = '%s' order by partition_ordinal_position"
+
+	now := time.Now()
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	bound := func(months int) string {
+		return thisMonth.AddDate(0, months, 0).Format("2006-01-02")
+	}
+	// The current month is in the partition less than bound(1), PREMAKE 2 keeps the partitions up to bound(3).
+	tk.MustExec(fmt.Sprintf("create table t1 (d date) partition by range columns (d) interval (1 month) first partition less than ('%s') last partition less than ('%s') premake 2", bound(-1), bound(0)))
+	// Partitions can't be added before the MAXVALUE partition, the table is skipped.
+	tk.MustExec(fmt.Sprintf("create table t2 (d date) partition by range columns (d) interval (1 month) first partition less than ('%s') last partition less than ('%s') maxvalue partition premake 2", bound(-1), bound(0)))
+	// The partitions are already ahead of the current time, nothing is added.
+	tk.MustExec(fmt.Sprintf("create table t3 (d date) partition by range columns (d) interval (1 month) first partition less than ('%s') last partition less than ('%s') premake 1", bound(0), bound(3)))
+
+	expected := testkit.Rows("P_LT_"+bound(-1), "P_LT_"+bound(0), "P_LT_"+bound(1), "P_LT_"+bound(2), "P_LT_"+bound(3))
+	for i := 0; len(tk.MustQuery(fmt.Sprintf(partitionsSQL, "t1")).Rows()) < len(expected); i++ {
+		c.Assert(i, Less, 100)
+		time.Sleep(50 * time.Millisecond)
+	}
+	// Wait for more rounds, the added partitions are kept as they are.
+	time.Sleep(200 * time.Millisecond)
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t1")).Check(expected)
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t2")).Check(testkit.Rows("P_LT_"+bound(-1), "P_LT_"+bound(0), "P_MAXVALUE"))
+	tk.MustQuery(fmt.Sprintf(partitionsSQL, "t3")).Check(testkit.Rows("P_LT_"+bound(0), "P_LT_"+bound(1), "P_LT_"+bound(2), "P_LT_"+bound(3)))
+	tk.MustExec(fmt.Sprintf("insert into t1 values ('%s')", thisMonth.AddDate(0, 2, 15).Format("2006-01-02")))
+	showCreateTable := tk.MustQuery("show create table t1").Rows()[0][1].(string)
+	c.Assert(strings.Contains(showCreateTable, fmt.Sprintf(`LAST PARTITION LESS THAN ("%s") PREMAKE 2 */`, bound(3))), IsTrue, Commentf("%s", showCreateTable))
+}
+
 func (s *testSerialDBSuite1) TestDropPartitionWithGlobalIndex(c *C) {
 	config.UpdateGlobal(func(conf *config.Config) {
 		conf.EnableGlobalIndex = true
@@ -1885,6 +2016,137 @@ func (s *testSerialDBSuite1) TestAlterTableExchangePartition(c *C) {
 	tk.MustExec("alter table e15 exchange partition p0 with table e16")
 }
 
+func (s *testSerialDBSuite1) TestExchangePartitionWithValidation(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists pt, nt")
+	// Exchange partition is enabled by default.
+	tk.MustQuery("select @@tidb_enable_exchange_partition").Check(testkit.Rows("1"))
+
+	// The hash partition is located by the absolute value of the remainder, and NULL is in the first partition.
+	tk.MustExec("create table pt (a int) partition by hash(a) partitions 4")
+	tk.MustExec("create table nt (a int)")
+	tk.MustExec("insert into nt values (-1), (-5)")
+	tk.MustExec("alter table pt exchange partition p1 with table nt")
+	tk.MustQuery("select * from pt partition(p1)").Sort().Check(testkit.Rows("-1", "-5"))
+	tk.MustExec("insert into nt values (null)")
+	tk.MustGetErrCode("alter table pt exchange partition p1 with table nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustExec("alter table pt exchange partition p0 with table nt")
+	tk.MustQuery("select * from pt partition(p0)").Check(testkit.Rows("<nil>"))
+
+	// NULL is in the first range partition.
+	tk.MustExec("drop table pt, nt")
+	tk.MustExec("create table pt (a int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (maxvalue))")
+	tk.MustExec("create table nt (a int)")
+	tk.MustExec("insert into nt values (null), (20)")
+	err := tk.ExecToErr("alter table pt exchange partition p1 with table nt")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[ddl:1737]Found a row that does not match the partition, handle: _tidb_rowid=1")
+	tk.MustExec("delete from nt where a is null")
+	tk.MustExec("alter table pt exchange partition p1 with table nt")
+	tk.MustQuery("select * from pt partition(p1)").Check(testkit.Rows("20"))
+
+	// The offending row is reported by its primary key.
+	tk.MustExec("drop table pt, nt")
+	tk.MustExec("create table pt (id int primary key, a int) partition by range (id) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("create table nt (id int primary key, a int)")
+	tk.MustExec("insert into nt values (12, 1), (5, 1)")
+	err = tk.ExecToErr("alter table pt exchange partition p1 with table nt")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[ddl:1737]Found a row that does not match the partition, handle: id=5")
+	// WITHOUT VALIDATION skips the check.
+	tk.MustExec("alter table pt exchange partition p1 with table nt without validation")
+	tk.MustQuery("select * from pt partition(p1)").Sort().Check(testkit.Rows("12 1", "5 1"))
+	tk.MustQuery("select * from nt").Check(testkit.Rows())
+
+	// NULL only matches a list partition that contains NULL.
+	tk.MustExec("drop table pt, nt")
+	tk.MustExec("set @@tidb_enable_list_partition=1")
+	defer tk.MustExec("set @@tidb_enable_list_partition=0")
+	tk.MustExec("create table pt (a int) partition by list (a) (partition p0 values in (1, null), partition p1 values in (2, 3))")
+	tk.MustExec("create table nt (a int)")
+	tk.MustExec("insert into nt values (null), (3)")
+	tk.MustGetErrCode("alter table pt exchange partition p0 with table nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustGetErrCode("alter table pt exchange partition p1 with table nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustExec("delete from nt where a = 3")
+	tk.MustExec("alter table pt exchange partition p0 with table nt")
+	tk.MustQuery("select * from pt partition(p0)").Check(testkit.Rows("<nil>"))
+
+	// The values of list columns partitions are compared as they are written in the definition.
+	tk.MustExec("drop table pt, nt")
+	tk.MustExec(`create table pt (a int, b varchar(10)) partition by list columns (a, b) (
+		partition p0 values in ((1, 'it''s'), (2, '100%')),
+		partition p1 values in ((3, 'c')))`)
+	tk.MustExec("create table nt (a int, b varchar(10))")
+	tk.MustExec("insert into nt values (1, 'it''s'), (2, '100%')")
+	tk.MustGetErrCode("alter table pt exchange partition p1 with table nt", tmysql.ErrRowDoesNotMatchPartition)
+	tk.MustExec("alter table pt exchange partition p0 with table nt")
+	tk.MustQuery("select * from pt partition(p0)").Sort().Check(testkit.Rows("1 it's", "2 100%"))
+	tk.MustExec("insert into nt values (1, '100%')")
+	tk.MustGetErrCode("alter table pt exchange partition p0 with table nt", tmysql.ErrRowDoesNotMatchPartition)
+
+	// The statement is skipped with a warning if exchange partition is disabled.
+	tk.MustExec("set @@tidb_enable_exchange_partition=0")
+	defer tk.MustExec("set @@tidb_enable_exchange_partition=default")
+	tk.MustExec("alter table pt exchange partition p1 with table nt")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 Exchange Partition is disabled, please set 'tidb_enable_exchange_partition' if you need to need to enable it"))
+	tk.MustQuery("select * from nt").Check(testkit.Rows("1 100%"))
+}
+
+func (s *testSerialDBSuite1) TestExchangePartitionRollback(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists pt, nt")
+	tk.MustExec("drop placement policy if exists rollback_nt")
+	tk.MustExec("set @@tidb_enable_exchange_partition=1")
+	tk.Se.GetSessionVars().EnableAlterPlacement = true
+	c.Assert(failpoint.Enable("github.com/pingcap/tidb/domain/infosync/mockPlacementManager", `return(true)`), IsNil)
+	defer func() {
+		tk.MustExec("drop table if exists pt, nt")
+		tk.MustExec("drop placement policy if exists rollback_nt")
+		tk.MustExec("set @@tidb_enable_exchange_partition=0")
+		tk.Se.GetSessionVars().EnableAlterPlacement = false
+		c.Assert(failpoint.Disable("github.com/pingcap/tidb/domain/infosync/mockPlacementManager"), IsNil)
+	}()
+	tk.MustExec(`create placement policy rollback_nt primary_region="r1" regions="r1,r2"`)
+	tk.MustExec("create table pt (a int) partition by hash(a) partitions 2")
+	tk.MustExec("create table nt (a int) placement policy=rollback_nt")
+	tk.MustExec("alter table pt alter partition p1 add placement policy role=follower replicas=3")
+	tk.MustExec("insert into pt values (1), (2)")
+	tk.MustExec("insert into nt values (3)")
+
+	is := s.dom.InfoSchema()
+	pt, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("pt"))
+	c.Assert(err, IsNil)
+	nt, err := is.TableByName(model.NewCIStr("test"), model.NewCIStr("nt"))
+	c.Assert(err, IsNil)
+	partID, ntID := pt.Meta().Partition.Definitions[1].ID, nt.Meta().ID
+	getBundle := func(id int64) *placement.Bundle {
+		bundle, err := infosync.GetRuleBundle(context.Background(), placement.GroupID(id))
+		c.Assert(err, IsNil)
+		return bundle
+	}
+	partBundle, ntBundle := getBundle(partID), getBundle(ntID)
+	c.Assert(partBundle.IsEmpty(), IsFalse)
+	c.Assert(ntBundle.IsEmpty(), IsFalse)
+
+	// The swapped placement rules are restored when the job is cancelled after putting them to PD.
+	c.Assert(failpoint.Enable("github.com/pingcap/tidb/ddl/exchangePartitionLabelRulesErr", `return(true)`), IsNil)
+	err = tk.ExecToErr("alter table pt exchange partition p1 with table nt")
+	c.Assert(failpoint.Disable("github.com/pingcap/tidb/ddl/exchangePartitionLabelRulesErr"), IsNil)
+	c.Assert(err, NotNil)
+	c.Assert(getBundle(partID), DeepEquals, partBundle)
+	c.Assert(getBundle(ntID), DeepEquals, ntBundle)
+	tk.MustQuery("select * from pt partition(p1)").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from nt").Check(testkit.Rows("3"))
+
+	tk.MustExec("alter table pt exchange partition p1 with table nt")
+	c.Assert(getBundle(partID), DeepEquals, ntBundle.Clone().Reset(partID))
+	c.Assert(getBundle(ntID), DeepEquals, partBundle.Clone().Reset(ntID))
+	tk.MustQuery("select * from pt partition(p1)").Check(testkit.Rows("3"))
+	tk.MustQuery("select * from nt").Check(testkit.Rows("1"))
+}
+
 func (s *testIntegrationSuite4) TestExchangePartitionTableCompatiable(c *C) {
 	type testCase struct {
 		ptSQL       string
@@ -30,6 +30,7 @@ import (
 	. "github.com/pingcap/check"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/auth"
 	"github.com/pingcap/parser/model"
@@ -50,6 +51,7 @@ import (
 	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/helper"
 	"github.com/pingcap/tidb/store/mockstore"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
@@ -717,6 +719,311 @@ func (s *testDBSuite4) TestCancelAddIndex1(c *C) {
 	s.mustExec(tk, c, "alter table t drop index idx_c2")
 }
 
+// TestPauseResumeAddIndex tests pausing, altering and resuming the add index job.
+func (s *testDBSuite4) TestPauseResumeAddIndex(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	s.mustExec(tk, c, "use test_db")
+	s.mustExec(tk, c, "drop table if exists t")
+	s.mustExec(tk, c, "create table t(c1 int, c2 int, c3 int)")
+	defer s.mustExec(tk, c, "drop table t;")
+	for i := 0; i < 200; i += defaultBatchSize {
+		batchInsert(tk, "t", i, i+defaultBatchSize)
+	}
+
+	controlJob := func(control func(txn kv.Transaction, ids []int64) ([]error, error), jobID int64) error {
+		return kv.RunInNewTxn(context.Background(), s.store, true, func(ctx context.Context, txn kv.Transaction) error {
+			errs, err := control(txn, []int64{jobID})
+			if err != nil {
+				return err
+			}
+			return errs[0]
+		})
+	}
+	var (
+		checkErr error
+		jobID    int64
+		paused   = make(chan struct{})
+	)
+	hook := &ddl.TestDDLCallback{Do: s.dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		// Pause the job after the reorganization is initialized, so the backfill is stopped by the pause.
+		if jobID != 0 || job.Type != model.ActionAddIndex || job.SchemaState != model.StateWriteReorganization || job.SnapshotVer == 0 {
+			return
+		}
+		jobID = job.ID
+		checkErr = controlJob(admin.PauseJobs, jobID)
+		close(paused)
+	}
+	originalHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(originalHook)
+	done := make(chan error, 1)
+	go backgroundExec(s.store, "alter table t add index idx_c2(c2)", done)
+
+	<-paused
+	c.Assert(checkErr, IsNil)
+	strJobID := strconv.FormatInt(jobID, 10)
+	tk.MustQuery("admin pause ddl jobs " + strJobID).Check(testkit.Rows(strJobID + " successful"))
+	tk.MustQuery("admin alter ddl jobs " + strJobID + " thread = 1, batch_size = 32").Check(testkit.Rows(strJobID + " successful"))
+	_, err := tk.Exec("admin alter ddl jobs " + strJobID + " thread = 0")
+	c.Assert(err, ErrorMatches, "THREAD must be in .*")
+	// The paused job isn't run any more.
+	select {
+	case err := <-done:
+		c.Fatalf("the paused job is done, err: %v", err)
+	case <-time.After(5 * s.lease):
+	}
+	err = kv.RunInNewTxn(context.Background(), s.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		ctl, err := admin.GetJobControl(txn, jobID)
+		c.Assert(ctl, DeepEquals, &meta.DDLJobControl{Paused: true, Thread: 1, BatchSize: 32})
+		return err
+	})
+	c.Assert(err, IsNil)
+
+	// The jobs behind the paused job are run, unless they depend on it.
+	s.mustExec(tk, c, "drop table if exists t1")
+	s.mustExec(tk, c, "create table t1(c1 int)")
+	defer s.mustExec(tk, c, "drop table t1;")
+	s.mustExec(tk, c, "alter table t1 add index idx_c1(c1)")
+	dependentDone := make(chan error, 1)
+	go backgroundExec(s.store, "alter table t add index idx_c3(c3)", dependentDone)
+	select {
+	case err := <-dependentDone:
+		c.Fatalf("the job depending on the paused job is done, err: %v", err)
+	case <-time.After(5 * s.lease):
+	}
+	// The paused job is kept at the head of the queue.
+	err = kv.RunInNewTxn(context.Background(), s.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		job, err := meta.NewMeta(txn).GetDDLJobByIdx(0, meta.AddIndexJobListKey)
+		c.Assert(job.ID, Equals, jobID)
+		return err
+	})
+	c.Assert(err, IsNil)
+
+	tk.MustQuery("admin resume ddl jobs " + strJobID).Check(testkit.Rows(strJobID + " successful"))
+	c.Assert(<-done, IsNil)
+	c.Assert(<-dependentDone, IsNil)
+	s.mustExec(tk, c, "admin check index t idx_c2")
+	s.mustExec(tk, c, "admin check index t idx_c3")
+	// The control options are removed after the job is finished.
+	err = kv.RunInNewTxn(context.Background(), s.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		ctl, err := admin.GetJobControl(txn, jobID)
+		c.Assert(ctl, IsNil)
+		return err
+	})
+	c.Assert(err, IsNil)
+}
+
+// mockIndexIngester keeps the index KV pairs in memory, and writes them in a transaction when they're imported.
+type mockIndexIngester struct {
+	openErr error
+	// beforeImport is called before an engine is imported.
+	beforeImport func()
+
+	mu       sync.Mutex
+	engines  int
+	imported int
+	// shadowed is the number of KV pairs not imported since they're overwritten after the engine is opened.
+	shadowed int
+}
+
+func (i *mockIndexIngester) OpenEngine(ctx context.Context, store kv.Storage, cfg *ddl.IngestEngineConfig) (ddl.IngestEngine, error) {
+	if i.openErr != nil {
+		return nil, i.openErr
+	}
+	ver, err := store.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return nil, err
+	}
+	i.mu.Lock()
+	i.engines++
+	i.mu.Unlock()
+	return &mockIngestEngine{ingester: i, store: store, ts: ver.Ver, indexName: cfg.Index.Name.O}, nil
+}
+
+type mockIngestEngine struct {
+	ingester  *mockIndexIngester
+	store     kv.Storage
+	ts        uint64
+	indexName string
+
+	mu      sync.Mutex
+	entries []kv.Entry
+}
+
+func (e *mockIngestEngine) NewWriter(ctx context.Context) (ddl.IngestWriter, error) {
+	return &mockIngestWriter{engine: e}, nil
+}
+
+// Import writes the KV pairs as if they were committed at the ts allocated when the engine is opened,
+// so the KV pairs overwritten or deleted after the ts are skipped. The duplicate keys are reported like
+// the duplicate detection of the local backend.
+func (e *mockIngestEngine) Import(ctx context.Context) error {
+	if e.ingester.beforeImport != nil {
+		e.ingester.beforeImport()
+	}
+	h := helper.NewHelper(e.store.(helper.Storage))
+	entries := make([]kv.Entry, 0, len(e.entries))
+	written := make(map[string]struct{}, len(e.entries))
+	var dupKey kv.Key
+	shadowed := 0
+	for _, entry := range e.entries {
+		if _, ok := written[string(entry.Key)]; ok {
+			if dupKey == nil {
+				dupKey = entry.Key
+			}
+			continue
+		}
+		written[string(entry.Key)] = struct{}{}
+		resp, err := h.GetMvccByEncodedKey(entry.Key)
+		if err != nil {
+			return err
+		}
+		if isWrittenAfter(resp.Info, e.ts) {
+			shadowed++
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	err := kv.RunInNewTxn(ctx, e.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		for _, entry := range entries {
+			if err := txn.Set(entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	e.ingester.mu.Lock()
+	e.ingester.imported += len(entries)
+	e.ingester.shadowed += shadowed
+	e.ingester.mu.Unlock()
+	if dupKey != nil {
+		_, _, values, err := tablecodec.DecodeIndexKey(dupKey)
+		if err != nil {
+			return err
+		}
+		return kv.ErrKeyExists.FastGenByArgs(strings.Join(values, "-"), e.indexName)
+	}
+	return nil
+}
+
+func isWrittenAfter(info *kvrpcpb.MvccInfo, ts uint64) bool {
+	if info == nil {
+		return false
+	}
+	for _, w := range info.Writes {
+		if (w.Type == kvrpcpb.Op_Put || w.Type == kvrpcpb.Op_Del) && w.CommitTs > ts {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *mockIngestEngine) Cleanup(ctx context.Context) error {
+	e.entries = nil
+	return nil
+}
+
+type mockIngestWriter struct {
+	engine *mockIngestEngine
+}
+
+func (w *mockIngestWriter) WriteKVs(ctx context.Context, entries []kv.Entry) error {
+	w.engine.mu.Lock()
+	defer w.engine.mu.Unlock()
+	w.engine.entries = append(w.engine.entries, entries...)
+	return nil
+}
+
+func (w *mockIngestWriter) Close(ctx context.Context) error {
+	return nil
+}
+
+func (s *testSerialDBSuite) TestAddIndexByIngest(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(c1 int, c2 int, c3 int)")
+	defer tk.MustExec("drop table t")
+	batchInsert(tk, "t", 0, 200)
+
+	ingester := &mockIndexIngester{}
+	ddl.SetIndexIngester(ingester)
+	defer ddl.SetIndexIngester(nil)
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = 1")
+	defer tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = default")
+	originBatchSize := tk.MustQuery("select @@global.tidb_ddl_reorg_batch_size")
+	tk.MustExec("set @@global.tidb_ddl_reorg_batch_size = 32")
+	defer tk.MustExec(fmt.Sprintf("set @@global.tidb_ddl_reorg_batch_size = %v", originBatchSize.Rows()[0][0]))
+
+	tk.MustExec("alter table t add index idx_c2(c2)")
+	tk.MustExec("admin check index t idx_c2")
+	c.Assert(ingester.engines > 0, IsTrue)
+	c.Assert(ingester.imported, Equals, 200)
+
+	// The duplicate keys written into the same engine by different transactions are detected when it's imported.
+	ingester.imported = 0
+	tk.MustExec("update t set c3 = 1 where c1 = 150")
+	err := tk.ExecToErr("alter table t add unique index idx_c3(c3)")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[kv:1062]Duplicate entry '1' for key 'idx_c3'")
+	c.Assert(ingester.imported, Equals, 199)
+	tk.MustExec("admin check table t")
+	tk.MustExec("update t set c3 = 150 where c1 = 150")
+	tk.MustExec("alter table t add unique index idx_c3(c3)")
+	tk.MustExec("admin check index t idx_c3")
+
+	// Fall back to backfill the index in transactions if the engine can't be opened.
+	ingester.imported = 0
+	ingester.openErr = errors.New("mock open engine error")
+	tk.MustExec("alter table t add index idx_c1(c1)")
+	tk.MustExec("admin check index t idx_c1")
+	c.Assert(ingester.imported, Equals, 0)
+}
+
+func (s *testSerialDBSuite) TestAddIndexByIngestWithConcurrentDML(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test_db")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(c1 int, c2 int, c3 int)")
+	defer tk.MustExec("drop table t")
+	batchInsert(tk, "t", 0, 100)
+
+	// The DMLs run in the write reorganization state after the rows are read, the index KV pairs
+	// they overwrite or delete must not be visible after the engine is imported.
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test_db")
+	var dmlErr error
+	var once sync.Once
+	ingester := &mockIndexIngester{beforeImport: func() {
+		once.Do(func() {
+			for _, sql := range []string{
+				"update t set c2 = c2 + 1000 where c1 = 10",
+				"delete from t where c1 = 20",
+				"insert into t values (1000, 1000, 1000)",
+			} {
+				if _, dmlErr = tk1.Exec(sql); dmlErr != nil {
+					return
+				}
+			}
+		})
+	}}
+	ddl.SetIndexIngester(ingester)
+	defer ddl.SetIndexIngester(nil)
+	tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = 1")
+	defer tk.MustExec("set @@global.tidb_ddl_enable_fast_reorg = default")
+
+	tk.MustExec("alter table t add index idx_c2(c2)")
+	c.Assert(dmlErr, IsNil)
+	c.Assert(ingester.shadowed, Equals, 2)
+	c.Assert(ingester.imported, Equals, 98)
+	tk.MustExec("admin check index t idx_c2")
+	tk.MustQuery("select c1 from t use index(idx_c2) where c2 in (10, 20, 1010, 1000)").Sort().Check(testkit.Rows("10", "1000"))
+}
+
 // TestCancelDropIndex tests cancel ddl job which type is drop primary key.
 func (s *testDBSuite4) TestCancelDropPrimaryKey(c *C) {
 	idxName := "primary"
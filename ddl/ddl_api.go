@@ -44,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -52,12 +53,15 @@ import (
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/admin"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/domainutil"
+	"github.com/pingcap/tidb/util/gcutil"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
 	"github.com/pingcap/tidb/util/set"
+	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
@@ -1636,6 +1640,9 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			}
 		}
 	}
+	if err := checkTTLInfoValid(tbInfo); err != nil {
+		return errors.Trace(err)
+	}
 	// Can not use both a placement policy and direct assignment. If you alter specify both in a CREATE TABLE or ALTER TABLE an error will be returned.
 	if tbInfo.DirectPlacementOpts != nil && tbInfo.PlacementPolicyRef != nil {
 		return errors.Trace(ErrPlacementPolicyWithDirectOption.GenWithStackByArgs(tbInfo.PlacementPolicyRef.Name))
@@ -1712,6 +1719,11 @@ func buildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
 	// Ignore TiFlash replicas for temporary tables.
+	if s.TemporaryKeyword != ast.TemporaryNone {
+		tblInfo.TTLInfo = nil
+	} else if tblInfo.TTLInfo != nil {
+		tblInfo.TTLInfo = tblInfo.TTLInfo.Clone()
+	}
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
 	} else if tblInfo.TiFlashReplica != nil {
@@ -1821,6 +1833,14 @@ func buildTableInfoWithStmt(ctx sessionctx.Context, s *ast.CreateTableStmt, dbCh
 		return nil, errors.Trace(err)
 	}
 
+	var ttlEnable *bool
+	if tbInfo.TTLInfo, ttlEnable, err = buildTTLInfo(ctx, s.Options); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tbInfo.TTLInfo == nil && ttlEnable != nil {
+		return nil, errors.Trace(errUnsupportedTTL.GenWithStackByArgs("TTL_ENABLE can't be set on a table without TTL"))
+	}
+
 	if tbInfo.PlacementPolicyRef == nil && tbInfo.DirectPlacementOpts == nil {
 		// Set the defaults from Schema. Note: they are mutual exlusive!
 		if placementPolicyRef != nil {
@@ -2086,6 +2106,146 @@ func (d *ddl) RecoverTable(ctx sessionctx.Context, recoverInfo *RecoverInfo) (er
 	return errors.Trace(err)
 }
 
+// RecoverSchema recovers a dropped schema with all its tables, views and sequences in one job.
+func (d *ddl) RecoverSchema(ctx sessionctx.Context, recoverSchemaInfo *RecoverSchemaInfo) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	dbInfo := recoverSchemaInfo.DBInfo
+	// Check not exist schema with same ID or name.
+	if schema, ok := is.SchemaByID(dbInfo.ID); ok {
+		return infoschema.ErrDatabaseExists.GenWithStack("Schema '%-.192s' already been recover to '%-.192s', can't be recover repeatedly", recoverSchemaInfo.OldSchemaName, schema.Name.O)
+	}
+	if is.SchemaExists(dbInfo.Name) {
+		return infoschema.ErrDatabaseExists.GenWithStackByArgs(dbInfo.Name)
+	}
+
+	dbInfo.State = model.StateNone
+	job := &model.Job{
+		SchemaID:   dbInfo.ID,
+		SchemaName: dbInfo.Name.L,
+		Type:       model.ActionRecoverSchema,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{recoverSchemaInfo, recoverTableCheckFlagNone},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// FlashbackToTimestamp rolls the data of the tables back to the timestamp in one job.
+// All the user tables are flashed back if tables is empty.
+func (d *ddl) FlashbackToTimestamp(ctx sessionctx.Context, tables []*ast.TableName, flashbackTS uint64) (err error) {
+	currentVer, err := d.store.CurrentVersion(oracle.GlobalTxnScope)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if flashbackTS > currentVer.Ver {
+		return errors.Errorf("Can't flashback to a future time %s", model.TSConvert2Time(flashbackTS).String())
+	}
+	if err = gcutil.ValidateSnapshot(ctx, flashbackTS); err != nil {
+		return err
+	}
+
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	tp := model.ActionFlashbackTable
+	var tblInfos []*model.TableInfo
+	var lockTables []model.TableLockTpInfo
+	if len(tables) == 0 {
+		tp = model.ActionFlashbackCluster
+		for _, schema := range is.AllSchemas() {
+			if util.IsMemOrSysDB(schema.Name.L) {
+				continue
+			}
+			for _, tblInfo := range schema.Tables {
+				// Views and sequences have no data, and temporary tables aren't persisted.
+				if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+					continue
+				}
+				tblInfos = append(tblInfos, tblInfo)
+				lockTables = append(lockTables, model.TableLockTpInfo{SchemaID: schema.ID, TableID: tblInfo.ID, Tp: model.TableLockReadOnly})
+			}
+		}
+		if len(lockTables) == 0 {
+			return nil
+		}
+		// The job locks all the user tables, so the jobs before it would be blocked by the locks.
+		err = kv.RunInNewTxn(context.Background(), d.store, false, func(ctx context.Context, txn kv.Transaction) error {
+			jobs, err := admin.GetDDLJobs(txn)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(jobs) > 0 {
+				return errors.Errorf("Can't flashback cluster while DDL job %d is running", jobs[0].ID)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	} else {
+		uniqueTableID := make(map[int64]struct{})
+		for _, tn := range tables {
+			if err = throwErrIfInMemOrSysDB(ctx, tn.Schema.L); err != nil {
+				return err
+			}
+			schema, t, err := d.getSchemaAndTableByIdent(ctx, ast.Ident{Schema: tn.Schema, Name: tn.Name})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			tblInfo := t.Meta()
+			if tblInfo.IsView() || tblInfo.IsSequence() {
+				return ErrWrongObject.GenWithStackByArgs(schema.Name, tblInfo.Name, "BASE TABLE")
+			}
+			if tblInfo.TempTableType != model.TempTableNone {
+				return ErrOptOnTemporaryTable.GenWithStackByArgs("flashback to timestamp")
+			}
+			if _, ok := uniqueTableID[tblInfo.ID]; ok {
+				return infoschema.ErrNonuniqTable.GenWithStackByArgs(tblInfo.Name)
+			}
+			uniqueTableID[tblInfo.ID] = struct{}{}
+			tblInfos = append(tblInfos, tblInfo)
+			lockTables = append(lockTables, model.TableLockTpInfo{SchemaID: schema.ID, TableID: tblInfo.ID, Tp: model.TableLockReadOnly})
+		}
+	}
+
+	// Rolling back the data across a DDL would make the rows undecodable, so the schema of every table
+	// must be the same at the flashback timestamp.
+	snapMeta := meta.NewSnapshotMeta(d.store.GetSnapshot(kv.NewVersion(flashbackTS)))
+	for i, tblInfo := range tblInfos {
+		if tblInfo.IsLocked() {
+			return infoschema.ErrTableLocked.GenWithStackByArgs(tblInfo.Name.L, tblInfo.Lock.Tp, tblInfo.Lock.Sessions[0])
+		}
+		snapInfo, err := snapMeta.GetTable(lockTables[i].SchemaID, tblInfo.ID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if snapInfo == nil || snapInfo.UpdateTS != tblInfo.UpdateTS {
+			return errors.Errorf("Can't flashback table '%s' to %s, the schema of the table has been changed since then", tblInfo.Name.O, model.TSConvert2Time(flashbackTS).String())
+		}
+	}
+
+	arg := &flashbackArg{
+		FlashbackTS: flashbackTS,
+		Tables:      lockTables,
+		SessionInfo: model.SessionInfo{
+			ServerID:  d.GetID(),
+			SessionID: ctx.GetSessionVars().ConnectionID,
+		},
+		CheckFlag: recoverTableCheckFlagNone,
+	}
+	job := &model.Job{
+		Type:       tp,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{arg},
+	}
+	if tp == model.ActionFlashbackTable {
+		job.SchemaID = lockTables[0].SchemaID
+		job.TableID = lockTables[0].TableID
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt) (err error) {
 	viewInfo, err := buildViewInfo(ctx, s)
 	if err != nil {
@@ -2537,6 +2697,13 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 	if is.TableIsView(ident.Schema, ident.Name) || is.TableIsSequence(ident.Schema, ident.Name) {
 		return ErrWrongObject.GenWithStackByArgs(ident.Schema, ident.Name, "BASE TABLE")
 	}
+	if tb, err := is.TableByName(ident.Schema, ident.Name); err == nil {
+		if len(validSpecs) != 1 || (validSpecs[0].Tp != ast.AlterTableCache && validSpecs[0].Tp != ast.AlterTableNoCache) {
+			if err = checkTableNotCached(tb, "Alter Table"); err != nil {
+				return err
+			}
+		}
+	}
 
 	if len(validSpecs) > 1 {
 		if !sctx.GetSessionVars().EnableChangeMultiSchema {
@@ -2564,7 +2731,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 	}
 
 	for _, spec := range validSpecs {
-		var handledCharsetOrCollate bool
+		var handledCharsetOrCollate, handledTTLOrTTLEnable bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
 			if len(spec.NewColumns) != 1 {
@@ -2572,7 +2739,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			} else {
 				err = d.AddColumn(sctx, ident, spec)
 			}
-		case ast.AlterTableAddPartitions:
+		case ast.AlterTableAddPartitions, ast.AlterTableLastPartition:
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
@@ -2586,6 +2753,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			err = errors.Trace(errUnsupportedOptimizePartition)
 		case ast.AlterTableRemovePartitioning:
 			err = errors.Trace(errUnsupportedRemovePartition)
+		case ast.AlterTableRemoveTTL:
+			err = d.AlterTableRemoveTTL(sctx, ident)
+		case ast.AlterTableCache:
+			err = d.AlterTableCache(sctx, ident)
+		case ast.AlterTableNoCache:
+			err = d.AlterTableNoCache(sctx, ident)
 		case ast.AlterTableRepairPartition:
 			err = errors.Trace(errUnsupportedRepairPartition)
 		case ast.AlterTableDropColumn:
@@ -2596,7 +2769,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			err = d.DropIndex(sctx, ident, model.NewCIStr(mysql.PrimaryKeyName), spec.IfExists)
 		case ast.AlterTableRenameIndex:
 			err = d.RenameIndex(sctx, ident, spec)
-		case ast.AlterTableDropPartition:
+		case ast.AlterTableDropPartition, ast.AlterTableFirstPartition:
 			err = d.DropTablePartition(sctx, ident, spec)
 		case ast.AlterTableTruncatePartition:
 			err = d.TruncateTablePartition(sctx, ident, spec)
@@ -2701,6 +2874,19 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 					needsOverwriteCols := needToOverwriteColCharset(spec.Options)
 					err = d.AlterTableCharsetAndCollate(sctx, ident, toCharset, toCollate, needsOverwriteCols)
 					handledCharsetOrCollate = true
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable:
+					// buildTTLInfo gets the TTL and TTL_ENABLE of all the options, so it should be handled only once.
+					if handledTTLOrTTLEnable {
+						continue
+					}
+					var ttlInfo *model.TTLInfo
+					var ttlEnable *bool
+					ttlInfo, ttlEnable, err = buildTTLInfo(sctx, spec.Options)
+					if err != nil {
+						return err
+					}
+					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable)
+					handledTTLOrTTLEnable = true
 				case ast.TableOptionEngine:
 				default:
 					err = errUnsupportedAlterTableOption
@@ -3244,9 +3430,21 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
 
-	partNames := make([]string, len(spec.PartitionNames))
-	for i, partCIName := range spec.PartitionNames {
-		partNames[i] = partCIName.L
+	var partNames []string
+	if spec.Tp == ast.AlterTableFirstPartition {
+		// FIRST PARTITION LESS THAN drops the partitions before the given bound in one job.
+		partNames, err = getPartitionNamesBeforeFirstRangeEnd(ctx, meta, spec.Partition.Interval.FirstRangeEnd)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(partNames) == 0 {
+			return nil
+		}
+	} else {
+		partNames = make([]string, len(spec.PartitionNames))
+		for i, partCIName := range spec.PartitionNames {
+			partNames[i] = partCIName.L
+		}
 	}
 	err = checkDropTablePartition(meta, partNames)
 	if err != nil {
@@ -3959,7 +4157,6 @@ func processAndCheckDefaultValueAndColumn(ctx sessionctx.Context, col *table.Col
 
 func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Context, ident ast.Ident, originalColName model.CIStr,
 	spec *ast.AlterTableSpec) (*model.Job, error) {
-	specNewColumn := spec.NewColumns[0]
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -3970,19 +4167,45 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		return nil, errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
 	}
 
+	newCol, modifyColumnTp, newAutoRandBits, err := buildModifiedColumn(ctx, sctx, schema, t, ident, originalColName, spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionModifyColumn,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       sctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args: []interface{}{&newCol, originalColName, spec.Position, modifyColumnTp, newAutoRandBits},
+	}
+	return job, nil
+}
+
+// buildModifiedColumn checks whether the column can be modified as the spec, and builds the modified column.
+// modifyColumnTp is mysql.TypeNull if the column is modified from null to not null.
+func buildModifiedColumn(ctx context.Context, sctx sessionctx.Context, schema *model.DBInfo, t table.Table, ident ast.Ident,
+	originalColName model.CIStr, spec *ast.AlterTableSpec) (_ *table.Column, modifyColumnTp byte, newAutoRandBits uint64, err error) {
+	specNewColumn := spec.NewColumns[0]
 	col := table.FindCol(t.Cols(), originalColName.L)
 	if col == nil {
-		return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(originalColName, ident.Name)
+		return nil, 0, 0, infoschema.ErrColumnNotExists.GenWithStackByArgs(originalColName, ident.Name)
 	}
 	newColName := specNewColumn.Name.Name
 	if newColName.L == model.ExtraHandleName.L {
-		return nil, ErrWrongColumnName.GenWithStackByArgs(newColName.L)
+		return nil, 0, 0, ErrWrongColumnName.GenWithStackByArgs(newColName.L)
 	}
 	// If we want to rename the column name, we need to check whether it already exists.
 	if newColName.L != originalColName.L {
 		c := table.FindCol(t.Cols(), newColName.L)
 		if c != nil {
-			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
+			return nil, 0, 0, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
 	}
 
@@ -3990,11 +4213,11 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 	// which will be done by `processColumnOptions` later.
 	if specNewColumn.Tp == nil {
 		// Make sure the column definition is simple field type.
-		return nil, errors.Trace(errUnsupportedModifyColumn)
+		return nil, 0, 0, errors.Trace(errUnsupportedModifyColumn)
 	}
 
 	if err = checkColumnAttributes(specNewColumn.Name.OrigColName(), specNewColumn.Tp); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
 
 	newCol := table.ToColumn(&model.ColumnInfo{
@@ -4025,7 +4248,7 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 	} else {
 		chs, coll, err = getCharsetAndCollateInColumnDef(specNewColumn)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, 0, 0, errors.Trace(err)
 		}
 		chs, coll, err = ResolveCharsetCollation(
 			ast.CharsetOpt{Chs: chs, Col: coll},
@@ -4034,12 +4257,12 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		)
 		chs, coll = OverwriteCollationWithBinaryFlag(specNewColumn, chs, coll)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, 0, 0, errors.Trace(err)
 		}
 	}
 
 	if err = setCharsetCollationFlenDecimal(&newCol.FieldType, chs, coll); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
 
 	// Check the column with foreign key, waiting for the default flen and decimal.
@@ -4047,7 +4270,7 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		// For now we strongly ban the all column type change for column with foreign key.
 		// Actually MySQL support change column with foreign key from varchar(m) -> varchar(m+t) and t > 0.
 		if newCol.Tp != col.Tp || newCol.Flen != col.Flen || newCol.Decimal != col.Decimal {
-			return nil, errFKIncompatibleColumns.GenWithStackByArgs(originalColName, fkInfo.Name)
+			return nil, 0, 0, errFKIncompatibleColumns.GenWithStackByArgs(originalColName, fkInfo.Name)
 		}
 	}
 
@@ -4060,7 +4283,7 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 	}
 
 	if err = processColumnOptions(sctx, newCol, specNewColumn.Options); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
 
 	if err = checkModifyTypes(sctx, &col.FieldType, &newCol.FieldType, isColumnWithIndex(col.Name.L, t.Meta().Indices)); err != nil {
@@ -4068,64 +4291,56 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 			colErrMsg := "Unsupported modifying collation of column '%s' from '%s' to '%s' when index is defined on it."
 			err = errUnsupportedModifyCollation.GenWithStack(colErrMsg, col.Name.L, col.Collate, newCol.Collate)
 		}
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
 	if needChangeColumnData(col.ColumnInfo, newCol.ColumnInfo) {
 		if err = isGeneratedRelatedColumn(t.Meta(), newCol.ColumnInfo, col.ColumnInfo); err != nil {
-			return nil, errors.Trace(err)
+			return nil, 0, 0, errors.Trace(err)
 		}
 		if t.Meta().Partition != nil {
-			return nil, errUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
+			return nil, 0, 0, errUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
 		}
 	}
 
 	// We don't support modifying column from not_auto_increment to auto_increment.
 	if !mysql.HasAutoIncrementFlag(col.Flag) && mysql.HasAutoIncrementFlag(newCol.Flag) {
-		return nil, errUnsupportedModifyColumn.GenWithStackByArgs("can't set auto_increment")
+		return nil, 0, 0, errUnsupportedModifyColumn.GenWithStackByArgs("can't set auto_increment")
 	}
 	// Disallow modifying column from auto_increment to not auto_increment if the session variable `AllowRemoveAutoInc` is false.
 	if !sctx.GetSessionVars().AllowRemoveAutoInc && mysql.HasAutoIncrementFlag(col.Flag) && !mysql.HasAutoIncrementFlag(newCol.Flag) {
-		return nil, errUnsupportedModifyColumn.GenWithStackByArgs("can't remove auto_increment without @@tidb_allow_remove_auto_inc enabled")
+		return nil, 0, 0, errUnsupportedModifyColumn.GenWithStackByArgs("can't remove auto_increment without @@tidb_allow_remove_auto_inc enabled")
 	}
 
 	// We support modifying the type definitions of 'null' to 'not null' now.
-	var modifyColumnTp byte
 	if !mysql.HasNotNullFlag(col.Flag) && mysql.HasNotNullFlag(newCol.Flag) {
-		if err = checkForNullValue(ctx, sctx, true, ident.Schema, ident.Name, newCol.Name, col.ColumnInfo); err != nil {
-			return nil, errors.Trace(err)
+		// The data of local temporary tables isn't visible to the internal sessions,
+		// it's checked when the records are rebuilt.
+		if t.Meta().TempTableType != model.TempTableLocal {
+			if err = checkForNullValue(ctx, sctx, true, ident.Schema, ident.Name, newCol.Name, col.ColumnInfo); err != nil {
+				return nil, 0, 0, errors.Trace(err)
+			}
 		}
 		// `modifyColumnTp` indicates that there is a type modification.
 		modifyColumnTp = mysql.TypeNull
 	}
 
 	if err = checkColumnWithIndexConstraint(t.Meta(), col.ColumnInfo, newCol.ColumnInfo); err != nil {
-		return nil, err
+		return nil, 0, 0, err
+	}
+
+	if err = checkTTLColumnModifiable(t.Meta(), col.ColumnInfo, newCol.ColumnInfo); err != nil {
+		return nil, 0, 0, err
 	}
 
 	// As same with MySQL, we don't support modifying the stored status for generated columns.
 	if err = checkModifyGeneratedColumn(sctx, t, col, newCol, specNewColumn, spec.Position); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
 
-	var newAutoRandBits uint64
 	if newAutoRandBits, err = checkAutoRandom(t.Meta(), col, specNewColumn); err != nil {
-		return nil, errors.Trace(err)
+		return nil, 0, 0, errors.Trace(err)
 	}
-
-	job := &model.Job{
-		SchemaID:   schema.ID,
-		TableID:    t.Meta().ID,
-		SchemaName: schema.Name.L,
-		Type:       model.ActionModifyColumn,
-		BinlogInfo: &model.HistoryInfo{},
-		ReorgMeta: &model.DDLReorgMeta{
-			SQLMode:       sctx.GetSessionVars().SQLMode,
-			Warnings:      make(map[errors.ErrorID]*terror.Error),
-			WarningsCount: make(map[errors.ErrorID]int64),
-		},
-		Args: []interface{}{&newCol, originalColName, spec.Position, modifyColumnTp, newAutoRandBits},
-	}
-	return job, nil
+	return newCol, modifyColumnTp, newAutoRandBits, nil
 }
 
 // checkColumnWithIndexConstraint is used to check the related index constraint of the modified column.
@@ -4333,19 +4548,47 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 		return errors.Trace(err)
 	}
 
+	newCol, err := buildRenamedColumn(tbl, ident, oldColName, newColName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tbl.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionModifyColumn,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args: []interface{}{&newCol, oldColName, spec.Position, 0},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// buildRenamedColumn checks whether the column can be renamed, and builds the renamed column.
+func buildRenamedColumn(tbl table.Table, ident ast.Ident, oldColName, newColName model.CIStr) (*model.ColumnInfo, error) {
 	oldCol := table.FindCol(tbl.VisibleCols(), oldColName.L)
 	if oldCol == nil {
-		return infoschema.ErrColumnNotExists.GenWithStackByArgs(oldColName, ident.Name)
+		return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(oldColName, ident.Name)
 	}
 
 	allCols := tbl.Cols()
 	colWithNewNameAlreadyExist := table.FindCol(allCols, newColName.L) != nil
 	if colWithNewNameAlreadyExist {
-		return infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
+		return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 	}
 
 	if fkInfo := getColumnForeignKeyInfo(oldColName.L, tbl.Meta().ForeignKeys); fkInfo != nil {
-		return errFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
+		return nil, errFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
+	}
+
+	if tbl.Meta().TTLInfo != nil && tbl.Meta().TTLInfo.ColumnName.L == oldColName.L {
+		return nil, errUnsupportedTTL.GenWithStackByArgs("the TTL column can't be renamed")
 	}
 
 	// Check generated expression.
@@ -4357,31 +4600,16 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 		for _, name := range dependedColNames {
 			if name.Name.L == oldColName.L {
 				if col.Hidden {
-					return errDependentByFunctionalIndex.GenWithStackByArgs(oldColName.O)
+					return nil, errDependentByFunctionalIndex.GenWithStackByArgs(oldColName.O)
 				}
-				return errDependentByGeneratedColumn.GenWithStackByArgs(oldColName.O)
+				return nil, errDependentByGeneratedColumn.GenWithStackByArgs(oldColName.O)
 			}
 		}
 	}
 
 	newCol := oldCol.Clone()
 	newCol.Name = newColName
-	job := &model.Job{
-		SchemaID:   schema.ID,
-		TableID:    tbl.Meta().ID,
-		SchemaName: schema.Name.L,
-		Type:       model.ActionModifyColumn,
-		BinlogInfo: &model.HistoryInfo{},
-		ReorgMeta: &model.DDLReorgMeta{
-			SQLMode:       ctx.GetSessionVars().SQLMode,
-			Warnings:      make(map[errors.ErrorID]*terror.Error),
-			WarningsCount: make(map[errors.ErrorID]int64),
-		},
-		Args: []interface{}{&newCol, oldColName, spec.Position, 0},
-	}
-	err = d.doDDLJob(ctx, job)
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return newCol, nil
 }
 
 // ModifyColumn does modification on an existing column, currently we only support limited kind of changes
@@ -4416,7 +4644,6 @@ func (d *ddl) ModifyColumn(ctx context.Context, sctx sessionctx.Context, ident a
 }
 
 func (d *ddl) AlterColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
-	specNewColumn := spec.NewColumns[0]
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -4427,46 +4654,56 @@ func (d *ddl) AlterColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Alt
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name)
 	}
 
+	col, err := buildColumnWithNewDefault(ctx, t, ident, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionSetDefaultValue,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{col},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// buildColumnWithNewDefault sets or drops the default value of the column as the spec of `ALTER COLUMN`.
+func buildColumnWithNewDefault(ctx sessionctx.Context, t table.Table, ident ast.Ident, spec *ast.AlterTableSpec) (*table.Column, error) {
+	specNewColumn := spec.NewColumns[0]
 	colName := specNewColumn.Name.Name
 	// Check whether alter column has existed.
 	col := table.FindCol(t.Cols(), colName.L)
 	if col == nil {
-		return ErrBadField.GenWithStackByArgs(colName, ident.Name)
+		return nil, ErrBadField.GenWithStackByArgs(colName, ident.Name)
 	}
 
 	// Clean the NoDefaultValueFlag value.
 	col.Flag &= ^mysql.NoDefaultValueFlag
 	if len(specNewColumn.Options) == 0 {
-		err = col.SetDefaultValue(nil)
+		err := col.SetDefaultValue(nil)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		setNoDefaultValueFlag(col, false)
 	} else {
 		if IsAutoRandomColumnID(t.Meta(), col.ID) {
-			return ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomIncompatibleWithDefaultValueErrMsg)
+			return nil, ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomIncompatibleWithDefaultValueErrMsg)
 		}
 		hasDefaultValue, err := setDefaultValue(ctx, col, specNewColumn.Options[0])
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		if err = checkDefaultValue(ctx, col, hasDefaultValue); err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 	}
-
-	job := &model.Job{
-		SchemaID:   schema.ID,
-		TableID:    t.Meta().ID,
-		SchemaName: schema.Name.L,
-		Type:       model.ActionSetDefaultValue,
-		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{col},
-	}
-
-	err = d.doDDLJob(ctx, job)
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return col, nil
 }
 
 // AlterTableComment updates the table comment information.
@@ -4839,6 +5076,9 @@ func (d *ddl) DropTable(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	if tb.Meta().IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name)
 	}
+	if err = checkTableNotCached(tb, "Drop Table"); err != nil {
+		return err
+	}
 
 	job := &model.Job{
 		SchemaID:   schema.ID,
@@ -4894,6 +5134,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tb.Meta().Name.O)
 	}
+	if err = checkTableNotCached(tb, "Truncate Table"); err != nil {
+		return err
+	}
 	genIDs, err := d.genGlobalIDs(1)
 	if err != nil {
 		return errors.Trace(err)
@@ -5020,6 +5263,11 @@ func extractTblInfos(is infoschema.InfoSchema, oldIdent, newIdent ast.Ident, isA
 		}
 		return nil, 0, errFileNotFound.GenWithStackByArgs(oldIdent.Schema, oldIdent.Name)
 	}
+	if oldTbl, err := is.TableByName(oldIdent.Schema, oldIdent.Name); err == nil {
+		if err = checkTableNotCached(oldTbl, "Rename Table"); err != nil {
+			return nil, 0, err
+		}
+	}
 	if isAlterTable && newIdent.Schema.L == oldIdent.Schema.L && newIdent.Name.L == oldIdent.Name.L {
 		// oldIdent is equal to newIdent, do nothing
 		return nil, 0, nil
@@ -5263,16 +5511,59 @@ func buildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*as
 
 func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return errUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkTableNotCached(t, "Create Index"); err != nil {
+		return err
 	}
+
 	unique := keyType == ast.IndexKeyTypeUnique
-	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	indexName, hiddenCols, global, err := checkCreateIndex(ctx, t, keyType, indexName, indexPartSpecifications, indexOption)
 	if err != nil {
+		if ErrDupKeyName.Equal(err) && ifNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
 		return errors.Trace(err)
 	}
 
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddIndex,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{unique, indexName, indexPartSpecifications, indexOption, hiddenCols, global},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.doDDLJob(ctx, job)
+	// key exists, but if_not_exists flags is true, so we ignore this error.
+	if ErrDupKeyName.Equal(err) && ifNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// checkCreateIndex checks whether the index can be created on the table, it returns the index name, the hidden
+// columns of the expression index, and whether the index is a global index.
+func checkCreateIndex(ctx sessionctx.Context, t table.Table, keyType ast.IndexKeyType, indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) (model.CIStr, []*model.ColumnInfo, bool, error) {
+	// not support Spatial and FullText index
+	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
+		return indexName, nil, false, errUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	}
+	unique := keyType == ast.IndexKeyTypeUnique
+	var err error
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -5291,15 +5582,11 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		} else {
 			err = ErrDupKeyName.GenWithStack("index already exist %s", indexName)
 		}
-		if ifNotExists {
-			ctx.GetSessionVars().StmtCtx.AppendNote(err)
-			return nil
-		}
-		return err
+		return indexName, nil, false, err
 	}
 
 	if err = checkTooLongIndex(indexName); err != nil {
-		return errors.Trace(err)
+		return indexName, nil, false, errors.Trace(err)
 	}
 
 	tblInfo := t.Meta()
@@ -5307,10 +5594,10 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// Build hidden columns if necessary.
 	hiddenCols, err := buildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
 	if err != nil {
-		return err
+		return indexName, nil, false, err
 	}
 	if err = checkAddColumnTooManyColumns(len(t.Cols()) + len(hiddenCols)); err != nil {
-		return errors.Trace(err)
+		return indexName, nil, false, errors.Trace(err)
 	}
 
 	finalColumns := make([]*model.ColumnInfo, len(tblInfo.Columns), len(tblInfo.Columns)+len(hiddenCols))
@@ -5324,7 +5611,7 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// For same reason, decide whether index is global here.
 	indexColumns, err := buildIndexColumns(finalColumns, indexPartSpecifications)
 	if err != nil {
-		return errors.Trace(err)
+		return indexName, nil, false, errors.Trace(err)
 	}
 
 	if !unique && tblInfo.IsCommonHandle {
@@ -5332,14 +5619,14 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 		var pkLen, idxLen int
 		pkLen, err = indexColumnsLen(tblInfo.Columns, tables.FindPrimaryIndex(tblInfo).Columns)
 		if err != nil {
-			return err
+			return indexName, nil, false, err
 		}
 		idxLen, err = indexColumnsLen(finalColumns, indexColumns)
 		if err != nil {
-			return err
+			return indexName, nil, false, err
 		}
 		if pkLen+idxLen > config.GetGlobalConfig().MaxIndexLength {
-			return errTooLongKey.GenWithStackByArgs(config.GetGlobalConfig().MaxIndexLength)
+			return indexName, nil, false, errTooLongKey.GenWithStackByArgs(config.GetGlobalConfig().MaxIndexLength)
 		}
 	}
 
@@ -5347,11 +5634,11 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if unique && tblInfo.GetPartitionInfo() != nil {
 		ck, err := checkPartitionKeysConstraint(tblInfo.GetPartitionInfo(), indexColumns, tblInfo)
 		if err != nil {
-			return err
+			return indexName, nil, false, err
 		}
 		if !ck {
 			if !config.GetGlobalConfig().EnableGlobalIndex {
-				return ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
+				return indexName, nil, false, ErrUniqueKeyNeedAllFieldsInPf.GenWithStackByArgs("UNIQUE INDEX")
 			}
 			// index columns does not contain all partition columns, must set global
 			global = true
@@ -5359,31 +5646,9 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	}
 	// May be truncate comment here, when index comment too long and sql_mode is't strict.
 	if _, err = validateCommentLength(ctx.GetSessionVars(), indexName.String(), indexOption); err != nil {
-		return errors.Trace(err)
+		return indexName, nil, false, errors.Trace(err)
 	}
-	job := &model.Job{
-		SchemaID:   schema.ID,
-		TableID:    t.Meta().ID,
-		SchemaName: schema.Name.L,
-		Type:       model.ActionAddIndex,
-		BinlogInfo: &model.HistoryInfo{},
-		ReorgMeta: &model.DDLReorgMeta{
-			SQLMode:       ctx.GetSessionVars().SQLMode,
-			Warnings:      make(map[errors.ErrorID]*terror.Error),
-			WarningsCount: make(map[errors.ErrorID]int64),
-		},
-		Args:     []interface{}{unique, indexName, indexPartSpecifications, indexOption, hiddenCols, global},
-		Priority: ctx.GetSessionVars().DDLReorgPriority,
-	}
-
-	err = d.doDDLJob(ctx, job)
-	// key exists, but if_not_exists flags is true, so we ignore this error.
-	if ErrDupKeyName.Equal(err) && ifNotExists {
-		ctx.GetSessionVars().StmtCtx.AppendNote(err)
-		return nil
-	}
-	err = d.callHookOnChanged(err)
-	return errors.Trace(err)
+	return indexName, hiddenCols, global, nil
 }
 
 func buildFKInfo(fkName model.CIStr, keys []*ast.IndexPartSpecification, refer *ast.ReferenceDef, cols []*table.Column, tbInfo *model.TableInfo) (*model.FKInfo, error) {
@@ -5537,6 +5802,9 @@ func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CI
 	if err != nil {
 		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
 	}
+	if err = checkTableNotCached(t, "Drop Index"); err != nil {
+		return err
+	}
 
 	indexInfo := t.Meta().FindIndexByName(indexName.L)
 
@@ -5672,6 +5940,9 @@ func isDroppableColumn(multiSchemaChange bool, tblInfo *model.TableInfo, colName
 	if fkInfo := getColumnForeignKeyInfo(colName.L, tblInfo.ForeignKeys); fkInfo != nil {
 		return errFkColumnCannotDrop.GenWithStackByArgs(colName, fkInfo.Name)
 	}
+	if tblInfo.TTLInfo != nil && tblInfo.TTLInfo.ColumnName.L == colName.L {
+		return errUnsupportedTTL.GenWithStackByArgs("the TTL column can't be dropped")
+	}
 	return nil
 }
 
@@ -5697,9 +5968,19 @@ func validateCommentLength(vars *variable.SessionVars, indexName string, indexOp
 
 // buildAddedPartitionInfo build alter table add partition info
 func buildAddedPartitionInfo(ctx sessionctx.Context, meta *model.TableInfo, spec *ast.AlterTableSpec) (*model.PartitionInfo, error) {
+	partDefs := spec.PartDefinitions
+	if spec.Tp == ast.AlterTableLastPartition {
+		// LAST PARTITION LESS THAN adds the partitions up to the given bound in one job.
+		var err error
+		partDefs, err = buildPartitionDefinitionsToLastRangeEnd(ctx, meta, spec.Partition.Interval.LastRangeEnd)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	switch meta.Partition.Type {
 	case model.PartitionTypeRange, model.PartitionTypeList:
-		if len(spec.PartDefinitions) == 0 {
+		if len(partDefs) == 0 {
 			return nil, ast.ErrPartitionsMustBeDefined.GenWithStackByArgs(meta.Partition.Type)
 		}
 	default:
@@ -5714,7 +5995,7 @@ func buildAddedPartitionInfo(ctx sessionctx.Context, meta *model.TableInfo, spec
 		Enable:  meta.Partition.Enable,
 	}
 
-	defs, err := buildPartitionDefinitionsInfo(ctx, spec.PartDefinitions, meta)
+	defs, err := buildPartitionDefinitionsInfo(ctx, partDefs, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -327,14 +327,56 @@ func (d *ddl) getHistoryDDLJob(id int64) (*model.Job, error) {
 	return job, errors.Trace(err)
 }
 
-// getFirstDDLJob gets the first DDL job form DDL queue.
-func (w *worker) getFirstDDLJob(t *meta.Meta) (*model.Job, error) {
-	job, err := t.GetDDLJobByIdx(0)
-	return job, errors.Trace(err)
+// getFirstDDLJob gets the first runnable DDL job form DDL queue, its index in the queue and its control
+// options. The paused jobs and the jobs depending on them are skipped, so they don't block the others.
+// The job whose backfill is still running is always chosen, so the result can be collected.
+func (w *worker) getFirstDDLJob(t *meta.Meta) (*model.Job, int64, *meta.DDLJobControl, error) {
+	var skipped []*model.Job
+	for idx := int64(0); ; idx++ {
+		job, err := t.GetDDLJobByIdx(idx)
+		if job == nil || err != nil {
+			return nil, 0, nil, errors.Trace(err)
+		}
+		ctl, err := t.GetDDLJobControl(job.ID)
+		if err != nil {
+			return nil, 0, nil, errors.Trace(err)
+		}
+		if w.reorgCtx.doneCh != nil {
+			// Run the job to collect the result of the backfill and save the progress, even if it's paused.
+			if job.ID != w.reorgCtx.jobID {
+				continue
+			}
+			return job, idx, ctl, nil
+		}
+		isDependent := false
+		for _, skippedJob := range skipped {
+			if isDependent, err = job.IsDependentOn(skippedJob); err != nil {
+				return nil, 0, nil, errors.Trace(err)
+			} else if isDependent {
+				break
+			}
+		}
+		if isDependent || isJobPaused(job, ctl) {
+			skipped = append(skipped, job)
+			continue
+		}
+		if idx > 0 {
+			logutil.Logger(w.logCtx).Info("[ddl] skip the paused DDL jobs", zap.Int64("firstJobID", skipped[0].ID), zap.Int64("jobID", job.ID))
+		}
+		return job, idx, ctl, nil
+	}
+}
+
+// isJobPaused returns whether the job is paused by users, the job which is done or cancelled is not paused.
+func isJobPaused(job *model.Job, ctl *meta.DDLJobControl) bool {
+	if ctl == nil || !ctl.Paused {
+		return false
+	}
+	return !job.IsDone() && !job.IsRollbackDone() && !job.IsCancelling() && !job.IsRollingback()
 }
 
 // handleUpdateJobError handles the too large DDL job.
-func (w *worker) handleUpdateJobError(t *meta.Meta, job *model.Job, err error) error {
+func (w *worker) handleUpdateJobError(t *meta.Meta, idx int64, job *model.Job, err error) error {
 	if err == nil {
 		return nil
 	}
@@ -346,14 +388,14 @@ func (w *worker) handleUpdateJobError(t *meta.Meta, job *model.Job, err error) e
 		job.ErrorCount++
 		job.SchemaState = model.StateNone
 		job.State = model.JobStateCancelled
-		err = w.finishDDLJob(t, job)
+		err = w.finishDDLJob(t, idx, job)
 	}
 	return errors.Trace(err)
 }
 
-// updateDDLJob updates the DDL job information.
+// updateDDLJob updates the DDL job information, idx is the index of the job in the DDL queue.
 // Every time we enter another state except final state, we must call this function.
-func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error {
+func (w *worker) updateDDLJob(t *meta.Meta, idx int64, job *model.Job, meetErr bool) error {
 	failpoint.Inject("mockErrEntrySizeTooLarge", func(val failpoint.Value) {
 		if val.(bool) {
 			failpoint.Return(kv.ErrEntryTooLarge)
@@ -367,7 +409,7 @@ func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error
 			zap.String("job", job.String()))
 		updateRawArgs = false
 	}
-	return errors.Trace(t.UpdateDDLJob(0, job, updateRawArgs))
+	return errors.Trace(t.UpdateDDLJob(idx, job, updateRawArgs))
 }
 
 func (w *worker) deleteRange(ctx context.Context, job *model.Job) error {
@@ -380,9 +422,9 @@ func (w *worker) deleteRange(ctx context.Context, job *model.Job) error {
 	return errors.Trace(err)
 }
 
-// finishDDLJob deletes the finished DDL job in the ddl queue and puts it to history queue.
+// finishDDLJob deletes the finished DDL job with the index idx in the ddl queue and puts it to history queue.
 // If the DDL job need to handle in background, it will prepare a background job.
-func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
+func (w *worker) finishDDLJob(t *meta.Meta, idx int64, job *model.Job) (err error) {
 	startTime := time.Now()
 	defer func() {
 		metrics.DDLWorkerHistogram.WithLabelValues(metrics.WorkerFinishDDLJob, job.Type.String(), metrics.RetLabel(err)).Observe(time.Since(startTime).Seconds())
@@ -402,14 +444,22 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 			err = w.deleteRange(w.ddlJobCtx, job)
 		}
 	}
-	if job.Type == model.ActionRecoverTable {
+	switch job.Type {
+	case model.ActionRecoverTable:
 		err = finishRecoverTable(w, job)
+	case model.ActionRecoverSchema:
+		err = finishRecoverSchema(w, job)
+	case model.ActionFlashbackTable, model.ActionFlashbackCluster:
+		err = finishFlashback(w, job)
 	}
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	_, err = t.DeQueueDDLJob()
+	if err = t.RemoveDDLJobControl(job.ID); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = t.DeQueueDDLJobByIdx(idx)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -443,6 +493,22 @@ func finishRecoverTable(w *worker, job *model.Job) error {
 	return nil
 }
 
+func finishRecoverSchema(w *worker, job *model.Job) error {
+	recoverSchemaInfo := &RecoverSchemaInfo{}
+	var recoverSchemaCheckFlag int64
+	err := job.DecodeArgs(recoverSchemaInfo, &recoverSchemaCheckFlag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if recoverSchemaCheckFlag == recoverTableCheckFlagEnableGC {
+		err = enableGC(w)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func isDependencyJobDone(t *meta.Meta, job *model.Job) (bool, error) {
 	if job.DependencyID == noneDependencyJob {
 		return true, nil
@@ -504,7 +570,11 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 			var err error
 			t := newMetaWithQueueTp(txn, w.tp)
 			// We become the owner. Get the first job and run it.
-			job, err = w.getFirstDDLJob(t)
+			var (
+				idx int64
+				ctl *meta.DDLJobControl
+			)
+			job, idx, ctl, err = w.getFirstDDLJob(t)
 			if job == nil || err != nil {
 				return errors.Trace(err)
 			}
@@ -529,10 +599,12 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 				if !job.IsRollbackDone() {
 					job.State = model.JobStateSynced
 				}
-				err = w.finishDDLJob(t, job)
+				err = w.finishDDLJob(t, idx, job)
 				return errors.Trace(err)
 			}
 
+			w.reorgCtx.setJobControl(ctl)
+
 			d.mu.RLock()
 			d.mu.hook.OnJobRunBefore(job)
 			d.mu.RUnlock()
@@ -542,7 +614,7 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 			schemaVer, runJobErr = w.runDDLJob(d, t, job)
 			if job.IsCancelled() {
 				txn.Reset()
-				err = w.finishDDLJob(t, job)
+				err = w.finishDDLJob(t, idx, job)
 				return errors.Trace(err)
 			}
 			if runJobErr != nil && !job.IsRollingback() && !job.IsRollbackDone() {
@@ -557,8 +629,8 @@ func (w *worker) handleDDLJobQueue(d *ddlCtx) error {
 				// Result in the retry duration is up to 2 * lease.
 				schemaVer = 0
 			}
-			err = w.updateDDLJob(t, job, runJobErr != nil)
-			if err = w.handleUpdateJobError(t, job, err); err != nil {
+			err = w.updateDDLJob(t, idx, job, runJobErr != nil)
+			if err = w.handleUpdateJobError(t, idx, job, err); err != nil {
 				return errors.Trace(err)
 			}
 			writeBinlog(d.binlogCli, txn, job)
@@ -798,12 +870,24 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onModifyTableComment(t, job)
 	case model.ActionModifyTableAutoIdCache:
 		ver, err = onModifyTableAutoIDCache(t, job)
+	case model.ActionAlterTTLInfo:
+		ver, err = onTTLInfoChange(t, job)
+	case model.ActionAlterTTLRemove:
+		ver, err = onTTLInfoRemove(t, job)
+	case model.ActionAlterCacheTable:
+		ver, err = onAlterCacheTable(t, job)
+	case model.ActionAlterNoCacheTable:
+		ver, err = onAlterNoCacheTable(t, job)
 	case model.ActionAddTablePartition:
 		ver, err = w.onAddTablePartition(d, t, job)
 	case model.ActionModifyTableCharsetAndCollate:
 		ver, err = onModifyTableCharsetAndCollate(t, job)
 	case model.ActionRecoverTable:
 		ver, err = w.onRecoverTable(d, t, job)
+	case model.ActionRecoverSchema:
+		ver, err = w.onRecoverSchema(d, t, job)
+	case model.ActionFlashbackTable, model.ActionFlashbackCluster:
+		ver, err = w.onFlashbackToTimestamp(d, t, job)
 	case model.ActionLockTable:
 		ver, err = onLockTables(t, job)
 	case model.ActionUnlockTable:
@@ -1052,6 +1136,37 @@ func updateSchemaVersion(t *meta.Meta, job *model.Job) (int64, error) {
 				diff.AffectedOpts = buildPlacementAffects(oldIDs, oldIDs)
 			}
 		}
+	case model.ActionRecoverSchema:
+		// affects are used to create the tables of the schema in the infoschema.
+		if len(job.CtxVars) > 0 {
+			if tableIDs, ok := job.CtxVars[0].([]int64); ok {
+				diff.AffectedOpts = make([]*model.AffectedOption, 0, len(tableIDs))
+				for _, tableID := range tableIDs {
+					diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+						SchemaID: job.SchemaID,
+						TableID:  tableID,
+					})
+				}
+			}
+		}
+	case model.ActionFlashbackTable, model.ActionFlashbackCluster:
+		// affects are used to reload all the tables of the job in the infoschema.
+		diff.TableID = job.TableID
+		if len(job.CtxVars) > 0 {
+			if tables, ok := job.CtxVars[0].([]model.TableLockTpInfo); ok && len(tables) > 0 {
+				diff.SchemaID = tables[0].SchemaID
+				diff.TableID = tables[0].TableID
+				diff.AffectedOpts = make([]*model.AffectedOption, 0, len(tables)-1)
+				for _, tl := range tables[1:] {
+					diff.AffectedOpts = append(diff.AffectedOpts, &model.AffectedOption{
+						SchemaID:    tl.SchemaID,
+						TableID:     tl.TableID,
+						OldSchemaID: tl.SchemaID,
+						OldTableID:  tl.TableID,
+					})
+				}
+			}
+		}
 	case model.ActionAlterTableAlterPartition:
 		diff.TableID = job.TableID
 		if len(job.CtxVars) > 0 {
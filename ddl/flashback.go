@@ -0,0 +1,278 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// flashbackArg is the argument of the flashback table and flashback cluster jobs.
+type flashbackArg struct {
+	FlashbackTS uint64
+	// Tables are the tables to flash back, they are locked as read only while the job is running.
+	Tables      []model.TableLockTpInfo
+	SessionInfo model.SessionInfo
+	// CheckFlag records whether GC is enabled before the job, see recoverTableCheckFlagNone.
+	CheckFlag int64
+	// PhysicalIDs are the physical tables to flash back, they are handled one by one in the reorganization,
+	// and NextKey is the checkpoint of the physical table with the index IndexOfPhysicalID.
+	PhysicalIDs       []int64
+	IndexOfPhysicalID int
+	NextKey           kv.Key
+}
+
+// Flashback to timestamp is divided into the following steps:
+//  1. none -> write only: record whether GC is enabled, and lock the tables as read only.
+//  2. write only -> write reorganization: disable GC and check the GC safe point. Because the writes
+//     are blocked since the previous step, no transaction with an earlier start TS can still commit
+//     after this step.
+//  3. write reorganization: write the old versions of the tables back in batches, the checkpoint is
+//     recorded in the job args, so the new owner continues from it.
+//  4. write reorganization -> public: unlock the tables and finish the job. GC is enabled again in
+//     finishFlashback if it was enabled before the job.
+//
+// The job can only be cancelled before it writes the data.
+func (w *worker) onFlashbackToTimestamp(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	arg := &flashbackArg{}
+	if err = job.DecodeArgs(arg); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if job.IsRollingback() {
+		// The data isn't written yet, just unlock the tables.
+		ver, err = updateFlashbackTables(t, job, arg, unlockFlashbackTable)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, nil)
+		return ver, nil
+	}
+
+	switch job.SchemaState {
+	case model.StateNone:
+		// none -> write only
+		gcEnable, err := checkGCEnable(w)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if gcEnable {
+			arg.CheckFlag = recoverTableCheckFlagEnableGC
+		} else {
+			arg.CheckFlag = recoverTableCheckFlagDisableGC
+		}
+		arg.PhysicalIDs = arg.PhysicalIDs[:0]
+		ver, err = updateFlashbackTables(t, job, arg, func(tblInfo *model.TableInfo) error {
+			if tblInfo.IsLocked() {
+				return infoschema.ErrTableLocked.GenWithStackByArgs(tblInfo.Name.L, tblInfo.Lock.Tp, tblInfo.Lock.Sessions[0])
+			}
+			tblInfo.Lock = &model.TableLockInfo{
+				Tp:       model.TableLockReadOnly,
+				Sessions: []model.SessionInfo{arg.SessionInfo},
+				State:    model.TableLockStatePublic,
+				TS:       t.StartTS,
+			}
+			if tblInfo.GetPartitionInfo() != nil {
+				arg.PhysicalIDs = append(arg.PhysicalIDs, getPartitionIDs(tblInfo)...)
+			} else {
+				arg.PhysicalIDs = append(arg.PhysicalIDs, tblInfo.ID)
+			}
+			return nil
+		})
+		if err != nil {
+			if infoschema.ErrTableLocked.Equal(err) || infoschema.ErrTableNotExists.Equal(err) || infoschema.ErrDatabaseNotExists.Equal(err) {
+				job.State = model.JobStateCancelled
+			}
+			return ver, errors.Trace(err)
+		}
+		job.Args = []interface{}{arg}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		if arg.CheckFlag == recoverTableCheckFlagEnableGC {
+			if err = disableGC(w); err != nil {
+				return ver, errors.Errorf("disable gc failed, try again later. err: %v", err)
+			}
+		}
+		// GC may have advanced while the job was queued.
+		if err = checkSafePoint(w, arg.FlashbackTS); err != nil {
+			job.State = model.JobStateRollingback
+			return ver, errors.Trace(err)
+		}
+		// Make sure the transactions committed with async commit or 1PC before the lock are visible.
+		delayForAsyncCommit()
+		ver, err = updateSchemaVersion(t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		if arg.IndexOfPhysicalID < len(arg.PhysicalIDs) {
+			physicalID := arg.PhysicalIDs[arg.IndexOfPhysicalID]
+			startKey := arg.NextKey
+			if len(startKey) == 0 {
+				startKey = tablecodec.EncodeTablePrefix(physicalID)
+			}
+			endKey := tablecodec.EncodeTablePrefix(physicalID + 1)
+			nextKey, count, err := flashbackRange(w.ddlJobCtx, d.store, arg.FlashbackTS, startKey, endKey, int(variable.GetDDLReorgBatchSize()))
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			logutil.Logger(w.logCtx).Info("[ddl] flashback range", zap.Int64("jobID", job.ID), zap.Int64("physicalTableID", physicalID),
+				zap.Stringer("startKey", startKey), zap.Stringer("nextKey", nextKey), zap.Int("count", count))
+			if len(nextKey) == 0 {
+				arg.IndexOfPhysicalID++
+			}
+			arg.NextKey = nextKey
+			job.SetRowCount(job.GetRowCount() + int64(count))
+			job.Args = []interface{}{arg}
+			return ver, nil
+		}
+		// write reorganization -> public
+		ver, err = updateFlashbackTables(t, job, arg, unlockFlashbackTable)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, nil)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("flashback", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// unlockFlashbackTable clears the read only lock set by the flashback job.
+func unlockFlashbackTable(tblInfo *model.TableInfo) error {
+	if tblInfo.Lock != nil && tblInfo.Lock.Tp == model.TableLockReadOnly {
+		tblInfo.Lock = nil
+	}
+	return nil
+}
+
+// updateFlashbackTables applies update to the tables of the flashback job and updates the schema version.
+// The UpdateTS of the tables is kept, because the lock doesn't change the schema of the data, and a later
+// flashback can still go back to the time before this job.
+func updateFlashbackTables(t *meta.Meta, job *model.Job, arg *flashbackArg, update func(*model.TableInfo) error) (ver int64, err error) {
+	for _, tl := range arg.Tables {
+		tblInfo, err := getTableInfo(t, tl.TableID, tl.SchemaID)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = update(tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = t.UpdateTable(tl.SchemaID, tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+	job.CtxVars = []interface{}{arg.Tables}
+	return updateSchemaVersion(t, job)
+}
+
+// flashbackRange writes the versions at flashbackTS of at most batchSize keys in [startKey, endKey) back.
+// It compares the snapshot at flashbackTS with the current snapshot, writes the old value of the keys
+// that are changed and deletes the keys that are written after flashbackTS. It returns the next key to
+// handle, which is nil if the range is done, and the number of the written keys.
+func flashbackRange(ctx context.Context, store kv.Storage, flashbackTS uint64, startKey, endKey kv.Key, batchSize int) (nextKey kv.Key, count int, err error) {
+	err = kv.RunInNewTxn(ctx, store, true, func(ctx context.Context, txn kv.Transaction) error {
+		nextKey, count = nil, 0
+		oldIter, err := store.GetSnapshot(kv.NewVersion(flashbackTS)).Iter(startKey, endKey)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer oldIter.Close()
+		curIter, err := txn.GetSnapshot().Iter(startKey, endKey)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer curIter.Close()
+
+		for i := 0; i < batchSize && (oldIter.Valid() || curIter.Valid()); i++ {
+			cmp := 0
+			switch {
+			case !curIter.Valid():
+				cmp = -1
+			case !oldIter.Valid():
+				cmp = 1
+			default:
+				cmp = oldIter.Key().Cmp(curIter.Key())
+			}
+			switch {
+			case cmp < 0:
+				// The key is deleted after flashbackTS.
+				err = txn.Set(oldIter.Key(), oldIter.Value())
+				count++
+			case cmp > 0:
+				// The key is written after flashbackTS.
+				err = txn.Delete(curIter.Key())
+				count++
+			case !bytes.Equal(oldIter.Value(), curIter.Value()):
+				err = txn.Set(oldIter.Key(), oldIter.Value())
+				count++
+			}
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if cmp <= 0 {
+				if err = oldIter.Next(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if cmp >= 0 {
+				if err = curIter.Next(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+
+		switch {
+		case oldIter.Valid() && curIter.Valid():
+			nextKey = oldIter.Key()
+			if curIter.Key().Cmp(nextKey) < 0 {
+				nextKey = curIter.Key()
+			}
+		case oldIter.Valid():
+			nextKey = oldIter.Key()
+		case curIter.Valid():
+			nextKey = curIter.Key()
+		}
+		nextKey = nextKey.Clone()
+		return nil
+	})
+	return nextKey, count, errors.Trace(err)
+}
+
+func finishFlashback(w *worker, job *model.Job) error {
+	arg := &flashbackArg{}
+	if err := job.DecodeArgs(arg); err != nil {
+		return errors.Trace(err)
+	}
+	if arg.CheckFlag == recoverTableCheckFlagEnableGC {
+		return errors.Trace(enableGC(w))
+	}
+	return nil
+}
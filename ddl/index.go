@@ -1310,6 +1310,12 @@ func (w *addIndexWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskC
 			return errors.Trace(err)
 		}
 
+		if handleRange.ingestWriter != nil {
+			taskCtx.scanCount = len(idxRecords)
+			taskCtx.addedCount, err = w.writeIngestKVs(handleRange, idxRecords)
+			return errors.Trace(err)
+		}
+
 		for _, idxRecord := range idxRecords {
 			taskCtx.scanCount++
 			// The index is already exists, we skip it, no needs to backfill it.
@@ -1345,6 +1351,34 @@ func (w *addIndexWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskC
 	return
 }
 
+// writeIngestKVs writes the index KV pairs of the records into the ingest writer of the task,
+// they are ingested into the storage after all the tasks of the batch finish.
+func (w *addIndexWorker) writeIngestKVs(handleRange reorgBackfillTask, idxRecords []*indexRecord) (int, error) {
+	stmtCtx := w.sessCtx.GetSessionVars().StmtCtx
+	tblInfo, idxInfo := w.table.Meta(), w.index.Meta()
+	needRsData := tables.NeedRestoredData(idxInfo.Columns, tblInfo.Columns)
+	entries := make([]kv.Entry, 0, len(idxRecords))
+	for _, idxRecord := range idxRecords {
+		if idxRecord.skip {
+			continue
+		}
+		key, distinct, err := w.index.GenIndexKey(stmtCtx, idxRecord.vals, idxRecord.handle, nil)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		val, err := tablecodec.GenIndexValuePortal(stmtCtx, tblInfo, idxInfo, needRsData, distinct, false, idxRecord.vals, idxRecord.handle, handleRange.physicalTableID, idxRecord.rsData)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		entries = append(entries, kv.Entry{Key: key, Value: val})
+	}
+	if len(entries) == 0 {
+		return 0, nil
+	}
+	err := handleRange.ingestWriter.WriteKVs(w.ddlWorker.ddlJobCtx, entries)
+	return len(entries), errors.Trace(err)
+}
+
 func (w *worker) addPhysicalTableIndex(t table.PhysicalTable, indexInfo *model.IndexInfo, reorgInfo *reorgInfo) error {
 	logutil.BgLogger().Info("[ddl] start to add table index", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
 	return w.writePhysicalTableRecord(t, typeAddIndexWorker, indexInfo, nil, nil, reorgInfo)
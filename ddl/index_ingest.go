@@ -0,0 +1,176 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// IndexIngester backfills indexes by sorting the index KV pairs locally and ingesting them into
+// the storage as SST files, which is much faster than writing them in transactions.
+// It's implemented by the ddl/ingest package on the top of the local backend of Lightning, which
+// can't be imported by the ddl package, so it's set by SetIndexIngester when TiDB starts.
+type IndexIngester interface {
+	// OpenEngine opens an engine to sort the index KV pairs. The KV pairs are ingested with
+	// a commit ts allocated when the engine is opened, so the rows must be read after it.
+	OpenEngine(ctx context.Context, store kv.Storage, cfg *IngestEngineConfig) (IngestEngine, error)
+}
+
+// IngestEngineConfig is the config to open an IngestEngine.
+type IngestEngineConfig struct {
+	JobID           int64
+	PhysicalTableID int64
+	Index           *model.IndexInfo
+	// Seq is the sequence of the engine in the job, a new engine is opened for every batch of backfill tasks.
+	Seq int
+}
+
+// IngestEngine sorts the written KV pairs locally and ingests them into the storage.
+type IngestEngine interface {
+	// NewWriter creates a writer of the engine, different writers can be used concurrently.
+	NewWriter(ctx context.Context) (IngestWriter, error)
+	// Import ingests the sorted KV pairs into the storage, all the writers must be closed before.
+	// It returns kv.ErrKeyExists with the conflicting key if different KV pairs have the same key.
+	Import(ctx context.Context) error
+	// Cleanup releases the resources of the engine, including the local files.
+	Cleanup(ctx context.Context) error
+}
+
+// IngestWriter writes KV pairs into an IngestEngine.
+type IngestWriter interface {
+	WriteKVs(ctx context.Context, entries []kv.Entry) error
+	Close(ctx context.Context) error
+}
+
+var indexIngester IndexIngester
+
+// SetIndexIngester sets the IndexIngester used to backfill indexes when `tidb_ddl_enable_fast_reorg` is on.
+func SetIndexIngester(ingester IndexIngester) {
+	indexIngester = ingester
+}
+
+// ingestBackfill is the context of backfilling an index by an IndexIngester.
+type ingestBackfill struct {
+	ingester IndexIngester
+	index    *model.IndexInfo
+	seq      int
+	// disabled means opening engines failed, the rest of the index is backfilled in transactions.
+	disabled bool
+}
+
+// newIngestBackfill returns nil if the index shouldn't be backfilled by ingesting SST files.
+func newIngestBackfill(bfWorkerType backfillWorkerType, tblInfo *model.TableInfo, indexInfo *model.IndexInfo) *ingestBackfill {
+	if bfWorkerType != typeAddIndexWorker || !variable.EnableFastReorg.Load() || indexIngester == nil {
+		return nil
+	}
+	// Temporary tables don't store data in TiKV.
+	if tblInfo.TempTableType != model.TempTableNone {
+		return nil
+	}
+	return &ingestBackfill{ingester: indexIngester, index: indexInfo}
+}
+
+// prepare opens an engine for the batch tasks and sets the writers of the tasks. It returns nil
+// if the ingester doesn't work, the tasks will backfill the index in transactions then.
+func (ib *ingestBackfill) prepare(ctx context.Context, reorgInfo *reorgInfo, batchTasks []*reorgBackfillTask) IngestEngine {
+	if ib == nil || ib.disabled {
+		return nil
+	}
+	ib.seq++
+	engine, err := ib.ingester.OpenEngine(ctx, reorgInfo.d.store, &IngestEngineConfig{
+		JobID:           reorgInfo.Job.ID,
+		PhysicalTableID: reorgInfo.PhysicalTableID,
+		Index:           ib.index,
+		Seq:             ib.seq,
+	})
+	if err != nil {
+		logutil.BgLogger().Warn("[ddl] open ingest engine failed, backfill the index in transactions",
+			zap.Int64("jobID", reorgInfo.Job.ID), zap.Error(err))
+		ib.disabled = true
+		return nil
+	}
+	for _, task := range batchTasks {
+		task.ingestWriter, err = engine.NewWriter(ctx)
+		if err != nil {
+			logutil.BgLogger().Warn("[ddl] create ingest writer failed, backfill the index in transactions",
+				zap.Int64("jobID", reorgInfo.Job.ID), zap.Error(err))
+			ib.disabled = true
+			ib.cleanup(ctx, engine, batchTasks)
+			return nil
+		}
+	}
+	return engine
+}
+
+// importEngine closes the writers of the tasks and ingests the engine into the storage.
+func (ib *ingestBackfill) importEngine(ctx context.Context, engine IngestEngine, batchTasks []*reorgBackfillTask) error {
+	var firstErr error
+	for _, task := range batchTasks {
+		if err := task.ingestWriter.Close(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		task.ingestWriter = nil
+	}
+	if firstErr != nil {
+		return errors.Trace(firstErr)
+	}
+	return errors.Trace(engine.Import(ctx))
+}
+
+func (ib *ingestBackfill) cleanup(ctx context.Context, engine IngestEngine, batchTasks []*reorgBackfillTask) {
+	for _, task := range batchTasks {
+		if task.ingestWriter != nil {
+			if err := task.ingestWriter.Close(ctx); err != nil {
+				logutil.BgLogger().Warn("[ddl] close ingest writer failed", zap.Error(err))
+			}
+			task.ingestWriter = nil
+		}
+	}
+	if err := engine.Cleanup(ctx); err != nil {
+		logutil.BgLogger().Warn("[ddl] clean up ingest engine failed", zap.Error(err))
+	}
+}
+
+// ingestReorgTasks ingests the index KV pairs written by the batch tasks into the storage. The duplicate keys
+// among the KV pairs fail the job by kv.ErrKeyExists returned by the engine. The unique keys written by DMLs
+// after the rows are read shadow the ingested ones, so the tasks of unique indexes are handled again in
+// transactions to check them, the existing index keys are skipped then.
+func (w *worker) ingestReorgTasks(ib *ingestBackfill, engine IngestEngine, workers []*backfillWorker,
+	batchTasks []*reorgBackfillTask, taskErr error) error {
+	defer ib.cleanup(w.ddlJobCtx, engine, batchTasks)
+	if taskErr != nil {
+		return errors.Trace(taskErr)
+	}
+	if err := ib.importEngine(w.ddlJobCtx, engine, batchTasks); err != nil {
+		return errors.Trace(err)
+	}
+	if !ib.index.Unique {
+		return nil
+	}
+
+	for i, task := range batchTasks {
+		workers[i].taskCh <- task
+	}
+	var addedCount int64
+	_, _, err := w.waitTaskResults(workers, len(batchTasks), &addedCount, batchTasks[0].startKey)
+	return errors.Trace(err)
+}
@@ -0,0 +1,226 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend"
+	lkv "github.com/pingcap/tidb/br/pkg/lightning/backend/kv"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/local"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	lcfg "github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/glue"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	rangeConcurrency        = 16
+	engineMemCacheSize      = 512 * 1024 * 1024
+	localWriterMemCacheSize = 128 * 1024 * 1024
+	maxOpenFiles            = 1024
+)
+
+// indexIngester implements ddl.IndexIngester by the local backend of Lightning.
+type indexIngester struct {
+	sortDir  string
+	security config.Security
+
+	mu      sync.Mutex
+	backend *backend.Backend
+}
+
+// NewIndexIngester creates a ddl.IndexIngester, which sorts the index KV pairs in sortDir and
+// connects to the cluster with the TLS settings in security.
+func NewIndexIngester(sortDir string, security config.Security) ddl.IndexIngester {
+	return &indexIngester{sortDir: sortDir, security: security}
+}
+
+// OpenEngine implements the ddl.IndexIngester interface.
+func (ii *indexIngester) OpenEngine(ctx context.Context, store kv.Storage, cfg *ddl.IngestEngineConfig) (ddl.IngestEngine, error) {
+	be, err := ii.getBackend(ctx, store)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag := fmt.Sprintf("ddl_job_%d_table_%d_index_%d", cfg.JobID, cfg.PhysicalTableID, cfg.Index.ID)
+	opened, err := be.OpenEngine(ctx, &backend.EngineConfig{}, tag, int32(cfg.Seq))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &engine{be: be, cfg: cfg, opened: opened}, nil
+}
+
+func (ii *indexIngester) getBackend(ctx context.Context, store kv.Storage) (*backend.Backend, error) {
+	ii.mu.Lock()
+	defer ii.mu.Unlock()
+	if ii.backend != nil {
+		return ii.backend, nil
+	}
+
+	etcd, ok := store.(kv.EtcdBackend)
+	if !ok {
+		return nil, errors.New("the storage doesn't support ingesting SST files")
+	}
+	pdAddrs, err := etcd.EtcdAddrs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(pdAddrs) == 0 {
+		return nil, errors.New("the storage doesn't support ingesting SST files")
+	}
+	tls, err := common.NewTLS(ii.security.ClusterSSLCA, ii.security.ClusterSSLCert, ii.security.ClusterSSLKey, pdAddrs[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The sorted KV pairs left by the last TiDB process are useless, the backfill restarts from the last
+	// checkpoint of the reorganization.
+	if err := os.RemoveAll(ii.sortDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(ii.sortDir), 0o700); err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg := lcfg.NewConfig()
+	cfg.TikvImporter.Backend = lcfg.BackendLocal
+	cfg.TikvImporter.SortedKVDir = ii.sortDir
+	cfg.TikvImporter.RangeConcurrency = rangeConcurrency
+	cfg.TikvImporter.EngineMemCacheSize = engineMemCacheSize
+	cfg.TikvImporter.LocalWriterMemCacheSize = localWriterMemCacheSize
+	// The duplicate keys of unique indexes written by different rows overwrite each other when they are
+	// ingested, the duplicate detection records them, see engine.checkDuplicates.
+	cfg.TikvImporter.DuplicateDetection = true
+	be, err := local.NewLocalBackend(ctx, tls, strings.Join(pdAddrs, ","), &cfg.TikvImporter, false, ingestGlue{}, maxOpenFiles, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logutil.BgLogger().Info("[ddl-ingest] create local backend", zap.String("sortDir", ii.sortDir))
+	ii.backend = &be
+	return ii.backend, nil
+}
+
+// ingestGlue is the glue of the local backend. The local backend only uses it to get the region
+// statistics of the table, which isn't needed by indexes.
+type ingestGlue struct {
+	glue.Glue
+}
+
+// GetDB implements the glue.Glue interface.
+func (ingestGlue) GetDB() (*sql.DB, error) {
+	return nil, nil
+}
+
+// Record implements the glue.Glue interface.
+func (ingestGlue) Record(string, uint64) {}
+
+type engine struct {
+	be     *backend.Backend
+	cfg    *ddl.IngestEngineConfig
+	opened *backend.OpenedEngine
+	closed *backend.ClosedEngine
+	// lastRowID allocates the row IDs of the written KV pairs, the duplicate detection only records
+	// the KV pairs with the same key and different row IDs.
+	lastRowID int64
+}
+
+// NewWriter implements the ddl.IngestEngine interface.
+func (e *engine) NewWriter(ctx context.Context) (ddl.IngestWriter, error) {
+	w, err := e.opened.LocalWriter(ctx, &backend.LocalWriterConfig{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &writer{e: e, w: w}, nil
+}
+
+// Import implements the ddl.IngestEngine interface.
+func (e *engine) Import(ctx context.Context) error {
+	closed, err := e.close(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := closed.Import(ctx, int64(lcfg.SplitRegionSize)); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(e.checkDuplicates())
+}
+
+// checkDuplicates returns kv.ErrKeyExists with the first duplicate key of the index detected when the
+// engine is imported.
+func (e *engine) checkDuplicates() error {
+	startKey := tablecodec.EncodeTableIndexPrefix(e.cfg.PhysicalTableID, e.cfg.Index.ID)
+	key, err := local.FirstLocalDuplicateKey(*e.be, startKey, startKey.PrefixNext())
+	if err != nil || key == nil {
+		return errors.Trace(err)
+	}
+	entry := kv.Key(key).String()
+	if _, _, values, err := tablecodec.DecodeIndexKey(key); err == nil {
+		entry = strings.Join(values, "-")
+	}
+	return kv.ErrKeyExists.FastGenByArgs(entry, e.cfg.Index.Name.O)
+}
+
+// Cleanup implements the ddl.IngestEngine interface.
+func (e *engine) Cleanup(ctx context.Context) error {
+	closed, err := e.close(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(closed.Cleanup(ctx))
+}
+
+func (e *engine) close(ctx context.Context) (*backend.ClosedEngine, error) {
+	if e.closed == nil {
+		closed, err := e.opened.Close(ctx, &backend.EngineConfig{})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		e.closed = closed
+	}
+	return e.closed, nil
+}
+
+type writer struct {
+	e *engine
+	w *backend.LocalEngineWriter
+}
+
+// WriteKVs implements the ddl.IngestWriter interface.
+func (w *writer) WriteKVs(ctx context.Context, entries []kv.Entry) error {
+	pairs := make([]common.KvPair, 0, len(entries))
+	rowID := atomic.AddInt64(&w.e.lastRowID, int64(len(entries))) - int64(len(entries))
+	for i, e := range entries {
+		pairs = append(pairs, common.KvPair{Key: e.Key, Val: e.Value, RowID: rowID + int64(i) + 1})
+	}
+	return errors.Trace(w.w.WriteRows(ctx, nil, lkv.MakeRowsFromKvPairs(pairs)))
+}
+
+// Close implements the ddl.IngestWriter interface.
+func (w *writer) Close(ctx context.Context) error {
+	_, err := w.w.Close(ctx)
+	return errors.Trace(err)
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenEngineWithoutPD(t *testing.T) {
+	t.Parallel()
+
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	sortDir := filepath.Join(t.TempDir(), "ddl-ingest")
+	ingester := NewIndexIngester(sortDir, config.Security{})
+	cfg := &ddl.IngestEngineConfig{JobID: 1, PhysicalTableID: 2, Index: &model.IndexInfo{ID: 1}, Seq: 1}
+	_, err = ingester.OpenEngine(context.Background(), store, cfg)
+	require.Error(t, err)
+	// The local backend isn't created, so the sort directory is untouched.
+	_, err = os.Stat(sortDir)
+	require.True(t, os.IsNotExist(err))
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -52,6 +53,7 @@ import (
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -365,7 +367,16 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 		}
 	}
 
-	defs, err := buildPartitionDefinitionsInfo(ctx, s.Definitions, tbInfo)
+	partDefs := s.Definitions
+	if s.Interval != nil {
+		var err error
+		partDefs, err = buildPartitionIntervalDefinitions(ctx, s, tbInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	defs, err := buildPartitionDefinitionsInfo(ctx, partDefs, tbInfo)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -1242,7 +1253,7 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 	}
 
 	if withValidation {
-		err = checkExchangePartitionRecordValidation(w, pt, index, ntDbInfo.Name, nt.Name)
+		err = checkExchangePartitionRecordValidation(w, pt, nt, index, ntDbInfo.Name)
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
@@ -1345,6 +1356,18 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 		job.State = model.JobStateCancelled
 		return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
 	}
+	// The meta changes are discarded when the job is cancelled, but the placement rules in PD are not,
+	// so they are restored if the job is cancelled from now on.
+	var rollbackBundles []*placement.Bundle
+	if len(bundles) > 0 {
+		rollbackBundles = []*placement.Bundle{placement.NewBundle(partDef.ID), placement.NewBundle(nt.ID)}
+		if ptOK {
+			rollbackBundles[0] = ptBundle.Clone()
+		}
+		if ntOK {
+			rollbackBundles[1] = ntBundle.Clone()
+		}
+	}
 
 	ntrID := fmt.Sprintf(label.TableIDFormat, label.IDPrefix, job.SchemaName, nt.Name.L)
 	ptrID := fmt.Sprintf(label.PartitionIDFormat, label.IDPrefix, job.SchemaName, pt.Name.L, partDef.Name.L)
@@ -1352,6 +1375,7 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 	rules, err := infosync.GetLabelRules(context.TODO(), []string{ntrID, ptrID})
 	if err != nil {
 		job.State = model.JobStateCancelled
+		rollbackExchangePartitionBundles(rollbackBundles)
 		return 0, errors.Wrapf(err, "failed to get PD the label rules")
 	}
 
@@ -1376,9 +1400,17 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 	}
 
 	patch := label.NewRulePatch(setRules, deleteRules)
+	failpoint.Inject("exchangePartitionLabelRulesErr", func(val failpoint.Value) {
+		if val.(bool) {
+			job.State = model.JobStateCancelled
+			rollbackExchangePartitionBundles(rollbackBundles)
+			failpoint.Return(ver, errors.New("occur an error when updating label rules"))
+		}
+	})
 	err = infosync.UpdateLabelRules(context.TODO(), patch)
 	if err != nil {
 		job.State = model.JobStateCancelled
+		rollbackExchangePartitionBundles(rollbackBundles)
 		return ver, errors.Wrapf(err, "failed to notify PD the label rules")
 	}
 
@@ -1391,43 +1423,53 @@ func (w *worker) onExchangeTablePartition(d *ddlCtx, t *meta.Meta, job *model.Jo
 	return ver, nil
 }
 
-func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, index int, schemaName, tableName model.CIStr) error {
-	var sql string
-	var paramList []interface{}
+// rollbackExchangePartitionBundles restores the placement rules swapped by a cancelled exchange partition job.
+func rollbackExchangePartitionBundles(bundles []*placement.Bundle) {
+	if len(bundles) == 0 {
+		return
+	}
+	if err := infosync.PutRuleBundles(context.TODO(), bundles); err != nil {
+		logutil.BgLogger().Warn("[ddl] restore the placement rules of exchange partition failed", zap.Error(err))
+	}
+}
 
+// checkExchangePartitionRecordValidation checks that all the rows of the non-partitioned table belong to the partition
+// at index. The check is a `select ... limit 1` on the rows that don't match, so it's pushed down to the coprocessor.
+func checkExchangePartitionRecordValidation(w *worker, pt, nt *model.TableInfo, index int, schemaName model.CIStr) error {
 	pi := pt.Partition
-
+	var cond string
 	switch pi.Type {
 	case model.PartitionTypeHash:
-		if pi.Num == 1 {
-			return nil
-		}
-		var buf strings.Builder
-		buf.WriteString("select 1 from %n.%n where mod(")
-		buf.WriteString(pi.Expr)
-		buf.WriteString(", %?) != %? limit 1")
-		sql = buf.String()
-		paramList = append(paramList, schemaName.L, tableName.L, pi.Num, index)
+		cond = buildCheckCondForHashPartition(pi, index)
 	case model.PartitionTypeRange:
-		// Table has only one partition and has the maximum value
-		if len(pi.Definitions) == 1 && strings.EqualFold(pi.Definitions[index].LessThan[0], partitionMaxValue) {
-			return nil
-		}
-		// For range expression and range columns
-		if len(pi.Columns) == 0 {
-			sql, paramList = buildCheckSQLForRangeExprPartition(pi, index, schemaName, tableName)
-		} else if len(pi.Columns) == 1 {
-			sql, paramList = buildCheckSQLForRangeColumnsPartition(pi, index, schemaName, tableName)
-		}
+		cond = buildCheckCondForRangePartition(pi, index)
 	case model.PartitionTypeList:
-		if len(pi.Columns) == 0 {
-			sql, paramList = buildCheckSQLForListPartition(pi, index, schemaName, tableName)
-		} else if len(pi.Columns) == 1 {
-			sql, paramList = buildCheckSQLForListColumnsPartition(pi, index, schemaName, tableName)
-		}
+		cond = buildCheckCondForListPartition(pi, index)
 	default:
 		return errUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O)
 	}
+	// All the rows belong to the partition.
+	if cond == "" {
+		return nil
+	}
+
+	handleCols := getHandleColNames(nt)
+	paramList := make([]interface{}, 0, len(handleCols)+2)
+	var buf strings.Builder
+	buf.WriteString("select ")
+	for i, col := range handleCols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("%n")
+		paramList = append(paramList, col)
+	}
+	// Since the partition expression and values may contain the identifiers, which couldn't be escaped in our
+	// ParseWithParams(...), we write them to the origin sql string here.
+	buf.WriteString(" from %n.%n where ")
+	buf.WriteString(strings.ReplaceAll(cond, "%", "%%"))
+	buf.WriteString(" limit 1")
+	paramList = append(paramList, schemaName.L, nt.Name.L)
 
 	var ctx sessionctx.Context
 	ctx, err := w.sessPool.get()
@@ -1436,97 +1478,95 @@ func checkExchangePartitionRecordValidation(w *worker, pt *model.TableInfo, inde
 	}
 	defer w.sessPool.put(ctx)
 
-	stmt, err := ctx.(sqlexec.RestrictedSQLExecutor).ParseWithParams(w.ddlJobCtx, sql, paramList...)
+	stmt, err := ctx.(sqlexec.RestrictedSQLExecutor).ParseWithParams(w.ddlJobCtx, buf.String(), paramList...)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedStmt(w.ddlJobCtx, stmt)
+	rows, fields, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedStmt(w.ddlJobCtx, stmt)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	rowCount := len(rows)
-	if rowCount != 0 {
-		return errors.Trace(ErrRowDoesNotMatchPartition)
+	if len(rows) == 0 {
+		return nil
 	}
-	return nil
-}
-
-func buildCheckSQLForRangeExprPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
-	var buf strings.Builder
-	paramList := make([]interface{}, 0, 4)
-	// Since the pi.Expr string may contain the identifier, which couldn't be escaped in our ParseWithParams(...)
-	// So we write it to the origin sql string here.
-	if index == 0 {
-		buf.WriteString("select 1 from %n.%n where ")
-		buf.WriteString(pi.Expr)
-		buf.WriteString(" >= %? limit 1")
-		paramList = append(paramList, schemaName.L, tableName.L, trimQuotation(pi.Definitions[index].LessThan[0]))
-		return buf.String(), paramList
-	} else if index == len(pi.Definitions)-1 && strings.EqualFold(pi.Definitions[index].LessThan[0], partitionMaxValue) {
-		buf.WriteString("select 1 from %n.%n where ")
-		buf.WriteString(pi.Expr)
-		buf.WriteString(" < %? limit 1")
-		paramList = append(paramList, schemaName.L, tableName.L, trimQuotation(pi.Definitions[index-1].LessThan[0]))
-		return buf.String(), paramList
-	} else {
-		buf.WriteString("select 1 from %n.%n where ")
-		buf.WriteString(pi.Expr)
-		buf.WriteString(" < %? or ")
-		buf.WriteString(pi.Expr)
-		buf.WriteString(" >= %? limit 1")
-		paramList = append(paramList, schemaName.L, tableName.L, trimQuotation(pi.Definitions[index-1].LessThan[0]), trimQuotation(pi.Definitions[index].LessThan[0]))
-		return buf.String(), paramList
+	handle := make([]string, 0, len(fields))
+	for i, field := range fields {
+		d := rows[0].GetDatum(i, &field.Column.FieldType)
+		str, err := d.ToString()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		handle = append(handle, fmt.Sprintf("%s=%s", handleCols[i], str))
 	}
+	return errors.Trace(ErrRowDoesNotMatchPartition.GenWithStack("Found a row that does not match the partition, handle: %s", strings.Join(handle, ", ")))
 }
 
-func trimQuotation(str string) string {
-	return strings.Trim(str, "\"")
-}
-
-func buildCheckSQLForRangeColumnsPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
-	paramList := make([]interface{}, 0, 6)
-	colName := pi.Columns[0].L
-	if index == 0 {
-		paramList = append(paramList, schemaName.L, tableName.L, colName, trimQuotation(pi.Definitions[index].LessThan[0]))
-		return "select 1 from %n.%n where %n >= %? limit 1", paramList
-	} else if index == len(pi.Definitions)-1 && strings.EqualFold(pi.Definitions[index].LessThan[0], partitionMaxValue) {
-		paramList = append(paramList, schemaName.L, tableName.L, colName, trimQuotation(pi.Definitions[index-1].LessThan[0]))
-		return "select 1 from %n.%n where %n < %? limit 1", paramList
-	} else {
-		paramList = append(paramList, schemaName.L, tableName.L, colName, trimQuotation(pi.Definitions[index-1].LessThan[0]), colName, trimQuotation(pi.Definitions[index].LessThan[0]))
-		return "select 1 from %n.%n where %n < %? or %n >= %? limit 1", paramList
+// getHandleColNames returns the names of the columns that identify a row of the table.
+func getHandleColNames(tblInfo *model.TableInfo) []string {
+	if tblInfo.PKIsHandle {
+		return []string{tblInfo.GetPkColInfo().Name.L}
+	}
+	if tblInfo.IsCommonHandle {
+		pkIdx := tables.FindPrimaryIndex(tblInfo)
+		names := make([]string, 0, len(pkIdx.Columns))
+		for _, col := range pkIdx.Columns {
+			names = append(names, col.Name.L)
+		}
+		return names
 	}
+	return []string{model.ExtraHandleName.L}
 }
 
-func buildCheckSQLForListPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
-	var buf strings.Builder
-	buf.WriteString("select 1 from %n.%n where ")
-	buf.WriteString(pi.Expr)
-	buf.WriteString(" not in (%?) limit 1")
-	inValues := getInValues(pi, index)
-
-	paramList := make([]interface{}, 0, 3)
-	paramList = append(paramList, schemaName.L, tableName.L, inValues)
-	return buf.String(), paramList
+// buildCheckCondForHashPartition builds the condition of the rows that don't belong to the hash partition.
+// A row is located by the absolute value of the remainder, and NULL is in the first partition.
+func buildCheckCondForHashPartition(pi *model.PartitionInfo, index int) string {
+	if pi.Num == 1 {
+		return ""
+	}
+	return fmt.Sprintf("ifnull(abs(mod(%s, %d)), 0) != %d", pi.Expr, pi.Num, index)
 }
 
-func buildCheckSQLForListColumnsPartition(pi *model.PartitionInfo, index int, schemaName, tableName model.CIStr) (string, []interface{}) {
-	colName := pi.Columns[0].L
-	var buf strings.Builder
-	buf.WriteString("select 1 from %n.%n where %n not in (%?) limit 1")
-	inValues := getInValues(pi, index)
-
-	paramList := make([]interface{}, 0, 4)
-	paramList = append(paramList, schemaName.L, tableName.L, colName, inValues)
-	return buf.String(), paramList
+// buildCheckCondForRangePartition builds the condition of the rows that don't belong to the range partition.
+// NULL is less than any value, so it's only in the first partition.
+func buildCheckCondForRangePartition(pi *model.PartitionInfo, index int) string {
+	expr := pi.Expr
+	if len(pi.Columns) > 0 {
+		expr = stringutil.Escape(pi.Columns[0].L, mysql.ModeNone)
+	}
+	conds := make([]string, 0, 3)
+	if index > 0 {
+		conds = append(conds, fmt.Sprintf("(%s) is null", expr))
+		conds = append(conds, fmt.Sprintf("(%s) < (%s)", expr, pi.Definitions[index-1].LessThan[0]))
+	}
+	if lessThan := pi.Definitions[index].LessThan[0]; !strings.EqualFold(lessThan, partitionMaxValue) {
+		conds = append(conds, fmt.Sprintf("(%s) >= (%s)", expr, lessThan))
+	}
+	return strings.Join(conds, " or ")
 }
 
-func getInValues(pi *model.PartitionInfo, index int) []string {
-	inValues := make([]string, 0, len(pi.Definitions[index].InValues))
-	for _, inValue := range pi.Definitions[index].InValues {
-		inValues = append(inValues, inValue...)
+// buildCheckCondForListPartition builds the condition of the rows that don't belong to the list partition.
+// The values are compared by `<=>`, so a NULL only matches a NULL in the values list.
+func buildCheckCondForListPartition(pi *model.PartitionInfo, index int) string {
+	exprs := []string{pi.Expr}
+	if len(pi.Columns) > 0 {
+		exprs = make([]string, 0, len(pi.Columns))
+		for _, col := range pi.Columns {
+			exprs = append(exprs, stringutil.Escape(col.L, mysql.ModeNone))
+		}
+	}
+	inValues := pi.Definitions[index].InValues
+	matches := make([]string, 0, len(inValues))
+	for _, vs := range inValues {
+		eqs := make([]string, 0, len(vs))
+		for i, v := range vs {
+			eqs = append(eqs, fmt.Sprintf("(%s) <=> (%s)", exprs[i], v))
+		}
+		matches = append(matches, "("+strings.Join(eqs, " and ")+")")
+	}
+	if len(matches) == 0 {
+		return "true"
 	}
-	return inValues
+	return "not (" + strings.Join(matches, " or ") + ")"
 }
 
 func checkAddPartitionTooManyPartitions(piDefs uint64) error {
@@ -0,0 +1,636 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	goutil "github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	partitionIntervalNamePrefix = "P_LT_"
+	partitionIntervalNullName   = "P_NULL"
+	partitionIntervalMaxName    = "P_MAXVALUE"
+)
+
+// premakeIntervalPartitionsCheckInterval is how often the DDL owner checks the tables with PREMAKE.
+var premakeIntervalPartitionsCheckInterval = 10 * time.Minute
+
+// partitionIntervalBound computes the partition bounds of interval partitioning.
+// The i-th bound is the first bound plus i intervals. It's computed from the first bound every time,
+// so a month interval starting on the 31st doesn't drift to the 28th.
+type partitionIntervalBound struct {
+	ctx      sessionctx.Context
+	tbInfo   *model.TableInfo
+	interval int64
+	unit     string
+	first    types.Datum
+	// ft is the type of the partition column, it's nil for RANGE partitioning by an integer expression.
+	ft *types.FieldType
+}
+
+// newPartitionIntervalBound builds the bound calculator of the interval stored in the table info.
+func newPartitionIntervalBound(ctx sessionctx.Context, tbInfo *model.TableInfo) (*partitionIntervalBound, error) {
+	pi := tbInfo.Partition.Interval
+	interval, err := strconv.ParseInt(pi.IntervalExpr, 10, 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	b := &partitionIntervalBound{ctx: ctx, tbInfo: tbInfo, interval: interval, unit: pi.IntervalUnit}
+	if len(tbInfo.Partition.Columns) > 0 {
+		b.ft = &findColumnByName(tbInfo.Partition.Columns[0].L, tbInfo).FieldType
+	}
+	b.first, err = b.parse(pi.FirstRangeEnd)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return b, nil
+}
+
+// eval evaluates a FIRST or LAST PARTITION LESS THAN value.
+func (b *partitionIntervalBound) eval(expr ast.ExprNode) (types.Datum, error) {
+	val, err := expression.EvalAstExpr(b.ctx, expr)
+	if err != nil {
+		return val, errors.Trace(err)
+	}
+	if b.ft != nil {
+		if val.IsNull() {
+			return val, errors.Trace(ErrWrongTypeColumnValue.GenWithStackByArgs())
+		}
+		return val.ConvertTo(b.ctx.GetSessionVars().StmtCtx, b.ft)
+	}
+	switch val.Kind() {
+	case types.KindInt64:
+		return val, nil
+	case types.KindUint64:
+		if val.GetUint64() > math.MaxInt64 {
+			return val, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the bounds must be in the range of BIGINT"))
+		}
+		return types.NewIntDatum(int64(val.GetUint64())), nil
+	}
+	return val, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the bounds of RANGE partitioning must be integers"))
+}
+
+// parse parses a VALUES LESS THAN value of a partition definition.
+func (b *partitionIntervalBound) parse(str string) (types.Datum, error) {
+	if b.ft == nil {
+		val, _, err := getRangeValue(b.ctx, str, false)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		return types.NewIntDatum(val.(int64)), nil
+	}
+	e, err := expression.ParseSimpleExprCastWithTableInfo(b.ctx, str, b.tbInfo, b.ft)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	t, isNull, err := e.EvalTime(b.ctx, chunk.Row{})
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	if isNull {
+		return types.Datum{}, errors.Trace(ErrWrongTypeColumnValue.GenWithStackByArgs())
+	}
+	return types.NewTimeDatum(t), nil
+}
+
+// unitMonths returns the months of a month, quarter or year interval, and 0 for other units.
+func (b *partitionIntervalBound) unitMonths() int64 {
+	switch b.unit {
+	case "MONTH":
+		return b.interval
+	case "QUARTER":
+		return b.interval * 3
+	case "YEAR":
+		return b.interval * 12
+	}
+	return 0
+}
+
+// unitMicroseconds returns the microseconds of an interval shorter than a month.
+func (b *partitionIntervalBound) unitMicroseconds() (int64, error) {
+	var us int64
+	switch b.unit {
+	case "MICROSECOND":
+		us = 1
+	case "SECOND":
+		us = int64(time.Second / time.Microsecond)
+	case "MINUTE":
+		us = int64(time.Minute / time.Microsecond)
+	case "HOUR":
+		us = int64(time.Hour / time.Microsecond)
+	case "DAY":
+		us = int64(24 * time.Hour / time.Microsecond)
+	case "WEEK":
+		us = int64(7 * 24 * time.Hour / time.Microsecond)
+	default:
+		return 0, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the unit " + b.unit))
+	}
+	return types.MulInt64(us, b.interval)
+}
+
+// bound returns the i-th bound, i is -1 for the bound of the NULL partition.
+func (b *partitionIntervalBound) bound(i int64) (types.Datum, error) {
+	if b.ft == nil {
+		diff, err := types.MulInt64(i, b.interval)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		v, err := types.AddInt64(b.first.GetInt64(), diff)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		return types.NewIntDatum(v), nil
+	}
+
+	first := b.first.GetMysqlTime()
+	goFirst, err := first.CoreTime().GoTime(time.UTC)
+	if err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	var goBound time.Time
+	if months := b.unitMonths(); months > 0 {
+		diff, err := types.MulInt64(i, months)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		goBound = types.AddDate(0, diff, 0, goFirst)
+	} else {
+		step, err := b.unitMicroseconds()
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		diff, err := types.MulInt64(i, step)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		us, err := types.AddInt64(goMicroseconds(goFirst), diff)
+		if err != nil {
+			return types.Datum{}, errors.Trace(err)
+		}
+		goBound = time.Unix(us/1e6, us%1e6*1e3).UTC()
+	}
+	t := types.NewTime(types.FromGoTime(goBound), first.Type(), first.Fsp())
+	if err := t.Check(b.ctx.GetSessionVars().StmtCtx); err != nil {
+		return types.Datum{}, errors.Trace(err)
+	}
+	return types.NewTimeDatum(t), nil
+}
+
+func goMicroseconds(t time.Time) int64 {
+	return t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+}
+
+// index returns the largest i which makes the i-th bound not larger than v,
+// and whether the i-th bound equals to v.
+func (b *partitionIntervalBound) index(v types.Datum) (int64, bool, error) {
+	var i int64
+	if b.ft == nil {
+		diff, err := types.SubInt64(v.GetInt64(), b.first.GetInt64())
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		i = diff / b.interval
+		if diff%b.interval < 0 {
+			i--
+		}
+	} else {
+		goFirst, err := b.first.GetMysqlTime().CoreTime().GoTime(time.UTC)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		goV, err := v.GetMysqlTime().CoreTime().GoTime(time.UTC)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		if months := b.unitMonths(); months > 0 {
+			i = (int64(goV.Year())*12 + int64(goV.Month()) - int64(goFirst.Year())*12 - int64(goFirst.Month())) / months
+		} else {
+			step, err := b.unitMicroseconds()
+			if err != nil {
+				return 0, false, errors.Trace(err)
+			}
+			i = (goMicroseconds(goV) - goMicroseconds(goFirst)) / step
+		}
+	}
+
+	// The estimated i may be off by one because of the day of month and the rounding.
+	sc := b.ctx.GetSessionVars().StmtCtx
+	for {
+		bound, err := b.bound(i)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		cmp, err := bound.CompareDatum(sc, &v)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		if cmp > 0 {
+			i--
+			continue
+		}
+		next, err := b.bound(i + 1)
+		if err != nil {
+			// The next bound is out of range, so it's larger than v.
+			return i, cmp == 0, nil
+		}
+		nextCmp, err := next.CompareDatum(sc, &v)
+		if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		if nextCmp <= 0 {
+			i++
+			continue
+		}
+		return i, cmp == 0, nil
+	}
+}
+
+// valueExpr returns the VALUES LESS THAN expression of a bound.
+func (b *partitionIntervalBound) valueExpr(v types.Datum) ast.ExprNode {
+	if b.ft == nil {
+		return ast.NewValueExpr(v.GetInt64(), "", "")
+	}
+	return ast.NewValueExpr(v.GetMysqlTime().String(), "", "")
+}
+
+// definition returns the partition definition of a bound.
+func (b *partitionIntervalBound) definition(v types.Datum) *ast.PartitionDefinition {
+	var name string
+	if b.ft == nil {
+		name = partitionIntervalNamePrefix + strconv.FormatInt(v.GetInt64(), 10)
+	} else {
+		name = partitionIntervalNamePrefix + v.GetMysqlTime().String()
+	}
+	return &ast.PartitionDefinition{
+		Name:   model.NewCIStr(name),
+		Clause: &ast.PartitionDefinitionClauseLessThan{Exprs: []ast.ExprNode{b.valueExpr(v)}},
+	}
+}
+
+// buildPartitionIntervalDefinitions checks the INTERVAL of CREATE TABLE, stores it in the partition info and
+// generates the partition definitions, from the NULL partition to the MAXVALUE partition.
+// The partition definitions written with the interval, as SHOW CREATE TABLE prints them, are used as they are
+// if their bounds are aligned with the interval.
+func buildPartitionIntervalDefinitions(ctx sessionctx.Context, s *ast.PartitionOptions, tbInfo *model.TableInfo) ([]*ast.PartitionDefinition, error) {
+	if s.Tp != model.PartitionTypeRange {
+		return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("only RANGE partitioning can be defined by an interval"))
+	}
+	intervalExpr := s.Interval.IntervalExpr
+	pi := &model.PartitionInterval{
+		NullPart:   s.Interval.NullPart,
+		MaxValPart: s.Interval.MaxValPart,
+		Premake:    s.Interval.Premake,
+	}
+	switch len(tbInfo.Partition.Columns) {
+	case 0:
+		if intervalExpr.TimeUnit != ast.TimeUnitInvalid {
+			return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the interval of RANGE partitioning can't have a time unit"))
+		}
+		if pi.Premake > 0 {
+			return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("PREMAKE only supports RANGE COLUMNS partitioning by a DATE or DATETIME column"))
+		}
+	case 1:
+		col := findColumnByName(tbInfo.Partition.Columns[0].L, tbInfo)
+		if col.Tp != mysql.TypeDate && col.Tp != mysql.TypeDatetime {
+			return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the interval of RANGE COLUMNS partitioning only supports DATE and DATETIME columns"))
+		}
+		if intervalExpr.TimeUnit == ast.TimeUnitInvalid {
+			return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the interval of RANGE COLUMNS partitioning must have a time unit"))
+		}
+		pi.IntervalUnit = intervalExpr.TimeUnit.String()
+	default:
+		return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the interval of RANGE COLUMNS partitioning only supports one column"))
+	}
+
+	val, err := expression.EvalAstExpr(ctx, intervalExpr.Expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if (val.Kind() != types.KindInt64 || val.GetInt64() <= 0) && (val.Kind() != types.KindUint64 || val.GetUint64() == 0 || val.GetUint64() > math.MaxInt64) {
+		return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the interval must be a positive integer"))
+	}
+	pi.IntervalExpr = strconv.FormatInt(val.GetInt64(), 10)
+	if err := checkAddPartitionTooManyPartitions(pi.Premake); err != nil {
+		return nil, err
+	}
+
+	b := &partitionIntervalBound{ctx: ctx, tbInfo: tbInfo, interval: val.GetInt64(), unit: pi.IntervalUnit}
+	if len(tbInfo.Partition.Columns) > 0 {
+		b.ft = &findColumnByName(tbInfo.Partition.Columns[0].L, tbInfo).FieldType
+	}
+	if b.first, err = b.eval(s.Interval.FirstRangeEnd); err != nil {
+		return nil, errors.Trace(err)
+	}
+	last, err := b.eval(s.Interval.LastRangeEnd)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	n, aligned, err := b.index(last)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !aligned || n < 0 {
+		return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("LAST PARTITION LESS THAN must be FIRST PARTITION LESS THAN plus a multiple of the interval"))
+	}
+	buf := new(bytes.Buffer)
+	b.valueExpr(b.first).Format(buf)
+	pi.FirstRangeEnd = buf.String()
+
+	if len(s.Definitions) > 0 {
+		if err := checkPartitionIntervalDefinitions(b, s.Definitions, pi.MaxValPart); err != nil {
+			return nil, err
+		}
+		tbInfo.Partition.Interval = pi
+		return s.Definitions, nil
+	}
+
+	count := n + 1
+	if pi.NullPart {
+		count++
+	}
+	if pi.MaxValPart {
+		count++
+	}
+	if err := checkAddPartitionTooManyPartitions(uint64(count)); err != nil {
+		return nil, err
+	}
+
+	defs := make([]*ast.PartitionDefinition, 0, count)
+	if pi.NullPart {
+		v, err := b.bound(-1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		def := b.definition(v)
+		def.Name = model.NewCIStr(partitionIntervalNullName)
+		defs = append(defs, def)
+	}
+	for i := int64(0); i <= n; i++ {
+		v, err := b.bound(i)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defs = append(defs, b.definition(v))
+	}
+	if pi.MaxValPart {
+		defs = append(defs, &ast.PartitionDefinition{
+			Name:   model.NewCIStr(partitionIntervalMaxName),
+			Clause: &ast.PartitionDefinitionClauseLessThan{Exprs: []ast.ExprNode{&ast.MaxValueExpr{}}},
+		})
+	}
+	tbInfo.Partition.Interval = pi
+	return defs, nil
+}
+
+// checkPartitionIntervalDefinitions checks the bounds of the partition definitions are aligned with the interval,
+// and only the last one of a table with a MAXVALUE partition is MAXVALUE.
+func checkPartitionIntervalDefinitions(b *partitionIntervalBound, defs []*ast.PartitionDefinition, maxValPart bool) error {
+	for i, def := range defs {
+		clause, ok := def.Clause.(*ast.PartitionDefinitionClauseLessThan)
+		if !ok || len(clause.Exprs) != 1 {
+			return errors.Trace(ast.ErrPartitionWrongValues.GenWithStackByArgs("RANGE", "LESS THAN"))
+		}
+		if _, ok := clause.Exprs[0].(*ast.MaxValueExpr); ok {
+			if i != len(defs)-1 || !maxValPart {
+				return errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("only the MAXVALUE PARTITION can be less than MAXVALUE"))
+			}
+			continue
+		}
+		v, err := b.eval(clause.Exprs[0])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, aligned, err := b.index(v); err != nil {
+			return errors.Trace(err)
+		} else if !aligned {
+			return errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("the partition bounds must be FIRST PARTITION LESS THAN plus a multiple of the interval"))
+		}
+	}
+	return nil
+}
+
+func checkIntervalPartitionTable(meta *model.TableInfo, clause string) error {
+	if meta.Partition.Interval == nil {
+		return errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs(clause + " on a table not partitioned by an interval"))
+	}
+	return nil
+}
+
+// getPartitionNamesBeforeFirstRangeEnd returns the partitions dropped by ALTER TABLE ... FIRST PARTITION LESS THAN,
+// which are the ones before the partition of the given bound, except the NULL partition.
+func getPartitionNamesBeforeFirstRangeEnd(ctx sessionctx.Context, meta *model.TableInfo, expr ast.ExprNode) ([]string, error) {
+	if err := checkIntervalPartitionTable(meta, "FIRST PARTITION"); err != nil {
+		return nil, err
+	}
+	b, err := newPartitionIntervalBound(ctx, meta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	v, err := b.eval(expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pi := meta.Partition
+	sc := ctx.GetSessionVars().StmtCtx
+	partNames := make([]string, 0, len(pi.Definitions))
+	for i, def := range pi.Definitions {
+		if i == 0 && pi.Interval.NullPart && def.Name.L == strings.ToLower(partitionIntervalNullName) {
+			continue
+		}
+		if strings.EqualFold(def.LessThan[0], partitionMaxValue) {
+			break
+		}
+		bound, err := b.parse(def.LessThan[0])
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cmp, err := bound.CompareDatum(sc, &v)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if cmp == 0 {
+			return partNames, nil
+		}
+		if cmp > 0 {
+			break
+		}
+		partNames = append(partNames, def.Name.L)
+	}
+	return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("FIRST PARTITION LESS THAN must be the bound of a partition"))
+}
+
+// buildPartitionDefinitionsToLastRangeEnd returns the partitions added by ALTER TABLE ... LAST PARTITION LESS THAN,
+// which are the ones after the current last partition, up to the given bound.
+func buildPartitionDefinitionsToLastRangeEnd(ctx sessionctx.Context, meta *model.TableInfo, expr ast.ExprNode) ([]*ast.PartitionDefinition, error) {
+	if err := checkIntervalPartitionTable(meta, "LAST PARTITION"); err != nil {
+		return nil, err
+	}
+	pi := meta.Partition
+	lastDef := pi.Definitions[len(pi.Definitions)-1]
+	if strings.EqualFold(lastDef.LessThan[0], partitionMaxValue) {
+		return nil, errors.Trace(ErrPartitionMaxvalue)
+	}
+	b, err := newPartitionIntervalBound(ctx, meta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	v, err := b.eval(expr)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	to, aligned, err := b.index(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !aligned {
+		return nil, errors.Trace(errUnsupportedIntervalPartition.GenWithStackByArgs("LAST PARTITION LESS THAN must be FIRST PARTITION LESS THAN plus a multiple of the interval"))
+	}
+	last, err := b.parse(lastDef.LessThan[0])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	from, _, err := b.index(last)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if to <= from {
+		return nil, errors.Trace(ErrRangeNotIncreasing)
+	}
+	if err := checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions)) + uint64(to-from)); err != nil {
+		return nil, err
+	}
+	defs := make([]*ast.PartitionDefinition, 0, to-from)
+	for i := from + 1; i <= to; i++ {
+		v, err := b.bound(i)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defs = append(defs, b.definition(v))
+	}
+	return defs, nil
+}
+
+// startPremakeIntervalPartitions runs on the DDL owner. It keeps PREMAKE partitions after the one of the current time
+// for the tables partitioned by an interval. It never drops partitions, the retention is left to the users.
+func (d *ddl) startPremakeIntervalPartitions() {
+	defer func() {
+		goutil.Recover(metrics.LabelDDL, "startPremakeIntervalPartitions", nil, false)
+		d.wg.Done()
+	}()
+
+	checkInterval := premakeIntervalPartitionsCheckInterval
+	failpoint.Inject("mockPremakeIntervalPartitionsCheckInterval", func(val failpoint.Value) {
+		checkInterval = time.Duration(val.(int)) * time.Millisecond
+	})
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !d.ownerManager.IsOwner() {
+				continue
+			}
+			is := d.infoCache.GetLatest()
+			for _, db := range is.AllSchemas() {
+				for _, tbl := range is.SchemaTables(db.Name) {
+					meta := tbl.Meta()
+					if meta.Partition == nil || meta.Partition.Interval == nil || meta.Partition.Interval.Premake == 0 {
+						continue
+					}
+					// A failed table is retried in the next round.
+					if err := d.premakeIntervalPartitions(db.Name, meta); err != nil {
+						logutil.BgLogger().Warn("[ddl] premake interval partitions failed",
+							zap.String("schema", db.Name.O), zap.String("table", meta.Name.O), zap.Error(err))
+					}
+				}
+			}
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// premakeIntervalPartitions adds the partitions up to PREMAKE intervals after the partition of the current time,
+// by the same ADD PARTITION job as ALTER TABLE ... LAST PARTITION LESS THAN.
+func (d *ddl) premakeIntervalPartitions(schema model.CIStr, meta *model.TableInfo) error {
+	pi := meta.Partition
+	lastDef := pi.Definitions[len(pi.Definitions)-1]
+	if strings.EqualFold(lastDef.LessThan[0], partitionMaxValue) {
+		// Partitions can't be added before the MAXVALUE partition.
+		return nil
+	}
+
+	ctx, err := d.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer d.sessPool.put(ctx)
+
+	b, err := newPartitionIntervalBound(ctx, meta)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	now := types.NewTimeDatum(types.NewTime(types.FromGoTime(time.Now().In(ctx.GetSessionVars().Location())), mysql.TypeDatetime, 0))
+	now, err = now.ConvertTo(sc, b.ft)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	i, _, err := b.index(now)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The i-th bound is not larger than the current time, so the partition of the current time is less than the next one.
+	target, err := b.bound(i + 1 + int64(pi.Interval.Premake))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	last, err := b.parse(lastDef.LessThan[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cmp, err := last.CompareDatum(sc, &target)
+	if err != nil || cmp >= 0 {
+		return errors.Trace(err)
+	}
+
+	spec := &ast.AlterTableSpec{
+		Tp: ast.AlterTableLastPartition,
+		Partition: &ast.PartitionOptions{
+			Interval: &ast.PartitionInterval{LastRangeEnd: b.valueExpr(target)},
+		},
+	}
+	logutil.BgLogger().Info("[ddl] premake interval partitions", zap.String("schema", schema.O), zap.String("table", meta.Name.O),
+		zap.String("last partition less than", target.GetMysqlTime().String()))
+	return errors.Trace(d.AddTablePartitions(ctx, ast.Ident{Schema: schema, Name: meta.Name}, spec))
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
@@ -55,6 +56,8 @@ type reorgCtx struct {
 	// TODO: Now we use goroutine to simulate reorganization jobs, later we may
 	// use a persistent job list.
 	doneCh chan error
+	// jobID is the ID of the job whose backfill is running, it's valid only if doneCh isn't nil.
+	jobID int64
 	// rowCount is used to simulate a job's row count.
 	rowCount int64
 	// notifyCancelReorgJob is used to notify the backfilling goroutine if the DDL job is cancelled.
@@ -69,6 +72,10 @@ type reorgCtx struct {
 	// accessed by reorg-worker and daemon-worker concurrently.
 	element atomic.Value
 
+	// jobCtl is the control options of the job set by users, it's reloaded from the meta
+	// periodically, so the backfill can be paused or throttled while it's running.
+	jobCtl atomic.Value // *meta.DDLJobControl
+
 	// warnings is used to store the warnings when doing the reorg job under
 	// a certain SQL Mode.
 	mu struct {
@@ -110,6 +117,38 @@ func (rc *reorgCtx) isReorgCanceled() bool {
 	return atomic.LoadInt32(&rc.notifyCancelReorgJob) == 1
 }
 
+func (rc *reorgCtx) setJobControl(ctl *meta.DDLJobControl) {
+	rc.jobCtl.Store(ctl)
+}
+
+func (rc *reorgCtx) getJobControl() *meta.DDLJobControl {
+	ctl, _ := rc.jobCtl.Load().(*meta.DDLJobControl)
+	return ctl
+}
+
+func (rc *reorgCtx) isReorgPaused() bool {
+	ctl := rc.getJobControl()
+	return ctl != nil && ctl.Paused
+}
+
+// getBackfillWorkerCount gets the count of backfill workers, the option of the job
+// takes precedence over "tidb_ddl_reorg_worker_cnt".
+func (rc *reorgCtx) getBackfillWorkerCount() int32 {
+	if ctl := rc.getJobControl(); ctl != nil && ctl.Thread > 0 {
+		return int32(ctl.Thread)
+	}
+	return variable.GetDDLReorgWorkerCounter()
+}
+
+// getBackfillBatchSize gets the backfill batch size, the option of the job takes
+// precedence over "tidb_ddl_reorg_batch_size".
+func (rc *reorgCtx) getBackfillBatchSize() int {
+	if ctl := rc.getJobControl(); ctl != nil && ctl.BatchSize > 0 {
+		return ctl.BatchSize
+	}
+	return int(variable.GetDDLReorgBatchSize())
+}
+
 func (rc *reorgCtx) setRowCount(count int64) {
 	atomic.StoreInt64(&rc.rowCount, count)
 }
@@ -211,6 +250,7 @@ func (w *worker) runReorgJob(t *meta.Meta, reorgInfo *reorgInfo, tblInfo *model.
 		// start a reorganization job
 		w.wg.Add(1)
 		w.reorgCtx.doneCh = make(chan error, 1)
+		w.reorgCtx.jobID = job.ID
 		// initial reorgCtx
 		w.reorgCtx.setRowCount(job.GetRowCount())
 		w.reorgCtx.setNextKey(reorgInfo.StartKey)
@@ -236,7 +276,7 @@ func (w *worker) runReorgJob(t *meta.Meta, reorgInfo *reorgInfo, tblInfo *model.
 	// wait reorganization job done or timeout
 	select {
 	case err := <-w.reorgCtx.doneCh:
-		rowCount, _, _ := w.reorgCtx.getRowCountAndKey()
+		rowCount, doneKey, currentElement := w.reorgCtx.getRowCountAndKey()
 		logutil.BgLogger().Info("[ddl] run reorg job done", zap.Int64("handled rows", rowCount))
 		// Update a job's RowCount.
 		job.SetRowCount(rowCount)
@@ -245,6 +285,20 @@ func (w *worker) runReorgJob(t *meta.Meta, reorgInfo *reorgInfo, tblInfo *model.
 		w.mergeWarningsIntoJob(job)
 
 		w.reorgCtx.clean()
+		if errReorgPaused.Equal(err) {
+			// Save the progress, the job continues from it after it's resumed.
+			err = t.UpdateDDLReorgStartHandle(job, currentElement, doneKey)
+			logutil.BgLogger().Info("[ddl] run reorg job paused",
+				zap.ByteString("elementType", currentElement.TypeKey),
+				zap.Int64("elementID", currentElement.ID),
+				zap.Int64("totalAddedRowCount", rowCount),
+				zap.String("doneKey", tryDecodeToHandleString(doneKey)),
+				zap.Error(err))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			return errWaitReorgTimeout
+		}
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -365,6 +419,11 @@ func (w *worker) isReorgRunnable(d *ddlCtx) error {
 		return errCancelledDDLJob
 	}
 
+	if w.reorgCtx.isReorgPaused() {
+		// Job is paused, it will be continued after it's resumed.
+		return errReorgPaused
+	}
+
 	if !d.isOwner() {
 		// If it's not the owner, we will try later, so here just returns an error.
 		logutil.BgLogger().Info("[ddl] DDL worker is not the DDL owner", zap.String("ID", d.uuid))
@@ -439,6 +439,28 @@ func rollingbackTruncateTable(t *meta.Meta, job *model.Job) (ver int64, err erro
 	return cancelOnlyNotHandledJob(job)
 }
 
+func rollingbackFlashback(job *model.Job) (ver int64, err error) {
+	// Keep the args, the job is encoded with job.Args after this step.
+	arg := &flashbackArg{}
+	if err = job.DecodeArgs(arg); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	job.Args = []interface{}{arg}
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	case model.StateWriteOnly:
+		// The tables are locked but the data isn't written yet, onFlashbackToTimestamp unlocks the tables.
+		job.State = model.JobStateRollingback
+		return ver, errCancelledDDLJob
+	}
+	// The data may be written partially, so it can't be rolled back.
+	job.State = model.JobStateRunning
+	return ver, nil
+}
+
 func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	switch job.Type {
 	case model.ActionAddColumn:
@@ -476,8 +498,10 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionExchangeTablePartition:
+		model.ActionExchangeTablePartition, model.ActionAlterCacheTable, model.ActionAlterNoCacheTable:
 		ver, err = cancelOnlyNotHandledJob(job)
+	case model.ActionFlashbackTable, model.ActionFlashbackCluster:
+		ver, err = rollingbackFlashback(job)
 	default:
 		job.State = model.JobStateCancelled
 		err = errCancelledDDLJob
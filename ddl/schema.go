@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/ddl/label"
 	"github.com/pingcap/tidb/ddl/placement"
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/infoschema"
@@ -216,6 +217,141 @@ func onDropSchema(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error)
 	return ver, errors.Trace(err)
 }
 
+func (w *worker) onRecoverSchema(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	recoverSchemaInfo := &RecoverSchemaInfo{}
+	var recoverSchemaCheckFlag int64
+	const checkFlagIndexInJobArgs = 1 // The index of `recoverSchemaCheckFlag` in job arg list.
+	if err := job.DecodeArgs(recoverSchemaInfo, &recoverSchemaCheckFlag); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	dbInfo := recoverSchemaInfo.DBInfo
+	dbInfo.ID = job.SchemaID
+
+	// check GC and safe point
+	gcEnable, err := checkGCEnable(w)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	oldDBInfo, err := t.GetDatabase(dbInfo.ID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if oldDBInfo != nil {
+		err = infoschema.ErrDatabaseExists.GenWithStack("Schema '%-.192s' already been recover to '%-.192s', can't be recover repeatedly", recoverSchemaInfo.OldSchemaName, oldDBInfo.Name.O)
+	} else {
+		err = checkSchemaNotExists(d, t, dbInfo.ID, dbInfo)
+	}
+	if err != nil {
+		if infoschema.ErrDatabaseExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+
+	// Recover schema is divided into 2 steps like recover table, see onRecoverTable for the details.
+	// All the tables of the schema are recovered with the schema in the second step.
+	switch dbInfo.State {
+	case model.StateNone:
+		// none -> write only
+		// check GC enable and update flag.
+		if gcEnable {
+			job.Args[checkFlagIndexInJobArgs] = recoverTableCheckFlagEnableGC
+		} else {
+			job.Args[checkFlagIndexInJobArgs] = recoverTableCheckFlagDisableGC
+		}
+
+		dbInfo.State = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> public
+		// do recover schema and its tables.
+		if gcEnable {
+			err = disableGC(w)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Errorf("disable gc failed, try again later. err: %v", err)
+			}
+		}
+		// check GC safe point
+		err = checkSafePoint(w, recoverSchemaInfo.SnapshotTS)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+
+		type labelRules struct {
+			tableRuleID string
+			partRuleIDs []string
+			oldRuleIDs  []string
+			oldRules    map[string]*label.Rule
+		}
+		tables := make([]*model.TableInfo, 0, len(recoverSchemaInfo.RecoverTabsInfo))
+		tablesLabelRules := make([]labelRules, 0, len(recoverSchemaInfo.RecoverTabsInfo))
+		for _, recoverInfo := range recoverSchemaInfo.RecoverTabsInfo {
+			var rules labelRules
+			rules.tableRuleID, rules.partRuleIDs, rules.oldRuleIDs, rules.oldRules, err = getOldLabelRules(recoverInfo.TableInfo, recoverInfo.OldSchemaName, recoverInfo.OldTableName)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Wrapf(err, "failed to get old label rules from PD")
+			}
+			tables = append(tables, recoverInfo.TableInfo)
+			tablesLabelRules = append(tablesLabelRules, rules)
+		}
+
+		// Remove the dropped tables of the DROP DATABASE job from gc_delete_range table.
+		err = w.delRangeManager.removeFromGCDeleteRange(w.ddlJobCtx, recoverSchemaInfo.DropJobID, getIDs(tables))
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+
+		dbInfo.State = model.StatePublic
+		err = t.CreateDatabase(dbInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		tableIDs := make([]int64, 0, len(tables))
+		for _, recoverInfo := range recoverSchemaInfo.RecoverTabsInfo {
+			tblInfo := recoverInfo.TableInfo
+			tblInfo.State = model.StatePublic
+			tblInfo.UpdateTS = t.StartTS
+			if tblInfo.IsSequence() {
+				err = t.CreateSequenceAndSetSeqValue(dbInfo.ID, tblInfo, recoverInfo.SequenceValue)
+			} else {
+				err = t.CreateTableAndSetAutoID(dbInfo.ID, tblInfo, recoverInfo.AutoIDs.RowID, recoverInfo.AutoIDs.RandomID)
+			}
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			tableIDs = append(tableIDs, tblInfo.ID)
+		}
+
+		for i, rules := range tablesLabelRules {
+			err = updateLabelRules(job, tables[i], rules.oldRules, rules.tableRuleID, rules.partRuleIDs, rules.oldRuleIDs, tables[i].ID)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Wrapf(err, "failed to update the label rule to PD")
+			}
+		}
+
+		job.CtxVars = []interface{}{tableIDs}
+		ver, err = updateSchemaVersion(t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+
+		// Finish this job.
+		job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	default:
+		// We can't enter here.
+		return ver, errors.Errorf("invalid db state %v", dbInfo.State)
+	}
+	return ver, nil
+}
+
 func checkSchemaExistAndCancelNotExistJob(t *meta.Meta, job *model.Job) (*model.DBInfo, error) {
 	dbInfo, err := t.GetDatabase(job.SchemaID)
 	if err != nil {
@@ -884,6 +884,268 @@ func (s *testSerialSuite) TestRecoverTableByJobID(c *C) {
 	c.Assert(gcEnable, Equals, false)
 }
 
+func (s *testSerialSuite) TestRecoverSchemaByJobID(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop database if exists test_recover_schema")
+	tk.MustExec("create database test_recover_schema")
+	tk.MustExec("use test_recover_schema")
+	tk.MustExec("create table t1 (a int primary key auto_increment, b int)")
+	tk.MustExec("create table t2 (a int) partition by hash(a) partitions 2")
+	tk.MustExec("create view v as select b from t1")
+	tk.MustExec("create sequence seq")
+	defer func(originGC bool) {
+		if originGC {
+			ddl.EmulatorGCEnable()
+		} else {
+			ddl.EmulatorGCDisable()
+		}
+	}(ddl.IsEmulatorGCEnable())
+
+	// disable emulator GC.
+	// Otherwise emulator GC will delete table record as soon as possible after execute drop database ddl.
+	ddl.EmulatorGCDisable()
+	gcTimeFormat := "20060102-15:04:05 -0700 MST"
+	timeBeforeDrop := time.Now().Add(0 - 48*60*60*time.Second).Format(gcTimeFormat)
+	timeAfterDrop := time.Now().Add(48 * 60 * 60 * time.Second).Format(gcTimeFormat)
+	safePointSQL := `INSERT HIGH_PRIORITY INTO mysql.tidb VALUES ('tikv_gc_safe_point', '%[1]s', '')
+			       ON DUPLICATE KEY
+			       UPDATE variable_value = '%[1]s'`
+	tk.MustExec("delete from mysql.tidb where variable_name in ( 'tikv_gc_safe_point','tikv_gc_enable' )")
+
+	tk.MustExec("insert into t1 (b) values (1),(2),(3)")
+	tk.MustExec("insert into t2 values (1),(2)")
+	tk.MustQuery("select nextval(seq)").Check(testkit.Rows("1"))
+	tk.MustExec("drop database test_recover_schema")
+
+	var jobID int64
+	rows := tk.MustQuery("admin show ddl jobs").Rows()
+	for _, row := range rows {
+		if row[1] == "test_recover_schema" && row[3] == "drop schema" {
+			jobID, _ = strconv.ParseInt(row[0].(string), 10, 64)
+			break
+		}
+	}
+	c.Assert(jobID, Greater, int64(0))
+	deleteRangeSQL := fmt.Sprintf("select count(*) from mysql.gc_delete_range where job_id = %d", jobID)
+	// t1, t2, v, seq and the 2 partitions of t2.
+	tk.MustQuery(deleteRangeSQL).Check(testkit.Rows("6"))
+
+	// The schema can't be recovered if the job is before GC safe point.
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeAfterDrop))
+	_, err := tk.Exec(fmt.Sprintf("recover table by job %d", jobID))
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "snapshot is older than GC safe point"), IsTrue)
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeBeforeDrop))
+
+	// The schema can't be recovered if there is a schema with the same name.
+	tk.MustExec("create database test_recover_schema")
+	_, err = tk.Exec(fmt.Sprintf("recover table by job %d", jobID))
+	c.Assert(infoschema.ErrDatabaseExists.Equal(err), IsTrue)
+	tk.MustExec("drop database test_recover_schema")
+
+	c.Assert(gcutil.EnableGC(tk.Se), IsNil)
+	tk.MustExec(fmt.Sprintf("recover table by job %d", jobID))
+	// The schema and all its tables are recovered by one job, and GC is enabled again after it.
+	recoverJobs := 0
+	for _, row := range tk.MustQuery("admin show ddl jobs").Rows() {
+		if row[1] == "test_recover_schema" && row[3] == "recover schema" {
+			recoverJobs++
+		}
+	}
+	c.Assert(recoverJobs, Equals, 1)
+	tk.MustQuery(deleteRangeSQL).Check(testkit.Rows("0"))
+	gcEnable, err := gcutil.CheckGCEnable(tk.Se)
+	c.Assert(err, IsNil)
+	c.Assert(gcEnable, IsTrue)
+
+	tk.MustExec("use test_recover_schema")
+	tk.MustQuery("show tables").Sort().Check(testkit.Rows("seq", "t1", "t2", "v"))
+	tk.MustQuery("select * from t1").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustQuery("select * from t2 order by a").Check(testkit.Rows("1", "2"))
+	// The auto IDs and the sequence value are recovered.
+	tk.MustExec("insert into t1 (b) values (4)")
+	tk.MustQuery("select b from t1 where a > 3").Check(testkit.Rows("4"))
+	c.Assert(tk.MustQuery("select nextval(seq)").Rows()[0][0].(string), Not(Equals), "1")
+
+	// The schema can't be recovered repeatedly.
+	_, err = tk.Exec(fmt.Sprintf("recover table by job %d", jobID))
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "can't be recover repeatedly"), IsTrue)
+
+	// Flashback the latest dropped schema by its name to a new name.
+	tk.MustExec("drop database test_recover_schema")
+	tk.MustExec("flashback database test_recover_schema to test_recover_schema2")
+	tk.MustExec("use test_recover_schema2")
+	tk.MustQuery("show tables").Sort().Check(testkit.Rows("seq", "t1", "t2", "v"))
+	tk.MustQuery("select b from t1").Sort().Check(testkit.Rows("1", "2", "3", "4"))
+	// The view still references the tables of the old schema name.
+	tk.MustGetErrCode("select * from v", errno.ErrViewInvalid)
+	_, err = tk.Exec("flashback database test_recover_schema")
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "can't be recover repeatedly"), IsTrue)
+	_, err = tk.Exec("flashback database test_recover_schema_not_exists")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "Can't find dropped database: test_recover_schema_not_exists in DDL history jobs")
+
+	tk.MustExec("drop database test_recover_schema2")
+}
+
+func (s *testSerialSuite) TestFlashbackToTimestamp(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("drop database if exists test_flashback")
+	tk.MustExec("create database test_flashback")
+	tk.MustExec("use test_flashback")
+	tk.MustExec("create table t1 (a int, b int, unique key(a), key(b))")
+	tk.MustExec("create table t2 (a varchar(10) primary key clustered, b int, key(b))")
+	tk.MustExec("create table t3 (a int, b int, key(b)) partition by hash(a) partitions 2")
+	tk.MustExec("create view v as select * from t1")
+	tk.MustExec("set tidb_enable_global_temporary_table=true")
+	tk.MustExec("create global temporary table tmp (a int) on commit delete rows")
+	timeBeforeDrop, timeAfterDrop, safePointSQL, resetGC := testkit.MockGC(tk)
+	defer resetGC()
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeBeforeDrop))
+	c.Assert(gcutil.EnableGC(tk.Se), IsNil)
+
+	for _, tbl := range []string{"t1", "t2", "t3"} {
+		tk.MustExec(fmt.Sprintf("insert into %s values (1, 1), (2, 2), (3, 3)", tbl))
+	}
+	time.Sleep(10 * time.Millisecond)
+	flashbackTS := time.Now().Format("2006-01-02 15:04:05.000")
+	time.Sleep(10 * time.Millisecond)
+	for _, tbl := range []string{"t1", "t2", "t3"} {
+		tk.MustExec(fmt.Sprintf("update %s set b = b + 10 where a = 1", tbl))
+		tk.MustExec(fmt.Sprintf("delete from %s where a = 2", tbl))
+		tk.MustExec(fmt.Sprintf("insert into %s values (4, 4)", tbl))
+	}
+
+	_, err := tk.Exec("flashback table t1 to timestamp now() + interval 1 day")
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "Can't flashback to a future time"), IsTrue)
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeAfterDrop))
+	_, err = tk.Exec(fmt.Sprintf("flashback table t1 to timestamp '%s'", flashbackTS))
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "snapshot is older than GC safe point"), IsTrue)
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeBeforeDrop))
+	tk.MustGetErrCode(fmt.Sprintf("flashback table v to timestamp '%s'", flashbackTS), errno.ErrWrongObject)
+	tk.MustGetErrCode(fmt.Sprintf("flashback table tmp to timestamp '%s'", flashbackTS), errno.ErrOptOnTemporaryTable)
+	tk.MustGetErrCode(fmt.Sprintf("flashback table t1, t1 to timestamp '%s'", flashbackTS), errno.ErrNonuniqTable)
+
+	// The writes from other sessions fail while the job is running.
+	tk2 := testkit.NewTestKit(c, s.store)
+	tk2.MustExec("use test_flashback")
+	var insertErr error
+	hook := &ddl.TestDDLCallback{}
+	hook.OnJobUpdatedExported = func(job *model.Job) {
+		if job.Type == model.ActionFlashbackTable && job.SchemaState == model.StateWriteReorganization && insertErr == nil {
+			_, insertErr = tk2.Exec("insert into t1 values (5, 5)")
+		}
+	}
+	origHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	tk.MustExec(fmt.Sprintf("flashback table t1, t2, t3 to timestamp '%s'", flashbackTS))
+	s.dom.DDL().(ddl.DDLForTest).SetHook(origHook)
+	c.Assert(infoschema.ErrTableLocked.Equal(insertErr), IsTrue, Commentf("err %v", insertErr))
+
+	for _, tbl := range []string{"t1", "t2", "t3"} {
+		tk.MustQuery(fmt.Sprintf("select * from %s order by a", tbl)).Check(testkit.Rows("1 1", "2 2", "3 3"))
+		tk.MustQuery(fmt.Sprintf("select b from %s use index(b) order by b", tbl)).Check(testkit.Rows("1", "2", "3"))
+		tk.MustExec(fmt.Sprintf("admin check table %s", tbl))
+	}
+	// The tables are unlocked and GC is enabled again after the job.
+	tk2.MustExec("insert into t1 values (5, 5)")
+	gcEnable, err := gcutil.CheckGCEnable(tk.Se)
+	c.Assert(err, IsNil)
+	c.Assert(gcEnable, IsTrue)
+	row := tk.MustQuery("admin show ddl jobs 1").Rows()[0]
+	c.Assert(row[3], Equals, "flashback table")
+	c.Assert(row[len(row)-1], Equals, "synced")
+
+	// The data can't be rolled back across a DDL.
+	tk.MustExec("alter table t1 add column c int")
+	_, err = tk.Exec(fmt.Sprintf("flashback table t1 to timestamp '%s'", flashbackTS))
+	c.Assert(err, NotNil)
+	c.Assert(strings.Contains(err.Error(), "the schema of the table has been changed"), IsTrue)
+
+	// Flashback cluster rolls back all the user tables whose schema isn't changed.
+	tk.MustExec("alter table t1 drop column c")
+	tk.MustExec("create table t4 (a int)")
+	time.Sleep(10 * time.Millisecond)
+	flashbackTS = time.Now().Format("2006-01-02 15:04:05.000")
+	time.Sleep(10 * time.Millisecond)
+	tk.MustExec("delete from t2")
+	tk.MustExec("insert into t4 values (1)")
+	tk.MustExec(fmt.Sprintf("flashback cluster to timestamp '%s'", flashbackTS))
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 1", "2 2", "3 3", "5 5"))
+	tk.MustQuery("select * from t2 order by a").Check(testkit.Rows("1 1", "2 2", "3 3"))
+	tk.MustQuery("select * from t4").Check(testkit.Rows())
+	row = tk.MustQuery("admin show ddl jobs 1").Rows()[0]
+	c.Assert(row[3], Equals, "flashback cluster")
+
+	tk.MustExec("drop database test_flashback")
+}
+
+func (s *testSerialSuite) TestCancelFlashbackToTimestamp(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_flashback")
+	tk.MustExec("create table t_flashback (a int)")
+	defer tk.MustExec("drop table if exists t_flashback")
+	timeBeforeDrop, _, safePointSQL, resetGC := testkit.MockGC(tk)
+	defer resetGC()
+	tk.MustExec(fmt.Sprintf(safePointSQL, timeBeforeDrop))
+	c.Assert(gcutil.EnableGC(tk.Se), IsNil)
+
+	tk.MustExec("insert into t_flashback values (1)")
+	time.Sleep(10 * time.Millisecond)
+	flashbackTS := time.Now().Format("2006-01-02 15:04:05.000")
+	time.Sleep(10 * time.Millisecond)
+	tk.MustExec("insert into t_flashback values (2)")
+
+	var cancelState model.SchemaState
+	var cancelled bool
+	var cancelErr error
+	hook := &ddl.TestDDLCallback{}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionFlashbackTable || job.SchemaState != cancelState || cancelled {
+			return
+		}
+		cancelled = true
+		err := kv.RunInNewTxn(context.Background(), s.store, false, func(ctx context.Context, txn kv.Transaction) error {
+			errs, err := admin.CancelJobs(txn, []int64{job.ID})
+			if err != nil {
+				return err
+			}
+			cancelErr = errs[0]
+			return nil
+		})
+		c.Assert(err, IsNil)
+	}
+	origHook := s.dom.DDL().GetHook()
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(origHook)
+
+	// The job can be cancelled before it writes the data, and the table is unlocked after it.
+	for _, state := range []model.SchemaState{model.StateNone, model.StateWriteOnly} {
+		cancelState, cancelled, cancelErr = state, false, nil
+		_, err := tk.Exec(fmt.Sprintf("flashback table t_flashback to timestamp '%s'", flashbackTS))
+		c.Assert(err, NotNil)
+		c.Assert(strings.Contains(err.Error(), "Cancelled DDL job"), IsTrue, Commentf("err %v", err))
+		c.Assert(cancelErr, IsNil)
+		tk.MustQuery("select * from t_flashback order by a").Check(testkit.Rows("1", "2"))
+		tbl, err := s.dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t_flashback"))
+		c.Assert(err, IsNil)
+		c.Assert(tbl.Meta().Lock, IsNil)
+	}
+	gcEnable, err := gcutil.CheckGCEnable(tk.Se)
+	c.Assert(err, IsNil)
+	c.Assert(gcEnable, IsTrue)
+
+	cancelState, cancelled, cancelErr = model.StateWriteReorganization, false, nil
+	tk.MustExec(fmt.Sprintf("flashback table t_flashback to timestamp '%s'", flashbackTS))
+	c.Assert(admin.ErrCannotCancelDDLJob.Equal(cancelErr), IsTrue, Commentf("err %v", cancelErr))
+	tk.MustQuery("select * from t_flashback").Check(testkit.Rows("1"))
+}
+
 func (s *testSerialSuite) TestRecoverTableByJobIDFail(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("create database if not exists test_recover")
@@ -1810,21 +2072,21 @@ func (s *testSerialDBSuite) TestLocalTemporaryTableBlockedDDL(c *C) {
 	tk.MustExec("use test")
 	tk.MustExec("create table t1 (id int)")
 	tk.MustExec("create temporary table tmp1 (id int primary key, a int unique, b int)")
-	err := tk.ExecToErr("rename table tmp1 to tmp2")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("alter table tmp1 add column c int")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("alter table tmp1 add index b(b)")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("create index a on tmp1(b)")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("drop index a on tmp1")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("lock tables tmp1 read")
-	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("lock tables tmp1 write")
+	tk.MustExec("rename table tmp1 to tmp2")
+	tk.MustExec("alter table tmp2 add column c int")
+	tk.MustExec("alter table tmp2 add index b(b)")
+	tk.MustExec("create index c on tmp2(c)")
+	tk.MustExec("drop index a on tmp2")
+	tk.MustExec("lock tables tmp2 read")
+	tk.MustExec("unlock tables")
+	tk.MustExec("lock tables tmp2 write")
+	tk.MustExec("unlock tables")
+	tk.MustExec("lock tables t1 read, tmp2 read")
+	tk.MustExec("unlock tables")
+	tk.MustExec("rename table tmp2 to tmp1")
+	err := tk.ExecToErr("rename table t1 to t2, tmp1 to tmp2")
 	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
-	err = tk.ExecToErr("lock tables t1 read, tmp1 read")
+	err = tk.ExecToErr("alter table tmp1 shard_row_id_bits = 4")
 	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
 	err = tk.ExecToErr("admin cleanup table lock tmp1")
 	c.Assert(ddl.ErrUnsupportedLocalTempTableDDL.Equal(err), IsTrue)
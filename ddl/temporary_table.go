@@ -0,0 +1,360 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+)
+
+// BuildAlteredLocalTemporaryTableInfo applies the specs of `ALTER TABLE` to a copy of the local temporary table's info.
+// Local temporary tables are only visible to the current session, so the specs are applied at once instead of running
+// DDL jobs, and the caller should rebuild the records of the table by the returned table info.
+func BuildAlteredLocalTemporaryTableInfo(ctx context.Context, sctx sessionctx.Context, schema *model.DBInfo, tbl table.Table,
+	specs []*ast.AlterTableSpec) (*model.TableInfo, error) {
+	validSpecs, err := resolveAlterTableSpec(sctx, specs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tblInfo := tbl.Meta().Clone()
+	ident := ast.Ident{Schema: schema.Name, Name: tblInfo.Name}
+	for _, spec := range validSpecs {
+		switch spec.Tp {
+		case ast.AlterTableAddColumns:
+			err = addLocalTemporaryTableColumns(sctx, schema, tblInfo, ident, spec)
+		case ast.AlterTableDropColumn:
+			err = dropLocalTemporaryTableColumn(sctx, tblInfo, spec)
+		case ast.AlterTableModifyColumn:
+			err = modifyLocalTemporaryTableColumn(ctx, sctx, schema, tblInfo, ident, spec.NewColumns[0].Name.Name, spec)
+		case ast.AlterTableChangeColumn:
+			err = modifyLocalTemporaryTableColumn(ctx, sctx, schema, tblInfo, ident, spec.OldColumnName.Name, spec)
+		case ast.AlterTableRenameColumn:
+			err = renameLocalTemporaryTableColumn(tblInfo, ident, spec)
+		case ast.AlterTableAlterColumn:
+			err = alterLocalTemporaryTableColumn(sctx, tblInfo, ident, spec)
+		case ast.AlterTableAddConstraint:
+			constr := spec.Constraint
+			switch constr.Tp {
+			case ast.ConstraintKey, ast.ConstraintIndex:
+				err = createLocalTemporaryTableIndex(sctx, tblInfo, ast.IndexKeyTypeNone, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
+				err = createLocalTemporaryTableIndex(sctx, tblInfo, ast.IndexKeyTypeUnique, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
+			case ast.ConstraintFulltext:
+				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+			case ast.ConstraintCheck:
+				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+			default:
+				err = ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+			}
+		case ast.AlterTableDropIndex:
+			err = dropLocalTemporaryTableIndex(sctx, tblInfo, model.NewCIStr(spec.Name), spec.IfExists)
+		case ast.AlterTableDropPrimaryKey:
+			err = dropLocalTemporaryTableIndex(sctx, tblInfo, model.NewCIStr(mysql.PrimaryKeyName), spec.IfExists)
+		case ast.AlterTableRenameIndex:
+			var skip bool
+			skip, err = validateRenameIndex(spec.FromKey, spec.ToKey, tblInfo)
+			if err == nil && !skip {
+				tblInfo.FindIndexByName(spec.FromKey.L).Name = spec.ToKey
+			}
+		case ast.AlterTableIndexInvisible:
+			invisible := spec.Visibility == ast.IndexVisibilityInvisible
+			var skip bool
+			skip, err = validateAlterIndexVisibility(spec.IndexName, invisible, tblInfo)
+			if err == nil && !skip {
+				tblInfo.FindIndexByName(spec.IndexName.L).Invisible = invisible
+				err = checkInvisibleIndexOnPK(tblInfo)
+			}
+		case ast.AlterTableRenameTable:
+			// Moving local temporary tables to another database is done by `RENAME TABLE`.
+			if spec.NewTable.Schema.L != "" && spec.NewTable.Schema.L != schema.Name.L {
+				err = ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE RENAME TO ANOTHER DATABASE")
+				break
+			}
+			err = checkTooLongTable(spec.NewTable.Name)
+			tblInfo.Name = spec.NewTable.Name
+		case ast.AlterTableOption:
+			for _, opt := range spec.Options {
+				switch opt.Tp {
+				case ast.TableOptionComment:
+					tblInfo.Comment = opt.StrValue
+				case ast.TableOptionEngine:
+				default:
+					err = ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+				}
+			}
+		case ast.AlterTableAlterCheck:
+			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+		case ast.AlterTableDropCheck:
+			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+		default:
+			err = ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+		}
+
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return tblInfo, nil
+}
+
+func addLocalTemporaryTableColumns(sctx sessionctx.Context, schema *model.DBInfo, tblInfo *model.TableInfo, ident ast.Ident,
+	spec *ast.AlterTableSpec) error {
+	for _, specNewColumn := range spec.NewColumns {
+		t, err := tables.TableFromMeta(nil, tblInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err = checkAddColumnTooManyColumns(len(t.Cols()) + 1); err != nil {
+			return errors.Trace(err)
+		}
+		col, err := checkAndCreateNewColumn(sctx, ident, schema, spec, t, specNewColumn)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Added column has existed and if_not_exists flag is true.
+		if col == nil {
+			continue
+		}
+		colInfo, _, offset, err := createColumnInfo(tblInfo, col.ColumnInfo, spec.Position)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		adjustColumnInfoInAddColumn(tblInfo, offset)
+		colInfo.State = model.StatePublic
+	}
+	return nil
+}
+
+func dropLocalTemporaryTableColumn(sctx sessionctx.Context, tblInfo *model.TableInfo, spec *ast.AlterTableSpec) error {
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	isDroppable, err := checkIsDroppableColumn(sctx, t, spec)
+	if err != nil || !isDroppable {
+		return errors.Trace(err)
+	}
+	if err = checkDropVisibleColumnCnt(t, 1); err != nil {
+		return errors.Trace(err)
+	}
+
+	colName := spec.OldColumnName.Name
+	idxInfos := listIndicesWithColumn(colName.L, tblInfo.Indices)
+	for _, idxInfo := range idxInfos {
+		if err = checkDropIndexOnAutoIncrementColumn(tblInfo, idxInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(idxInfos) > 0 {
+		newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+		for _, idx := range tblInfo.Indices {
+			if !indexInfoContains(idx.ID, idxInfos) {
+				newIndices = append(newIndices, idx)
+			}
+		}
+		tblInfo.Indices = newIndices
+	}
+	colInfo := model.FindColumnInfo(tblInfo.Columns, colName.L)
+	adjustColumnInfoInDropColumn(tblInfo, colInfo.Offset)
+	tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
+	return nil
+}
+
+func modifyLocalTemporaryTableColumn(ctx context.Context, sctx sessionctx.Context, schema *model.DBInfo, tblInfo *model.TableInfo,
+	ident ast.Ident, originalColName model.CIStr, spec *ast.AlterTableSpec) error {
+	specNewColumn := spec.NewColumns[0]
+	if len(specNewColumn.Name.Schema.O) != 0 && ident.Schema.L != specNewColumn.Name.Schema.L {
+		return ErrWrongDBName.GenWithStackByArgs(specNewColumn.Name.Schema.O)
+	}
+	if len(specNewColumn.Name.Table.O) != 0 && ident.Name.L != specNewColumn.Name.Table.L {
+		return ErrWrongTableName.GenWithStackByArgs(specNewColumn.Name.Table.O)
+	}
+
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newCol, _, _, err := buildModifiedColumn(ctx, sctx, schema, t, ident, originalColName, spec)
+	if err != nil {
+		if infoschema.ErrColumnNotExists.Equal(err) && spec.IfExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	oldCol := model.FindColumnInfo(tblInfo.Columns, originalColName.L)
+	needChangeData := needChangeColumnData(oldCol, newCol.ColumnInfo)
+	if needChangeData && mysql.HasPriKeyFlag(oldCol.Flag) {
+		return errUnsupportedModifyColumn.GenWithStackByArgs("this column has primary key flag")
+	}
+	pos := spec.Position
+	if pos == nil {
+		pos = &ast.ColumnPosition{Tp: ast.ColumnPositionNone}
+	}
+	// The job is only used to be marked as rolling back when meeting errors, which isn't needed here.
+	if err = adjustColumnInfoInModifyColumn(&model.Job{}, tblInfo, newCol.ColumnInfo, oldCol, pos, ""); err != nil {
+		return errors.Trace(err)
+	}
+	if !needChangeData {
+		return nil
+	}
+	// The index columns are rebuilt by the new column type, drop the prefix length if it's no longer applicable.
+	canPrefix := types.IsTypePrefixable(newCol.Tp)
+	for _, idx := range tblInfo.Indices {
+		for _, idxCol := range idx.Columns {
+			if idxCol.Name.L == newCol.Name.L && (!canPrefix || newCol.Flen < idxCol.Length) {
+				idxCol.Length = types.UnspecifiedLength
+			}
+		}
+	}
+	return nil
+}
+
+func renameLocalTemporaryTableColumn(tblInfo *model.TableInfo, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	oldColName := spec.OldColumnName.Name
+	newColName := spec.NewColumnName.Name
+	if oldColName.L == newColName.L {
+		return nil
+	}
+	if newColName.L == model.ExtraHandleName.L {
+		return ErrWrongColumnName.GenWithStackByArgs(newColName.L)
+	}
+
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newCol, err := buildRenamedColumn(t, ident, oldColName, newColName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oldCol := model.FindColumnInfo(tblInfo.Columns, oldColName.L)
+	return errors.Trace(adjustColumnInfoInModifyColumn(&model.Job{}, tblInfo, newCol, oldCol, &ast.ColumnPosition{Tp: ast.ColumnPositionNone}, ""))
+}
+
+func alterLocalTemporaryTableColumn(sctx sessionctx.Context, tblInfo *model.TableInfo, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newCol, err := buildColumnWithNewDefault(sctx, t, ident, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oldCol := model.FindColumnInfo(tblInfo.Columns, newCol.Name.L)
+	oldCol.DefaultValue = newCol.DefaultValue
+	oldCol.DefaultValueBit = newCol.DefaultValueBit
+	oldCol.Flag = newCol.Flag
+	return nil
+}
+
+func createLocalTemporaryTableIndex(sctx sessionctx.Context, tblInfo *model.TableInfo, keyType ast.IndexKeyType, indexName model.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	indexName, hiddenCols, _, err := checkCreateIndex(sctx, t, keyType, indexName, indexPartSpecifications, indexOption)
+	if err != nil {
+		if ErrDupKeyName.Equal(err) && ifNotExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	for _, hiddenCol := range hiddenCols {
+		colInfo, _, _, err := createColumnInfo(tblInfo, hiddenCol, &ast.ColumnPosition{Tp: ast.ColumnPositionNone})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		colInfo.State = model.StatePublic
+	}
+	indexInfo, err := buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StatePublic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Use btree as default index type.
+	indexInfo.Tp = model.IndexTypeBtree
+	if indexOption != nil {
+		indexInfo.Comment = indexOption.Comment
+		indexInfo.Invisible = indexOption.Visibility == ast.IndexVisibilityInvisible
+		if indexOption.Tp != model.IndexTypeInvalid {
+			indexInfo.Tp = indexOption.Tp
+		}
+	}
+	indexInfo.Unique = keyType == ast.IndexKeyTypeUnique
+	indexInfo.ID = allocateIndexID(tblInfo)
+	tblInfo.Indices = append(tblInfo.Indices, indexInfo)
+	if err = checkTooManyIndexes(tblInfo.Indices); err != nil {
+		return errors.Trace(err)
+	}
+	addIndexColumnFlag(tblInfo, indexInfo)
+	return nil
+}
+
+func dropLocalTemporaryTableIndex(sctx sessionctx.Context, tblInfo *model.TableInfo, indexName model.CIStr, ifExists bool) error {
+	t, err := tables.TableFromMeta(nil, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	indexInfo := tblInfo.FindIndexByName(indexName.L)
+	if _, err = checkIsDropPrimaryKey(indexName, indexInfo, t); err != nil {
+		return err
+	}
+	if indexInfo == nil {
+		err = ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", indexName)
+		if ifExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if err = checkDropIndexOnAutoIncrementColumn(tblInfo, indexInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	dependentHiddenCols := 0
+	for _, indexColumn := range indexInfo.Columns {
+		if tblInfo.Columns[indexColumn.Offset].Hidden {
+			// Set this column's offset to the last and reset all following columns' offsets.
+			adjustColumnInfoInDropColumn(tblInfo, indexColumn.Offset)
+			dependentHiddenCols++
+		}
+	}
+	newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		if idx.ID != indexInfo.ID {
+			newIndices = append(newIndices, idx)
+		}
+	}
+	tblInfo.Indices = newIndices
+	dropIndexColumnFlag(tblInfo, indexInfo)
+	tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-dependentHiddenCols]
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// buildTTLInfo builds the TTL of the TTL and TTL_ENABLE table options. ttlInfo is nil if there is no TTL
+// option, it is enabled unless TTL_ENABLE = 'OFF' is set. ttlEnable is nil if there is no TTL_ENABLE option.
+func buildTTLInfo(ctx sessionctx.Context, options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, err error) {
+	for _, op := range options {
+		switch op.Tp {
+		case ast.TableOptionTTL:
+			switch op.TimeUnitValue.Unit {
+			case ast.TimeUnitMicrosecond, ast.TimeUnitSecond, ast.TimeUnitMinute, ast.TimeUnitHour, ast.TimeUnitDay,
+				ast.TimeUnitWeek, ast.TimeUnitMonth, ast.TimeUnitQuarter, ast.TimeUnitYear:
+			default:
+				return nil, nil, errors.Trace(errUnsupportedTTL.GenWithStackByArgs("the unit of the interval can't be " + op.TimeUnitValue.Unit.String()))
+			}
+			val, err := expression.EvalAstExpr(ctx, op.Value)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if (val.Kind() != types.KindInt64 || val.GetInt64() <= 0) && (val.Kind() != types.KindUint64 || val.GetUint64() == 0 || val.GetUint64() > math.MaxInt64) {
+				return nil, nil, errors.Trace(errUnsupportedTTL.GenWithStackByArgs("the interval must be a positive integer"))
+			}
+			ttlInfo = &model.TTLInfo{
+				ColumnName:       op.ColumnName.Name,
+				IntervalExprStr:  strconv.FormatInt(val.GetInt64(), 10),
+				IntervalTimeUnit: int(op.TimeUnitValue.Unit),
+				Enable:           true,
+			}
+		case ast.TableOptionTTLEnable:
+			enable := op.BoolValue
+			ttlEnable = &enable
+		}
+	}
+	if ttlInfo != nil && ttlEnable != nil {
+		ttlInfo.Enable = *ttlEnable
+	}
+	return ttlInfo, ttlEnable, nil
+}
+
+// checkTTLInfoValid checks whether the table can have its TTL.
+func checkTTLInfoValid(tblInfo *model.TableInfo) error {
+	ttlInfo := tblInfo.TTLInfo
+	if ttlInfo == nil {
+		return nil
+	}
+	if tblInfo.TempTableType != model.TempTableNone {
+		return errors.Trace(ErrOptOnTemporaryTable.GenWithStackByArgs("TTL"))
+	}
+	col := findColumnByName(ttlInfo.ColumnName.L, tblInfo)
+	if col == nil {
+		return errors.Trace(infoschema.ErrColumnNotExists.GenWithStackByArgs(ttlInfo.ColumnName, tblInfo.Name))
+	}
+	if !isTTLColumnType(col.Tp) {
+		return errors.Trace(errUnsupportedTTL.GenWithStackByArgs("the TTL column must be a DATE, DATETIME or TIMESTAMP column"))
+	}
+	return nil
+}
+
+func isTTLColumnType(tp byte) bool {
+	return tp == mysql.TypeDate || tp == mysql.TypeDatetime || tp == mysql.TypeTimestamp
+}
+
+// checkTTLColumnModifiable checks the TTL column isn't renamed or changed to a type that doesn't hold a time.
+func checkTTLColumnModifiable(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) error {
+	if tblInfo.TTLInfo == nil || tblInfo.TTLInfo.ColumnName.L != oldCol.Name.L {
+		return nil
+	}
+	if newCol.Name.L != oldCol.Name.L {
+		return errors.Trace(errUnsupportedTTL.GenWithStackByArgs("the TTL column can't be renamed"))
+	}
+	if !isTTLColumnType(newCol.Tp) {
+		return errors.Trace(errUnsupportedTTL.GenWithStackByArgs("the TTL column must be a DATE, DATETIME or TIMESTAMP column"))
+	}
+	return nil
+}
+
+// AlterTableTTLInfoOrEnable sets the TTL of the table, or turns the TTL jobs of the table on or off.
+func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Check the TTL before the job, the job checks it again with the latest table info.
+	tblInfo := tb.Meta().Clone()
+	if err = setTTLInfoOrEnable(tblInfo, ttlInfo, ttlEnable); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkTTLInfoValid(tblInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterTTLInfo,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{ttlInfo, ttlEnable},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterTableRemoveTTL removes the TTL of the table.
+func (d *ddl) AlterTableRemoveTTL(ctx sessionctx.Context, ident ast.Ident) error {
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tb.Meta().TTLInfo == nil {
+		return nil
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterTTLRemove,
+		BinlogInfo: &model.HistoryInfo{},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// setTTLInfoOrEnable replaces the TTL of the table with ttlInfo if it isn't nil, and keeps the table's
+// TTL_ENABLE if ttlEnable is nil. Otherwise, it only sets TTL_ENABLE of the table's TTL.
+func setTTLInfoOrEnable(tblInfo *model.TableInfo, ttlInfo *model.TTLInfo, ttlEnable *bool) error {
+	if ttlInfo != nil {
+		ttlInfo = ttlInfo.Clone()
+		if ttlEnable == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Enable = tblInfo.TTLInfo.Enable
+		}
+		tblInfo.TTLInfo = ttlInfo
+		return nil
+	}
+	if tblInfo.TTLInfo == nil {
+		return errors.Trace(errUnsupportedTTL.GenWithStackByArgs("TTL_ENABLE can't be set on a table without TTL"))
+	}
+	tblInfo.TTLInfo.Enable = *ttlEnable
+	return nil
+}
+
+func onTTLInfoChange(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var ttlInfo *model.TTLInfo
+	var ttlEnable *bool
+	if err = job.DecodeArgs(&ttlInfo, &ttlEnable); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if ttlInfo == nil && ttlEnable == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(errUnsupportedTTL.GenWithStackByArgs("neither TTL nor TTL_ENABLE is set"))
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = setTTLInfoOrEnable(tblInfo, ttlInfo, ttlEnable); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if err = checkTTLInfoValid(tblInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onTTLInfoRemove(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo.TTLInfo = nil
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
@@ -49,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics/handle"
 	"github.com/pingcap/tidb/telemetry"
+	"github.com/pingcap/tidb/ttl"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/domainutil"
@@ -156,7 +157,7 @@ func (do *Domain) loadInfoSchema(startTS uint64) (infoschema.InfoSchema, bool, i
 		return nil, false, currentSchemaVersion, nil, err
 	}
 
-	newISBuilder, err := infoschema.NewBuilder(do.Store()).InitWithDBInfos(schemas, bundles, policies, neededSchemaVersion)
+	newISBuilder, err := infoschema.NewBuilder(do.Store(), do.sysSessionPool).InitWithDBInfos(schemas, bundles, policies, neededSchemaVersion)
 	if err != nil {
 		return nil, false, currentSchemaVersion, nil, err
 	}
@@ -268,7 +269,7 @@ func (do *Domain) tryLoadSchemaDiffs(m *meta.Meta, usedVersion, newVersion int64
 		}
 		diffs = append(diffs, diff)
 	}
-	builder := infoschema.NewBuilder(do.Store()).InitWithOldInfoSchema(do.infoCache.GetLatest())
+	builder := infoschema.NewBuilder(do.Store(), do.sysSessionPool).InitWithOldInfoSchema(do.infoCache.GetLatest())
 	phyTblIDs := make([]int64, 0, len(diffs))
 	actions := make([]uint64, 0, len(diffs))
 	for _, diff := range diffs {
@@ -1044,6 +1045,15 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 				if variable.TiDBOptOn(variable.CapturePlanBaseline.GetVal()) {
 					do.bindHandle.CaptureBaselines()
 				}
+				if variable.EnablePlanRegressionGuard.Load() {
+					do.bindHandle.CapturePlanHistory()
+					if owner.IsOwner() {
+						err = do.bindHandle.GuardPlanRegressions()
+						if err != nil {
+							logutil.BgLogger().Error("guard plan regressions failed", zap.Error(err))
+						}
+					}
+				}
 				do.bindHandle.SaveEvolveTasksToStore()
 			case <-gcBindTicker.C:
 				if !owner.IsOwner() {
@@ -1053,6 +1063,10 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 				if err != nil {
 					logutil.BgLogger().Error("GC bind record failed", zap.Error(err))
 				}
+				err = do.bindHandle.GCPlanHistory()
+				if err != nil {
+					logutil.BgLogger().Error("GC plan history failed", zap.Error(err))
+				}
 			}
 		}
 	}()
@@ -1119,6 +1133,42 @@ func (do *Domain) TelemetryReportLoop(ctx sessionctx.Context) {
 	}()
 }
 
+// TTLJobManagerLoop runs the jobs which delete the expired rows of TTL tables on the DDL owner.
+func (do *Domain) TTLJobManagerLoop(ctx sessionctx.Context) {
+	ctx.GetSessionVars().InRestrictedSQL = true
+	manager := ttl.NewJobManager(ctx, do.InfoSchema, do.ddl.OwnerManager())
+	do.wg.Add(1)
+	go func() {
+		jobCtx, cancel := context.WithCancel(context.Background())
+		defer func() {
+			cancel()
+			do.wg.Done()
+			logutil.BgLogger().Info("TTLJobManagerLoop exited.")
+			util.Recover(metrics.LabelDomain, "TTLJobManagerLoop", nil, false)
+		}()
+		go func() {
+			select {
+			case <-do.exit:
+				cancel()
+			case <-jobCtx.Done():
+			}
+		}()
+		ticker := time.NewTicker(ttl.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-ticker.C:
+				err := manager.CheckAndRunJobs(jobCtx)
+				if err != nil {
+					logutil.BgLogger().Warn("TTLJobManagerLoop check and run jobs failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
 // TelemetryRotateSubWindowLoop create a goroutine that rotates the telemetry window regularly.
 func (do *Domain) TelemetryRotateSubWindowLoop(ctx sessionctx.Context) {
 	ctx.GetSessionVars().InRestrictedSQL = true
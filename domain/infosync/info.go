@@ -15,7 +15,6 @@
 package infosync
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -97,6 +96,7 @@ type InfoSyncer struct {
 	prometheusAddr   string
 	modifyTime       time.Time
 	labelRuleManager LabelRuleManager
+	placementManager PlacementManager
 }
 
 // ServerInfo is server static information.
@@ -177,6 +177,7 @@ func GlobalInfoSyncerInit(ctx context.Context, id string, serverIDGetter func()
 	}
 	if etcdCli != nil {
 		is.labelRuleManager = initLabelRuleManager(etcdCli.Endpoints())
+		is.placementManager = &PDPlacementManager{etcdCli: etcdCli}
 	} else {
 		is.labelRuleManager = initLabelRuleManager([]string{})
 	}
@@ -365,23 +366,11 @@ func GetAllRuleBundles(ctx context.Context) ([]*placement.Bundle, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	bundles := []*placement.Bundle{}
-	if is.etcdCli == nil {
-		return bundles, nil
-	}
-
-	addrs := is.etcdCli.Endpoints()
-
-	if len(addrs) == 0 {
-		return nil, errors.Errorf("pd unavailable")
-	}
-
-	res, err := doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule"), "GET", nil)
-	if err == nil && res != nil {
-		err = json.Unmarshal(res, &bundles)
+	manager := is.getPlacementManager()
+	if manager == nil {
+		return []*placement.Bundle{}, nil
 	}
-	return bundles, err
+	return manager.GetAllRuleBundles(ctx)
 }
 
 // GetRuleBundle is used to get one specific rule bundle from PD.
@@ -390,24 +379,11 @@ func GetRuleBundle(ctx context.Context, name string) (*placement.Bundle, error)
 	if err != nil {
 		return nil, err
 	}
-
-	bundle := &placement.Bundle{ID: name}
-
-	if is.etcdCli == nil {
-		return bundle, nil
-	}
-
-	addrs := is.etcdCli.Endpoints()
-
-	if len(addrs) == 0 {
-		return nil, errors.Errorf("pd unavailable")
+	manager := is.getPlacementManager()
+	if manager == nil {
+		return &placement.Bundle{ID: name}, nil
 	}
-
-	res, err := doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule", name), "GET", nil)
-	if err == nil && res != nil {
-		err = json.Unmarshal(res, bundle)
-	}
-	return bundle, err
+	return manager.GetRuleBundle(ctx, name)
 }
 
 // PutRuleBundles is used to post specific rule bundles to PD.
@@ -420,24 +396,11 @@ func PutRuleBundles(ctx context.Context, bundles []*placement.Bundle) error {
 	if err != nil {
 		return err
 	}
-
-	if is.etcdCli == nil {
+	manager := is.getPlacementManager()
+	if manager == nil {
 		return nil
 	}
-
-	addrs := is.etcdCli.Endpoints()
-
-	if len(addrs) == 0 {
-		return errors.Errorf("pd unavailable")
-	}
-
-	b, err := json.Marshal(bundles)
-	if err != nil {
-		return err
-	}
-
-	_, err = doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule")+"?partial=true", "POST", bytes.NewReader(b))
-	return err
+	return manager.PutRuleBundles(ctx, bundles)
 }
 
 func (is *InfoSyncer) getAllServerInfo(ctx context.Context) (map[string]*ServerInfo, error) {
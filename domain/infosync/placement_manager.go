@@ -0,0 +1,138 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package infosync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/ddl/placement"
+	"github.com/pingcap/tidb/util/pdapi"
+	"go.etcd.io/etcd/clientv3"
+)
+
+// PlacementManager manages placement rule bundles
+type PlacementManager interface {
+	GetAllRuleBundles(ctx context.Context) ([]*placement.Bundle, error)
+	GetRuleBundle(ctx context.Context, name string) (*placement.Bundle, error)
+	PutRuleBundles(ctx context.Context, bundles []*placement.Bundle) error
+}
+
+// PDPlacementManager manages placement rule bundles with pd
+type PDPlacementManager struct {
+	etcdCli *clientv3.Client
+}
+
+// GetAllRuleBundles implements GetAllRuleBundles
+func (m *PDPlacementManager) GetAllRuleBundles(ctx context.Context) ([]*placement.Bundle, error) {
+	addrs := m.etcdCli.Endpoints()
+	if len(addrs) == 0 {
+		return nil, errors.Errorf("pd unavailable")
+	}
+	bundles := []*placement.Bundle{}
+	res, err := doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule"), "GET", nil)
+	if err == nil && res != nil {
+		err = json.Unmarshal(res, &bundles)
+	}
+	return bundles, err
+}
+
+// GetRuleBundle implements GetRuleBundle
+func (m *PDPlacementManager) GetRuleBundle(ctx context.Context, name string) (*placement.Bundle, error) {
+	addrs := m.etcdCli.Endpoints()
+	if len(addrs) == 0 {
+		return nil, errors.Errorf("pd unavailable")
+	}
+	bundle := &placement.Bundle{ID: name}
+	res, err := doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule", name), "GET", nil)
+	if err == nil && res != nil {
+		err = json.Unmarshal(res, bundle)
+	}
+	return bundle, err
+}
+
+// PutRuleBundles implements PutRuleBundles
+func (m *PDPlacementManager) PutRuleBundles(ctx context.Context, bundles []*placement.Bundle) error {
+	addrs := m.etcdCli.Endpoints()
+	if len(addrs) == 0 {
+		return errors.Errorf("pd unavailable")
+	}
+
+	b, err := json.Marshal(bundles)
+	if err != nil {
+		return err
+	}
+
+	_, err = doRequest(ctx, addrs, path.Join(pdapi.Config, "placement-rule")+"?partial=true", "POST", bytes.NewReader(b))
+	return err
+}
+
+// mockPlacement keeps the placement rule bundles in memory for the tests which check the bundles put to PD,
+// it's used instead of PD when the failpoint `mockPlacementManager` is enabled.
+var mockPlacement = &mockPlacementManager{bundles: map[string]*placement.Bundle{}}
+
+func (is *InfoSyncer) getPlacementManager() PlacementManager {
+	failpoint.Inject("mockPlacementManager", func(val failpoint.Value) {
+		if val.(bool) {
+			failpoint.Return(mockPlacement)
+		}
+	})
+	return is.placementManager
+}
+
+type mockPlacementManager struct {
+	sync.RWMutex
+	bundles map[string]*placement.Bundle
+}
+
+// GetAllRuleBundles implements GetAllRuleBundles
+func (m *mockPlacementManager) GetAllRuleBundles(ctx context.Context) ([]*placement.Bundle, error) {
+	m.RLock()
+	defer m.RUnlock()
+	bundles := make([]*placement.Bundle, 0, len(m.bundles))
+	for _, bundle := range m.bundles {
+		bundles = append(bundles, bundle.Clone())
+	}
+	return bundles, nil
+}
+
+// GetRuleBundle implements GetRuleBundle
+func (m *mockPlacementManager) GetRuleBundle(ctx context.Context, name string) (*placement.Bundle, error) {
+	m.RLock()
+	defer m.RUnlock()
+	if bundle, ok := m.bundles[name]; ok {
+		return bundle.Clone(), nil
+	}
+	return &placement.Bundle{ID: name}, nil
+}
+
+// PutRuleBundles implements PutRuleBundles, an empty bundle deletes the rules of the group like PD does.
+func (m *mockPlacementManager) PutRuleBundles(ctx context.Context, bundles []*placement.Bundle) error {
+	m.Lock()
+	defer m.Unlock()
+	for _, bundle := range bundles {
+		if bundle.IsEmpty() {
+			delete(m.bundles, bundle.ID)
+		} else {
+			m.bundles[bundle.ID] = bundle.Clone()
+		}
+	}
+	return nil
+}
@@ -210,6 +210,24 @@ func checkEnableServerGlobalVar(name, sVal string) {
 		variable.TopSQLVariable.ReportIntervalSeconds.Store(val)
 	case variable.TiDBRestrictedReadOnly:
 		variable.RestrictedReadOnly.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBEnablePlanRegressionGuard:
+		variable.EnablePlanRegressionGuard.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBDDLEnableFastReorg:
+		variable.EnableFastReorg.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBTableCacheLease:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TableCacheLease.Store(val)
+	case variable.TiDBTableCacheMaxSize:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.TableCacheMaxSize.Store(val)
 	case variable.TiDBStoreLimit:
 		var val int64
 		val, err = strconv.ParseInt(sVal, 10, 64)
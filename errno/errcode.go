@@ -1052,6 +1052,10 @@ const (
 	ErrPlacementPolicyNotExists           = 8239
 	ErrPlacementPolicyWithDirectOption    = 8240
 	ErrPlacementPolicyInUse               = 8241
+	ErrCannotPauseDDLJob                  = 8242
+	ErrDDLJobNotPaused                    = 8243
+	ErrReorgPaused                        = 8244
+	ErrOptOnCacheTable                    = 8245
 
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
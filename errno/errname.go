@@ -1059,6 +1059,10 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPlacementPolicyNotExists:        mysql.Message("Unknown placement policy '%-.192s'", nil),
 	ErrPlacementPolicyWithDirectOption: mysql.Message("Placement policy '%s' can't co-exist with direct placement options", nil),
 	ErrPlacementPolicyInUse:            mysql.Message("Placement policy '%-.192s' is still in use", nil),
+	ErrCannotPauseDDLJob:               mysql.Message("This job:%v can't be paused or altered, only the running reorganization jobs are supported", nil),
+	ErrDDLJobNotPaused:                 mysql.Message("This job:%v is not paused", nil),
+	ErrReorgPaused:                     mysql.Message("The reorganization of the DDL job is paused", nil),
+	ErrOptOnCacheTable:                 mysql.Message("'%s' is unsupported on cache tables.", nil),
 
 	// TiKV/PD errors.
 	ErrPDServerTimeout:           mysql.Message("PD server timeout", nil),
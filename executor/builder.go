@@ -172,6 +172,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildSelectLock(v)
 	case *plannercore.CancelDDLJobs:
 		return b.buildCancelDDLJobs(v)
+	case *plannercore.ControlDDLJobs:
+		return b.buildControlDDLJobs(v)
 	case *plannercore.ShowNextRowID:
 		return b.buildShowNextRowID(v)
 	case *plannercore.ShowDDL:
@@ -288,6 +290,31 @@ func (b *executorBuilder) buildCancelDDLJobs(v *plannercore.CancelDDLJobs) Execu
 	return e
 }
 
+func (b *executorBuilder) buildControlDDLJobs(v *plannercore.ControlDDLJobs) Executor {
+	e := &CancelDDLJobsExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		jobIDs:       v.JobIDs,
+	}
+	txn, err := e.ctx.Txn(true)
+	if err != nil {
+		b.err = err
+		return nil
+	}
+
+	switch v.Tp {
+	case ast.AdminPauseDDLJobs:
+		e.errs, b.err = admin.PauseJobs(txn, e.jobIDs)
+	case ast.AdminResumeDDLJobs:
+		e.errs, b.err = admin.ResumeJobs(txn, e.jobIDs)
+	case ast.AdminAlterDDLJob:
+		e.errs, b.err = admin.AlterJobs(txn, e.jobIDs, v.Thread, v.BatchSize)
+	}
+	if b.err != nil {
+		return nil
+	}
+	return e
+}
+
 func (b *executorBuilder) buildChange(v *plannercore.Change) Executor {
 	return &ChangeExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -1047,6 +1074,8 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.columns = x.columns
 		us.table = x.table
 		us.virtualColumnIndex = x.virtualColumnIndex
+		us.cacheTable = b.getCacheTable(x.table.Meta())
+		x.dummy = us.cacheTable != nil
 	case *IndexReaderExecutor:
 		us.desc = x.desc
 		for _, ic := range x.index.Columns {
@@ -1060,6 +1089,8 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.conditions, us.conditionsWithVirCol = plannercore.SplitSelCondsWithVirtualColumn(v.Conditions)
 		us.columns = x.columns
 		us.table = x.table
+		us.cacheTable = b.getCacheTable(x.table.Meta())
+		x.dummy = us.cacheTable != nil
 	case *IndexLookUpExecutor:
 		us.desc = x.desc
 		for _, ic := range x.index.Columns {
@@ -1074,6 +1105,14 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.columns = x.columns
 		us.table = x.table
 		us.virtualColumnIndex = buildVirtualColumnIndex(us.Schema(), us.columns)
+		us.cacheTable = b.getCacheTable(x.table.Meta())
+		x.dummy = us.cacheTable != nil
+	case *IndexMergeReaderExecutor:
+		// IndexMergeReader doesn't care order for now. So we will not set desc and useIndex.
+		us.conditions, us.conditionsWithVirCol = plannercore.SplitSelCondsWithVirtualColumn(v.Conditions)
+		us.columns = x.columns
+		us.table = x.table
+		us.virtualColumnIndex = buildVirtualColumnIndex(us.Schema(), us.columns)
 	default:
 		// The mem table will not be written by sql directly, so we can omit the union scan to avoid err reporting.
 		return originReader
@@ -1081,6 +1120,32 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 	return us
 }
 
+// getCacheTable returns the data of the cached table if it can be read at the snapshot TS, or nil.
+// The statements which lock or write the table read it from TiKV.
+func (b *executorBuilder) getCacheTable(tblInfo *model.TableInfo) kv.MemBuffer {
+	if tblInfo == nil || tblInfo.TableCacheStatusType != model.TableCacheStatusEnable {
+		return nil
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	if b.hasLock || sc.InInsertStmt || sc.InUpdateStmt || sc.InDeleteStmt {
+		return nil
+	}
+	tbl, ok := b.is.TableByID(tblInfo.ID)
+	if !ok {
+		return nil
+	}
+	cachedTable, ok := tbl.(table.CachedTable)
+	if !ok {
+		return nil
+	}
+	ts, err := b.getSnapshotTS()
+	if err != nil {
+		// The reader reports the error.
+		return nil
+	}
+	return cachedTable.TryReadFromCache(ts)
+}
+
 // buildMergeJoin builds MergeJoinExec executor.
 func (b *executorBuilder) buildMergeJoin(v *plannercore.PhysicalMergeJoin) Executor {
 	leftExec := b.build(v.Children()[0])
@@ -1124,11 +1189,13 @@ func (b *executorBuilder) buildMergeJoin(v *plannercore.PhysicalMergeJoin) Execu
 		childIndex: 0,
 		joinKeys:   v.LeftJoinKeys,
 		filters:    v.LeftConditions,
+		isNullEQ:   v.IsNullEQ,
 	}
 	rightTable := &mergeJoinTable{
 		childIndex: 1,
 		joinKeys:   v.RightJoinKeys,
 		filters:    v.RightConditions,
+		isNullEQ:   v.IsNullEQ,
 	}
 
 	if v.JoinType == plannercore.RightOuterJoin {
@@ -1606,6 +1673,18 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 					columns: v.Columns,
 				},
 			}
+		case strings.ToLower(infoschema.TableTiDBCostCalibration):
+			return &MemTableReaderExec{
+				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+				table:        v.Table,
+				retriever:    &costCalibrationRetriever{},
+			}
+		case strings.ToLower(infoschema.TableTiDBTTLJobHistory):
+			return &MemTableReaderExec{
+				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+				table:        v.Table,
+				retriever:    &ttlJobHistoryRetriever{},
+			}
 		case strings.ToLower(infoschema.TableTiDBTrx),
 			strings.ToLower(infoschema.ClusterTableTiDBTrx):
 			return &MemTableReaderExec{
@@ -1846,6 +1925,12 @@ func (b *executorBuilder) buildUnionAll(v *plannercore.PhysicalUnionAll) Executo
 			return nil
 		}
 	}
+	if len(v.ByItems) > 0 {
+		return &MergeUnionExec{
+			baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), childExecs...),
+			ByItems:      v.ByItems,
+		}
+	}
 	e := &UnionExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID(), childExecs...),
 		concurrency:  b.ctx.GetSessionVars().UnionConcurrency(),
@@ -2648,6 +2733,7 @@ func (b *executorBuilder) buildIndexLookUpJoin(v *plannercore.PhysicalIndexJoin)
 		outerCtx: outerCtx{
 			rowTypes: outerTypes,
 			filter:   outerFilter,
+			isNullEQ: v.IsNullEQ,
 		},
 		innerCtx: innerCtx{
 			readerBuilder: &dataReaderBuilder{Plan: innerPlan, executorBuilder: b},
@@ -2751,6 +2837,7 @@ func (b *executorBuilder) buildIndexLookUpMergeJoin(v *plannercore.PhysicalIndex
 			keyCols:       outerKeyCols,
 			needOuterSort: v.NeedOuterSort,
 			compareFuncs:  v.OuterCompareFuncs,
+			isNullEQ:      v.IsNullEQ,
 		},
 		innerMergeCtx: innerMergeCtx{
 			readerBuilder:           &dataReaderBuilder{Plan: innerPlan, executorBuilder: b},
@@ -3412,6 +3499,7 @@ func buildNoRangeIndexMergeReader(b *executorBuilder, v *plannercore.PhysicalInd
 		columns:           ts.Columns,
 		partialStreamings: partialStreamings,
 		tableStreaming:    tableStreaming,
+		isIntersection:    v.IsIntersectionType,
 		partialPlans:      v.PartialPlans,
 		tblPlans:          v.TablePlans,
 		dataReaderBuilder: &dataReaderBuilder{executorBuilder: b},
@@ -4268,6 +4356,8 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 
 	if e.lock {
 		b.hasLock = true
+	} else {
+		e.cacheTable = b.getCacheTable(plan.TblInfo)
 	}
 	var capacity int
 	if plan.IndexInfo != nil && !isCommonHandleRead(plan.TblInfo, plan.IndexInfo) {
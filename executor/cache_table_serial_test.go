@@ -0,0 +1,91 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func waitCacheTableLoaded(t *testing.T, store kv.Storage, dom *domain.Domain, name string) {
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr(name))
+	require.NoError(t, err)
+	cachedTable, ok := tbl.(table.CachedTable)
+	require.True(t, ok)
+	require.Eventually(t, func() bool {
+		ver, err := store.CurrentVersion(kv.GlobalTxnScope)
+		require.NoError(t, err)
+		return cachedTable.TryReadFromCache(ver.Ver) != nil
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestCacheTableRead(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set global tidb_table_cache_lease = 5")
+	defer tk.MustExec("set global tidb_table_cache_lease = default")
+	tk.MustExec("create table t (id int primary key, a int, b int, index(a))")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2)")
+	tk.MustExec("alter table t cache")
+	waitCacheTableLoaded(t, store, dom, "t")
+
+	// The cached table doesn't send requests to TiKV, all the requests are discarded with the failpoint.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/store/mockstore/unistore/rpcServerBusy", "return(true)"))
+	require.True(t, tk.HasPlan("select * from t where id=1", "Point_Get"))
+	tk.MustQuery("select * from t where id=1").Check(testkit.Rows("1 1 1"))
+	tk.MustQuery("select * from t where id=3").Check(testkit.Rows())
+	require.True(t, tk.HasPlan("select * from t where id in (1, 2, 3)", "Batch_Point_Get"))
+	tk.MustQuery("select * from t where id in (1, 2, 3)").Check(testkit.Rows("1 1 1", "2 2 2"))
+	require.True(t, tk.HasPlan("select * from t", "TableReader"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 1", "2 2 2"))
+	require.True(t, tk.HasPlan("select /*+ USE_INDEX(t, a) */ a from t", "IndexReader"))
+	tk.MustQuery("select /*+ USE_INDEX(t, a) */ a from t").Check(testkit.Rows("1", "2"))
+	require.True(t, tk.HasPlan("select /*+ USE_INDEX(t, a) */ b from t where a = 1", "IndexLookUp"))
+	tk.MustQuery("select /*+ USE_INDEX(t, a) */ b from t where a = 1").Check(testkit.Rows("1"))
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/store/mockstore/unistore/rpcServerBusy"))
+
+	// The writes of the transaction are merged with the cache, and they're visible after the commit.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (3, 3, 3)")
+	tk.MustExec("update t set b = 10 where id = 1")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 10", "3 3 3"))
+	tk.MustQuery("select /*+ USE_INDEX(t, a) */ b from t where a > 0").Check(testkit.Rows("10", "3"))
+	tk.MustQuery("select * from t where id in (1, 2, 3)").Check(testkit.Rows("1 1 10", "3 3 3"))
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 10", "3 3 3"))
+	tk.MustQuery("select lock_type from mysql.table_cache_meta").Check(testkit.Rows("WRITE"))
+
+	// The cache is loaded again after the write lease expires.
+	waitCacheTableLoaded(t, store, dom, "t")
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/store/mockstore/unistore/rpcServerBusy", "return(true)"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 10", "3 3 3"))
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/store/mockstore/unistore/rpcServerBusy"))
+
+	tk.MustExec("alter table t nocache")
+	tk.MustExec("insert into t values (4, 4, 4)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1 10", "3 3 3", "4 4 4"))
+}
@@ -0,0 +1,278 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+const (
+	// costCalibrationTablePrefix is the prefix of the scratch table used by the micro-benchmarks,
+	// every run creates its own table and drops it afterwards.
+	costCalibrationTablePrefix = "tidb_cost_calibration_"
+	costCalibrationRows        = 4096
+	costCalibrationSeeks       = 256
+	costCalibrationPad         = 128
+	// costCalibrationRepeats is the number of times each benchmark runs,
+	// the fastest run is used to reduce the noise.
+	costCalibrationRepeats = 3
+	costCalibrationBatch   = 512
+)
+
+// costCalibrationRetriever reads the cost factors recommended by the last
+// ADMIN CALIBRATE COST from mysql.cost_calibration. The factors of unreliable
+// measurements are stored with 0 nanoseconds and shown as NULL.
+type costCalibrationRetriever struct {
+	dummyCloser
+	retrieved bool
+}
+
+// costCalibration is the measured cost of one factor.
+type costCalibration struct {
+	name    string
+	current float64
+	// nanos is the time of processing one unit, it is 0 if the measurement
+	// is not precise enough to recommend a factor.
+	nanos float64
+	unit  string
+}
+
+func (e *costCalibrationRetriever) retrieve(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if e.retrieved {
+		return nil, nil
+	}
+	e.retrieved = true
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	stmt, err := exec.ParseWithParams(ctx, "select factor, current_value, recommended_value, nanoseconds, unit, calibrate_time from mysql.cost_calibration order by factor")
+	if err != nil {
+		return nil, err
+	}
+	rows, _, err := exec.ExecRestrictedStmt(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	res := make([][]types.Datum, 0, len(rows))
+	for _, row := range rows {
+		var recommended, nanos interface{}
+		if row.GetFloat64(3) > 0 {
+			recommended, nanos = row.GetFloat64(2), row.GetFloat64(3)
+		}
+		res = append(res, types.MakeDatums(
+			row.GetString(0),
+			row.GetFloat64(1),
+			recommended,
+			nanos,
+			row.GetString(4),
+			row.GetTime(5),
+		))
+	}
+	return res, nil
+}
+
+// calibrateAndSaveCost runs the micro-benchmarks and replaces the results in mysql.cost_calibration.
+// The time is measured in nanoseconds and converted to the cost unit by the CPU
+// factor of current session, i.e. the CPU factor is kept as is.
+func calibrateAndSaveCost(ctx context.Context, sctx sessionctx.Context) error {
+	calibrations, err := calibrateCost(ctx, sctx)
+	if err != nil {
+		return err
+	}
+	sessVars := sctx.GetSessionVars()
+	cpuNanos := calibrations[0].nanos
+	sql := new(strings.Builder)
+	sqlexec.MustFormatSQL(sql, "replace into mysql.cost_calibration values ")
+	for i, c := range calibrations {
+		recommended := c.current
+		if c.nanos > 0 && cpuNanos > 0 {
+			recommended = c.nanos / cpuNanos * sessVars.CPUFactor
+		} else {
+			// e.g. the time of a coprocessor filter is less than the noise of the scan, no
+			// factor is recommended rather than keeping the current one silently.
+			c.nanos = 0
+			sessVars.StmtCtx.AppendWarning(errors.Errorf("the measurement of %s is unreliable, no value is recommended", c.name))
+		}
+		if i > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		sqlexec.MustFormatSQL(sql, "(%?, %?, %?, %?, %?, now(3))", c.name, c.current, recommended, c.nanos, c.unit)
+	}
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	stmt, err := exec.ParseWithParams(ctx, sql.String())
+	if err != nil {
+		return err
+	}
+	_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+	return err
+}
+
+// calibrateCost prepares the scratch table and measures the factors, the CPU factor is always the first one.
+func calibrateCost(ctx context.Context, sctx sessionctx.Context) (_ []costCalibration, err error) {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	// The scratch table is unique, so the concurrent calibrations don't interfere with each other.
+	table := costCalibrationTablePrefix + strings.ReplaceAll(uuid.New().String(), "-", "_")
+	run := func(sql string, args ...interface{}) (time.Duration, error) {
+		stmt, err := exec.ParseWithParams(ctx, sql, args...)
+		if err != nil {
+			return 0, err
+		}
+		start := time.Now()
+		_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+		return time.Since(start), err
+	}
+	// measure returns the time of the fastest run of the sql.
+	measure := func(sql string, args ...interface{}) (float64, error) {
+		minDur := time.Duration(math.MaxInt64)
+		for i := 0; i < costCalibrationRepeats; i++ {
+			dur, err := run(sql, args...)
+			if err != nil {
+				return 0, err
+			}
+			if dur < minDur {
+				minDur = dur
+			}
+		}
+		return float64(minDur.Nanoseconds()), nil
+	}
+
+	if _, err = run("drop table if exists %n.%n", mysql.SystemDB, table); err != nil {
+		return nil, err
+	}
+	if _, err = run("create table %n.%n (id bigint primary key, k bigint, pad varchar(255), key idx_k(k))", mysql.SystemDB, table); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, dropErr := run("drop table if exists %n.%n", mysql.SystemDB, table); err == nil {
+			err = dropErr
+		}
+	}()
+	pad := strings.Repeat("x", costCalibrationPad)
+	for i := 0; i < costCalibrationRows; i += costCalibrationBatch {
+		values := make([]string, 0, costCalibrationBatch)
+		for j := i; j < i+costCalibrationBatch && j < costCalibrationRows; j++ {
+			values = append(values, fmt.Sprintf("(%d, %d, '%s')", j, j, pad))
+		}
+		if _, err = run("insert into %n.%n values "+strings.Join(values, ","), mysql.SystemDB, table); err != nil {
+			return nil, err
+		}
+	}
+
+	cpuNanos, err := calibrateCPU(sctx)
+	if err != nil {
+		return nil, err
+	}
+	// Only the count of rows is sent back, so it's nearly the time of scanning the rows on TiKV.
+	scanNanos, err := measure("select /*+ use_index(t) */ count(pad) from %n.%n t", mysql.SystemDB, table)
+	if err != nil {
+		return nil, err
+	}
+	// All the rows pass the filter, so the extra time is spent on evaluating it in the coprocessor.
+	copCPUNanos, err := measure("select /*+ use_index(t) */ count(pad) from %n.%n t where k >= 0", mysql.SystemDB, table)
+	if err != nil {
+		return nil, err
+	}
+	// The extra time is spent on sending the rows back to TiDB.
+	networkNanos, err := measure("select /*+ use_index(t) */ id, pad from %n.%n t", mysql.SystemDB, table)
+	if err != nil {
+		return nil, err
+	}
+	ascNanos, err := measure("select count(pad) from (select /*+ use_index(t) */ pad from %n.%n t order by id limit %?) s", mysql.SystemDB, table, costCalibrationRows)
+	if err != nil {
+		return nil, err
+	}
+	descNanos, err := measure("select count(pad) from (select /*+ use_index(t) */ pad from %n.%n t order by id desc limit %?) s", mysql.SystemDB, table, costCalibrationRows)
+	if err != nil {
+		return nil, err
+	}
+	// Every value of k is a point range which reads one index entry.
+	points := make([]float64, 0, costCalibrationSeeks)
+	for i := 0; i < costCalibrationSeeks; i++ {
+		points = append(points, float64(i*costCalibrationRows/costCalibrationSeeks))
+	}
+	seekNanos, err := measure("select /*+ use_index(t, idx_k) */ count(k) from %n.%n t where k in (%?)", mysql.SystemDB, table, points)
+	if err != nil {
+		return nil, err
+	}
+	idxScanNanos, err := measure("select /*+ use_index(t, idx_k) */ count(k) from %n.%n t where k >= 0", mysql.SystemDB, table)
+	if err != nil {
+		return nil, err
+	}
+
+	sessVars := sctx.GetSessionVars()
+	rowBytes := float64(tablecodec.RecordRowKeyLen + 8 + 8 + costCalibrationPad)
+	perUnit := func(nanos, units float64) float64 {
+		if nanos <= 0 {
+			return 0
+		}
+		return nanos / units
+	}
+	scanPerByte := perUnit(scanNanos, costCalibrationRows*rowBytes)
+	descScanPerByte := 0.0
+	if ascNanos > 0 {
+		descScanPerByte = scanPerByte * descNanos / ascNanos
+	}
+	idxScanPerEntry := idxScanNanos / costCalibrationRows
+	return []costCalibration{
+		{name: variable.TiDBOptCPUFactor, current: sessVars.CPUFactor, nanos: cpuNanos, unit: "row"},
+		{name: variable.TiDBOptCopCPUFactor, current: sessVars.CopCPUFactor, nanos: perUnit(copCPUNanos-scanNanos, costCalibrationRows), unit: "row"},
+		{name: variable.TiDBOptNetworkFactor, current: sessVars.GetNetworkFactor(nil), nanos: perUnit(networkNanos-scanNanos, costCalibrationRows*float64(8+costCalibrationPad)), unit: "byte"},
+		{name: variable.TiDBOptScanFactor, current: sessVars.GetScanFactor(nil), nanos: scanPerByte, unit: "byte"},
+		{name: variable.TiDBOptDescScanFactor, current: sessVars.GetDescScanFactor(nil), nanos: descScanPerByte, unit: "byte"},
+		{name: variable.TiDBOptSeekFactor, current: sessVars.GetSeekFactor(nil), nanos: perUnit(seekNanos-costCalibrationSeeks*idxScanPerEntry, costCalibrationSeeks), unit: "range"},
+	}, nil
+}
+
+// calibrateCPU measures the time of evaluating one expression for one row in TiDB.
+func calibrateCPU(sctx sessionctx.Context) (float64, error) {
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	col := &expression.Column{RetType: tp, Index: 0}
+	filter, err := expression.NewFunction(sctx, ast.GE, types.NewFieldType(mysql.TypeTiny), col, expression.NewZero())
+	if err != nil {
+		return 0, err
+	}
+	chk := chunk.NewChunkWithCapacity([]*types.FieldType{tp}, costCalibrationRows)
+	for i := 0; i < costCalibrationRows; i++ {
+		chk.AppendInt64(0, int64(i))
+	}
+	iter := chunk.NewIterator4Chunk(chk)
+	selected := make([]bool, 0, costCalibrationRows)
+	minDur := time.Duration(math.MaxInt64)
+	for i := 0; i < costCalibrationRepeats; i++ {
+		start := time.Now()
+		selected, err = expression.VectorizedFilter(sctx, []expression.Expression{filter}, iter, selected)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if dur := time.Since(start); dur < minDur {
+			minDur = dur
+		}
+	}
+	return float64(minDur.Nanoseconds()) / costCalibrationRows, nil
+}
@@ -165,6 +165,10 @@ func (e *DDLExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeRecoverTable(x)
 	case *ast.FlashBackTableStmt:
 		err = e.executeFlashbackTable(x)
+	case *ast.FlashBackDatabaseStmt:
+		err = e.executeFlashbackDatabase(x)
+	case *ast.FlashBackToTimestampStmt:
+		err = e.executeFlashbackToTimestamp(x)
 	case *ast.RenameTableStmt:
 		err = e.executeRenameTable(x)
 	case *ast.TruncateTableStmt:
@@ -222,11 +226,25 @@ func (e *DDLExec) executeTruncateTable(s *ast.TruncateTableStmt) error {
 func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
 	isAlterTable := false
 	var err error
-	if len(s.TableToTables) == 1 {
-		oldIdent := ast.Ident{Schema: s.TableToTables[0].OldTable.Schema, Name: s.TableToTables[0].OldTable.Name}
-		if _, ok := e.getLocalTemporaryTable(oldIdent.Schema, oldIdent.Name); ok {
+	// A local temporary table may be renamed to an intermediate name and renamed again in the same statement.
+	localTempTablesCnt := 0
+	renamedTempTables := make(map[string]struct{})
+	for _, tables := range s.TableToTables {
+		_, renamed := renamedTempTables[tables.OldTable.Schema.L+"."+tables.OldTable.Name.L]
+		if _, ok := e.getLocalTemporaryTable(tables.OldTable.Schema, tables.OldTable.Name); ok || renamed {
+			localTempTablesCnt++
+			renamedTempTables[tables.NewTable.Schema.L+"."+tables.NewTable.Name.L] = struct{}{}
+		}
+	}
+	if localTempTablesCnt > 0 {
+		if localTempTablesCnt != len(s.TableToTables) {
 			return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("RENAME TABLE")
 		}
+		return e.renameLocalTemporaryTables(s.TableToTables)
+	}
+
+	if len(s.TableToTables) == 1 {
+		oldIdent := ast.Ident{Schema: s.TableToTables[0].OldTable.Schema, Name: s.TableToTables[0].OldTable.Name}
 		newIdent := ast.Ident{Schema: s.TableToTables[0].NewTable.Schema, Name: s.TableToTables[0].NewTable.Name}
 		err = domain.GetDomain(e.ctx).DDL().RenameTable(e.ctx, oldIdent, newIdent, isAlterTable)
 	} else {
@@ -234,9 +252,6 @@ func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
 		newIdents := make([]ast.Ident, 0, len(s.TableToTables))
 		for _, tables := range s.TableToTables {
 			oldIdent := ast.Ident{Schema: tables.OldTable.Schema, Name: tables.OldTable.Name}
-			if _, ok := e.getLocalTemporaryTable(oldIdent.Schema, oldIdent.Name); ok {
-				return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("RENAME TABLE")
-			}
 			newIdent := ast.Ident{Schema: tables.NewTable.Schema, Name: tables.NewTable.Name}
 			oldIdents = append(oldIdents, oldIdent)
 			newIdents = append(newIdents, newIdent)
@@ -246,6 +261,38 @@ func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
 	return err
 }
 
+// renameLocalTemporaryTables renames the local temporary tables one by one, the renamed tables are restored
+// if any of them fails, so that the statement is atomic.
+func (e *DDLExec) renameLocalTemporaryTables(tableToTables []*ast.TableToTable) (err error) {
+	is := e.ctx.GetInfoSchema().(infoschema.InfoSchema)
+	renamed := 0
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := renamed - 1; i >= 0; i-- {
+			tables := tableToTables[i]
+			if rollbackErr := e.tempTableDDL.RenameLocalTemporaryTable(tables.NewTable.Schema, tables.NewTable.Name,
+				tables.OldTable.Schema, tables.OldTable.Name); rollbackErr != nil {
+				logutil.BgLogger().Error("restore local temporary table failed", zap.Error(rollbackErr))
+			}
+		}
+	}()
+
+	for _, tables := range tableToTables {
+		if _, ok := is.SchemaByName(tables.NewTable.Schema); !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(tables.NewTable.Schema)
+		}
+		err = e.tempTableDDL.RenameLocalTemporaryTable(tables.OldTable.Schema, tables.OldTable.Name,
+			tables.NewTable.Schema, tables.NewTable.Name)
+		if err != nil {
+			return err
+		}
+		renamed++
+	}
+	return nil
+}
+
 func (e *DDLExec) executeCreateDatabase(s *ast.CreateDatabaseStmt) error {
 	var charOpt *ast.CharsetOpt
 	var directPlacementOpts *model.PlacementSettings
@@ -319,7 +366,17 @@ func (e *DDLExec) createSessionTemporaryTable(s *ast.CreateTableStmt) error {
 		return err
 	}
 
-	return e.tempTableDDL.CreateLocalTemporaryTable(dbInfo.Name, tbInfo)
+	sessVars := e.ctx.GetSessionVars()
+	isNewData := sessVars.TemporaryTableData == nil
+	if err = e.tempTableDDL.CreateLocalTemporaryTable(dbInfo.Name, tbInfo); err != nil {
+		return err
+	}
+	// The session data lives until the session is closed, so it's tracked by the session trackers
+	// rather than the statement ones.
+	if isNewData && sessVars.TemporaryTableData != nil {
+		sessVars.TemporaryTableData.AttachTo(sessVars.MemTracker, sessVars.DiskTracker)
+	}
+	return nil
 }
 
 func (e *DDLExec) executeCreateView(s *ast.CreateViewStmt) error {
@@ -337,8 +394,22 @@ func (e *DDLExec) executeCreateView(s *ast.CreateViewStmt) error {
 
 func (e *DDLExec) executeCreateIndex(s *ast.CreateIndexStmt) error {
 	ident := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
-	if _, ok := e.getLocalTemporaryTable(ident.Schema, ident.Name); ok {
-		return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("CREATE INDEX")
+	if tbl, ok := e.getLocalTemporaryTable(ident.Schema, ident.Name); ok {
+		constraint := &ast.Constraint{
+			IfNotExists: s.IfNotExists,
+			Name:        s.IndexName,
+			Keys:        s.IndexPartSpecifications,
+			Option:      s.IndexOption,
+			Tp:          ast.ConstraintIndex,
+		}
+		switch s.KeyType {
+		case ast.IndexKeyTypeUnique:
+			constraint.Tp = ast.ConstraintUniq
+		case ast.IndexKeyTypeFullText:
+			constraint.Tp = ast.ConstraintFulltext
+		}
+		return e.alterLocalTemporaryTable(context.Background(), tbl, ident,
+			[]*ast.AlterTableSpec{{Tp: ast.AlterTableAddConstraint, Constraint: constraint}})
 	}
 
 	err := domain.GetDomain(e.ctx).DDL().CreateIndex(e.ctx, ident, s.KeyType, model.NewCIStr(s.IndexName),
@@ -512,8 +583,9 @@ func (e *DDLExec) dropLocalTemporaryTables(localTempTables []*ast.TableName) err
 
 func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
 	ti := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
-	if _, ok := e.getLocalTemporaryTable(ti.Schema, ti.Name); ok {
-		return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("DROP INDEX")
+	if tbl, ok := e.getLocalTemporaryTable(ti.Schema, ti.Name); ok {
+		return e.alterLocalTemporaryTable(context.Background(), tbl, ti,
+			[]*ast.AlterTableSpec{{Tp: ast.AlterTableDropIndex, Name: s.IndexName, IfExists: s.IfExists}})
 	}
 
 	err := domain.GetDomain(e.ctx).DDL().DropIndex(e.ctx, ti, model.NewCIStr(s.IndexName), s.IfExists)
@@ -525,14 +597,29 @@ func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
 
 func (e *DDLExec) executeAlterTable(ctx context.Context, s *ast.AlterTableStmt) error {
 	ti := ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name}
-	if _, ok := e.getLocalTemporaryTable(ti.Schema, ti.Name); ok {
-		return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+	if tbl, ok := e.getLocalTemporaryTable(ti.Schema, ti.Name); ok {
+		return e.alterLocalTemporaryTable(ctx, tbl, ti, s.Specs)
 	}
 
 	err := domain.GetDomain(e.ctx).DDL().AlterTable(ctx, e.ctx, ti, s.Specs)
 	return err
 }
 
+// alterLocalTemporaryTable applies the specs to the local temporary table directly instead of running DDL jobs,
+// because the table is only visible to the current session.
+func (e *DDLExec) alterLocalTemporaryTable(ctx context.Context, tbl table.Table, ti ast.Ident, specs []*ast.AlterTableSpec) error {
+	dbInfo, ok := e.ctx.GetInfoSchema().(infoschema.InfoSchema).SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema.O)
+	}
+
+	tbInfo, err := ddl.BuildAlteredLocalTemporaryTableInfo(ctx, e.ctx, dbInfo, tbl, specs)
+	if err != nil {
+		return err
+	}
+	return e.tempTableDDL.AlterLocalTemporaryTable(ti.Schema, ti.Name, tbInfo)
+}
+
 // executeRecoverTable represents a recover table executor.
 // It is built from "recover table" statement,
 // is used to recover the table that deleted by mistake.
@@ -543,6 +630,16 @@ func (e *DDLExec) executeRecoverTable(s *ast.RecoverTableStmt) error {
 	}
 	t := meta.NewMeta(txn)
 	dom := domain.GetDomain(e.ctx)
+	if s.JobID != 0 {
+		// Recover the whole schema if the job is a `DROP DATABASE` job.
+		job, err := t.GetHistoryDDLJob(s.JobID)
+		if err != nil {
+			return err
+		}
+		if job != nil && job.Type == model.ActionDropSchema {
+			return e.recoverSchema(job, "")
+		}
+	}
 	var job *model.Job
 	var tblInfo *model.TableInfo
 	if s.JobID != 0 {
@@ -582,6 +679,69 @@ func (e *DDLExec) executeRecoverTable(s *ast.RecoverTableStmt) error {
 	return err
 }
 
+// recoverSchema recovers the schema dropped by the job, along with all its tables, views and sequences.
+// The schema is renamed to newName if it's not empty.
+func (e *DDLExec) recoverSchema(job *model.Job, newName string) error {
+	// Check GC safe point for getting snapshot infoSchema.
+	err := gcutil.ValidateSnapshot(e.ctx, job.StartTS)
+	if err != nil {
+		return err
+	}
+
+	// Get the snapshot infoSchema before drop schema.
+	dom := domain.GetDomain(e.ctx)
+	snapInfo, err := dom.GetSnapshotInfoSchema(job.StartTS)
+	if err != nil {
+		return err
+	}
+	dbInfo, ok := snapInfo.SchemaByID(job.SchemaID)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(fmt.Sprintf("(Schema ID %d)", job.SchemaID))
+	}
+	tables := snapInfo.SchemaTables(dbInfo.Name)
+
+	m, err := dom.GetSnapshotMeta(job.StartTS)
+	if err != nil {
+		return err
+	}
+	recoverTabsInfo := make([]*ddl.RecoverInfo, 0, len(tables))
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta().Clone()
+		autoIDs, err := m.GetAutoIDAccessors(dbInfo.ID, tblInfo.ID).Get()
+		if err != nil {
+			return err
+		}
+		var seqValue int64
+		if tblInfo.IsSequence() {
+			seqValue, err = m.GetAutoIDAccessors(dbInfo.ID, tblInfo.ID).SequenceValue().Get()
+			if err != nil {
+				return err
+			}
+		}
+		recoverTabsInfo = append(recoverTabsInfo, &ddl.RecoverInfo{
+			SchemaID:      dbInfo.ID,
+			TableInfo:     tblInfo,
+			DropJobID:     job.ID,
+			SnapshotTS:    job.StartTS,
+			AutoIDs:       autoIDs,
+			SequenceValue: seqValue,
+			OldSchemaName: dbInfo.Name.L,
+			OldTableName:  tblInfo.Name.L,
+		})
+	}
+	recoverSchemaInfo := &ddl.RecoverSchemaInfo{
+		DBInfo:          dbInfo.Clone(),
+		RecoverTabsInfo: recoverTabsInfo,
+		DropJobID:       job.ID,
+		SnapshotTS:      job.StartTS,
+		OldSchemaName:   dbInfo.Name.O,
+	}
+	if len(newName) != 0 {
+		recoverSchemaInfo.DBInfo.Name = model.NewCIStr(newName)
+	}
+	return dom.DDL().RecoverSchema(e.ctx, recoverSchemaInfo)
+}
+
 func (e *DDLExec) getRecoverTableByJobID(s *ast.RecoverTableStmt, t *meta.Meta, dom *domain.Domain) (*model.Job, *model.TableInfo, error) {
 	job, err := t.GetHistoryDDLJob(s.JobID)
 	if err != nil {
@@ -711,6 +871,53 @@ func (e *DDLExec) getRecoverTableByTableName(tableName *ast.TableName) (*model.J
 	return jobInfo, tableInfo, nil
 }
 
+// executeFlashbackDatabase represents a flashback database executor.
+// It recovers the latest dropped schema with the name, along with all its tables, views and sequences.
+func (e *DDLExec) executeFlashbackDatabase(s *ast.FlashBackDatabaseStmt) error {
+	txn, err := e.ctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	gcSafePoint, err := gcutil.GetGCSafePoint(e.ctx)
+	if err != nil {
+		return err
+	}
+	var jobInfo *model.Job
+	err = admin.IterHistoryDDLJobs(txn, func(jobs []*model.Job) (bool, error) {
+		for _, job := range jobs {
+			// Check GC safe point for getting snapshot infoSchema.
+			if err := gcutil.ValidateSnapshotWithGCSafePoint(job.StartTS, gcSafePoint); err != nil {
+				return false, err
+			}
+			if job.Type == model.ActionDropSchema && job.SchemaName == s.DBName.L && job.IsSynced() {
+				jobInfo = job
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		if terror.ErrorEqual(variable.ErrSnapshotTooOld, err) {
+			return errors.Errorf("Can't find dropped database '%s' in GC safe point %s", s.DBName.O, model.TSConvert2Time(gcSafePoint).String())
+		}
+		return err
+	}
+	if jobInfo == nil {
+		return errors.Errorf("Can't find dropped database: %v in DDL history jobs", s.DBName)
+	}
+	return e.recoverSchema(jobInfo, s.NewName)
+}
+
+// executeFlashbackToTimestamp represents a flashback to timestamp executor.
+// It rolls the data of the tables, or of all the user tables when no table is given, back to the timestamp.
+func (e *DDLExec) executeFlashbackToTimestamp(s *ast.FlashBackToTimestampStmt) error {
+	flashbackTS, err := core.CalculateAsOfTsExpr(e.ctx, &ast.AsOfClause{TsExpr: s.FlashbackTS})
+	if err != nil {
+		return err
+	}
+	return domain.GetDomain(e.ctx).DDL().FlashbackToTimestamp(e.ctx, s.Tables, flashbackTS)
+}
+
 func (e *DDLExec) executeFlashbackTable(s *ast.FlashBackTableStmt) error {
 	job, tblInfo, err := e.getRecoverTableByTableName(s.Table)
 	if err != nil {
@@ -749,15 +956,21 @@ func (e *DDLExec) executeFlashbackTable(s *ast.FlashBackTableStmt) error {
 }
 
 func (e *DDLExec) executeLockTables(s *ast.LockTablesStmt) error {
+	if !config.TableLockEnabled() {
+		return nil
+	}
+
+	// Local temporary tables are invisible to other sessions, so locking them is a no-op.
+	tableLocks := make([]ast.TableLock, 0, len(s.TableLocks))
 	for _, tb := range s.TableLocks {
-		if _, ok := e.getLocalTemporaryTable(tb.Table.Schema, tb.Table.Name); ok {
-			return ddl.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("LOCK TABLES")
+		if _, ok := e.getLocalTemporaryTable(tb.Table.Schema, tb.Table.Name); !ok {
+			tableLocks = append(tableLocks, tb)
 		}
 	}
-
-	if !config.TableLockEnabled() {
+	if len(tableLocks) == 0 {
 		return nil
 	}
+	s.TableLocks = tableLocks
 	return domain.GetDomain(e.ctx).DDL().LockTables(e.ctx, s)
 }
 
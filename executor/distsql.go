@@ -86,6 +86,9 @@ type lookupTableTask struct {
 	// partitionTable indicates whether this task belongs to a partition table and which partition table it is.
 	partitionTable table.PhysicalTable
 
+	// partialWorkerID is the ID of the IndexMerge partial worker which fetches the handles of this task.
+	partialWorkerID int
+
 	// memUsage records the memory usage of this task calculated by table worker.
 	// memTracker is used to release memUsage after task is done and unused.
 	//
@@ -166,6 +169,9 @@ type IndexReaderExecutor struct {
 	partitions      []table.PhysicalTable
 	partRangeMap    map[int64][]*ranger.Range // each partition may have different ranges
 
+	// dummy means the rows are read from the cache of the cached table by the UnionScanExec,
+	// the reader only computes the kv ranges.
+	dummy bool
 	// kvRanges are only used for union scan.
 	kvRanges         []kv.KeyRange
 	dagPB            *tipb.DAGRequest
@@ -198,7 +204,7 @@ type IndexReaderExecutor struct {
 
 // Close clears all resources hold by current object.
 func (e *IndexReaderExecutor) Close() error {
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -210,7 +216,7 @@ func (e *IndexReaderExecutor) Close() error {
 
 // Next implements the Executor Next interface.
 func (e *IndexReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		req.Reset()
 		return nil
 	}
@@ -277,9 +283,9 @@ func (e *IndexReaderExecutor) open(ctx context.Context, kvRanges []kv.KeyRange)
 		e.dagPB.CollectExecutionSummaries = &collExec
 	}
 	e.kvRanges = kvRanges
-	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
+	// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
 	// In a test case IndexReaderExecutor is mocked and e.table is nil.
-	if e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -319,6 +325,9 @@ type IndexLookUpExecutor struct {
 	ranges  []*ranger.Range
 	dagPB   *tipb.DAGRequest
 	startTS uint64
+	// dummy means the rows are read from the cache of the cached table by the UnionScanExec,
+	// the reader only computes the kv ranges.
+	dummy bool
 	// handleIdx is the index of handle, which is only used for case of keeping order.
 	handleIdx       []int
 	handleCols      []*expression.Column
@@ -403,8 +412,8 @@ func (e *IndexLookUpExecutor) Open(ctx context.Context) error {
 		return err
 	}
 
-	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -667,7 +676,7 @@ func (e *IndexLookUpExecutor) buildTableReader(ctx context.Context, task *lookup
 
 // Close implements Exec Close interface.
 func (e *IndexLookUpExecutor) Close() error {
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
@@ -691,7 +700,7 @@ func (e *IndexLookUpExecutor) Close() error {
 
 // Next implements Exec Next interface.
 func (e *IndexLookUpExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
-	if e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta().TempTableType != model.TempTableNone {
 		req.Reset()
 		return nil
 	}
@@ -295,7 +295,8 @@ func Next(ctx context.Context, e Executor, req *chunk.Chunk) error {
 	return err
 }
 
-// CancelDDLJobsExec represents a cancel DDL jobs executor.
+// CancelDDLJobsExec represents a cancel DDL jobs executor. It's also used to show the results of
+// pausing, resuming and altering DDL jobs.
 type CancelDDLJobsExec struct {
 	baseExecutor
 
@@ -1674,17 +1675,24 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	if globalConfig.OOMUseTmpStorage && GlobalDiskUsageTracker != nil {
 		sc.DiskTracker.AttachToGlobalTracker(GlobalDiskUsageTracker)
 	}
-	switch globalConfig.OOMAction {
-	case config.OOMActionCancel:
-		action := &memory.PanicOnExceed{ConnID: ctx.GetSessionVars().ConnectionID}
-		action.SetLogHook(domain.GetDomain(ctx).ExpensiveQueryHandle().LogOnQueryExceedMemQuota)
-		sc.MemTracker.SetActionOnExceed(action)
-	case config.OOMActionLog:
-		fallthrough
-	default:
-		action := &memory.LogOnExceed{ConnID: ctx.GetSessionVars().ConnectionID}
-		action.SetLogHook(domain.GetDomain(ctx).ExpensiveQueryHandle().LogOnQueryExceedMemQuota)
-		sc.MemTracker.SetActionOnExceed(action)
+	// The data living across statements, such as the data of temporary tables, is limited by the quota of
+	// the current statement.
+	vars.MemTracker.SetBytesLimit(vars.MemQuotaQuery)
+	vars.MemTracker.AttachToGlobalTracker(GlobalMemoryUsageTracker)
+	vars.DiskTracker.AttachToGlobalTracker(GlobalDiskUsageTracker)
+	for _, tracker := range []*memory.Tracker{sc.MemTracker, vars.MemTracker} {
+		switch globalConfig.OOMAction {
+		case config.OOMActionCancel:
+			action := &memory.PanicOnExceed{ConnID: ctx.GetSessionVars().ConnectionID}
+			action.SetLogHook(domain.GetDomain(ctx).ExpensiveQueryHandle().LogOnQueryExceedMemQuota)
+			tracker.SetActionOnExceed(action)
+		case config.OOMActionLog:
+			fallthrough
+		default:
+			action := &memory.LogOnExceed{ConnID: ctx.GetSessionVars().ConnectionID}
+			action.SetLogHook(domain.GetDomain(ctx).ExpensiveQueryHandle().LogOnQueryExceedMemQuota)
+			tracker.SetActionOnExceed(action)
+		}
 	}
 	if execStmt, ok := s.(*ast.ExecuteStmt); ok {
 		prepareStmt, err := planner.GetPreparedStmt(execStmt, vars)
@@ -1707,6 +1715,7 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	sc.OriginalSQL = s.Text()
 	if explainStmt, ok := s.(*ast.ExplainStmt); ok {
 		sc.InExplainStmt = true
+		sc.InExplainAnalyzeStmt = explainStmt.Analyze
 		sc.IgnoreExplainIDSuffix = (strings.ToLower(explainStmt.Format) == types.ExplainFormatBrief)
 		sc.InVerboseExplain = strings.ToLower(explainStmt.Format) == types.ExplainFormatVerbose
 		s = explainStmt.Stmt
@@ -1253,6 +1253,27 @@ func (s *testSuiteWithData) TestSetOperationOnDiffColType(c *C) {
 	}
 }
 
+func (s *testSuite) TestSetOperationAllOnMixedColType(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`drop table if exists t_int, t_dec, t_str`)
+	tk.MustExec(`create table t_int(a int)`)
+	tk.MustExec(`create table t_dec(a decimal(10,2))`)
+	tk.MustExec(`create table t_str(a varchar(10))`)
+	tk.MustExec(`insert into t_int values (1),(1),(2)`)
+	tk.MustExec(`insert into t_dec values (1),(2),(2)`)
+	tk.MustExec(`insert into t_str values ('1'),('1.0'),('2')`)
+
+	// the rows are numbered by the values casted to the type in which they are compared, so '1' and '1.0'
+	// are duplicates when compared with numbers.
+	tk.MustQuery(`select a from t_int intersect all select a from t_dec`).Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery(`select a from t_str intersect all select a from t_int`).Sort().Check(testkit.Rows("1", "1.0", "2"))
+	tk.MustQuery(`select count(*) from (select a from t_str intersect all select a from t_dec) t`).Check(testkit.Rows("2"))
+	tk.MustQuery(`select a from t_int except all select a from t_str`).Check(testkit.Rows())
+	tk.MustQuery(`select a from t_dec except all select a from t_int`).Check(testkit.Rows("2.00"))
+	tk.MustQuery(`select count(*) from (select a from t_str except all select a from t_dec) t`).Check(testkit.Rows("1"))
+}
+
 // issue-23038: wrong key range of index scan for year column
 func (s *testSuiteWithData) TestIndexScanWithYearCol(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
@@ -1537,6 +1558,40 @@ func (s *testSuite2) TestUnionLimit(c *C) {
 	tk.MustQuery("select * from union_limit limit 10")
 }
 
+func (s *testSuite2) TestMergeUnion(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (a int, b varchar(10), key(a))")
+	tk.MustExec("create table t2 (a int, b varchar(10), key(a))")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	var expected []string
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t1 values (%d, 'a%d')", i*2, i))
+		tk.MustExec(fmt.Sprintf("insert into t2 values (%d, 'b%d')", i*3, i))
+	}
+	tk.MustExec("insert into t1 values (null, 'null')")
+	for i := 0; i < 300; i++ {
+		if i%2 == 0 && i/2 < 100 {
+			expected = append(expected, fmt.Sprintf("%d a%d", i, i/2))
+		}
+		if i%3 == 0 && i/3 < 100 {
+			expected = append(expected, fmt.Sprintf("%d b%d", i, i/3))
+		}
+	}
+
+	// The children are merged across several chunks, and the rows with the
+	// same keys keep the order of the children.
+	rows := tk.MustQuery("explain format=brief select * from t1 union all select * from t2 order by a limit 100, 50").Rows()
+	c.Assert(rows[1][0], Equals, "└─Union")
+	c.Assert(rows[1][4], Equals, "order by:Column#7")
+	tk.MustQuery("select * from t1 union all select * from t2 order by a limit 100, 50").Check(testkit.Rows(expected[99:149]...))
+	tk.MustQuery("select * from t1 union all select * from t2 order by a desc limit 3").Check(testkit.Rows("297 b99", "294 b98", "291 b97"))
+	tk.MustQuery("select * from t1 union all select * from t2 order by a limit 2").Check(testkit.Rows("<nil> null", "0 a0"))
+	tk.MustQuery("select * from t1 union all select * from t2 order by b desc, a limit 2").Check(testkit.Rows("<nil> null", "297 b99"))
+	tk.MustQuery("select count(*) from (select * from t1 union all select * from t2 order by a limit 1000) t").Check(testkit.Rows("201"))
+}
+
 func (s *testSuiteP1) TestNeighbouringProj(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
@@ -548,8 +548,8 @@ func (iw *indexHashJoinInnerWorker) buildHashTableForOuterResult(ctx context.Con
 			}
 			row := chk.GetRow(rowIdx)
 			hashColIdx := iw.outerCtx.hashCols
-			for _, i := range hashColIdx {
-				if row.IsNull(i) {
+			for keyIdx, i := range hashColIdx {
+				if row.IsNull(i) && !iw.outerCtx.isNullEQKey(keyIdx) {
 					continue OUTER
 				}
 			}
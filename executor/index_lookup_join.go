@@ -90,6 +90,14 @@ type outerCtx struct {
 	keyCols  []int
 	hashCols []int
 	filter   expression.CNFExprs
+	// isNullEQ[i] indicates whether the i-th join key comes from a `<=>`
+	// condition, in which case NULL keys should be looked up and matched.
+	isNullEQ []bool
+}
+
+// isNullEQKey returns whether the i-th join key can match NULL values.
+func (ctx *outerCtx) isNullEQKey(i int) bool {
+	return len(ctx.isNullEQ) > i && ctx.isNullEQ[i]
 }
 
 type innerCtx struct {
@@ -575,13 +583,20 @@ func (iw *innerWorker) constructDatumLookupKey(task *lookUpJoinTask, chkIdx, row
 	dHashKey := make([]types.Datum, 0, len(iw.hashCols))
 	for i, hashCol := range iw.outerCtx.hashCols {
 		outerValue := outerRow.GetDatum(hashCol, iw.outerCtx.rowTypes[hashCol])
-		// Join-on-condition can be promised to be equal-condition in
-		// IndexNestedLoopJoin, thus the filter will always be false if
-		// outerValue is null, and we don't need to lookup it.
+		innerColType := iw.rowTypes[iw.hashCols[i]]
 		if outerValue.IsNull() {
-			return nil, nil, nil
+			// The equal condition is always false if outerValue is null, and the
+			// null-safe equal condition can't be true either if the inner column
+			// is NOT NULL, so we don't need to lookup it in both cases.
+			if !iw.outerCtx.isNullEQKey(i) || mysql.HasNotNullFlag(innerColType.Flag) {
+				return nil, nil, nil
+			}
+			if i < keyLen {
+				dLookupKey = append(dLookupKey, outerValue)
+			}
+			dHashKey = append(dHashKey, outerValue)
+			continue
 		}
-		innerColType := iw.rowTypes[iw.hashCols[i]]
 		innerValue, err := outerValue.ConvertTo(sc, innerColType)
 		if err != nil && !(terror.ErrorEqual(err, types.ErrTruncated) && (innerColType.Tp == mysql.TypeSet || innerColType.Tp == mysql.TypeEnum)) {
 			// If the converted outerValue overflows or invalid to innerValue, we don't need to lookup it.
@@ -715,7 +730,10 @@ func (iw *innerWorker) buildLookUpMap(task *lookUpJoinTask) error {
 }
 
 func (iw *innerWorker) hasNullInJoinKey(row chunk.Row) bool {
-	for _, ordinal := range iw.hashCols {
+	for i, ordinal := range iw.hashCols {
+		if iw.outerCtx.isNullEQKey(i) {
+			continue
+		}
 		if row.IsNull(ordinal) {
 			return true
 		}
@@ -81,6 +81,8 @@ type outerMergeCtx struct {
 	filter        expression.CNFExprs
 	needOuterSort bool
 	compareFuncs  []expression.CompareFunc
+	// isNullEQ[i] indicates whether joinKeys[i] comes from a `<=>` condition.
+	isNullEQ []bool
 }
 
 type innerMergeCtx struct {
@@ -679,13 +681,18 @@ func (imw *innerMergeWorker) constructDatumLookupKey(task *lookUpMergeJoinTask,
 	dLookupKey := make([]types.Datum, 0, keyLen)
 	for i, keyCol := range imw.outerMergeCtx.keyCols {
 		outerValue := outerRow.GetDatum(keyCol, imw.outerMergeCtx.rowTypes[keyCol])
-		// Join-on-condition can be promised to be equal-condition in
-		// IndexNestedLoopJoin, thus the filter will always be false if
-		// outerValue is null, and we don't need to lookup it.
+		innerColType := imw.rowTypes[imw.keyCols[i]]
 		if outerValue.IsNull() {
-			return nil, nil
+			// The equal condition is always false if outerValue is null, and the
+			// null-safe equal condition can't be true either if the inner column
+			// is NOT NULL, so we don't need to lookup it in both cases.
+			isNullEQ := len(imw.outerMergeCtx.isNullEQ) > i && imw.outerMergeCtx.isNullEQ[i]
+			if !isNullEQ || mysql.HasNotNullFlag(innerColType.Flag) {
+				return nil, nil
+			}
+			dLookupKey = append(dLookupKey, outerValue)
+			continue
 		}
-		innerColType := imw.rowTypes[imw.keyCols[i]]
 		innerValue, err := outerValue.ConvertTo(sc, innerColType)
 		if err != nil {
 			// If the converted outerValue overflows, we don't need to lookup it.
@@ -52,7 +52,7 @@ var (
 // IndexMergeReaderExecutor accesses a table with multiple index/table scan.
 // There are three types of workers:
 // 1. partialTableWorker/partialIndexWorker, which are used to fetch the handles
-// 2. indexMergeProcessWorker, which is used to do the `Union` operation.
+// 2. indexMergeProcessWorker, which is used to do the `Union` or `Intersection` operation.
 // 3. indexMergeTableScanWorker, which is used to get the table tuples with the given handles.
 //
 // The execution flow is really like IndexLookUpReader. However, it uses multiple index scans
@@ -64,6 +64,8 @@ var (
 //    1. check whether it has been accessed.
 //    2. if not, record it and send it to the indexMergeTableScanWorker.
 //    3. if accessed, just ignore it.
+//    For the intersection type, indexMergeProcessWorker waits for all the handles from the partial workers,
+//    and only sends the handles which are fetched by every partial worker to the indexMergeTableScanWorker.
 type IndexMergeReaderExecutor struct {
 	baseExecutor
 
@@ -78,6 +80,8 @@ type IndexMergeReaderExecutor struct {
 	columns           []*model.ColumnInfo
 	partialStreamings []bool
 	tableStreaming    bool
+	// isIntersection indicates whether the handles of the partial plans are intersected rather than unioned.
+	isIntersection bool
 	*dataReaderBuilder
 
 	// fields about accessing partition tables
@@ -133,6 +137,8 @@ func (e *IndexMergeReaderExecutor) Open(ctx context.Context) (err error) {
 	}
 	e.finished = make(chan struct{})
 	e.resultCh = make(chan *lookupTableTask, atomic.LoadInt32(&LookupTableTaskChannelSize))
+	e.memTracker = memory.NewTracker(e.id, -1)
+	e.memTracker.AttachTo(e.ctx.GetSessionVars().StmtCtx.MemTracker)
 	return nil
 }
 
@@ -209,6 +215,10 @@ func (e *IndexMergeReaderExecutor) startIndexMergeProcessWorker(ctx context.Cont
 			},
 			idxMergeProcessWorker.handleLoopFetcherPanic(ctx, e.resultCh),
 		)
+		// the channels are closed after the panic is reported, so the error is
+		// not sent to a closed channel.
+		close(workCh)
+		close(e.resultCh)
 		e.processWokerWg.Done()
 	}()
 }
@@ -252,6 +262,7 @@ func (e *IndexMergeReaderExecutor) startPartialIndexWorker(ctx context.Context,
 				worker := &partialIndexWorker{
 					stats:        e.stats,
 					idxID:        e.getPartitalPlanID(workID),
+					workerID:     workID,
 					sc:           e.ctx,
 					batchSize:    e.maxChunkSize,
 					maxBatchSize: e.ctx.GetSessionVars().IndexLookupSize,
@@ -338,6 +349,7 @@ func (e *IndexMergeReaderExecutor) startPartialTableWorker(ctx context.Context,
 				}
 				worker := &partialTableWorker{
 					stats:        e.stats,
+					workerID:     workID,
 					sc:           e.ctx,
 					batchSize:    e.maxChunkSize,
 					maxBatchSize: e.ctx.GetSessionVars().IndexLookupSize,
@@ -418,6 +430,7 @@ func (e *IndexMergeReaderExecutor) getTablePlanRootID() int {
 
 type partialTableWorker struct {
 	stats        *IndexMergeRuntimeStat
+	workerID     int
 	sc           sessionctx.Context
 	batchSize    int
 	maxBatchSize int
@@ -503,7 +516,8 @@ func (w *partialTableWorker) buildTableTask(handles []kv.Handle, retChk *chunk.C
 		handles: handles,
 		idxRows: retChk,
 
-		partitionTable: w.partition,
+		partitionTable:  w.partition,
+		partialWorkerID: w.workerID,
 	}
 
 	task.doneCh = make(chan error, 1)
@@ -644,10 +658,10 @@ type indexMergeProcessWorker struct {
 
 func (w *indexMergeProcessWorker) fetchLoop(ctx context.Context, fetchCh <-chan *lookupTableTask,
 	workCh chan<- *lookupTableTask, resultCh chan<- *lookupTableTask, finished <-chan struct{}) {
-	defer func() {
-		close(workCh)
-		close(resultCh)
-	}()
+	if w.indexMerge.isIntersection {
+		w.fetchLoopIntersection(ctx, fetchCh, workCh, resultCh, finished)
+		return
+	}
 
 	distinctHandles := make(map[int64]*kv.HandleMap)
 	for task := range fetchCh {
@@ -695,6 +709,102 @@ func (w *indexMergeProcessWorker) fetchLoop(ctx context.Context, fetchCh <-chan
 	}
 }
 
+// fetchLoopIntersection collects all the handles from the partial workers, and sends the handles which are
+// fetched by all of the partial workers to the table workers. The collected handles are tracked by the
+// memory tracker of the executor, so the memory quota of the query applies to them.
+func (w *indexMergeProcessWorker) fetchLoopIntersection(ctx context.Context, fetchCh <-chan *lookupTableTask,
+	workCh chan<- *lookupTableTask, resultCh chan<- *lookupTableTask, finished <-chan struct{}) {
+	memTracker := memory.NewTracker(memory.LabelForIndexMergeHandles, -1)
+	memTracker.AttachTo(w.indexMerge.memTracker)
+	defer memTracker.Detach()
+
+	partialWorkerCount := len(w.indexMerge.partialPlans)
+	// handleHits records the partial workers which have fetched the handle, for each physical table.
+	handleHits := make(map[int64]*kv.HandleMap)
+	partitions := make(map[int64]table.PhysicalTable)
+	var tblIDs []int64
+	for task := range fetchCh {
+		start := time.Now()
+		var tblID int64
+		if w.indexMerge.partitionTableMode {
+			tblID = getPhysicalTableID(task.partitionTable)
+		} else {
+			tblID = getPhysicalTableID(w.indexMerge.table)
+		}
+		hMap, ok := handleHits[tblID]
+		if !ok {
+			hMap = kv.NewHandleMap()
+			handleHits[tblID] = hMap
+			partitions[tblID] = task.partitionTable
+			tblIDs = append(tblIDs, tblID)
+		}
+		var memUsage int64
+		for _, h := range task.handles {
+			var hits []bool
+			if v, ok := hMap.Get(h); ok {
+				hits = v.([]bool)
+			} else {
+				hits = make([]bool, partialWorkerCount)
+				hMap.Set(h, hits)
+				memUsage += handleHitsMemUsage(h, partialWorkerCount)
+			}
+			hits[task.partialWorkerID] = true
+		}
+		memTracker.Consume(memUsage)
+		if w.stats != nil {
+			w.stats.IndexMergeProcess += time.Since(start)
+		}
+	}
+
+	batchSize := w.indexMerge.ctx.GetSessionVars().IndexLookupSize
+	for _, tblID := range tblIDs {
+		start := time.Now()
+		fhs := make([]kv.Handle, 0, 8)
+		handleHits[tblID].Range(func(h kv.Handle, val interface{}) bool {
+			for _, hit := range val.([]bool) {
+				if !hit {
+					return true
+				}
+			}
+			fhs = append(fhs, h)
+			return true
+		})
+		memTracker.Consume(int64(cap(fhs)) * int64(unsafe.Sizeof(kv.Handle(nil))))
+		if w.stats != nil {
+			w.stats.IndexMergeProcess += time.Since(start)
+		}
+		for len(fhs) > 0 {
+			n := mathutil.Min(len(fhs), batchSize)
+			task := &lookupTableTask{
+				handles: fhs[:n:n],
+				doneCh:  make(chan error, 1),
+
+				partitionTable: partitions[tblID],
+			}
+			fhs = fhs[n:]
+			select {
+			case <-ctx.Done():
+				return
+			case <-finished:
+				return
+			case workCh <- task:
+				resultCh <- task
+			}
+		}
+	}
+}
+
+// handleHitsMemUsage estimates the memory used to record a handle and the partial workers which have
+// fetched it in fetchLoopIntersection.
+func handleHitsMemUsage(h kv.Handle, partialWorkerCount int) int64 {
+	size := int64(unsafe.Sizeof(kv.IntHandle(0)))
+	if !h.IsInt() {
+		size = int64(len(h.Encoded())) + int64(unsafe.Sizeof([]byte(nil)))
+	}
+	// the hits slice and the entry in the map.
+	return size + int64(partialWorkerCount) + int64(unsafe.Sizeof([]bool(nil))) + int64(unsafe.Sizeof(kv.Handle(nil)))
+}
+
 func (w *indexMergeProcessWorker) handleLoopFetcherPanic(ctx context.Context, resultCh chan<- *lookupTableTask) func(r interface{}) {
 	return func(r interface{}) {
 		if r == nil {
@@ -715,6 +825,7 @@ type partialIndexWorker struct {
 	stats        *IndexMergeRuntimeStat
 	sc           sessionctx.Context
 	idxID        int
+	workerID     int
 	batchSize    int
 	maxBatchSize int
 	maxChunkSize int
@@ -807,7 +918,8 @@ func (w *partialIndexWorker) buildTableTask(handles []kv.Handle, retChk *chunk.C
 		handles: handles,
 		idxRows: retChk,
 
-		partitionTable: w.partition,
+		partitionTable:  w.partition,
+		partialWorkerID: w.workerID,
 	}
 
 	task.doneCh = make(chan error, 1)
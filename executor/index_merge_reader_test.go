@@ -20,6 +20,7 @@ import (
 	"strings"
 
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/util/israce"
 	"github.com/pingcap/tidb/util/testkit"
 )
@@ -170,3 +171,64 @@ func (s *testSuite1) TestPartitionTableRandomIndexMerge(c *C) {
 		tk.MustQuery("select /*+ USE_INDEX_MERGE(tpk, a, b) */ * from tpk where " + cond).Sort().Check(result)
 	}
 }
+
+func (s *testSuite1) TestIndexMergeIntersection(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, tp")
+	tk.MustExec("create table t(id int primary key, a int, b int, c int, key(a), key(b), key(c))")
+	tk.MustExec("insert into t values(1,1,1,1),(2,1,2,2),(3,1,2,3),(4,2,2,1),(5,2,1,2),(6,1,2,2)")
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ id from t where a = 1 and b = 2 order by id").Check(testkit.Rows("2", "3", "6"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b, c) */ id from t where a = 1 and b = 2 and c = 2 order by id").Check(testkit.Rows("2", "6"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ id from t where a = 1 and b = 2 and c > 2").Check(testkit.Rows("3"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ id from t where a = 2 and b = 3").Check(testkit.Rows())
+	// The intersected handles are sent to the table workers in several batches.
+	tk.MustExec("set @@tidb_index_lookup_size = 1")
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ id from t where a = 1 and b = 2 order by id").Check(testkit.Rows("2", "3", "6"))
+	tk.MustExec("set @@tidb_index_lookup_size = default")
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec(`create table tp (a int, b int, key(a), key(b))
+		partition by range (a) (
+		partition p1 values less than (10),
+		partition p2 values less than (20),
+		partition p3 values less than (30),
+		partition p4 values less than (40))`)
+	values := make([]string, 0, 128)
+	for i := 0; i < 128; i++ {
+		values = append(values, fmt.Sprintf("(%v, %v)", rand.Intn(40), rand.Intn(40)))
+	}
+	tk.MustExec(fmt.Sprintf("insert into tp values %v", strings.Join(values, ", ")))
+	for i := 0; i < 64; i++ {
+		la, lb := rand.Intn(40), rand.Intn(40)
+		cond := fmt.Sprintf("a > %v and b < %v", la, lb)
+		c.Assert(tk.HasPlan("select /*+ USE_INDEX_MERGE(tp, a, b) */ * from tp where "+cond, "IndexMerge"), IsTrue)
+		result := tk.MustQuery("select /*+ ignore_index(tp, a, b) */ * from tp where " + cond).Sort().Rows()
+		tk.MustQuery("select /*+ USE_INDEX_MERGE(tp, a, b) */ * from tp where " + cond).Sort().Check(result)
+	}
+}
+
+func (s *testSuite1) TestIndexMergeIntersectionMemTracker(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b int, key(a), key(b))")
+	values := make([]string, 0, 256)
+	for i := 0; i < 256; i++ {
+		values = append(values, fmt.Sprintf("(%v, 1, 1)", i))
+	}
+	tk.MustExec(fmt.Sprintf("insert into t values %v", strings.Join(values, ", ")))
+	sql := "select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 1"
+	c.Assert(tk.MustQuery(sql).Rows(), HasLen, 256)
+
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMAction = config.OOMActionCancel
+	})
+	tk.MustExec("set @@tidb_mem_quota_query = 1")
+	err := tk.QueryToErr(sql)
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, ".*Out Of Memory Quota!.*")
+	tk.MustExec("set @@tidb_mem_quota_query = default")
+	c.Assert(tk.MustQuery(sql).Rows(), HasLen, 256)
+}
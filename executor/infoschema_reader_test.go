@@ -984,3 +984,47 @@ func (s *testInfoschemaTableSuite) TestTablesPKType(c *C) {
 	tk.MustQuery("SELECT TIDB_PK_TYPE FROM information_schema.tables where table_schema = 'test' and table_name = 't_common'").Check(testkit.Rows("CLUSTERED"))
 	tk.MustQuery("SELECT TIDB_PK_TYPE FROM information_schema.tables where table_schema = 'INFORMATION_SCHEMA' and table_name = 'TABLES'").Check(testkit.Rows("NONCLUSTERED"))
 }
+
+func (s *testInfoschemaTableSuite) TestCostCalibration(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	// Reading the table doesn't calibrate the cost.
+	tk.MustQuery("select * from information_schema.tidb_cost_calibration").Check(testkit.Rows())
+	tk.MustExec("admin calibrate cost")
+	rows := tk.MustQuery("select factor, current_value, recommended_value > 0, unit from information_schema.tidb_cost_calibration").Rows()
+	c.Assert(rows, HasLen, 6)
+	factors := make([]string, 0, len(rows))
+	for _, row := range rows {
+		factors = append(factors, row[0].(string))
+		// An unreliable measurement recommends nothing, which is NULL.
+		if row[2] != "<nil>" {
+			c.Assert(row[2], Equals, "1")
+		}
+	}
+	c.Assert(factors, DeepEquals, []string{"tidb_opt_copcpu_factor", "tidb_opt_cpu_factor", "tidb_opt_desc_factor",
+		"tidb_opt_network_factor", "tidb_opt_scan_factor", "tidb_opt_seek_factor"})
+	// The stored results are shown until the next calibration.
+	tk.MustExec("set @@tidb_opt_cpu_factor = 5")
+	tk.MustQuery("select current_value from information_schema.tidb_cost_calibration where factor = 'tidb_opt_cpu_factor'").Check(
+		testkit.Rows("3"))
+	// The recommended CPU factor is always the current one, others are relative to it.
+	tk.MustExec("admin calibrate cost")
+	tk.MustQuery("select current_value, recommended_value from information_schema.tidb_cost_calibration where factor = 'tidb_opt_cpu_factor'").Check(
+		testkit.Rows("5 5"))
+	// The factors of unreliable measurements are stored with 0 nanoseconds and shown as NULL.
+	tk.MustExec("update mysql.cost_calibration set nanoseconds = 0 where factor = 'tidb_opt_seek_factor'")
+	tk.MustQuery("select current_value, recommended_value, nanoseconds from information_schema.tidb_cost_calibration where factor = 'tidb_opt_seek_factor'").Check(
+		testkit.Rows("20 <nil> <nil>"))
+	// The scratch tables are dropped after calibrating.
+	tk.MustQuery("select count(*) from information_schema.tables where table_schema = 'mysql' and table_name like 'tidb_cost_calibration%'").Check(
+		testkit.Rows("0"))
+
+	tk.MustExec("create user cost_calibration_tester")
+	tester := testkit.NewTestKitWithInit(c, s.store)
+	c.Assert(tester.Se.Auth(&auth.UserIdentity{
+		Username: "cost_calibration_tester",
+		Hostname: "127.0.0.1",
+	}, nil, nil), IsTrue)
+	err := tester.ExecToErr("admin calibrate cost")
+	c.Assert(err.Error(), Equals, "[planner:8121]privilege check for 'Super' fail")
+	tester.MustQuery("select count(*) from information_schema.tidb_cost_calibration").Check(testkit.Rows("6"))
+}
@@ -2610,3 +2610,55 @@ func (s *testSuiteJoinSerial) TestIssue25902(c *C) {
 	tk.MustQuery("select * from tt1 where ts in (select ts from tt2);").Check(testkit.Rows())
 	tk.MustExec("set @@session.time_zone = @tmp;")
 }
+
+func (s *testSuiteJoin3) TestNullEQJoinKeys(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t1, t2, t3, t4")
+	tk.MustExec("create table t1(a int, b int, key(a))")
+	tk.MustExec("create table t2(a int, b int, key(a, b))")
+	tk.MustExec("create table t3(a int not null, b int, primary key(a) clustered)")
+	tk.MustExec("create table t4(a int, b int, key(a)) partition by hash(a) partitions 3")
+	tk.MustExec("insert into t1 values (1, 1), (null, 2), (2, null), (null, null), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 1), (null, 2), (null, null), (2, 2), (4, 4)")
+	tk.MustExec("insert into t3 values (1, 1), (2, 2), (4, 4)")
+	tk.MustExec("insert into t4 values (1, 1), (null, 2), (null, null), (2, 2), (4, 4)")
+
+	// Merge join.
+	sql := "select /*+ MERGE_JOIN(t1, t2) */ t1.a, t1.b, t2.a, t2.b from t1 %s join t2 on t1.a <=> t2.a order by t1.a, t1.b, t2.a, t2.b"
+	c.Assert(tk.HasPlan(fmt.Sprintf(sql, ""), "MergeJoin"), IsTrue)
+	tk.MustQuery(fmt.Sprintf(sql, "")).Check(testkit.Rows(
+		"<nil> <nil> <nil> <nil>", "<nil> <nil> <nil> 2", "<nil> 2 <nil> <nil>",
+		"<nil> 2 <nil> 2", "1 1 1 1", "2 <nil> 2 2"))
+	tk.MustQuery(fmt.Sprintf(sql, "left")).Check(testkit.Rows(
+		"<nil> <nil> <nil> <nil>", "<nil> <nil> <nil> 2", "<nil> 2 <nil> <nil>",
+		"<nil> 2 <nil> 2", "1 1 1 1", "2 <nil> 2 2", "3 3 <nil> <nil>"))
+	tk.MustQuery("select /*+ MERGE_JOIN(t1, t2) */ t1.a, t1.b from t1 join t2 on t1.a <=> t2.a and t1.b = t2.b order by t1.a, t1.b").Check(testkit.Rows(
+		"<nil> 2", "1 1"))
+	tk.MustQuery("select /*+ MERGE_JOIN(t1, t2) */ t1.a, t1.b from t1 where not exists (select 1 from t2 where t1.a <=> t2.a) order by t1.a").Check(testkit.Rows(
+		"3 3"))
+
+	// Join reorder should keep the null-safe equal conditions.
+	tk.MustQuery("select count(*) from t1, t2, t4 where t1.a <=> t2.a and t2.a <=> t4.a").Check(testkit.Rows("10"))
+	tk.MustQuery("select count(*) from t1, t2, t4 where t1.a <=> t4.a and t2.a <=> t4.a").Check(testkit.Rows("10"))
+
+	// Index joins.
+	for _, hint := range []string{"INL_JOIN", "INL_HASH_JOIN", "INL_MERGE_JOIN"} {
+		sql = fmt.Sprintf("select /*+ %s(t2) */ t1.a, t1.b, t2.a, t2.b from t1 join t2 on t1.a <=> t2.a order by t1.a, t1.b, t2.a, t2.b", hint)
+		c.Assert(tk.HasPlan(sql, "IndexRangeScan"), IsTrue)
+		tk.MustQuery(sql).Check(testkit.Rows(
+			"<nil> <nil> <nil> <nil>", "<nil> <nil> <nil> 2", "<nil> 2 <nil> <nil>",
+			"<nil> 2 <nil> 2", "1 1 1 1", "2 <nil> 2 2"))
+		tk.MustQuery("show warnings").Check(testkit.Rows())
+		sql = fmt.Sprintf("select /*+ %s(t2) */ t1.a, t1.b, t2.a, t2.b from t1 left join t2 on t1.a <=> t2.a and t1.b <=> t2.b order by t1.a, t1.b", hint)
+		tk.MustQuery(sql).Check(testkit.Rows(
+			"<nil> <nil> <nil> <nil>", "<nil> 2 <nil> 2", "1 1 1 1", "2 <nil> <nil> <nil>", "3 3 <nil> <nil>"))
+		// NULL keys can't match the NOT NULL inner column.
+		sql = fmt.Sprintf("select /*+ %s(t3) */ t1.a, t3.b from t1 left join t3 on t1.a <=> t3.a order by t1.a, t1.b", hint)
+		tk.MustQuery(sql).Check(testkit.Rows(
+			"<nil> <nil>", "<nil> <nil>", "1 1", "2 2", "3 <nil>"))
+	}
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	defer tk.MustExec("set @@tidb_partition_prune_mode = default")
+	tk.MustQuery("select /*+ INL_JOIN(t4) */ t1.b, t4.b from t1 join t4 on t1.a <=> t4.a order by t1.b, t4.b").Check(testkit.Rows(
+		"<nil> <nil>", "<nil> 2", "<nil> 2", "1 1", "2 <nil>", "2 2"))
+}
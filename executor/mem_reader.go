@@ -15,6 +15,8 @@
 package executor
 
 import (
+	"sort"
+
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
@@ -46,6 +48,8 @@ type memIndexReader struct {
 	outputOffset  []int
 	// belowHandleCols is the handle's position of the below scan plan.
 	belowHandleCols plannercore.HandleCols
+	// cacheTable is the data of the cached table, it's nil if the table isn't read from the cache.
+	cacheTable kv.MemBuffer
 }
 
 func buildMemIndexReader(us *UnionScanExec, idxReader *IndexReaderExecutor) *memIndexReader {
@@ -64,6 +68,7 @@ func buildMemIndexReader(us *UnionScanExec, idxReader *IndexReaderExecutor) *mem
 		retFieldTypes:   retTypes(us),
 		outputOffset:    outputOffset,
 		belowHandleCols: us.belowHandleCols,
+		cacheTable:      us.cacheTable,
 	}
 }
 
@@ -92,7 +97,7 @@ func (m *memIndexReader) getMemRows() ([][]types.Datum, error) {
 	}
 
 	mutableRow := chunk.MutRowFromTypes(m.retFieldTypes)
-	err := iterTxnMemBuffer(m.ctx, m.kvRanges, func(key, value []byte) error {
+	err := iterTxnMemBuffer(m.ctx, m.cacheTable, m.kvRanges, func(key, value []byte) error {
 		data, err := m.decodeIndexKeyValue(key, value, tps)
 		if err != nil {
 			return err
@@ -152,6 +157,8 @@ type memTableReader struct {
 	colIDs        map[int64]int
 	buffer        allocBuf
 	pkColIDs      []int64
+	// cacheTable is the data of the cached table, it's nil if the table isn't read from the cache.
+	cacheTable kv.MemBuffer
 }
 
 type allocBuf struct {
@@ -194,14 +201,15 @@ func buildMemTableReader(us *UnionScanExec, tblReader *TableReaderExecutor) *mem
 			handleBytes: make([]byte, 0, 16),
 			rd:          rd,
 		},
-		pkColIDs: pkColIDs,
+		pkColIDs:   pkColIDs,
+		cacheTable: us.cacheTable,
 	}
 }
 
 // TODO: Try to make memXXXReader lazy, There is no need to decode many rows when parent operator only need 1 row.
 func (m *memTableReader) getMemRows() ([][]types.Datum, error) {
 	mutableRow := chunk.MutRowFromTypes(m.retFieldTypes)
-	err := iterTxnMemBuffer(m.ctx, m.kvRanges, func(key, value []byte) error {
+	err := iterTxnMemBuffer(m.ctx, m.cacheTable, m.kvRanges, func(key, value []byte) error {
 		row, err := m.decodeRecordKeyValue(key, value)
 		if err != nil {
 			return err
@@ -319,7 +327,9 @@ func hasColVal(data [][]byte, colIDs map[int64]int, id int64) bool {
 
 type processKVFunc func(key, value []byte) error
 
-func iterTxnMemBuffer(ctx sessionctx.Context, kvRanges []kv.KeyRange, fn processKVFunc) error {
+// iterTxnMemBuffer iterates the rows in the transaction MemBuffer, merged with the data of the
+// temporary tables and cacheTable, the data of the cached table if it isn't nil.
+func iterTxnMemBuffer(ctx sessionctx.Context, cacheTable kv.MemBuffer, kvRanges []kv.KeyRange, fn processKVFunc) error {
 	txn, err := ctx.Txn(true)
 	if err != nil {
 		return err
@@ -339,6 +349,17 @@ func iterTxnMemBuffer(ctx sessionctx.Context, kvRanges []kv.KeyRange, fn process
 				return err
 			}
 		}
+		if cacheTable != nil {
+			cacheIter, err := cacheTable.Iter(rg.StartKey, rg.EndKey)
+			if err != nil {
+				return err
+			}
+
+			iter, err = transaction.NewUnionIter(iter, cacheIter, false)
+			if err != nil {
+				return err
+			}
+		}
 
 		for ; iter.Valid(); err = iter.Next() {
 			if err != nil {
@@ -365,7 +386,7 @@ func reverseDatumSlice(rows [][]types.Datum) {
 
 func (m *memIndexReader) getMemRowsHandle() ([]kv.Handle, error) {
 	handles := make([]kv.Handle, 0, m.addedRowsLen)
-	err := iterTxnMemBuffer(m.ctx, m.kvRanges, func(key, value []byte) error {
+	err := iterTxnMemBuffer(m.ctx, m.cacheTable, m.kvRanges, func(key, value []byte) error {
 		handle, err := tablecodec.DecodeIndexHandle(key, value, len(m.index.Columns))
 		if err != nil {
 			return err
@@ -395,6 +416,8 @@ type memIndexLookUpReader struct {
 	retFieldTypes []*types.FieldType
 
 	idxReader *memIndexReader
+	// cacheTable is the data of the cached table, it's nil if the table isn't read from the cache.
+	cacheTable kv.MemBuffer
 
 	// partition mode
 	partitionMode     bool                  // if it is accessing a partition table
@@ -414,6 +437,7 @@ func buildMemIndexLookUpReader(us *UnionScanExec, idxLookUpReader *IndexLookUpEx
 		retFieldTypes:   retTypes(us),
 		outputOffset:    outputOffset,
 		belowHandleCols: us.belowHandleCols,
+		cacheTable:      us.cacheTable,
 	}
 
 	return &memIndexLookUpReader{
@@ -425,6 +449,7 @@ func buildMemIndexLookUpReader(us *UnionScanExec, idxLookUpReader *IndexLookUpEx
 		conditions:    us.conditions,
 		retFieldTypes: retTypes(us),
 		idxReader:     memIdxReader,
+		cacheTable:    us.cacheTable,
 
 		partitionMode:     idxLookUpReader.partitionTableMode,
 		partitionKVRanges: idxLookUpReader.partitionKVRanges,
@@ -462,15 +487,21 @@ func (m *memIndexLookUpReader) getMemRows() ([][]types.Datum, error) {
 		return nil, nil
 	}
 
-	colIDs := make(map[int64]int, len(m.columns))
-	for i, col := range m.columns {
+	memTblReader := buildMemTableReaderForHandles(m.ctx, m.table.Meta(), m.columns, m.conditions, m.retFieldTypes, tblKVRanges, numHandles, m.cacheTable)
+	return memTblReader.getMemRows()
+}
+
+// buildMemTableReaderForHandles builds a memTableReader which reads the rows of the given handle kv ranges.
+func buildMemTableReaderForHandles(ctx sessionctx.Context, tblInfo *model.TableInfo, columns []*model.ColumnInfo,
+	conditions []expression.Expression, retFieldTypes []*types.FieldType, kvRanges []kv.KeyRange, numHandles int, cacheTable kv.MemBuffer) *memTableReader {
+	colIDs := make(map[int64]int, len(columns))
+	for i, col := range columns {
 		colIDs[col.ID] = i
 	}
 
-	tblInfo := m.table.Meta()
-	colInfos := make([]rowcodec.ColInfo, 0, len(m.columns))
-	for i := range m.columns {
-		col := m.columns[i]
+	colInfos := make([]rowcodec.ColInfo, 0, len(columns))
+	for i := range columns {
+		col := columns[i]
 		colInfos = append(colInfos, rowcodec.ColInfo{
 			ID:         col.ID,
 			IsPKHandle: tblInfo.PKIsHandle && mysql.HasPriKeyFlag(col.Flag),
@@ -482,21 +513,152 @@ func (m *memIndexLookUpReader) getMemRows() ([][]types.Datum, error) {
 		pkColIDs = []int64{-1}
 	}
 	rd := rowcodec.NewByteDecoder(colInfos, pkColIDs, nil, nil)
-	memTblReader := &memTableReader{
-		ctx:           m.ctx,
-		table:         m.table.Meta(),
-		columns:       m.columns,
-		kvRanges:      tblKVRanges,
-		conditions:    m.conditions,
+	return &memTableReader{
+		ctx:           ctx,
+		table:         tblInfo,
+		columns:       columns,
+		kvRanges:      kvRanges,
+		conditions:    conditions,
 		addedRows:     make([][]types.Datum, 0, numHandles),
-		retFieldTypes: m.retFieldTypes,
+		retFieldTypes: retFieldTypes,
 		colIDs:        colIDs,
 		pkColIDs:      pkColIDs,
 		buffer: allocBuf{
 			handleBytes: make([]byte, 0, 16),
 			rd:          rd,
 		},
+		cacheTable: cacheTable,
+	}
+}
+
+func (m *memTableReader) getMemRowsHandle() ([]kv.Handle, error) {
+	handles := make([]kv.Handle, 0, 16)
+	err := iterTxnMemBuffer(m.ctx, m.cacheTable, m.kvRanges, func(key, value []byte) error {
+		handle, err := tablecodec.DecodeRowKey(key)
+		if err != nil {
+			return err
+		}
+		handles = append(handles, handle)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return handles, nil
+}
+
+type memIndexMergeReader struct {
+	ctx              sessionctx.Context
+	columns          []*model.ColumnInfo
+	table            table.Table
+	conditions       []expression.Expression
+	retFieldTypes    []*types.FieldType
+	indexMergeReader *IndexMergeReaderExecutor
+
+	// partition mode
+	partitionMode     bool                  // if it is accessing a partition table
+	partitionTables   []table.PhysicalTable // partition tables to access
+	partitionKVRanges [][][]kv.KeyRange     // kv ranges for these partition tables
+}
+
+func buildMemIndexMergeReader(us *UnionScanExec, indexMergeReader *IndexMergeReaderExecutor) *memIndexMergeReader {
+	return &memIndexMergeReader{
+		ctx:              us.ctx,
+		columns:          indexMergeReader.columns,
+		table:            indexMergeReader.table,
+		conditions:       us.conditions,
+		retFieldTypes:    retTypes(us),
+		indexMergeReader: indexMergeReader,
+
+		partitionMode:     indexMergeReader.partitionTableMode,
+		partitionTables:   indexMergeReader.prunedPartitions,
+		partitionKVRanges: indexMergeReader.partitionKeyRanges,
+	}
+}
+
+func (m *memIndexMergeReader) getMemRows() ([][]types.Datum, error) {
+	kvRanges := [][][]kv.KeyRange{m.indexMergeReader.keyRanges}
+	tbls := []table.Table{m.table}
+	if m.partitionMode {
+		kvRanges = m.partitionKVRanges
+		tbls = tbls[:0]
+		for _, p := range m.partitionTables {
+			tbls = append(tbls, p)
+		}
+	}
+
+	tblKVRanges := make([]kv.KeyRange, 0, 16)
+	numHandles := 0
+	for i, tbl := range tbls {
+		handles, err := m.getMemRowsHandle(tbl, kvRanges[i])
+		if err != nil {
+			return nil, err
+		}
+		if len(handles) == 0 {
+			continue
+		}
+		numHandles += len(handles)
+		tblKVRanges = append(tblKVRanges, distsql.TableHandlesToKVRanges(getPhysicalTableID(tbl), handles)...)
+	}
+	if numHandles == 0 {
+		return nil, nil
 	}
 
+	memTblReader := buildMemTableReaderForHandles(m.ctx, m.table.Meta(), m.columns, m.conditions, m.retFieldTypes, tblKVRanges, numHandles, nil)
 	return memTblReader.getMemRows()
 }
+
+// getMemRowsHandle unions or intersects the handles which are read from the mem-buffer by every partial plan.
+func (m *memIndexMergeReader) getMemRowsHandle(tbl table.Table, kvRanges [][]kv.KeyRange) ([]kv.Handle, error) {
+	partialPlanCount := len(m.indexMergeReader.partialPlans)
+	hMap := kv.NewHandleMap()
+	for i := 0; i < partialPlanCount; i++ {
+		var handles []kv.Handle
+		var err error
+		if idx := m.indexMergeReader.indexes[i]; idx != nil {
+			memIdxReader := &memIndexReader{
+				ctx:      m.ctx,
+				index:    idx,
+				table:    m.table.Meta(),
+				kvRanges: kvRanges[i],
+			}
+			handles, err = memIdxReader.getMemRowsHandle()
+		} else {
+			tblRanges := kvRanges[i]
+			if !m.table.Meta().IsCommonHandle {
+				tblRanges = distsql.TableRangesToKVRanges(getPhysicalTableID(tbl), m.indexMergeReader.ranges[i], nil)
+			}
+			memTblReader := &memTableReader{
+				ctx:      m.ctx,
+				kvRanges: tblRanges,
+			}
+			handles, err = memTblReader.getMemRowsHandle()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range handles {
+			hits := 0
+			if v, ok := hMap.Get(h); ok {
+				hits = v.(int)
+			}
+			// The handles read by the same partial plan are distinct, so the hits equals to i only if the handle
+			// is read by all the partial plans before.
+			if !m.indexMergeReader.isIntersection || hits == i {
+				hMap.Set(h, hits+1)
+			}
+		}
+	}
+
+	handles := make([]kv.Handle, 0, hMap.Len())
+	hMap.Range(func(h kv.Handle, val interface{}) bool {
+		if !m.indexMergeReader.isIntersection || val.(int) == partialPlanCount {
+			handles = append(handles, h)
+		}
+		return true
+	})
+	sort.Slice(handles, func(i, j int) bool {
+		return handles[i].Compare(handles[j]) < 0
+	})
+	return handles, nil
+}
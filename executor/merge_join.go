@@ -57,6 +57,9 @@ type mergeJoinTable struct {
 	childIndex int
 	joinKeys   []*expression.Column
 	filters    []expression.Expression
+	// isNullEQ[i] is true when joinKeys[i] comes from a `<=>` join condition,
+	// NULL values of such keys can be matched with each other.
+	isNullEQ []bool
 
 	executed          bool
 	childChunk        *chunk.Chunk
@@ -267,7 +270,10 @@ func (t *mergeJoinTable) fetchNextOuterGroup(ctx context.Context, exec *MergeJoi
 }
 
 func (t *mergeJoinTable) hasNullInJoinKey(row chunk.Row) bool {
-	for _, col := range t.joinKeys {
+	for i, col := range t.joinKeys {
+		if len(t.isNullEQ) > i && t.isNullEQ[i] {
+			continue
+		}
 		ordinal := col.Index
 		if row.IsNull(ordinal) {
 			return true
@@ -0,0 +1,186 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// MergeUnionExec merges the ordered results of its children and keeps the
+// order of ByItems, it is used to satisfy the order required by the parent,
+// e.g. `ORDER BY ... LIMIT` over UNION ALL or a partitioned table, without
+// sorting all the rows again.
+// Unlike UnionExec, the children are read on demand in the main thread,
+// so the merge stops pulling data as soon as the parent is satisfied.
+type MergeUnionExec struct {
+	baseExecutor
+
+	ByItems     []*util.ByItems
+	keyColumns  []int
+	keyCmpFuncs []chunk.CompareFunc
+
+	cursors  []*mergeUnionCursor
+	heap     *mergeUnionHeap
+	prepared bool
+}
+
+// mergeUnionCursor points to the current row of a child of MergeUnionExec.
+type mergeUnionCursor struct {
+	childID int
+	chk     *chunk.Chunk
+	idx     int
+}
+
+func (c *mergeUnionCursor) row() chunk.Row {
+	return c.chk.GetRow(c.idx)
+}
+
+type mergeUnionHeap struct {
+	cursors    []*mergeUnionCursor
+	compareRow func(rowI, rowJ chunk.Row) int
+}
+
+func (h *mergeUnionHeap) Len() int {
+	return len(h.cursors)
+}
+
+func (h *mergeUnionHeap) Less(i, j int) bool {
+	cmp := h.compareRow(h.cursors[i].row(), h.cursors[j].row())
+	if cmp != 0 {
+		return cmp < 0
+	}
+	// Keep the output stable for the rows with the same keys.
+	return h.cursors[i].childID < h.cursors[j].childID
+}
+
+func (h *mergeUnionHeap) Swap(i, j int) {
+	h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i]
+}
+
+func (h *mergeUnionHeap) Push(x interface{}) {
+	h.cursors = append(h.cursors, x.(*mergeUnionCursor))
+}
+
+func (h *mergeUnionHeap) Pop() interface{} {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
+
+// Open implements the Executor Open interface.
+func (e *MergeUnionExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.keyColumns = make([]int, 0, len(e.ByItems))
+	e.keyCmpFuncs = make([]chunk.CompareFunc, 0, len(e.ByItems))
+	for _, item := range e.ByItems {
+		col, ok := item.Expr.(*expression.Column)
+		if !ok {
+			return errors.New("the by items of MergeUnionExec should be columns")
+		}
+		e.keyColumns = append(e.keyColumns, col.Index)
+		e.keyCmpFuncs = append(e.keyCmpFuncs, chunk.GetCompareFunc(col.RetType))
+	}
+	e.cursors = make([]*mergeUnionCursor, len(e.children))
+	for i := range e.children {
+		e.cursors[i] = &mergeUnionCursor{childID: i, chk: newFirstChunk(e.children[i])}
+	}
+	e.heap = &mergeUnionHeap{cursors: make([]*mergeUnionCursor, 0, len(e.children)), compareRow: e.compareRow}
+	e.prepared = false
+	return nil
+}
+
+func (e *MergeUnionExec) compareRow(rowI, rowJ chunk.Row) int {
+	for i, colIdx := range e.keyColumns {
+		cmp := e.keyCmpFuncs[i](rowI, colIdx, rowJ, colIdx)
+		if e.ByItems[i].Desc {
+			cmp = -cmp
+		}
+		if cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// fetchNextChunk fetches the next non-empty chunk of the child which the cursor belongs to.
+// It returns false if the child is drained.
+func (e *MergeUnionExec) fetchNextChunk(ctx context.Context, cursor *mergeUnionCursor) (bool, error) {
+	cursor.idx = 0
+	err := Next(ctx, e.children[cursor.childID], cursor.chk)
+	if err != nil {
+		return false, err
+	}
+	return cursor.chk.NumRows() > 0, nil
+}
+
+func (e *MergeUnionExec) prepare(ctx context.Context) error {
+	for _, cursor := range e.cursors {
+		ok, err := e.fetchNextChunk(ctx, cursor)
+		if err != nil {
+			return err
+		}
+		if ok {
+			e.heap.cursors = append(e.heap.cursors, cursor)
+		}
+	}
+	heap.Init(e.heap)
+	e.prepared = true
+	return nil
+}
+
+// Next implements the Executor Next interface.
+func (e *MergeUnionExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.prepared {
+		if err := e.prepare(ctx); err != nil {
+			return err
+		}
+	}
+	for !req.IsFull() && e.heap.Len() > 0 {
+		cursor := e.heap.cursors[0]
+		req.AppendRow(cursor.row())
+		cursor.idx++
+		if cursor.idx < cursor.chk.NumRows() {
+			heap.Fix(e.heap, 0)
+			continue
+		}
+		// The rows in req are copied, so the chunk of the cursor can be reused.
+		ok, err := e.fetchNextChunk(ctx, cursor)
+		if err != nil {
+			return err
+		}
+		if ok {
+			heap.Fix(e.heap, 0)
+		} else {
+			heap.Remove(e.heap, 0)
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *MergeUnionExec) Close() error {
+	e.cursors = nil
+	e.heap = nil
+	return e.baseExecutor.Close()
+}
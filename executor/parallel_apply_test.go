@@ -16,14 +16,17 @@ package executor_test
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/session"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/israce"
+	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/testkit"
 )
 
@@ -409,17 +412,25 @@ func (s *testSerialSuite) TestApplyWithOtherFeatures(c *C) {
 	// plan cache
 	orgEnable := core.PreparedPlanCacheEnabled()
 	core.SetPreparedPlanCache(true)
-	tk.MustExec("drop table if exists t1, t2")
-	tk.MustExec("create table t1(a int, b int)")
-	tk.MustExec("create table t2(a int, b int)")
-	tk.MustExec("insert into t1 values (1, 1), (1, 5), (2, 3), (2, 4), (3, 3)")
-	tk.MustExec("insert into t2 values (0, 1), (2, -1), (3, 2)")
-	tk.MustExec(`prepare stmt from "select * from t1 where t1.b >= (select sum(t2.b) from t2 where t2.a > t1.a and t2.a > ?)"`)
-	tk.MustExec("set @a=1")
-	tk.MustQuery("execute stmt using @a").Sort().Check(testkit.Rows("1 1", "1 5", "2 3", "2 4"))
-	tk.MustExec("set @a=2")
-	tk.MustQuery("execute stmt using @a").Sort().Check(testkit.Rows("1 5", "2 3", "2 4"))
-	tk.MustQuery(" select @@last_plan_from_cache").Check(testkit.Rows("0")) // sub-queries are not cacheable
+	tkCache := testkit.NewTestKit(c, s.store)
+	var err error
+	tkCache.Se, err = session.CreateSession4TestWithOpt(s.store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+	tkCache.MustExec("use test")
+	tkCache.MustExec("set tidb_enable_parallel_apply=true")
+	tkCache.MustExec("drop table if exists t1, t2")
+	tkCache.MustExec("create table t1(a int, b int)")
+	tkCache.MustExec("create table t2(a int, b int)")
+	tkCache.MustExec("insert into t1 values (1, 1), (1, 5), (2, 3), (2, 4), (3, 3)")
+	tkCache.MustExec("insert into t2 values (0, 1), (2, -1), (3, 2)")
+	tkCache.MustExec(`prepare stmt from "select * from t1 where t1.b >= (select sum(t2.b) from t2 where t2.a > t1.a and t2.a > ?)"`)
+	tkCache.MustExec("set @a=1")
+	tkCache.MustQuery("execute stmt using @a").Sort().Check(testkit.Rows("1 1", "1 5", "2 3", "2 4"))
+	tkCache.MustExec("set @a=2")
+	tkCache.MustQuery("execute stmt using @a").Sort().Check(testkit.Rows("1 5", "2 3", "2 4"))
+	tkCache.MustQuery(" select @@last_plan_from_cache").Check(testkit.Rows("1"))
 	core.SetPreparedPlanCache(orgEnable)
 
 	// cluster index
@@ -63,6 +63,8 @@ func (b *executorBuilder) buildPointGet(p *plannercore.PointGetPlan) Executor {
 	e.Init(p, startTS)
 	if e.lock {
 		b.hasLock = true
+	} else {
+		e.cacheTable = b.getCacheTable(p.TblInfo)
 	}
 	return e
 }
@@ -96,6 +98,9 @@ type PointGetExecutor struct {
 	// virtualColumnRetFieldTypes records the RetFieldTypes of virtual columns.
 	virtualColumnRetFieldTypes []*types.FieldType
 
+	// cacheTable is the data of the cached table, the row is read from it rather than TiKV if it isn't nil.
+	cacheTable kv.MemBuffer
+
 	stats *runtimeStatsWithSnapshot
 }
 
@@ -119,6 +124,7 @@ func (e *PointGetExecutor) Init(p *plannercore.PointGetPlan, startTs uint64) {
 	e.rowDecoder = decoder
 	e.partInfo = p.PartitionInfo
 	e.columns = p.Columns
+	e.cacheTable = nil
 	e.buildVirtualColumnInfo()
 }
 
@@ -183,6 +189,9 @@ func (e *PointGetExecutor) Open(context.Context) error {
 		}
 	})
 	setResourceGroupTagForTxn(e.ctx.GetSessionVars().StmtCtx, e.snapshot)
+	if e.cacheTable != nil {
+		e.snapshot = cacheTableSnapshot{e.snapshot, e.cacheTable}
+	}
 	return nil
 }
 
@@ -651,3 +660,32 @@ func (e *runtimeStatsWithSnapshot) Merge(other execdetails.RuntimeStats) {
 func (e *runtimeStatsWithSnapshot) Tp() int {
 	return execdetails.TpRuntimeStatsWithSnapshot
 }
+
+// cacheTableSnapshot reads the data of the cached table from the cache rather than TiKV.
+// The cache holds the whole table, so the keys not in it don't exist.
+type cacheTableSnapshot struct {
+	kv.Snapshot
+	memBuffer kv.MemBuffer
+}
+
+func (s cacheTableSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]byte, error) {
+	values := make(map[string][]byte)
+	for _, key := range keys {
+		val, err := s.memBuffer.Get(ctx, key)
+		if kv.IsErrNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(val) == 0 {
+			continue
+		}
+		values[string(key)] = val
+	}
+	return values, nil
+}
+
+func (s cacheTableSnapshot) Get(ctx context.Context, key kv.Key) ([]byte, error) {
+	return s.memBuffer.Get(ctx, key)
+}
@@ -1070,6 +1070,15 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		fmt.Fprintf(buf, " ON COMMIT DELETE ROWS")
 	}
 
+	if ttlInfo := tableInfo.TTLInfo; ttlInfo != nil {
+		ttlEnable := "OFF"
+		if ttlInfo.Enable {
+			ttlEnable = "ON"
+		}
+		fmt.Fprintf(buf, " /*T![ttl] TTL=%s + INTERVAL %s %s */ /*T![ttl] TTL_ENABLE='%s' */",
+			stringutil.Escape(ttlInfo.ColumnName.O, sqlMode), ttlInfo.IntervalExprStr, ast.TimeUnitType(ttlInfo.IntervalTimeUnit).String(), ttlEnable)
+	}
+
 	if tableInfo.PlacementPolicyRef != nil {
 		fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=`%s` */", tableInfo.PlacementPolicyRef.Name.String())
 	}
@@ -1228,6 +1237,37 @@ func appendDirectPlacementInfo(directPlacementOpts *model.PlacementSettings, buf
 	fmt.Fprintf(buf, " */")
 }
 
+// appendPartitionIntervalInfo appends the interval of the interval partitioning in a feature comment.
+// The partitions are listed after it, so MySQL and the TiDB versions without the feature create the same partitions.
+func appendPartitionIntervalInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer) {
+	interval := partitionInfo.Interval
+	if interval == nil {
+		return
+	}
+	fmt.Fprintf(buf, " /*T![interval_partition] INTERVAL (%s", interval.IntervalExpr)
+	if interval.IntervalUnit != "" {
+		fmt.Fprintf(buf, " %s", interval.IntervalUnit)
+	}
+	lastRangeEnd := interval.FirstRangeEnd
+	for i, def := range partitionInfo.Definitions {
+		if i == 0 && interval.NullPart && def.Name.L == "p_null" || strings.EqualFold(def.LessThan[0], "MAXVALUE") {
+			continue
+		}
+		lastRangeEnd = def.LessThan[0]
+	}
+	fmt.Fprintf(buf, ") FIRST PARTITION LESS THAN (%s) LAST PARTITION LESS THAN (%s)", interval.FirstRangeEnd, lastRangeEnd)
+	if interval.NullPart {
+		buf.WriteString(" NULL PARTITION")
+	}
+	if interval.MaxValPart {
+		buf.WriteString(" MAXVALUE PARTITION")
+	}
+	if interval.Premake > 0 {
+		fmt.Fprintf(buf, " PREMAKE %d", interval.Premake)
+	}
+	buf.WriteString(" */")
+}
+
 func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer) {
 	if partitionInfo == nil {
 		return
@@ -1246,7 +1286,9 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer)
 				buf.WriteString(",")
 			}
 		}
-		buf.WriteString(") (\n")
+		buf.WriteString(")")
+		appendPartitionIntervalInfo(partitionInfo, buf)
+		buf.WriteString(" (\n")
 	} else if partitionInfo.Type == model.PartitionTypeList {
 		if len(partitionInfo.Columns) == 0 {
 			fmt.Fprintf(buf, "\nPARTITION BY %s (%s) (\n", partitionInfo.Type.String(), partitionInfo.Expr)
@@ -1261,7 +1303,9 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer)
 			fmt.Fprintf(buf, "\nPARTITION BY LIST COLUMNS(%s) (\n", colsName)
 		}
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s ( %s ) (\n", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s ( %s )", partitionInfo.Type.String(), partitionInfo.Expr)
+		appendPartitionIntervalInfo(partitionInfo, buf)
+		buf.WriteString(" (\n")
 	}
 	if partitionInfo.Type == model.PartitionTypeRange {
 		for i, def := range partitionInfo.Definitions {
@@ -160,7 +160,11 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	case *ast.ShutdownStmt:
 		err = e.executeShutdown(x)
 	case *ast.AdminStmt:
-		err = e.executeAdminReloadStatistics(x)
+		if x.Tp == ast.AdminCalibrateCost {
+			err = calibrateAndSaveCost(ctx, e.ctx)
+		} else {
+			err = e.executeAdminReloadStatistics(x)
+		}
 	}
 	e.done = true
 	return err
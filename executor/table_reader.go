@@ -64,6 +64,9 @@ type TableReaderExecutor struct {
 	baseExecutor
 
 	table table.Table
+	// dummy means the rows are read from the cache of the cached table by the UnionScanExec,
+	// the reader only computes the kv ranges.
+	dummy bool
 
 	// The source of key ranges varies from case to case.
 	// It may be calculated from PhysicalPlan by executorBuilder, or calculated from argument by dataBuilder;
@@ -177,9 +180,9 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 	}
 	firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(e.ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
 
-	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
+	// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
 	// Calculate the kv ranges here, UnionScan rely on this kv ranges.
-	if e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		kvReq, err := e.buildKVReq(ctx, firstPartRanges)
 		if err != nil {
 			return err
@@ -217,8 +220,8 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 // Next fills data into the chunk passed by its caller.
 // The task was actually done by tableReaderHandler.
 func (e *TableReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
-	if e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
-		// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
+	if e.dummy || e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
+		// Treat temporary table and cached table as dummy table, avoid sending distsql request to TiKV.
 		req.Reset()
 		return nil
 	}
@@ -262,7 +265,7 @@ func fillExtraPIDColumn(req *chunk.Chunk, extraPIDColumnIndex int, physicalID in
 
 // Close implements the Executor Close interface.
 func (e *TableReaderExecutor) Close() error {
-	if e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
+	if e.dummy || e.table.Meta() != nil && e.table.Meta().TempTableType != model.TempTableNone {
 		return nil
 	}
 
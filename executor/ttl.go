@@ -0,0 +1,64 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// ttlJobHistoryRetriever reads the TTL jobs from mysql.tidb_ttl_job_history. Only the jobs
+// of the tables which the user has any privilege on are shown.
+type ttlJobHistoryRetriever struct {
+	dummyCloser
+	retrieved bool
+}
+
+func (e *ttlJobHistoryRetriever) retrieve(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if e.retrieved {
+		return nil, nil
+	}
+	e.retrieved = true
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	stmt, err := exec.ParseWithParams(ctx, "select job_id, table_id, table_schema, table_name, expire_time, create_time, finish_time, "+
+		"scanned_rows, deleted_rows, error_delete_rows, status, message from mysql.tidb_ttl_job_history order by create_time")
+	if err != nil {
+		return nil, err
+	}
+	rows, fields, err := exec.ExecRestrictedStmt(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	res := make([][]types.Datum, 0, len(rows))
+	for _, row := range rows {
+		schema, table := strings.ToLower(row.GetString(2)), strings.ToLower(row.GetString(3))
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema, table, "", mysql.AllPrivMask) {
+			continue
+		}
+		res = append(res, row.GetDatumRow(fieldTypes))
+	}
+	return res, nil
+}
@@ -56,6 +56,10 @@ type UnionScanExec struct {
 	// virtualColumnIndex records all the indices of virtual columns and sort them in definition
 	// to make sure we can compute the virtual column in right order.
 	virtualColumnIndex []int
+
+	// cacheTable is the data of the cached table, it's not nil if the rows are read from the cache
+	// rather than the reader.
+	cacheTable kv.MemBuffer
 }
 
 // Open implements the Executor Open interface.
@@ -98,6 +102,8 @@ func (us *UnionScanExec) open(ctx context.Context) error {
 		us.addedRows, err = buildMemIndexReader(us, x).getMemRows()
 	case *IndexLookUpExecutor:
 		us.addedRows, err = buildMemIndexLookUpReader(us, x).getMemRows()
+	case *IndexMergeReaderExecutor:
+		us.addedRows, err = buildMemIndexMergeReader(us, x).getMemRows()
 	default:
 		err = fmt.Errorf("unexpected union scan children:%T", reader)
 	}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 
 	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/util/testkit"
 )
 
@@ -403,3 +404,50 @@ func (s *testSuite7) TestForApplyAndUnionScan(c *C) {
 	tk.MustQuery("select c_int, c_str from t where (select count(*) from t1 where t1.c_int in (t.c_int, t.c_int + 2, t.c_int + 10)) > 2").Check(testkit.Rows())
 	tk.MustExec("rollback")
 }
+
+func (s *testSuite7) TestUnionScanForIndexMerge(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, tc, tp")
+	tk.MustExec("create table t (id int primary key, a int, b int, c int, key(a), key(b))")
+	tk.MustExec("insert into t values (1, 1, 1, 1), (2, 2, 2, 2), (3, 3, 3, 3), (4, 4, 4, 4)")
+
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (5, 5, 5, 5), (6, 1, 6, 6)")
+	tk.MustExec("delete from t where id = 2")
+	// The index key of `a` is changed, and the index key of `b` is untouched.
+	tk.MustExec("update t set a = 10 where id = 3")
+	tk.MustExec("update t set c = 40 where id = 4")
+	sql := "select /*+ use_index_merge(t, a, b) */ * from t where a = 1 or b = 2 or b = 3 or a = 10 or b = 4 or a = 5"
+	c.Assert(tk.HasPlan(sql, "IndexMerge"), IsTrue)
+	c.Assert(tk.HasPlan(sql, "UnionScan"), IsTrue)
+	tk.MustQuery(sql).Sort().Check(testkit.Rows("1 1 1 1", "3 10 3 3", "4 4 4 40", "5 5 5 5", "6 1 6 6"))
+	tk.MustQuery("select /*+ use_index_merge(t, primary, b) */ * from t where id < 3 or b > 4").Sort().Check(testkit.Rows("1 1 1 1", "5 5 5 5", "6 1 6 6"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where a = 1 and b = 6").Check(testkit.Rows("6 1 6 6"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where a = 10 and b = 3").Check(testkit.Rows("3 10 3 3"))
+	tk.MustQuery("select /*+ use_index_merge(t, a, b) */ * from t where a = 3 and b = 3").Check(testkit.Rows())
+	tk.MustExec("rollback")
+
+	// clustered index
+	tk.Se.GetSessionVars().EnableClusteredIndex = variable.ClusteredIndexDefModeOn
+	tk.MustExec("create table tc (id varchar(10) primary key, a int, b int, key(a), key(b))")
+	tk.MustExec("insert into tc values ('1', 1, 1), ('2', 2, 2), ('3', 3, 3)")
+	tk.MustExec("begin")
+	tk.MustExec("insert into tc values ('4', 4, 4)")
+	tk.MustExec("update tc set b = 30 where id = '3'")
+	tk.MustExec("delete from tc where id = '1'")
+	tk.MustQuery("select /*+ use_index_merge(tc, primary, b) */ * from tc where id < '2' or b > 3").Sort().Check(testkit.Rows("3 3 30", "4 4 4"))
+	tk.MustQuery("select /*+ use_index_merge(tc, a, b) */ * from tc where a = 3 and b = 30").Check(testkit.Rows("3 3 30"))
+	tk.MustExec("commit")
+
+	// partition table
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustExec("create table tp (a int, b int, key(a), key(b)) partition by hash(a) partitions 4")
+	tk.MustExec("insert into tp values (1, 1), (2, 2), (3, 3), (4, 4)")
+	tk.MustExec("begin")
+	tk.MustExec("insert into tp values (5, 5), (6, 6)")
+	tk.MustExec("update tp set b = 10 where a = 2")
+	tk.MustExec("delete from tp where a = 3")
+	tk.MustQuery("select /*+ use_index_merge(tp, a, b) */ * from tp where a < 2 or b > 4").Sort().Check(testkit.Rows("1 1", "2 10", "5 5", "6 6"))
+	tk.MustExec("rollback")
+}
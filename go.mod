@@ -96,3 +96,6 @@ require (
 // cloud.google.com/go/storage will upgrade grpc to v1.40.0
 // we need keep the replacement until go.etcd.io supports the higher version of grpc.
 replace google.golang.org/grpc => google.golang.org/grpc v1.29.1
+
+// the parser is maintained in this repository, see parser/README.md.
+replace github.com/pingcap/parser => ./parser
@@ -41,6 +41,8 @@ type Builder struct {
 	// TODO: store is only used by autoid allocators
 	// detach allocators from storage, use passed transaction in the feature
 	store kv.Storage
+	// sessPool is used by the cached tables to access their locks.
+	sessPool table.SessionPool
 }
 
 // ApplyDiff applies SchemaDiff to the new InfoSchema.
@@ -52,6 +54,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return nil, b.applyCreateSchema(m, diff)
 	case model.ActionDropSchema:
 		return b.applyDropSchema(diff.SchemaID), nil
+	case model.ActionRecoverSchema:
+		return b.applyRecoverSchema(m, diff)
 	case model.ActionModifySchemaCharsetAndCollate:
 		return nil, b.applyModifySchemaCharsetAndCollate(m, diff)
 	case model.ActionCreatePlacementPolicy:
@@ -298,6 +302,27 @@ func (b *Builder) applyCreateSchema(m *meta.Meta, diff *model.SchemaDiff) error
 	return nil
 }
 
+func (b *Builder) applyRecoverSchema(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	if err := b.applyCreateSchema(m, diff); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tblIDs := make([]int64, 0, len(diff.AffectedOpts))
+	for _, opt := range diff.AffectedOpts {
+		affectedDiff := &model.SchemaDiff{
+			Version:  diff.Version,
+			Type:     model.ActionRecoverTable,
+			SchemaID: opt.SchemaID,
+			TableID:  opt.TableID,
+		}
+		affectedIDs, err := b.ApplyDiff(m, affectedDiff)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tblIDs = append(tblIDs, affectedIDs...)
+	}
+	return tblIDs, nil
+}
+
 func (b *Builder) applyModifySchemaCharsetAndCollate(m *meta.Meta, diff *model.SchemaDiff) error {
 	di, err := m.GetDatabase(diff.SchemaID)
 	if err != nil {
@@ -429,7 +454,7 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 			}
 		}
 	}
-	tbl, err := tables.TableFromMeta(allocs, tblInfo)
+	tbl, err := b.tableFromMeta(allocs, tblInfo)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -592,7 +617,7 @@ func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.
 	}
 
 	for _, di := range dbInfos {
-		err := b.createSchemaTablesForDB(di, tables.TableFromMeta)
+		err := b.createSchemaTablesForDB(di, b.tableFromMeta)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -613,6 +638,18 @@ func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, bundles []*placement.
 	return b, nil
 }
 
+// tableFromMeta creates the table from the table info, and initializes it if it's cached.
+func (b *Builder) tableFromMeta(alloc autoid.Allocators, tblInfo *model.TableInfo) (table.Table, error) {
+	tbl, err := tables.TableFromMeta(alloc, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if t, ok := tbl.(table.CachedTable); ok {
+		t.Init(b.store, b.sessPool)
+	}
+	return tbl, nil
+}
+
 type tableFromMetaFunc func(alloc autoid.Allocators, tblInfo *model.TableInfo) (table.Table, error)
 
 func (b *Builder) createSchemaTablesForDB(di *model.DBInfo, tableFromMeta tableFromMetaFunc) error {
@@ -648,10 +685,12 @@ func RegisterVirtualTable(dbInfo *model.DBInfo, tableFromMeta tableFromMetaFunc)
 	drivers = append(drivers, &virtualTableDriver{dbInfo, tableFromMeta})
 }
 
-// NewBuilder creates a new Builder with a Handle.
-func NewBuilder(store kv.Storage) *Builder {
+// NewBuilder creates a new Builder with a Handle. sessPool can be nil if the cached tables are
+// never read from the cache, e.g. in the tests.
+func NewBuilder(store kv.Storage, sessPool table.SessionPool) *Builder {
 	return &Builder{
-		store: store,
+		store:    store,
+		sessPool: sessPool,
 		is: &infoSchema{
 			schemaMap:           map[string]*schemaTables{},
 			policyMap:           map[string]*model.PolicyInfo{},
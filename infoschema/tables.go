@@ -179,6 +179,10 @@ const (
 	TableDataLockWaits = "DATA_LOCK_WAITS"
 	// TableRegionLabel is the string constant of region label table.
 	TableRegionLabel = "REGION_LABEL"
+	// TableTiDBCostCalibration is the string constant of the cost calibration table.
+	TableTiDBCostCalibration = "TIDB_COST_CALIBRATION"
+	// TableTiDBTTLJobHistory is the string constant of the TTL job history table.
+	TableTiDBTTLJobHistory = "TIDB_TTL_JOB_HISTORY"
 )
 
 const (
@@ -275,6 +279,8 @@ var tableIDMap = map[string]int64{
 	ClusterTableStatementsSummaryEvicted:    autoid.InformationSchemaDBID + 76,
 	TableRegionLabel:                        autoid.InformationSchemaDBID + 77,
 	TableTiDBHotRegionsHistory:              autoid.InformationSchemaDBID + 78,
+	TableTiDBCostCalibration:                autoid.InformationSchemaDBID + 79,
+	TableTiDBTTLJobHistory:                  autoid.InformationSchemaDBID + 80,
 }
 
 type columnInfo struct {
@@ -1451,6 +1457,36 @@ var tableRegionLabelCols = []columnInfo{
 	{name: "RANGES", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
 }
 
+// tableTiDBCostCalibrationCols is the columns of the cost calibration table, it shows
+// the factors of the cost model recommended by the last ADMIN CALIBRATE COST. The
+// recommendations are advisory, they are measured by micro-benchmarks on a single TiDB
+// and are never applied automatically.
+var tableTiDBCostCalibrationCols = []columnInfo{
+	{name: "FACTOR", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CURRENT_VALUE", tp: mysql.TypeDouble, size: 22},
+	{name: "RECOMMENDED_VALUE", tp: mysql.TypeDouble, size: 22, comment: "Advisory only, NULL if the measurement is unreliable"},
+	{name: "NANOSECONDS", tp: mysql.TypeDouble, size: 22, comment: "The measured time of processing one unit, NULL if the measurement is unreliable"},
+	{name: "UNIT", tp: mysql.TypeVarchar, size: 16},
+	{name: "CALIBRATE_TIME", tp: mysql.TypeTimestamp, size: 26, decimal: 3},
+}
+
+// tableTiDBTTLJobHistoryCols is the columns of the TTL job history table, it shows the jobs
+// which delete the expired rows of TTL tables in the last 90 days.
+var tableTiDBTTLJobHistoryCols = []columnInfo{
+	{name: "JOB_ID", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "TABLE_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "EXPIRE_TIME", tp: mysql.TypeDatetime, size: 19, comment: "The rows whose TTL column is before it are expired"},
+	{name: "CREATE_TIME", tp: mysql.TypeTimestamp, size: 26, decimal: 3},
+	{name: "FINISH_TIME", tp: mysql.TypeTimestamp, size: 26, decimal: 3},
+	{name: "SCANNED_ROWS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "DELETED_ROWS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "ERROR_DELETE_ROWS", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "STATUS", tp: mysql.TypeVarchar, size: 16, comment: "running, finished or failed"},
+	{name: "MESSAGE", tp: mysql.TypeBlob, size: types.UnspecifiedLength},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //  - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -1835,6 +1871,8 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableDeadlocks:                          tableDeadlocksCols,
 	TableDataLockWaits:                      tableDataLockWaitsCols,
 	TableRegionLabel:                        tableRegionLabelCols,
+	TableTiDBCostCalibration:                tableTiDBCostCalibrationCols,
+	TableTiDBTTLJobHistory:                  tableTiDBTTLJobHistoryCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
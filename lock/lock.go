@@ -95,6 +95,27 @@ func (c *Checker) CheckTableLock(db, table string, privilege mysql.PrivilegeType
 	return infoschema.ErrTableLocked.GenWithStackByArgs(tb.Meta().Name.L, tb.Meta().Lock.Tp, tb.Meta().Lock.Sessions[0])
 }
 
+// CheckTableReadOnly checks whether the table is locked as read only when table lock is disabled.
+// The flashback jobs lock the tables as read only even if table lock is disabled.
+func (c *Checker) CheckTableReadOnly(db, table string, privilege mysql.PrivilegeType) error {
+	if table == "" || util.IsMemOrSysDB(db) {
+		return nil
+	}
+	switch privilege {
+	case mysql.SelectPriv, mysql.ShowDBPriv, mysql.AllPrivMask, mysql.CreatePriv, mysql.CreateViewPriv:
+		return nil
+	}
+	tb, err := c.is.TableByName(model.NewCIStr(db), model.NewCIStr(table))
+	if err != nil {
+		// Leave the error to the statement, for example, "drop table if exists t" when t doesn't exist.
+		return nil
+	}
+	if lock := tb.Meta().Lock; lock != nil && lock.Tp == model.TableLockReadOnly {
+		return infoschema.ErrTableLocked.GenWithStackByArgs(tb.Meta().Name.L, lock.Tp, lock.Sessions[0])
+	}
+	return nil
+}
+
 func checkLockTpMeetPrivilege(tp model.TableLockType, privilege mysql.PrivilegeType) bool {
 	// TableLockReadOnly doesn't need to check in this, because it is session unrelated.
 	switch tp {
@@ -668,6 +668,7 @@ func (m *Meta) GetTable(dbID int64, tableID int64) (*model.TableInfo, error) {
 //	DDLJobList: list jobs
 //	DDLJobHistory: hash
 //	DDLJobReorg: hash
+//	DDLJobControl: hash
 //
 // for multi DDL workers, only one can become the owner
 // to operate DDL jobs, and dispatch them to MR Jobs.
@@ -677,6 +678,7 @@ var (
 	mDDLJobAddIdxList = []byte("DDLJobAddIdxList")
 	mDDLJobHistoryKey = []byte("DDLJobHistory")
 	mDDLJobReorgKey   = []byte("DDLJobReorg")
+	mDDLJobControlKey = []byte("DDLJobControl")
 )
 
 // JobListKeyType is a key type of the DDL job queue.
@@ -723,6 +725,29 @@ func (m *Meta) DeQueueDDLJob() (*model.Job, error) {
 	return m.deQueueDDLJob(m.jobListKey)
 }
 
+// DeQueueDDLJobByIdx removes the DDL job with the index from the list. The jobs before it are moved
+// backward by one, so the order of the other jobs is kept.
+func (m *Meta) DeQueueDDLJobByIdx(index int64) (*model.Job, error) {
+	if index == 0 {
+		return m.DeQueueDDLJob()
+	}
+	job, err := m.getDDLJob(m.jobListKey, index)
+	if err != nil || job == nil {
+		return nil, errors.Trace(err)
+	}
+	for i := index; i > 0; i-- {
+		value, err := m.txn.LIndex(m.jobListKey, i-1)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err = m.txn.LSet(m.jobListKey, i, value); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	_, err = m.txn.LPop(m.jobListKey)
+	return job, errors.Trace(err)
+}
+
 func (m *Meta) getDDLJob(key []byte, index int64) (*model.Job, error) {
 	value, err := m.txn.LIndex(key, index)
 	if err != nil || value == nil {
@@ -1195,6 +1220,42 @@ func getReorgJobFieldHandle(t *structure.TxStructure, reorgJobField []byte) (kv.
 	return bs, nil
 }
 
+// DDLJobControl is the control options of a DDL job set by users, the DDL owner checks them
+// when it runs the job.
+type DDLJobControl struct {
+	// Paused means the job shouldn't be run until it's resumed.
+	Paused bool `json:"paused"`
+	// Thread is the count of the backfill workers, 0 means using tidb_ddl_reorg_worker_cnt.
+	Thread int `json:"thread"`
+	// BatchSize is the count of rows backfilled in one txn, 0 means using tidb_ddl_reorg_batch_size.
+	BatchSize int `json:"batch_size"`
+}
+
+// GetDDLJobControl gets the control options of the job, it returns nil if there are none.
+func (m *Meta) GetDDLJobControl(jobID int64) (*DDLJobControl, error) {
+	value, err := m.txn.HGet(mDDLJobControlKey, m.jobIDKey(jobID))
+	if err != nil || value == nil {
+		return nil, errors.Trace(err)
+	}
+	ctl := &DDLJobControl{}
+	err = json.Unmarshal(value, ctl)
+	return ctl, errors.Trace(err)
+}
+
+// SetDDLJobControl sets the control options of the job.
+func (m *Meta) SetDDLJobControl(jobID int64, ctl *DDLJobControl) error {
+	value, err := json.Marshal(ctl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.txn.HSet(mDDLJobControlKey, m.jobIDKey(jobID), value))
+}
+
+// RemoveDDLJobControl removes the control options of the job.
+func (m *Meta) RemoveDDLJobControl(jobID int64) error {
+	return errors.Trace(m.txn.HDel(mDDLJobControlKey, m.jobIDKey(jobID)))
+}
+
 func (m *Meta) schemaDiffKey(schemaVersion int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mSchemaDiffPrefix, schemaVersion))
 }
@@ -653,6 +653,85 @@ func TestAddIndexJob(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDDLJobControl(t *testing.T) {
+	t.Parallel()
+
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		err := store.Close()
+		require.NoError(t, err)
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		err := txn.Rollback()
+		require.NoError(t, err)
+	}()
+
+	m := meta.NewMeta(txn)
+	ctl, err := m.GetDDLJobControl(1)
+	require.NoError(t, err)
+	require.Nil(t, ctl)
+
+	expected := &meta.DDLJobControl{Paused: true, Thread: 4, BatchSize: 1024}
+	require.NoError(t, m.SetDDLJobControl(1, expected))
+	ctl, err = m.GetDDLJobControl(1)
+	require.NoError(t, err)
+	require.Equal(t, expected, ctl)
+	ctl, err = m.GetDDLJobControl(2)
+	require.NoError(t, err)
+	require.Nil(t, ctl)
+
+	require.NoError(t, m.RemoveDDLJobControl(1))
+	ctl, err = m.GetDDLJobControl(1)
+	require.NoError(t, err)
+	require.Nil(t, ctl)
+}
+
+func TestDeQueueDDLJobByIdx(t *testing.T) {
+	t.Parallel()
+
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		err := store.Close()
+		require.NoError(t, err)
+	}()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		err := txn.Rollback()
+		require.NoError(t, err)
+	}()
+
+	m := meta.NewMeta(txn)
+	for id := int64(1); id <= 4; id++ {
+		require.NoError(t, m.EnQueueDDLJob(&model.Job{ID: id}))
+	}
+	job, err := m.DeQueueDDLJobByIdx(2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), job.ID)
+	job, err = m.DeQueueDDLJobByIdx(0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), job.ID)
+	job, err = m.DeQueueDDLJobByIdx(2)
+	require.NoError(t, err)
+	require.Nil(t, job)
+
+	// The order of the other jobs is kept.
+	l, err := m.DDLJobQueueLen()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), l)
+	for idx, id := range []int64{2, 4} {
+		job, err = m.GetDDLJobByIdx(int64(idx))
+		require.NoError(t, err)
+		require.Equal(t, id, job.ID)
+	}
+}
+
 func BenchmarkGenGlobalIDs(b *testing.B) {
 	store, err := mockstore.NewMockStore()
 	require.NoError(b, err)
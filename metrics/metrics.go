@@ -157,6 +157,12 @@ func RegisterMetrics() {
 	prometheus.MustRegister(TopSQLIgnoredCounter)
 	prometheus.MustRegister(TopSQLReportDurationHistogram)
 	prometheus.MustRegister(TopSQLReportDataHistogram)
+	prometheus.MustRegister(TTLJobCounter)
+	prometheus.MustRegister(TTLRowsCounter)
+	prometheus.MustRegister(TTLJobDuration)
+	prometheus.MustRegister(TableCacheReadCounter)
+	prometheus.MustRegister(TableCacheLoadDuration)
+	prometheus.MustRegister(TableCacheWriteLockDuration)
 
 	tikvmetrics.InitMetrics(TiDB, TiKVClient)
 	tikvmetrics.RegisterMetrics()
@@ -0,0 +1,51 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Cached table metrics.
+var (
+	TableCacheReadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "read_cached_table_total",
+			Help:      "Counter of the reads of cached tables, hit means the read is served by the cache.",
+		}, []string{LblType})
+
+	TableCacheLoadDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "load_table_cache_seconds",
+			Help:      "Bucket histogram of the duration of loading cached tables.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 20), // 1ms ~ 524s
+		}, []string{LblResult})
+
+	TableCacheWriteLockDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "server",
+			Name:      "table_cache_write_lock_seconds",
+			Help:      "Bucket histogram of the duration of the writes to cached tables waiting for the write lock.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16), // 1ms ~ 32s
+		})
+)
+
+// Label constants of cached table metrics.
+const (
+	TableCacheHit  = "hit"
+	TableCacheMiss = "miss"
+)
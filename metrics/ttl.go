@@ -0,0 +1,52 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// TTL metrics.
+var (
+	TTLJobCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "ttl",
+			Name:      "job_total",
+			Help:      "Counter of the finished TTL jobs.",
+		}, []string{LblResult})
+
+	TTLRowsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "ttl",
+			Name:      "rows_total",
+			Help:      "Counter of the expired rows scanned, deleted and failed to delete by TTL jobs.",
+		}, []string{LblType})
+
+	TTLJobDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "tidb",
+			Subsystem: "ttl",
+			Name:      "job_duration_seconds",
+			Help:      "Bucket histogram of the duration of TTL jobs.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 24), // 10ms ~ 23h
+		}, []string{LblResult})
+)
+
+// Label constants of TTL metrics.
+const (
+	TTLRowsScanned = "scanned"
+	TTLRowsDeleted = "deleted"
+	TTLRowsFailed  = "failed"
+)
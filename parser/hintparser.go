@@ -0,0 +1,1386 @@
+// Code generated by goyacc DO NOT EDIT.
+
+// Copyright 2020 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parser
+
+import __yyfmt__ "fmt"
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+)
+
+type yyhintSymType struct {
+	yys         int
+	offset      int
+	ident       string
+	number      uint64
+	hint        *ast.TableOptimizerHint
+	hints       []*ast.TableOptimizerHint
+	table       ast.HintTable
+	modelIdents []model.CIStr
+	leadingList *ast.LeadingList
+	leadingItem interface{}
+}
+
+type yyhintXError struct {
+	state, xsym int
+}
+
+const (
+	yyhintDefault             = 57416
+	yyhintEOFCode             = 57344
+	yyhintErrCode             = 57345
+	hintAggToCop              = 57376
+	hintBCJoin                = 57389
+	hintBCJoinPreferLocal     = 57390
+	hintBKA                   = 57354
+	hintBNL                   = 57356
+	hintDupsWeedOut           = 57412
+	hintFalse                 = 57408
+	hintFirstMatch            = 57413
+	hintForceIndex            = 57401
+	hintGB                    = 57411
+	hintHashAgg               = 57378
+	hintHashJoin              = 57358
+	hintIdentifier            = 57347
+	hintIgnoreIndex           = 57379
+	hintIgnorePlanCache       = 57377
+	hintIndexMerge            = 57362
+	hintInlHashJoin           = 57380
+	hintInlJoin               = 57381
+	hintInlMergeJoin          = 57382
+	hintIntLit                = 57346
+	hintJoinFixedOrder        = 57350
+	hintJoinOrder             = 57351
+	hintJoinPrefix            = 57352
+	hintJoinSuffix            = 57353
+	hintLeading               = 57402
+	hintLimitToCop            = 57400
+	hintLooseScan             = 57414
+	hintMB                    = 57410
+	hintMRR                   = 57364
+	hintMaterialization       = 57415
+	hintMaxExecutionTime      = 57372
+	hintMemoryQuota           = 57383
+	hintMerge                 = 57360
+	hintNoBKA                 = 57355
+	hintNoBNL                 = 57357
+	hintNoHashJoin            = 57359
+	hintNoICP                 = 57366
+	hintNoIndexMerge          = 57363
+	hintNoMRR                 = 57365
+	hintNoMerge               = 57361
+	hintNoRangeOptimization   = 57367
+	hintNoSemijoin            = 57371
+	hintNoSkipScan            = 57369
+	hintNoSwapJoinInputs      = 57384
+	hintNthPlan               = 57399
+	hintOLAP                  = 57403
+	hintOLTP                  = 57404
+	hintPartition             = 57405
+	hintQBName                = 57375
+	hintQueryType             = 57385
+	hintReadConsistentReplica = 57386
+	hintReadFromStorage       = 57387
+	hintResourceGroup         = 57374
+	hintSMJoin                = 57388
+	hintSemijoin              = 57370
+	hintSetVar                = 57373
+	hintSingleAtIdentifier    = 57348
+	hintSkipScan              = 57368
+	hintStreamAgg             = 57391
+	hintStringLit             = 57349
+	hintSwapJoinInputs        = 57392
+	hintTiFlash               = 57407
+	hintTiKV                  = 57406
+	hintTimeRange             = 57397
+	hintTrue                  = 57409
+	hintUseCascades           = 57398
+	hintUseIndex              = 57394
+	hintUseIndexMerge         = 57393
+	hintUsePlanCache          = 57395
+	hintUseToja               = 57396
+
+	yyhintMaxDepth = 200
+	yyhintTabOfs   = -178
+)
+
+var (
+	yyhintXLAT = map[int]int{
+		41:    0,   // ')' (137x)
+		44:    1,   // ',' (128x)
+		57376: 2,   // hintAggToCop (128x)
+		57389: 3,   // hintBCJoin (128x)
+		57390: 4,   // hintBCJoinPreferLocal (128x)
+		57354: 5,   // hintBKA (128x)
+		57356: 6,   // hintBNL (128x)
+		57401: 7,   // hintForceIndex (128x)
+		57378: 8,   // hintHashAgg (128x)
+		57358: 9,   // hintHashJoin (128x)
+		57379: 10,  // hintIgnoreIndex (128x)
+		57377: 11,  // hintIgnorePlanCache (128x)
+		57362: 12,  // hintIndexMerge (128x)
+		57380: 13,  // hintInlHashJoin (128x)
+		57381: 14,  // hintInlJoin (128x)
+		57382: 15,  // hintInlMergeJoin (128x)
+		57350: 16,  // hintJoinFixedOrder (128x)
+		57351: 17,  // hintJoinOrder (128x)
+		57352: 18,  // hintJoinPrefix (128x)
+		57353: 19,  // hintJoinSuffix (128x)
+		57402: 20,  // hintLeading (128x)
+		57400: 21,  // hintLimitToCop (128x)
+		57372: 22,  // hintMaxExecutionTime (128x)
+		57383: 23,  // hintMemoryQuota (128x)
+		57360: 24,  // hintMerge (128x)
+		57364: 25,  // hintMRR (128x)
+		57355: 26,  // hintNoBKA (128x)
+		57357: 27,  // hintNoBNL (128x)
+		57359: 28,  // hintNoHashJoin (128x)
+		57366: 29,  // hintNoICP (128x)
+		57363: 30,  // hintNoIndexMerge (128x)
+		57361: 31,  // hintNoMerge (128x)
+		57365: 32,  // hintNoMRR (128x)
+		57367: 33,  // hintNoRangeOptimization (128x)
+		57371: 34,  // hintNoSemijoin (128x)
+		57369: 35,  // hintNoSkipScan (128x)
+		57384: 36,  // hintNoSwapJoinInputs (128x)
+		57399: 37,  // hintNthPlan (128x)
+		57375: 38,  // hintQBName (128x)
+		57385: 39,  // hintQueryType (128x)
+		57386: 40,  // hintReadConsistentReplica (128x)
+		57387: 41,  // hintReadFromStorage (128x)
+		57374: 42,  // hintResourceGroup (128x)
+		57370: 43,  // hintSemijoin (128x)
+		57373: 44,  // hintSetVar (128x)
+		57368: 45,  // hintSkipScan (128x)
+		57388: 46,  // hintSMJoin (128x)
+		57391: 47,  // hintStreamAgg (128x)
+		57392: 48,  // hintSwapJoinInputs (128x)
+		57397: 49,  // hintTimeRange (128x)
+		57398: 50,  // hintUseCascades (128x)
+		57394: 51,  // hintUseIndex (128x)
+		57393: 52,  // hintUseIndexMerge (128x)
+		57395: 53,  // hintUsePlanCache (128x)
+		57396: 54,  // hintUseToja (128x)
+		57412: 55,  // hintDupsWeedOut (105x)
+		57413: 56,  // hintFirstMatch (105x)
+		57414: 57,  // hintLooseScan (105x)
+		57415: 58,  // hintMaterialization (105x)
+		57407: 59,  // hintTiFlash (105x)
+		57406: 60,  // hintTiKV (105x)
+		57408: 61,  // hintFalse (104x)
+		57403: 62,  // hintOLAP (104x)
+		57404: 63,  // hintOLTP (104x)
+		57409: 64,  // hintTrue (104x)
+		57411: 65,  // hintGB (103x)
+		57410: 66,  // hintMB (103x)
+		57347: 67,  // hintIdentifier (102x)
+		57348: 68,  // hintSingleAtIdentifier (84x)
+		93:    69,  // ']' (77x)
+		57405: 70,  // hintPartition (71x)
+		40:    71,  // '(' (67x)
+		46:    72,  // '.' (67x)
+		61:    73,  // '=' (67x)
+		57344: 74,  // $end (25x)
+		57438: 75,  // QueryBlockOpt (18x)
+		57428: 76,  // Identifier (16x)
+		57346: 77,  // hintIntLit (8x)
+		57424: 78,  // HintTable (7x)
+		57349: 79,  // hintStringLit (5x)
+		57418: 80,  // CommaOpt (4x)
+		57425: 81,  // HintTableList (4x)
+		91:    82,  // '[' (3x)
+		57432: 83,  // LeadingTableElement (3x)
+		57417: 84,  // BooleanHintName (2x)
+		57419: 85,  // HintIndexList (2x)
+		57421: 86,  // HintStorageType (2x)
+		57422: 87,  // HintStorageTypeAndTable (2x)
+		57426: 88,  // HintTableListOpt (2x)
+		57431: 89,  // JoinOrderOptimizerHintName (2x)
+		57433: 90,  // LeadingTableList (2x)
+		57434: 91,  // NullaryHintName (2x)
+		57437: 92,  // PartitionListOpt (2x)
+		57440: 93,  // StorageOptimizerHintOpt (2x)
+		57441: 94,  // SubqueryOptimizerHintName (2x)
+		57444: 95,  // SubqueryStrategy (2x)
+		57445: 96,  // SupportedIndexLevelOptimizerHintName (2x)
+		57446: 97,  // SupportedTableLevelOptimizerHintName (2x)
+		57447: 98,  // TableOptimizerHintOpt (2x)
+		57449: 99,  // UnsupportedIndexLevelOptimizerHintName (2x)
+		57450: 100, // UnsupportedTableLevelOptimizerHintName (2x)
+		57420: 101, // HintQueryType (1x)
+		57423: 102, // HintStorageTypeAndTableList (1x)
+		57427: 103, // HintTrueOrFalse (1x)
+		57429: 104, // IndexNameList (1x)
+		57430: 105, // IndexNameListOpt (1x)
+		57435: 106, // OptimizerHintList (1x)
+		57436: 107, // PartitionList (1x)
+		57439: 108, // Start (1x)
+		57442: 109, // SubqueryStrategies (1x)
+		57443: 110, // SubqueryStrategiesOpt (1x)
+		57448: 111, // UnitOfBytes (1x)
+		57451: 112, // Value (1x)
+		57416: 113, // $default (0x)
+		57345: 114, // error (0x)
+	}
+
+	yyhintSymNames = []string{
+		"')'",
+		"','",
+		"hintAggToCop",
+		"hintBCJoin",
+		"hintBCJoinPreferLocal",
+		"hintBKA",
+		"hintBNL",
+		"hintForceIndex",
+		"hintHashAgg",
+		"hintHashJoin",
+		"hintIgnoreIndex",
+		"hintIgnorePlanCache",
+		"hintIndexMerge",
+		"hintInlHashJoin",
+		"hintInlJoin",
+		"hintInlMergeJoin",
+		"hintJoinFixedOrder",
+		"hintJoinOrder",
+		"hintJoinPrefix",
+		"hintJoinSuffix",
+		"hintLeading",
+		"hintLimitToCop",
+		"hintMaxExecutionTime",
+		"hintMemoryQuota",
+		"hintMerge",
+		"hintMRR",
+		"hintNoBKA",
+		"hintNoBNL",
+		"hintNoHashJoin",
+		"hintNoICP",
+		"hintNoIndexMerge",
+		"hintNoMerge",
+		"hintNoMRR",
+		"hintNoRangeOptimization",
+		"hintNoSemijoin",
+		"hintNoSkipScan",
+		"hintNoSwapJoinInputs",
+		"hintNthPlan",
+		"hintQBName",
+		"hintQueryType",
+		"hintReadConsistentReplica",
+		"hintReadFromStorage",
+		"hintResourceGroup",
+		"hintSemijoin",
+		"hintSetVar",
+		"hintSkipScan",
+		"hintSMJoin",
+		"hintStreamAgg",
+		"hintSwapJoinInputs",
+		"hintTimeRange",
+		"hintUseCascades",
+		"hintUseIndex",
+		"hintUseIndexMerge",
+		"hintUsePlanCache",
+		"hintUseToja",
+		"hintDupsWeedOut",
+		"hintFirstMatch",
+		"hintLooseScan",
+		"hintMaterialization",
+		"hintTiFlash",
+		"hintTiKV",
+		"hintFalse",
+		"hintOLAP",
+		"hintOLTP",
+		"hintTrue",
+		"hintGB",
+		"hintMB",
+		"hintIdentifier",
+		"hintSingleAtIdentifier",
+		"']'",
+		"hintPartition",
+		"'('",
+		"'.'",
+		"'='",
+		"$end",
+		"QueryBlockOpt",
+		"Identifier",
+		"hintIntLit",
+		"HintTable",
+		"hintStringLit",
+		"CommaOpt",
+		"HintTableList",
+		"'['",
+		"LeadingTableElement",
+		"BooleanHintName",
+		"HintIndexList",
+		"HintStorageType",
+		"HintStorageTypeAndTable",
+		"HintTableListOpt",
+		"JoinOrderOptimizerHintName",
+		"LeadingTableList",
+		"NullaryHintName",
+		"PartitionListOpt",
+		"StorageOptimizerHintOpt",
+		"SubqueryOptimizerHintName",
+		"SubqueryStrategy",
+		"SupportedIndexLevelOptimizerHintName",
+		"SupportedTableLevelOptimizerHintName",
+		"TableOptimizerHintOpt",
+		"UnsupportedIndexLevelOptimizerHintName",
+		"UnsupportedTableLevelOptimizerHintName",
+		"HintQueryType",
+		"HintStorageTypeAndTableList",
+		"HintTrueOrFalse",
+		"IndexNameList",
+		"IndexNameListOpt",
+		"OptimizerHintList",
+		"PartitionList",
+		"Start",
+		"SubqueryStrategies",
+		"SubqueryStrategiesOpt",
+		"UnitOfBytes",
+		"Value",
+		"$default",
+		"error",
+	}
+
+	yyhintReductions = []struct{ xsym, components int }{
+		{0, 1},
+		{108, 1},
+		{106, 1},
+		{106, 3},
+		{106, 1},
+		{106, 3},
+		{98, 4},
+		{98, 4},
+		{98, 4},
+		{98, 4},
+		{98, 4},
+		{98, 4},
+		{98, 5},
+		{98, 5},
+		{98, 5},
+		{98, 5},
+		{98, 6},
+		{98, 4},
+		{98, 4},
+		{98, 6},
+		{98, 6},
+		{98, 5},
+		{98, 4},
+		{98, 5},
+		{93, 5},
+		{102, 1},
+		{102, 3},
+		{87, 4},
+		{75, 0},
+		{75, 1},
+		{80, 0},
+		{80, 1},
+		{92, 0},
+		{92, 4},
+		{107, 1},
+		{107, 3},
+		{88, 1},
+		{88, 1},
+		{81, 2},
+		{81, 3},
+		{78, 3},
+		{78, 5},
+		{90, 1},
+		{90, 3},
+		{83, 1},
+		{83, 3},
+		{85, 4},
+		{105, 0},
+		{105, 1},
+		{104, 1},
+		{104, 3},
+		{110, 0},
+		{110, 1},
+		{109, 1},
+		{109, 3},
+		{112, 1},
+		{112, 1},
+		{112, 1},
+		{111, 1},
+		{111, 1},
+		{103, 1},
+		{103, 1},
+		{89, 1},
+		{89, 1},
+		{89, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{97, 1},
+		{99, 1},
+		{99, 1},
+		{99, 1},
+		{99, 1},
+		{99, 1},
+		{99, 1},
+		{99, 1},
+		{96, 1},
+		{96, 1},
+		{96, 1},
+		{96, 1},
+		{94, 1},
+		{94, 1},
+		{95, 1},
+		{95, 1},
+		{95, 1},
+		{95, 1},
+		{84, 1},
+		{84, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{91, 1},
+		{101, 1},
+		{101, 1},
+		{86, 1},
+		{86, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+		{76, 1},
+	}
+
+	yyhintXErrors = map[yyhintXError]string{}
+
+	yyhintParseTab = [268][]uint16{
+		// 0
+		{2: 239, 211, 212, 205, 207, 231, 237, 218, 229, 243, 221, 214, 213, 217, 183, 202, 203, 204, 190, 240, 191, 196, 219, 222, 206, 208, 209, 224, 241, 220, 223, 225, 233, 227, 216, 192, 195, 200, 242, 201, 194, 232, 193, 226, 210, 238, 215, 197, 235, 228, 230, 236, 234, 84: 198, 89: 184, 91: 199, 93: 182, 189, 96: 188, 186, 181, 187, 185, 106: 180, 108: 179},
+		{74: 178},
+		{1: 332, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 74: 177, 80: 443},
+		{1: 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 74: 176},
+		{1: 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 74: 174},
+		// 5
+		{71: 440},
+		{71: 437},
+		{71: 434},
+		{71: 429},
+		{71: 426},
+		// 10
+		{71: 415},
+		{71: 403},
+		{71: 392},
+		{71: 388},
+		{71: 384},
+		// 15
+		{71: 376},
+		{71: 373},
+		{71: 370},
+		{71: 363},
+		{71: 358},
+		// 20
+		{71: 352},
+		{71: 349},
+		{71: 343},
+		{71: 244},
+		{71: 116},
+		// 25
+		{71: 115},
+		{71: 114},
+		{71: 113},
+		{71: 112},
+		{71: 111},
+		// 30
+		{71: 110},
+		{71: 109},
+		{71: 108},
+		{71: 107},
+		{71: 106},
+		// 35
+		{71: 105},
+		{71: 104},
+		{71: 103},
+		{71: 102},
+		{71: 101},
+		// 40
+		{71: 100},
+		{71: 99},
+		{71: 98},
+		{71: 97},
+		{71: 96},
+		// 45
+		{71: 95},
+		{71: 94},
+		{71: 93},
+		{71: 92},
+		{71: 91},
+		// 50
+		{71: 90},
+		{71: 89},
+		{71: 88},
+		{71: 87},
+		{71: 86},
+		// 55
+		{71: 85},
+		{71: 80},
+		{71: 79},
+		{71: 78},
+		{71: 77},
+		// 60
+		{71: 76},
+		{71: 75},
+		{71: 74},
+		{71: 73},
+		{71: 72},
+		// 65
+		{71: 71},
+		{59: 150, 150, 68: 246, 75: 245},
+		{59: 251, 250, 86: 249, 248, 102: 247},
+		{149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 69: 149, 149, 149, 77: 149},
+		{340, 341},
+		// 70
+		{153, 153},
+		{82: 252},
+		{82: 68},
+		{82: 67},
+		{2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 254, 81: 253},
+		// 75
+		{1: 338, 69: 337},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 256, 78: 255},
+		{140, 140, 69: 140},
+		{150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 150, 150, 72: 324, 75: 323},
+		{66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 72: 66, 66},
+		// 80
+		{65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 72: 65, 65},
+		{64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 72: 64, 64},
+		{63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 72: 63, 63},
+		{62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 72: 62, 62},
+		{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 72: 61, 61},
+		// 85
+		{60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 72: 60, 60},
+		{59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 72: 59, 59},
+		{58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 72: 58, 58},
+		{57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 72: 57, 57},
+		{56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 72: 56, 56},
+		// 90
+		{55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 72: 55, 55},
+		{54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 72: 54, 54},
+		{53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 72: 53, 53},
+		{52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 72: 52, 52},
+		{51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 72: 51, 51},
+		// 95
+		{50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 72: 50, 50},
+		{49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 72: 49, 49},
+		{48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 72: 48, 48},
+		{47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 72: 47, 47},
+		{46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 72: 46, 46},
+		// 100
+		{45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 72: 45, 45},
+		{44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 72: 44, 44},
+		{43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 72: 43, 43},
+		{42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 72: 42, 42},
+		{41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 72: 41, 41},
+		// 105
+		{40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 72: 40, 40},
+		{39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 72: 39, 39},
+		{38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 72: 38, 38},
+		{37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 72: 37, 37},
+		{36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 72: 36, 36},
+		// 110
+		{35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 72: 35, 35},
+		{34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 72: 34, 34},
+		{33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 72: 33, 33},
+		{32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 72: 32, 32},
+		{31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 72: 31, 31},
+		// 115
+		{30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 72: 30, 30},
+		{29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 72: 29, 29},
+		{28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 72: 28, 28},
+		{27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 72: 27, 27},
+		{26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 72: 26, 26},
+		// 120
+		{25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 72: 25, 25},
+		{24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 72: 24, 24},
+		{23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 72: 23, 23},
+		{22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 72: 22, 22},
+		{21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 72: 21, 21},
+		// 125
+		{20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 72: 20, 20},
+		{19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 72: 19, 19},
+		{18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 72: 18, 18},
+		{17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 72: 17, 17},
+		{16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 72: 16, 16},
+		// 130
+		{15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 72: 15, 15},
+		{14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 72: 14, 14},
+		{13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 72: 13, 13},
+		{12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 72: 12, 12},
+		{11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 72: 11, 11},
+		// 135
+		{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 72: 10, 10},
+		{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 72: 9, 9},
+		{8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 72: 8, 8},
+		{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 72: 7, 7},
+		{6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 72: 6, 6},
+		// 140
+		{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 72: 5, 5},
+		{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 72: 4, 4},
+		{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 72: 3, 3},
+		{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 72: 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 72: 1, 1},
+		// 145
+		{146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 69: 146, 327, 92: 336},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 325},
+		{150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 150, 150, 75: 326},
+		{146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 69: 146, 327, 92: 328},
+		{71: 329},
+		// 150
+		{137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 137, 69: 137},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 331, 107: 330},
+		{333, 332, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 80: 334},
+		{144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144},
+		{147, 2: 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 79: 147},
+		// 155
+		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 69: 145},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 335},
+		{143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143},
+		{138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 69: 138},
+		{151, 151},
+		// 160
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 256, 78: 339},
+		{139, 139, 69: 139},
+		{1: 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 74: 154},
+		{59: 251, 250, 86: 249, 342},
+		{152, 152},
+		// 165
+		{62: 150, 150, 68: 246, 75: 344},
+		{62: 346, 347, 101: 345},
+		{348},
+		{70},
+		{69},
+		// 170
+		{1: 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 74: 155},
+		{150, 68: 246, 75: 350},
+		{351},
+		{1: 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 74: 156},
+		{61: 150, 64: 150, 68: 246, 75: 353},
+		// 175
+		{61: 356, 64: 355, 103: 354},
+		{357},
+		{118},
+		{117},
+		{1: 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 74: 157},
+		// 180
+		{79: 359},
+		{1: 332, 79: 148, 360},
+		{79: 361},
+		{362},
+		{1: 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 74: 158},
+		// 185
+		{68: 246, 75: 364, 77: 150},
+		{77: 365},
+		{65: 368, 367, 111: 366},
+		{369},
+		{120},
+		// 190
+		{119},
+		{1: 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 74: 159},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 371},
+		{372},
+		{1: 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 74: 160},
+		// 195
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 374},
+		{375},
+		{1: 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 74: 161},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 377},
+		{73: 378},
+		// 200
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 381, 382, 79: 380, 112: 379},
+		{383},
+		{123},
+		{122},
+		{121},
+		// 205
+		{1: 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 74: 162},
+		{68: 246, 75: 385, 77: 150},
+		{77: 386},
+		{387},
+		{1: 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 74: 163},
+		// 210
+		{68: 246, 75: 389, 77: 150},
+		{77: 390},
+		{391},
+		{1: 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 74: 164},
+		{2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 71: 150, 75: 393},
+		// 215
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 71: 397, 76: 256, 78: 396, 83: 395, 90: 394},
+		{402, 399},
+		{136, 136},
+		{134, 134},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 71: 397, 76: 256, 78: 396, 83: 395, 90: 398},
+		// 220
+		{400, 399},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 71: 397, 76: 256, 78: 396, 83: 401},
+		{133, 133},
+		{135, 135},
+		{1: 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 74: 165},
+		// 225
+		{150, 55: 150, 150, 150, 150, 68: 246, 75: 404},
+		{127, 55: 408, 409, 410, 411, 95: 407, 109: 406, 405},
+		{414},
+		{126, 412},
+		{125, 125},
+		// 230
+		{84, 84},
+		{83, 83},
+		{82, 82},
+		{81, 81},
+		{55: 408, 409, 410, 411, 95: 413},
+		// 235
+		{124, 124},
+		{1: 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 74: 166},
+		{2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 417, 85: 416},
+		{425},
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 256, 78: 418},
+		// 240
+		{148, 332, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 80: 419},
+		{131, 2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 422, 104: 421, 420},
+		{132},
+		{130, 423},
+		{129, 129},
+		// 245
+		{2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 424},
+		{128, 128},
+		{1: 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 74: 167},
+		{2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 417, 85: 427},
+		{428},
+		// 250
+		{1: 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 74: 168},
+		{150, 2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 432, 81: 431, 88: 430},
+		{433},
+		{142, 338},
+		{141, 2: 284, 298, 299, 262, 264, 309, 287, 266, 288, 286, 270, 289, 290, 291, 258, 259, 260, 261, 310, 285, 280, 292, 268, 272, 263, 265, 267, 274, 271, 269, 273, 275, 279, 277, 293, 308, 283, 294, 295, 296, 282, 278, 281, 276, 297, 300, 301, 306, 307, 303, 302, 304, 305, 319, 320, 321, 322, 314, 313, 315, 311, 312, 316, 318, 317, 257, 76: 256, 78: 255},
+		// 255
+		{1: 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 74: 169},
+		{150, 2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 432, 81: 431, 88: 435},
+		{436},
+		{1: 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 74: 170},
+		{2: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 246, 75: 254, 81: 438},
+		// 260
+		{439, 338},
+		{1: 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 74: 171},
+		{150, 68: 246, 75: 441},
+		{442},
+		{1: 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 74: 172},
+		// 265
+		{2: 239, 211, 212, 205, 207, 231, 237, 218, 229, 243, 221, 214, 213, 217, 183, 202, 203, 204, 190, 240, 191, 196, 219, 222, 206, 208, 209, 224, 241, 220, 223, 225, 233, 227, 216, 192, 195, 200, 242, 201, 194, 232, 193, 226, 210, 238, 215, 197, 235, 228, 230, 236, 234, 84: 198, 89: 184, 91: 199, 93: 445, 189, 96: 188, 186, 444, 187, 185},
+		{1: 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 74: 175},
+		{1: 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 74: 173},
+	}
+)
+
+var yyhintDebug = 0
+
+type yyhintLexer interface {
+	Lex(lval *yyhintSymType) int
+	Errorf(format string, a ...interface{}) error
+	AppendError(err error)
+	Errors() (warns []error, errs []error)
+}
+
+type yyhintLexerEx interface {
+	yyhintLexer
+	Reduced(rule, state int, lval *yyhintSymType) bool
+}
+
+func yyhintSymName(c int) (s string) {
+	x, ok := yyhintXLAT[c]
+	if ok {
+		return yyhintSymNames[x]
+	}
+
+	return __yyfmt__.Sprintf("%d", c)
+}
+
+func yyhintlex1(yylex yyhintLexer, lval *yyhintSymType) (n int) {
+	n = yylex.Lex(lval)
+	if n <= 0 {
+		n = yyhintEOFCode
+	}
+	if yyhintDebug >= 3 {
+		__yyfmt__.Printf("\nlex %s(%#x %d), lval: %+v\n", yyhintSymName(n), n, n, lval)
+	}
+	return n
+}
+
+func yyhintParse(yylex yyhintLexer, parser *hintParser) int {
+	const yyError = 114
+
+	yyEx, _ := yylex.(yyhintLexerEx)
+	var yyn int
+	parser.yylval = yyhintSymType{}
+	yyS := parser.cache
+
+	Nerrs := 0   /* number of errors */
+	Errflag := 0 /* error recovery flag */
+	yyerrok := func() {
+		if yyhintDebug >= 2 {
+			__yyfmt__.Printf("yyerrok()\n")
+		}
+		Errflag = 0
+	}
+	_ = yyerrok
+	yystate := 0
+	yychar := -1
+	var yyxchar int
+	var yyshift int
+	yyp := -1
+	goto yystack
+
+ret0:
+	return 0
+
+ret1:
+	return 1
+
+yystack:
+	/* put a state and value onto the stack */
+	yyp++
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yyhintSymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+		parser.cache = yyS
+	}
+	parser.yyVAL = &yyS[yyp+1]
+	yyS[yyp].yys = yystate
+
+yynewstate:
+	if yychar < 0 {
+		yychar = yyhintlex1(yylex, &parser.yylval)
+		var ok bool
+		if yyxchar, ok = yyhintXLAT[yychar]; !ok {
+			yyxchar = len(yyhintSymNames) // > tab width
+		}
+	}
+	if yyhintDebug >= 4 {
+		var a []int
+		for _, v := range yyS[:yyp+1] {
+			a = append(a, v.yys)
+		}
+		__yyfmt__.Printf("state stack %v\n", a)
+	}
+	row := yyhintParseTab[yystate]
+	yyn = 0
+	if yyxchar < len(row) {
+		if yyn = int(row[yyxchar]); yyn != 0 {
+			yyn += yyhintTabOfs
+		}
+	}
+	switch {
+	case yyn > 0: // shift
+		yychar = -1
+		*parser.yyVAL = parser.yylval
+		yystate = yyn
+		yyshift = yyn
+		if yyhintDebug >= 2 {
+			__yyfmt__.Printf("shift, and goto state %d\n", yystate)
+		}
+		if Errflag > 0 {
+			Errflag--
+		}
+		goto yystack
+	case yyn < 0: // reduce
+	case yystate == 1: // accept
+		if yyhintDebug >= 2 {
+			__yyfmt__.Println("accept")
+		}
+		goto ret0
+	}
+
+	if yyn == 0 {
+		/* error ... attempt to resume parsing */
+		switch Errflag {
+		case 0: /* brand new error */
+			if yyhintDebug >= 1 {
+				__yyfmt__.Printf("no action for %s in state %d\n", yyhintSymName(yychar), yystate)
+			}
+			msg, ok := yyhintXErrors[yyhintXError{yystate, yyxchar}]
+			if !ok {
+				msg, ok = yyhintXErrors[yyhintXError{yystate, -1}]
+			}
+			if !ok && yyshift != 0 {
+				msg, ok = yyhintXErrors[yyhintXError{yyshift, yyxchar}]
+			}
+			if !ok {
+				msg, ok = yyhintXErrors[yyhintXError{yyshift, -1}]
+			}
+			if !ok || msg == "" {
+				msg = "syntax error"
+			}
+			// ignore goyacc error message
+			yylex.AppendError(yylex.Errorf(""))
+			Nerrs++
+			fallthrough
+
+		case 1, 2: /* incompletely recovered error ... try again */
+			Errflag = 3
+
+			/* find a state where "error" is a legal shift action */
+			for yyp >= 0 {
+				row := yyhintParseTab[yyS[yyp].yys]
+				if yyError < len(row) {
+					yyn = int(row[yyError]) + yyhintTabOfs
+					if yyn > 0 { // hit
+						if yyhintDebug >= 2 {
+							__yyfmt__.Printf("error recovery found error shift in state %d\n", yyS[yyp].yys)
+						}
+						yystate = yyn /* simulate a shift of "error" */
+						goto yystack
+					}
+				}
+
+				/* the current p has no shift on "error", pop stack */
+				if yyhintDebug >= 2 {
+					__yyfmt__.Printf("error recovery pops state %d\n", yyS[yyp].yys)
+				}
+				yyp--
+			}
+			/* there is no state on the stack with an error shift ... abort */
+			if yyhintDebug >= 2 {
+				__yyfmt__.Printf("error recovery failed\n")
+			}
+			goto ret1
+
+		case 3: /* no shift yet; clobber input char */
+			if yyhintDebug >= 2 {
+				__yyfmt__.Printf("error recovery discards %s\n", yyhintSymName(yychar))
+			}
+			if yychar == yyhintEOFCode {
+				goto ret1
+			}
+
+			yychar = -1
+			goto yynewstate /* try again in the same state */
+		}
+	}
+
+	r := -yyn
+	x0 := yyhintReductions[r]
+	x, n := x0.xsym, x0.components
+	yypt := yyp
+	_ = yypt // guard against "declared and not used"
+
+	yyp -= n
+	if yyp+1 >= len(yyS) {
+		nyys := make([]yyhintSymType, len(yyS)*2)
+		copy(nyys, yyS)
+		yyS = nyys
+		parser.cache = yyS
+	}
+	parser.yyVAL = &yyS[yyp+1]
+
+	/* consult goto table to find next state */
+	exState := yystate
+	yystate = int(yyhintParseTab[yyS[yyp].yys][x]) + yyhintTabOfs
+	/* reduction by production r */
+	if yyhintDebug >= 2 {
+		__yyfmt__.Printf("reduce using rule %v (%s), and goto state %d\n", r, yyhintSymNames[x], yystate)
+	}
+
+	switch r {
+	case 1:
+		{
+			parser.result = yyS[yypt-0].hints
+		}
+	case 2:
+		{
+			if yyS[yypt-0].hint != nil {
+				parser.yyVAL.hints = []*ast.TableOptimizerHint{yyS[yypt-0].hint}
+			}
+		}
+	case 3:
+		{
+			if yyS[yypt-0].hint != nil {
+				parser.yyVAL.hints = append(yyS[yypt-2].hints, yyS[yypt-0].hint)
+			} else {
+				parser.yyVAL.hints = yyS[yypt-2].hints
+			}
+		}
+	case 4:
+		{
+			parser.yyVAL.hints = yyS[yypt-0].hints
+		}
+	case 5:
+		{
+			parser.yyVAL.hints = append(yyS[yypt-2].hints, yyS[yypt-0].hints...)
+		}
+	case 6:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-3].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 7:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-3].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 8:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-3].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 9:
+		{
+			h := yyS[yypt-1].hint
+			h.HintName = model.NewCIStr(yyS[yypt-3].ident)
+			parser.yyVAL.hint = h
+		}
+	case 10:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-3].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 11:
+		{
+			h := yyS[yypt-1].hint
+			h.HintName = model.NewCIStr(yyS[yypt-3].ident)
+			parser.yyVAL.hint = h
+		}
+	case 12:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-4].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 13:
+		{
+			parser.yyVAL.hint = newLeadingHint(yyS[yypt-4].ident, yyS[yypt-2].ident, yyS[yypt-1].leadingList)
+		}
+	case 14:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-4].ident),
+				QBName:   model.NewCIStr(yyS[yypt-2].ident),
+				HintData: yyS[yypt-1].number,
+			}
+		}
+	case 15:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-4].ident),
+				QBName:   model.NewCIStr(yyS[yypt-2].ident),
+				HintData: int64(yyS[yypt-1].number),
+			}
+		}
+	case 16:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-5].ident),
+				HintData: ast.HintSetVar{
+					VarName: yyS[yypt-3].ident,
+					Value:   yyS[yypt-1].ident,
+				},
+			}
+		}
+	case 17:
+		{
+			parser.warnUnsupportedHint(yyS[yypt-3].ident)
+			parser.yyVAL.hint = nil
+		}
+	case 18:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-3].ident),
+				QBName:   model.NewCIStr(yyS[yypt-1].ident),
+			}
+		}
+	case 19:
+		{
+			maxValue := uint64(math.MaxInt64) / yyS[yypt-1].number
+			if yyS[yypt-2].number <= maxValue {
+				parser.yyVAL.hint = &ast.TableOptimizerHint{
+					HintName: model.NewCIStr(yyS[yypt-5].ident),
+					HintData: int64(yyS[yypt-2].number * yyS[yypt-1].number),
+					QBName:   model.NewCIStr(yyS[yypt-3].ident),
+				}
+			} else {
+				yylex.AppendError(ErrWarnMemoryQuotaOverflow.GenWithStackByArgs(math.MaxInt64))
+				parser.lastErrorAsWarn()
+				parser.yyVAL.hint = nil
+			}
+		}
+	case 20:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-5].ident),
+				HintData: ast.HintTimeRange{
+					From: yyS[yypt-3].ident,
+					To:   yyS[yypt-1].ident,
+				},
+			}
+		}
+	case 21:
+		{
+			h := yyS[yypt-1].hint
+			h.HintName = model.NewCIStr(yyS[yypt-4].ident)
+			h.QBName = model.NewCIStr(yyS[yypt-2].ident)
+			parser.yyVAL.hint = h
+		}
+	case 22:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-3].ident),
+				QBName:   model.NewCIStr(yyS[yypt-1].ident),
+			}
+		}
+	case 23:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-4].ident),
+				QBName:   model.NewCIStr(yyS[yypt-2].ident),
+				HintData: model.NewCIStr(yyS[yypt-1].ident),
+			}
+		}
+	case 24:
+		{
+			hs := yyS[yypt-1].hints
+			name := model.NewCIStr(yyS[yypt-4].ident)
+			qb := model.NewCIStr(yyS[yypt-2].ident)
+			for _, h := range hs {
+				h.HintName = name
+				h.QBName = qb
+			}
+			parser.yyVAL.hints = hs
+		}
+	case 25:
+		{
+			parser.yyVAL.hints = []*ast.TableOptimizerHint{yyS[yypt-0].hint}
+		}
+	case 26:
+		{
+			parser.yyVAL.hints = append(yyS[yypt-2].hints, yyS[yypt-0].hint)
+		}
+	case 27:
+		{
+			h := yyS[yypt-1].hint
+			h.HintData = model.NewCIStr(yyS[yypt-3].ident)
+			parser.yyVAL.hint = h
+		}
+	case 28:
+		{
+			parser.yyVAL.ident = ""
+		}
+	case 32:
+		{
+			parser.yyVAL.modelIdents = nil
+		}
+	case 33:
+		{
+			parser.yyVAL.modelIdents = yyS[yypt-1].modelIdents
+		}
+	case 34:
+		{
+			parser.yyVAL.modelIdents = []model.CIStr{model.NewCIStr(yyS[yypt-0].ident)}
+		}
+	case 35:
+		{
+			parser.yyVAL.modelIdents = append(yyS[yypt-2].modelIdents, model.NewCIStr(yyS[yypt-0].ident))
+		}
+	case 37:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				QBName: model.NewCIStr(yyS[yypt-0].ident),
+			}
+		}
+	case 38:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				Tables: []ast.HintTable{yyS[yypt-0].table},
+				QBName: model.NewCIStr(yyS[yypt-1].ident),
+			}
+		}
+	case 39:
+		{
+			h := yyS[yypt-2].hint
+			h.Tables = append(h.Tables, yyS[yypt-0].table)
+			parser.yyVAL.hint = h
+		}
+	case 40:
+		{
+			parser.yyVAL.table = ast.HintTable{
+				TableName:     model.NewCIStr(yyS[yypt-2].ident),
+				QBName:        model.NewCIStr(yyS[yypt-1].ident),
+				PartitionList: yyS[yypt-0].modelIdents,
+			}
+		}
+	case 41:
+		{
+			parser.yyVAL.table = ast.HintTable{
+				DBName:        model.NewCIStr(yyS[yypt-4].ident),
+				TableName:     model.NewCIStr(yyS[yypt-2].ident),
+				QBName:        model.NewCIStr(yyS[yypt-1].ident),
+				PartitionList: yyS[yypt-0].modelIdents,
+			}
+		}
+	case 42:
+		{
+			parser.yyVAL.leadingList = &ast.LeadingList{Items: []interface{}{yyS[yypt-0].leadingItem}}
+		}
+	case 43:
+		{
+			l := yyS[yypt-2].leadingList
+			l.Items = append(l.Items, yyS[yypt-0].leadingItem)
+			parser.yyVAL.leadingList = l
+		}
+	case 44:
+		{
+			table := yyS[yypt-0].table
+			parser.yyVAL.leadingItem = &table
+		}
+	case 45:
+		{
+			parser.yyVAL.leadingItem = yyS[yypt-1].leadingList
+		}
+	case 46:
+		{
+			h := yyS[yypt-0].hint
+			h.Tables = []ast.HintTable{yyS[yypt-2].table}
+			h.QBName = model.NewCIStr(yyS[yypt-3].ident)
+			parser.yyVAL.hint = h
+		}
+	case 47:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{}
+		}
+	case 49:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				Indexes: []model.CIStr{model.NewCIStr(yyS[yypt-0].ident)},
+			}
+		}
+	case 50:
+		{
+			h := yyS[yypt-2].hint
+			h.Indexes = append(h.Indexes, model.NewCIStr(yyS[yypt-0].ident))
+			parser.yyVAL.hint = h
+		}
+	case 57:
+		{
+			parser.yyVAL.ident = strconv.FormatUint(yyS[yypt-0].number, 10)
+		}
+	case 58:
+		{
+			parser.yyVAL.number = 1024 * 1024
+		}
+	case 59:
+		{
+			parser.yyVAL.number = 1024 * 1024 * 1024
+		}
+	case 60:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{HintData: true}
+		}
+	case 61:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{HintData: false}
+		}
+
+	}
+
+	if !parser.lexer.skipPositionRecording {
+		yyhintSetOffset(parser.yyVAL, parser.yyVAL.offset)
+	}
+
+	if yyEx != nil && yyEx.Reduced(r, exState, parser.yyVAL) {
+		return -1
+	}
+	goto yystack /* stack new state and value */
+}
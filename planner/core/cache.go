@@ -174,6 +174,9 @@ type PSTMTPlanCacheValue struct {
 	OutPutNames       []*types.FieldName
 	TblInfo2UnionScan map[*model.TableInfo]bool
 	UserVarTypes      FieldSlice
+	// ScalarSubQueries are the lazily evaluated subqueries of the plan, their
+	// ranges are rebuilt when the plan is reused.
+	ScalarSubQueries []*ScalarSubqueryEvalCtx
 }
 
 // NewPSTMTPlanCacheValue creates a SQLCacheValue.
@@ -44,9 +44,10 @@ func Cacheable(node ast.Node, is infoschema.InfoSchema) bool {
 }
 
 // cacheableChecker checks whether a query's plan can be cached, querys that:
-//	 1. have ExistsSubqueryExpr, or
-//	 2. have VariableExpr
-// will not be cached currently.
+//	 1. have VariableExpr
+// will not be cached currently. The uncorrelated subqueries are evaluated
+// during execution when the plan is built for the plan cache, so the queries
+// with subqueries can be cached.
 // NOTE: we can add more rules in the future.
 type cacheableChecker struct {
 	cacheable bool
@@ -77,7 +78,7 @@ func (checker *cacheableChecker) Enter(in ast.Node) (out ast.Node, skipChildren
 				return in, true
 			}
 		}
-	case *ast.VariableExpr, *ast.ExistsSubqueryExpr, *ast.SubqueryExpr:
+	case *ast.VariableExpr:
 		checker.cacheable = false
 		return in, true
 	case *ast.FuncCallExpr:
@@ -78,9 +78,9 @@ func (s *testCacheableSuite) TestCacheable(c *C) {
 
 	stmt = &ast.DeleteStmt{
 		TableRefs: tableRefsClause,
-		Where:     &ast.ExistsSubqueryExpr{},
+		Where:     &ast.ExistsSubqueryExpr{Sel: &ast.SubqueryExpr{Query: &ast.SelectStmt{}}},
 	}
-	c.Assert(core.Cacheable(stmt, is), IsFalse)
+	c.Assert(core.Cacheable(stmt, is), IsTrue)
 
 	limitStmt := &ast.Limit{
 		Count: &driver.ParamMarkerExpr{},
@@ -130,9 +130,9 @@ func (s *testCacheableSuite) TestCacheable(c *C) {
 
 	stmt = &ast.UpdateStmt{
 		TableRefs: tableRefsClause,
-		Where:     &ast.ExistsSubqueryExpr{},
+		Where:     &ast.ExistsSubqueryExpr{Sel: &ast.SubqueryExpr{Query: &ast.SelectStmt{}}},
 	}
-	c.Assert(core.Cacheable(stmt, is), IsFalse)
+	c.Assert(core.Cacheable(stmt, is), IsTrue)
 
 	limitStmt = &ast.Limit{
 		Count: &driver.ParamMarkerExpr{},
@@ -180,9 +180,9 @@ func (s *testCacheableSuite) TestCacheable(c *C) {
 	c.Assert(core.Cacheable(stmt, is), IsTrue)
 
 	stmt = &ast.SelectStmt{
-		Where: &ast.ExistsSubqueryExpr{},
+		Where: &ast.ExistsSubqueryExpr{Sel: &ast.SubqueryExpr{Query: &ast.SelectStmt{}}},
 	}
-	c.Assert(core.Cacheable(stmt, is), IsFalse)
+	c.Assert(core.Cacheable(stmt, is), IsTrue)
 
 	limitStmt = &ast.Limit{
 		Count: &driver.ParamMarkerExpr{},
@@ -128,6 +128,16 @@ type CancelDDLJobs struct {
 	JobIDs []int64
 }
 
+// ControlDDLJobs represents a plan to pause, resume or alter the reorganization DDL jobs.
+type ControlDDLJobs struct {
+	baseSchemaProducer
+
+	Tp        ast.AdminStmtType
+	JobIDs    []int64
+	Thread    int
+	BatchSize int
+}
+
 // ReloadExprPushdownBlacklist reloads the data from expr_pushdown_blacklist table.
 type ReloadExprPushdownBlacklist struct {
 	baseSchemaProducer
@@ -448,6 +458,14 @@ func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context,
 						logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
 						goto REBUILD
 					}
+					for _, subQuery := range cachedVal.ScalarSubQueries {
+						err = e.rebuildRange(subQuery.scalarSubQuery)
+						if err != nil {
+							logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
+							goto REBUILD
+						}
+						registerScalarSubQuery(sctx, subQuery)
+					}
 					err = e.setFoundInPlanCache(sctx, true)
 					if err != nil {
 						return err
@@ -468,6 +486,8 @@ func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context,
 	}
 
 REBUILD:
+	// Drop the subqueries registered by the cached plan which is not used.
+	stmtCtx.ScalarSubQueries = nil
 	stmt := TryAddExtraLimit(sctx, prepared.Stmt)
 	p, names, err := OptimizeAstNode(ctx, sctx, stmt, is)
 	if err != nil {
@@ -488,6 +508,7 @@ REBUILD:
 			sessVars.IsolationReadEngines[kv.TiFlash] = struct{}{}
 		}
 		cached := NewPSTMTPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, tps)
+		cached.ScalarSubQueries = scalarSubQueries(sctx)
 		preparedStmt.NormalizedPlan, preparedStmt.PlanDigest = NormalizePlan(p)
 		stmtCtx.SetPlanDigest(preparedStmt.NormalizedPlan, preparedStmt.PlanDigest)
 		if cacheVals, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
@@ -1077,6 +1098,10 @@ func (e *Explain) RenderResult() error {
 			if err != nil {
 				return err
 			}
+			err = e.explainPlanInRowFormatScalarSubQuery()
+			if err != nil {
+				return err
+			}
 			err = e.explainPlanInRowFormatCTE()
 			if err != nil {
 				return err
@@ -1096,6 +1121,21 @@ func (e *Explain) RenderResult() error {
 	return nil
 }
 
+func (e *Explain) explainPlanInRowFormatScalarSubQuery() (err error) {
+	if e.ctx == nil {
+		return nil
+	}
+	for _, subQuery := range scalarSubQueries(e.ctx) {
+		e.prepareOperatorInfo(subQuery, "root", "", "", true)
+		childIndent := texttree.Indent4Child("", true)
+		err = e.explainPlanInRowFormat(subQuery.scalarSubQuery, "root", "", childIndent, true)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (e *Explain) explainPlanInRowFormatCTE() (err error) {
 	explainedCTEPlan := make(map[int]struct{})
 	for i := 0; i < len(e.ctes); i++ {
@@ -1399,6 +1439,7 @@ func (e *Explain) prepareTaskDot(p PhysicalPlan, taskTp string, buffer *bytes.Bu
 //  1. ctx is auto commit tagged
 //  2. session is not InTxn
 //  3. plan is point get by pk, or point get by unique index (no double read)
+//  4. the table isn't cached, the cache can't be read with math.MaxUint64
 func IsPointGetWithPKOrUniqueKeyByAutoCommit(ctx sessionctx.Context, p Plan) (bool, error) {
 	if !IsAutoCommitTxn(ctx) {
 		return false, nil
@@ -1430,7 +1471,7 @@ func IsPointGetWithPKOrUniqueKeyByAutoCommit(ctx sessionctx.Context, p Plan) (bo
 		// can't use max uint64, because using math.MaxUint64 can't guarantee repeatable-read
 		// and the data and index would be inconsistent!
 		isPointGet := v.IndexInfo == nil || (v.IndexInfo.Primary && v.TblInfo.IsCommonHandle)
-		return isPointGet, nil
+		return isPointGet && v.TblInfo.TableCacheStatusType != model.TableCacheStatusEnable, nil
 	default:
 		return false, nil
 	}
@@ -150,7 +150,7 @@ func (p *LogicalJoin) checkJoinKeyCollation(leftKeys, rightKeys []*expression.Co
 func (p *LogicalJoin) GetMergeJoin(prop *property.PhysicalProperty, schema *expression.Schema, statsInfo *property.StatsInfo, leftStatsInfo *property.StatsInfo, rightStatsInfo *property.StatsInfo) []PhysicalPlan {
 	joins := make([]PhysicalPlan, 0, len(p.leftProperties)+1)
 	// The leftProperties caches all the possible properties that are provided by its children.
-	leftJoinKeys, rightJoinKeys, isNullEQ, hasNullEQ := p.GetJoinKeys()
+	leftJoinKeys, rightJoinKeys, isNullEQ, _ := p.GetJoinKeys()
 
 	// EnumType/SetType Unsupported: merge join conflicts with index order.
 	// ref: https://github.com/pingcap/tidb/issues/24473, https://github.com/pingcap/tidb/issues/25669
@@ -165,10 +165,6 @@ func (p *LogicalJoin) GetMergeJoin(prop *property.PhysicalProperty, schema *expr
 		}
 	}
 
-	// TODO: support null equal join keys for merge join
-	if hasNullEQ {
-		return nil
-	}
 	for _, lhsChildProperty := range p.leftProperties {
 		offsets := getMaxSortPrefix(lhsChildProperty, leftJoinKeys)
 		// If not all equal conditions hit properties. We ban merge join heuristically. Because in this case, merge join
@@ -275,11 +271,7 @@ func getNewNullEQByOffsets(oldNullEQ []bool, offsets []int) []bool {
 
 func (p *LogicalJoin) getEnforcedMergeJoin(prop *property.PhysicalProperty, schema *expression.Schema, statsInfo *property.StatsInfo) []PhysicalPlan {
 	// Check whether SMJ can satisfy the required property
-	leftJoinKeys, rightJoinKeys, isNullEQ, hasNullEQ := p.GetJoinKeys()
-	// TODO: support null equal join keys for merge join
-	if hasNullEQ {
-		return nil
-	}
+	leftJoinKeys, rightJoinKeys, isNullEQ, _ := p.GetJoinKeys()
 	offsets := make([]int, 0, len(leftJoinKeys))
 	all, desc := prop.AllSameOrder()
 	if !all {
@@ -425,16 +417,11 @@ func (p *LogicalJoin) constructIndexJoin(
 		innerJoinKeys []*expression.Column
 		outerJoinKeys []*expression.Column
 		isNullEQ      []bool
-		hasNullEQ     bool
 	)
 	if outerIdx == 0 {
-		outerJoinKeys, innerJoinKeys, isNullEQ, hasNullEQ = p.GetJoinKeys()
+		outerJoinKeys, innerJoinKeys, isNullEQ, _ = p.GetJoinKeys()
 	} else {
-		innerJoinKeys, outerJoinKeys, isNullEQ, hasNullEQ = p.GetJoinKeys()
-	}
-	// TODO: support null equal join keys for index join
-	if hasNullEQ {
-		return nil
+		innerJoinKeys, outerJoinKeys, isNullEQ, _ = p.GetJoinKeys()
 	}
 	chReqProps := make([]*property.PhysicalProperty, 2)
 	chReqProps[outerIdx] = &property.PhysicalProperty{TaskTp: property.RootTaskType, ExpectedCnt: math.MaxFloat64, SortItems: prop.SortItems}
@@ -2665,9 +2652,39 @@ func (p *LogicalLock) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]P
 	return []PhysicalPlan{lock}, true, nil
 }
 
+// getMergeUnionAll returns a PhysicalUnionAll which keeps the order required by prop
+// by merging its ordered children, e.g. the ordered index scans of each partition.
+func (p *LogicalUnionAll) getMergeUnionAll(prop *property.PhysicalProperty) []PhysicalPlan {
+	if prop.TaskTp != property.RootTaskType || len(p.children) < 2 || !prop.AllColsFromSchema(p.schema) {
+		return nil
+	}
+	byItems := make([]*util.ByItems, 0, len(prop.SortItems))
+	for _, item := range prop.SortItems {
+		byItems = append(byItems, &util.ByItems{Expr: item.Col, Desc: item.Desc})
+	}
+	chReqProps := make([]*property.PhysicalProperty, 0, len(p.children))
+	for _, child := range p.children {
+		// The columns of the union and its children are matched by their offsets.
+		sortItems := make([]property.SortItem, 0, len(prop.SortItems))
+		for _, item := range prop.SortItems {
+			col := child.Schema().Columns[p.schema.ColumnIndex(item.Col)]
+			sortItems = append(sortItems, property.SortItem{Col: col, Desc: item.Desc})
+		}
+		chReqProps = append(chReqProps, &property.PhysicalProperty{
+			SortItems:   sortItems,
+			ExpectedCnt: prop.ExpectedCnt,
+		})
+	}
+	ua := PhysicalUnionAll{ByItems: byItems}.Init(p.ctx, p.stats.ScaleByExpectCnt(prop.ExpectedCnt), p.blockOffset, chReqProps...)
+	ua.SetSchema(p.Schema())
+	return []PhysicalPlan{ua}
+}
+
 func (p *LogicalUnionAll) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
-	// TODO: UnionAll can not pass any order, but we can change it to sort merge to keep order.
-	if !prop.IsEmpty() || (prop.IsFlashProp() && prop.TaskTp != property.MppTaskType) {
+	if !prop.IsEmpty() {
+		return p.getMergeUnionAll(prop), true, nil
+	}
+	if prop.IsFlashProp() && prop.TaskTp != property.MppTaskType {
 		return nil, true, nil
 	}
 	// TODO: UnionAll can pass partition info, but for briefness, we prevent it from pushing down.
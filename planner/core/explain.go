@@ -462,6 +462,9 @@ func (p *PhysicalIndexLookUpReader) accessObject(sctx sessionctx.Context) string
 
 // ExplainInfo implements Plan interface.
 func (p *PhysicalIndexMergeReader) ExplainInfo() string {
+	if p.IsIntersectionType {
+		return "type: intersection"
+	}
 	return ""
 }
 
@@ -521,6 +524,15 @@ func (p *PhysicalSort) ExplainInfo() string {
 	return explainByItems(buffer, p.ByItems).String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalUnionAll) ExplainInfo() string {
+	if len(p.ByItems) == 0 {
+		return ""
+	}
+	buffer := bytes.NewBufferString("order by:")
+	return explainByItems(buffer, p.ByItems).String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalLimit) ExplainInfo() string {
 	var str strings.Builder
@@ -814,6 +814,17 @@ func (er *expressionRewriter) handleExistSubquery(ctx context.Context, v *ast.Ex
 			er.err = err
 			return v, true
 		}
+		if er.b.evalSubqueryLazily() {
+			exprs := er.buildLazySubquery(np, physicalPlan, true)
+			if v.Not {
+				exprs[0], er.err = er.newFunction(ast.UnaryNot, types.NewFieldType(mysql.TypeTiny), exprs[0])
+				if er.err != nil {
+					return v, true
+				}
+			}
+			er.ctxStackAppend(exprs[0], types.EmptyName)
+			return v, true
+		}
 		row, err := EvalSubqueryFirstRow(ctx, physicalPlan, er.b.is, er.b.ctx)
 		if err != nil {
 			er.err = err
@@ -1000,6 +1011,20 @@ func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, v *ast.S
 		er.err = err
 		return v, true
 	}
+	if er.b.evalSubqueryLazily() {
+		exprs := er.buildLazySubquery(np, physicalPlan, false)
+		if len(exprs) > 1 {
+			expr, err1 := er.newFunction(ast.RowFunc, exprs[0].GetType(), exprs...)
+			if err1 != nil {
+				er.err = err1
+				return v, true
+			}
+			er.ctxStackAppend(expr, types.EmptyName)
+		} else {
+			er.ctxStackAppend(exprs[0], types.EmptyName)
+		}
+		return v, true
+	}
 	row, err := EvalSubqueryFirstRow(ctx, physicalPlan, er.b.is, er.b.ctx)
 	if err != nil {
 		er.err = err
@@ -1027,6 +1052,31 @@ func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, v *ast.S
 	return v, true
 }
 
+// buildLazySubquery registers the uncorrelated subquery to be evaluated during
+// execution, and returns the placeholders of its output columns. For EXISTS,
+// the only output is whether the subquery returns any row.
+func (er *expressionRewriter) buildLazySubquery(np LogicalPlan, physicalPlan PhysicalPlan, isExists bool) []expression.Expression {
+	evalCtx := newScalarSubqueryEvalCtx(er.sctx, np.SelectBlockOffset(), physicalPlan, isExists)
+	outputCols := np.Schema().Columns
+	if isExists {
+		outputCols = []*expression.Column{{RetType: expression.NewOne().GetType()}}
+	}
+	exprs := make([]expression.Expression, 0, len(outputCols))
+	for i, col := range outputCols {
+		expr := &ScalarSubQueryExpr{
+			scalarSubqueryColID: er.sctx.GetSessionVars().AllocPlanColumnID(),
+			outputIdx:           i,
+			evalCtx:             evalCtx,
+		}
+		expr.RetType = col.GetType()
+		expr.SetCoercibility(col.Coercibility())
+		evalCtx.outputColIDs = append(evalCtx.outputColIDs, expr.scalarSubqueryColID)
+		exprs = append(exprs, expr)
+	}
+	registerScalarSubQuery(er.sctx, evalCtx)
+	return exprs
+}
+
 // Leave implements Visitor interface.
 func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok bool) {
 	if er.err != nil {
@@ -958,6 +958,10 @@ func (ds *DataSource) convertToIndexMergeScan(prop *property.PhysicalProperty, c
 		}
 		scans = append(scans, scan)
 		totalCost += partialCost
+		if path.IndexMergeIsIntersection {
+			// The handles of all partial paths are collected and intersected in TiDB.
+			totalCost += scan.statsInfo().RowCount * ds.ctx.GetSessionVars().CPUFactor
+		}
 	}
 	totalRowCount := path.CountAfterAccess
 	if prop.ExpectedCnt < ds.stats.RowCount {
@@ -970,6 +974,7 @@ func (ds *DataSource) convertToIndexMergeScan(prop *property.PhysicalProperty, c
 	totalCost += partialCost
 	cop.tablePlan = ts
 	cop.idxMergePartPlans = scans
+	cop.idxMergeIsIntersection = path.IndexMergeIsIntersection
 	cop.cst = totalCost
 	task = cop.convertToRootTask(ds.ctx)
 	return task, nil
@@ -1495,6 +1495,32 @@ func (s *testIntegrationSuite) TestIndexMergeHint4CNF(c *C) {
 	}
 }
 
+func (s *testIntegrationSuite) TestIndexMergeIntersection(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(id int primary key, a int, b int, c int, d int, key(a), key(b), key(c), unique key(d))")
+
+	var input []string
+	var output []struct {
+		SQL     string
+		Plan    []string
+		Warning []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+			output[i].Warning = s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings())
+		})
+		res := tk.MustQuery(tt)
+		res.Check(testkit.Rows(output[i].Plan...))
+		c.Assert(s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings()), DeepEquals, output[i].Warning)
+	}
+}
+
 func (s *testIntegrationSuite) TestInvisibleIndex(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 
@@ -4188,6 +4214,122 @@ func (s *testIntegrationSerialSuite) TestCTESelfJoin(c *C) {
 			and inv2.t3a = 4+1`)
 }
 
+func (s *testIntegrationSuite) TestMergeUnionAll(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, tp")
+	tk.MustExec("create table t1(a int, b int, key(a))")
+	tk.MustExec("create table t2(a int, b int, key(a))")
+	tk.MustExec("insert into t1 values (1, 1), (3, 3), (5, 5), (null, 0)")
+	tk.MustExec("insert into t2 values (2, 2), (3, 30), (4, 4), (null, 10)")
+
+	// The ordered children are merged, so the TopN above the union becomes a Limit.
+	tk.MustQuery("explain format=brief select a from t1 union all select a from t2 order by a limit 3").Check(testkit.Rows(
+		"Limit 3.00 root  offset:0, count:3",
+		"└─Union 3.00 root  order by:Column#7",
+		"  ├─Limit 3.00 root  offset:0, count:3",
+		"  │ └─IndexReader 3.00 root  index:Limit",
+		"  │   └─Limit 3.00 cop[tikv]  offset:0, count:3",
+		"  │     └─IndexFullScan 3.00 cop[tikv] table:t1, index:a(a) keep order:true, stats:pseudo",
+		"  └─Limit 3.00 root  offset:0, count:3",
+		"    └─IndexReader 3.00 root  index:Limit",
+		"      └─Limit 3.00 cop[tikv]  offset:0, count:3",
+		"        └─IndexFullScan 3.00 cop[tikv] table:t2, index:a(a) keep order:true, stats:pseudo"))
+	tk.MustQuery("select a from t1 union all select a from t2 order by a limit 3").Check(testkit.Rows("<nil>", "<nil>", "1"))
+	tk.MustQuery("select a from t1 union all select a from t2 order by a limit 3, 4").Check(testkit.Rows("2", "3", "3", "4"))
+	tk.MustQuery("explain format=brief select a, b from t1 union all select a, b from t2 order by a desc limit 3").Check(testkit.Rows(
+		"Limit 3.00 root  offset:0, count:3",
+		"└─Union 3.00 root  order by:Column#7:desc",
+		"  ├─Limit 3.00 root  offset:0, count:3",
+		"  │ └─Projection 3.00 root  test.t1.a, test.t1.b",
+		"  │   └─IndexLookUp 3.00 root  ",
+		"  │     ├─Limit(Build) 3.00 cop[tikv]  offset:0, count:3",
+		"  │     │ └─IndexFullScan 3.00 cop[tikv] table:t1, index:a(a) keep order:true, desc, stats:pseudo",
+		"  │     └─TableRowIDScan(Probe) 3.00 cop[tikv] table:t1 keep order:false, stats:pseudo",
+		"  └─Limit 3.00 root  offset:0, count:3",
+		"    └─Projection 3.00 root  test.t2.a, test.t2.b",
+		"      └─IndexLookUp 3.00 root  ",
+		"        ├─Limit(Build) 3.00 cop[tikv]  offset:0, count:3",
+		"        │ └─IndexFullScan 3.00 cop[tikv] table:t2, index:a(a) keep order:true, desc, stats:pseudo",
+		"        └─TableRowIDScan(Probe) 3.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select a, b from t1 union all select a, b from t2 order by a desc limit 3").Check(testkit.Rows("5 5", "4 4", "3 3"))
+	// The children without a usable index are ordered by the pushed down TopN.
+	tk.MustQuery("explain format=brief select b from t1 union all select b from t2 order by b limit 3").Check(testkit.Rows(
+		"Limit 3.00 root  offset:0, count:3",
+		"└─Union 3.00 root  order by:Column#7",
+		"  ├─TopN 3.00 root  test.t1.b, offset:0, count:3",
+		"  │ └─TableReader 3.00 root  data:TopN",
+		"  │   └─TopN 3.00 cop[tikv]  test.t1.b, offset:0, count:3",
+		"  │     └─TableFullScan 10000.00 cop[tikv] table:t1 keep order:false, stats:pseudo",
+		"  └─TopN 3.00 root  test.t2.b, offset:0, count:3",
+		"    └─TableReader 3.00 root  data:TopN",
+		"      └─TopN 3.00 cop[tikv]  test.t2.b, offset:0, count:3",
+		"        └─TableFullScan 10000.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select b from t1 union all select b from t2 order by b limit 3").Check(testkit.Rows("0", "1", "2"))
+	tk.MustQuery("select a from t1 union all select a from t2 order by a").Check(testkit.Rows("<nil>", "<nil>", "1", "2", "3", "3", "4", "5"))
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	tk.MustExec("create table tp(a int, b int, key(a)) partition by hash(a) partitions 3")
+	tk.MustExec("insert into tp values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6)")
+	tk.MustQuery("explain format=brief select * from tp where b > 1 order by a desc limit 2").Check(testkit.Rows(
+		"Limit 2.00 root  offset:0, count:2",
+		"└─PartitionUnion 2.00 root  order by:test.tp.a:desc",
+		"  ├─TopN 2.00 root  test.tp.a:desc, offset:0, count:2",
+		"  │ └─TableReader 2.00 root  data:TopN",
+		"  │   └─TopN 2.00 cop[tikv]  test.tp.a:desc, offset:0, count:2",
+		"  │     └─Selection 3333.33 cop[tikv]  gt(test.tp.b, 1)",
+		"  │       └─TableFullScan 10000.00 cop[tikv] table:tp, partition:p0 keep order:false, stats:pseudo",
+		"  ├─TopN 2.00 root  test.tp.a:desc, offset:0, count:2",
+		"  │ └─TableReader 2.00 root  data:TopN",
+		"  │   └─TopN 2.00 cop[tikv]  test.tp.a:desc, offset:0, count:2",
+		"  │     └─Selection 3333.33 cop[tikv]  gt(test.tp.b, 1)",
+		"  │       └─TableFullScan 10000.00 cop[tikv] table:tp, partition:p1 keep order:false, stats:pseudo",
+		"  └─TopN 2.00 root  test.tp.a:desc, offset:0, count:2",
+		"    └─TableReader 2.00 root  data:TopN",
+		"      └─TopN 2.00 cop[tikv]  test.tp.a:desc, offset:0, count:2",
+		"        └─Selection 3333.33 cop[tikv]  gt(test.tp.b, 1)",
+		"          └─TableFullScan 10000.00 cop[tikv] table:tp, partition:p2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select * from tp where b > 1 order by a desc limit 2").Check(testkit.Rows("6 6", "5 5"))
+	tk.MustQuery("select a from tp order by a limit 4").Check(testkit.Rows("1", "2", "3", "4"))
+}
+
+func (s *testIntegrationSuite) TestInlineCTE(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, key(a))")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 2), (3, 3)")
+
+	// A CTE referenced only once is inlined, so the predicate can reach the index.
+	tk.MustQuery("explain format=brief with cte as (select a, b from t where b > 1) select * from cte where a = 1").Check(testkit.Rows(
+		"IndexLookUp 3.33 root  ",
+		"├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:a(a) range:[1,1], keep order:false, stats:pseudo",
+		"└─Selection(Probe) 3.33 cop[tikv]  gt(test.t.b, 1)",
+		"  └─TableRowIDScan 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("with cte as (select a, b from t where b > 1) select * from cte where a = 1").Check(testkit.Rows("1 2"))
+	tk.MustQuery("with cte1 as (select a from t), cte2 as (select a from cte1 where a > 1) select * from cte2 order by a").Check(testkit.Rows("2", "3"))
+
+	// A CTE referenced more than once stays materialized, and the disjunction
+	// of the predicates on every consumer is pushed into its seed part.
+	tk.MustQuery("explain format=brief with cte as (select a, b from t) select * from cte c1, cte c2 where c1.a = 1 and c2.b = 2").Check(testkit.Rows(
+		"HashJoin 255.74 root  CARTESIAN inner join",
+		"├─Selection(Build) 15.99 root  eq(test.t.b, 2)",
+		"│ └─CTEFullScan 19.99 root CTE:c2 data:CTE_0",
+		"└─Selection(Probe) 15.99 root  eq(test.t.a, 1)",
+		"  └─CTEFullScan 19.99 root CTE:c1 data:CTE_0",
+		"CTE_0 19.99 root  Non-Recursive CTE",
+		"└─TableReader(Seed Part) 19.99 root  data:Selection",
+		"  └─Selection 19.99 cop[tikv]  or(eq(test.t.a, 1), eq(test.t.b, 2))",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("with cte as (select a, b from t) select * from cte c1, cte c2 where c1.a = 1 and c2.b = 2 order by c1.b, c2.a").Check(testkit.Rows(
+		"1 1 1 2", "1 1 2 2", "1 2 1 2", "1 2 2 2"))
+	// Nothing is pushed into the seed part if one consumer has no predicate.
+	tk.MustQuery("with cte as (select a, b from t) select count(*) from cte c1, cte c2 where c1.a = 1").Check(testkit.Rows("8"))
+
+	// Recursive CTEs are never inlined.
+	tk.MustQuery("with recursive cte(n) as (select 1 union all select n + 1 from cte where n < 5) select * from cte where n = 3").Check(testkit.Rows("3"))
+}
+
 // https://github.com/pingcap/tidb/issues/26214
 func (s *testIntegrationSerialSuite) TestIssue26214(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
@@ -4548,3 +4690,83 @@ func (s *testIntegrationSuite) TestIssue28154(c *C) {
 	result = tk.MustQuery("select * from t")
 	result.Check(testkit.Rows("abc"))
 }
+
+func (s *testIntegrationSuite) TestExplainLazyScalarSubquery(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 10), (2, 20)")
+
+	// The uncorrelated subquery is shown as a child plan and is not executed by EXPLAIN,
+	// so EXPLAIN succeeds even if the subquery returns more than one row.
+	tk.MustQuery("explain format=brief select * from t1 where a = (select a from t2)").Check(testkit.Rows(
+		"Selection 8000.00 root  eq(test.t1.a, ScalarQueryCol#7)",
+		"└─TableReader 10000.00 root  data:TableFullScan",
+		"  └─TableFullScan 10000.00 cop[tikv] table:t1 keep order:false, stats:pseudo",
+		"ScalarSubQuery N/A root  Output: ScalarQueryCol#7",
+		"└─MaxOneRow 1.00 root  ",
+		"  └─TableReader 2.00 root  data:TableFullScan",
+		"    └─TableFullScan 2.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	err := tk.ExecToErr("select * from t1 where a = (select a from t2)")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[executor:1242]Subquery returns more than 1 row")
+
+	tk.MustQuery("explain format=brief select * from t1 where not exists (select 1 from t2 where b > 10)").Check(testkit.Rows(
+		"Selection 8000.00 root  not(ScalarQueryCol#8)",
+		"└─TableReader 10000.00 root  data:TableFullScan",
+		"  └─TableFullScan 10000.00 cop[tikv] table:t1 keep order:false, stats:pseudo",
+		"ScalarSubQuery N/A root  Output: ScalarQueryCol#8",
+		"└─TableReader 3333.33 root  data:Selection",
+		"  └─Selection 3333.33 cop[tikv]  gt(test.t2.b, 10)",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select * from t1 where not exists (select 1 from t2 where b > 10)").Check(testkit.Rows())
+	tk.MustQuery("select * from t1 where exists (select 1 from t2 where b > 10)").Sort().Check(testkit.Rows("1 1", "2 2", "3 3"))
+
+	tk.MustQuery("explain format=brief select * from t1 where (a, b) = (select a, b from t2 where a = 1)").Check(testkit.Rows(
+		"Selection 8000.00 root  eq(test.t1.a, ScalarQueryCol#7), eq(test.t1.b, ScalarQueryCol#8)",
+		"└─TableReader 10000.00 root  data:TableFullScan",
+		"  └─TableFullScan 10000.00 cop[tikv] table:t1 keep order:false, stats:pseudo",
+		"ScalarSubQuery N/A root  Output: ScalarQueryCol#7, ScalarQueryCol#8",
+		"└─MaxOneRow 1.00 root  ",
+		"  └─TableReader 2.00 root  data:Selection",
+		"    └─Selection 2.00 cop[tikv]  eq(test.t2.a, 1)",
+		"      └─TableFullScan 2000.00 cop[tikv] table:t2 keep order:false, stats:pseudo"))
+	tk.MustQuery("select * from t1 where (a, b) = (select a, 1 from t2 where a = 1)").Check(testkit.Rows("1 1"))
+
+	// EXPLAIN ANALYZE executes the statement, so the subquery is evaluated eagerly.
+	rows := tk.MustQuery("explain analyze select * from t1 where a > (select max(a) from t2)").Rows()
+	for _, row := range rows {
+		c.Assert(row[0], Not(Matches), "ScalarSubQuery.*")
+	}
+}
+
+func (s *testIntegrationSuite) TestCostModelVersion2(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c varchar(100), key(a))")
+	vals := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		vals = append(vals, fmt.Sprintf("(%d, %d, '%s')", i%100, i, strings.Repeat("x", 50)))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ","))
+	tk.MustExec("analyze table t")
+
+	tk.MustQuery("select @@tidb_cost_model_version").Check(testkit.Rows("1"))
+	_, err := tk.Exec("set @@tidb_cost_model_version = 3")
+	c.Assert(err, NotNil)
+	tk.MustQuery("select @@tidb_cost_model_version").Check(testkit.Rows("1"))
+
+	// The version 1 estimates every cop task is served by all the workers, so the
+	// table scan looks cheaper than the double read earlier.
+	rows := tk.MustQuery("explain format = 'brief' select * from t where a < 45").Rows()
+	c.Assert(rows[0][0], Matches, "TableReader.*")
+	tk.MustExec("set @@tidb_cost_model_version = 2")
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a < 45").Rows()
+	c.Assert(rows[0][0], Matches, "IndexLookUp.*")
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a < 60").Rows()
+	c.Assert(rows[0][0], Matches, "TableReader.*")
+}
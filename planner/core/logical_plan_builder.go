@@ -111,6 +111,12 @@ const (
 	HintIgnorePlanCache = "ignore_plan_cache"
 	// HintLimitToCop is a hint enforce pushing limit or topn to coprocessor.
 	HintLimitToCop = "limit_to_cop"
+	// HintMerge is a hint to inline a non-recursive CTE as a derived table.
+	HintMerge = "merge"
+	// HintNoMerge is a hint to materialize a CTE instead of inlining it.
+	HintNoMerge = "no_merge"
+	// HintLeading is a hint to specify the tables which are joined first and their join order.
+	HintLeading = "leading"
 )
 
 const (
@@ -464,8 +470,8 @@ func (p *LogicalJoin) ExtractOnCondition(
 							rightCond = append(rightCond, notNullExpr)
 						}
 					}
-					if binop.FuncName.L == ast.EQ {
-						cond := expression.NewFunctionInternal(ctx, ast.EQ, types.NewFieldType(mysql.TypeTiny), arg0, arg1)
+					if binop.FuncName.L == ast.EQ || binop.FuncName.L == ast.NullEQ {
+						cond := expression.NewFunctionInternal(ctx, binop.FuncName.L, types.NewFieldType(mysql.TypeTiny), arg0, arg1)
 						eqCond = append(eqCond, cond.(*expression.ScalarFunction))
 						continue
 					}
@@ -742,6 +748,9 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 
 	// Set preferred join algorithm if some join hints is specified by user.
 	joinPlan.setPreferredJoinType(b.TableHints())
+	if hintInfo := b.TableHints(); hintInfo != nil {
+		joinPlan.leadingJoinOrder = hintInfo.leadingJoinOrder
+	}
 
 	// "NATURAL JOIN" doesn't have "ON" or "USING" conditions.
 	//
@@ -1422,7 +1431,7 @@ func (b *PlanBuilder) buildSetOpr(ctx context.Context, setOpr *ast.SetOprStmt) (
 		defer func() {
 			b.outerCTEs = b.outerCTEs[:l]
 		}()
-		err := b.buildWith(ctx, setOpr.With)
+		err := b.buildWith(ctx, setOpr.With, setOpr)
 		if err != nil {
 			return nil, err
 		}
@@ -1509,13 +1518,134 @@ func (b *PlanBuilder) buildSemiJoinForSetOperator(
 	if err != nil {
 		return nil, err
 	}
+	return b.buildJoinForSetOperator(leftPlan, rightPlan, joinType)
+}
+
+// buildSemiJoinForSetOperatorAll builds the semi join for 'intersect all' and the anti semi join for 'except all'.
+// The duplicated rows of both sides are numbered by row_number(), so each row of the right side can match at most
+// one duplicated row of the left side. The rows are compared as the types in which the join compares them, so
+// they are partitioned by the columns casted to these types rather than by the original columns.
+func (b *PlanBuilder) buildSemiJoinForSetOperatorAll(
+	leftOriginPlan LogicalPlan,
+	rightOriginPlan LogicalPlan,
+	joinType JoinType) (LogicalPlan, error) {
+	cmpTypes := make([]types.EvalType, 0, leftOriginPlan.Schema().Len())
+	for i, leftCol := range leftOriginPlan.Schema().Columns {
+		cmpTypes = append(cmpTypes, expression.GetAccurateCmpType(leftCol, rightOriginPlan.Schema().Columns[i]))
+	}
+	leftPlan, leftKeys := b.buildKeysForSetOperator(leftOriginPlan, cmpTypes, true)
+	rightPlan, rightKeys := b.buildKeysForSetOperator(rightOriginPlan, cmpTypes, false)
+	leftPlan, leftRowNumber, err := b.buildRowNumberForSetOperator(leftPlan, leftKeys)
+	if err != nil {
+		return nil, err
+	}
+	rightPlan, rightRowNumber, err := b.buildRowNumberForSetOperator(rightPlan, rightKeys)
+	if err != nil {
+		return nil, err
+	}
+	leftKeys = append(leftKeys, leftRowNumber)
+	rightKeys = append(rightKeys, rightRowNumber)
+	joinPlan, err := b.buildJoinOnKeysForSetOperator(leftPlan, rightPlan, leftKeys, rightKeys, joinType)
+	if err != nil {
+		return nil, err
+	}
+	// Remove the key and the row number columns.
+	proj := LogicalProjection{Exprs: expression.Column2Exprs(leftOriginPlan.Schema().Columns)}.Init(b.ctx, b.getSelectOffset())
+	proj.SetChildren(joinPlan)
+	proj.SetSchema(leftOriginPlan.Schema().Clone())
+	proj.names = make([]*types.FieldName, leftOriginPlan.Schema().Len())
+	copy(proj.names, leftOriginPlan.OutputNames())
+	return proj, nil
+}
+
+// buildKeysForSetOperator builds a projection which casts the columns of the plan to the compare types. The
+// original columns are kept before the keys if keepOrigin is true.
+func (b *PlanBuilder) buildKeysForSetOperator(p LogicalPlan, cmpTypes []types.EvalType, keepOrigin bool) (LogicalPlan, []*expression.Column) {
+	exprs := make([]expression.Expression, 0, 2*len(cmpTypes))
+	cols := make([]*expression.Column, 0, 2*len(cmpTypes))
+	names := make([]*types.FieldName, 0, 2*len(cmpTypes))
+	if keepOrigin {
+		exprs = append(exprs, expression.Column2Exprs(p.Schema().Columns)...)
+		cols = append(cols, p.Schema().Clone().Columns...)
+		names = append(names, p.OutputNames()...)
+	}
+	keys := make([]*expression.Column, 0, len(cmpTypes))
+	for i, col := range p.Schema().Columns {
+		var key expression.Expression
+		switch cmpTypes[i] {
+		case types.ETInt:
+			key = expression.WrapWithCastAsInt(b.ctx, col)
+		case types.ETReal:
+			key = expression.WrapWithCastAsReal(b.ctx, col)
+		case types.ETDecimal:
+			key = expression.WrapWithCastAsDecimal(b.ctx, col)
+		case types.ETDatetime, types.ETTimestamp:
+			key = expression.WrapWithCastAsTime(b.ctx, col, types.NewFieldType(mysql.TypeDatetime))
+		case types.ETDuration:
+			key = expression.WrapWithCastAsDuration(b.ctx, col)
+		case types.ETJson:
+			key = expression.WrapWithCastAsJSON(b.ctx, col)
+		default:
+			key = expression.WrapWithCastAsString(b.ctx, col)
+		}
+		keyCol := &expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  key.GetType(),
+		}
+		exprs = append(exprs, key)
+		cols = append(cols, keyCol)
+		names = append(names, types.EmptyName)
+		keys = append(keys, keyCol)
+	}
+	proj := LogicalProjection{Exprs: exprs}.Init(b.ctx, b.getSelectOffset())
+	proj.SetChildren(p)
+	proj.SetSchema(expression.NewSchema(cols...))
+	proj.names = names
+	return proj, keys
+}
+
+// buildRowNumberForSetOperator appends a `row_number() over (partition by keys)` column to the plan.
+func (b *PlanBuilder) buildRowNumberForSetOperator(p LogicalPlan, keys []*expression.Column) (LogicalPlan, *expression.Column, error) {
+	desc, err := aggregation.NewWindowFuncDesc(b.ctx, ast.WindowFuncRowNumber, []expression.Expression{})
+	if err != nil {
+		return nil, nil, err
+	}
+	partitionBy := make([]property.SortItem, 0, len(keys))
+	for _, col := range keys {
+		partitionBy = append(partitionBy, property.SortItem{Col: col})
+	}
+	window := LogicalWindow{
+		WindowFuncDescs: []*aggregation.WindowFuncDesc{desc},
+		PartitionBy:     partitionBy,
+	}.Init(b.ctx, b.getSelectOffset())
+	rowNumber := &expression.Column{
+		UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  desc.RetTp,
+	}
+	schema := p.Schema().Clone()
+	schema.Append(rowNumber)
+	window.names = make([]*types.FieldName, 0, schema.Len())
+	window.names = append(window.names, p.OutputNames()...)
+	window.names = append(window.names, types.EmptyName)
+	window.SetChildren(p)
+	window.SetSchema(schema)
+	return window, rowNumber, nil
+}
+
+func (b *PlanBuilder) buildJoinForSetOperator(leftPlan, rightPlan LogicalPlan, joinType JoinType) (LogicalPlan, error) {
+	return b.buildJoinOnKeysForSetOperator(leftPlan, rightPlan, leftPlan.Schema().Columns, rightPlan.Schema().Columns, joinType)
+}
+
+// buildJoinOnKeysForSetOperator builds the join whose schema is the schema of the left plan, the rows are matched
+// if each pair of the keys is null-safe equal.
+func (b *PlanBuilder) buildJoinOnKeysForSetOperator(leftPlan, rightPlan LogicalPlan, leftKeys, rightKeys []*expression.Column, joinType JoinType) (LogicalPlan, error) {
 	joinPlan := LogicalJoin{JoinType: joinType}.Init(b.ctx, b.getSelectOffset())
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(leftPlan.Schema())
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len())
 	copy(joinPlan.names, leftPlan.OutputNames())
-	for j := 0; j < len(rightPlan.Schema().Columns); j++ {
-		leftCol, rightCol := leftPlan.Schema().Columns[j], rightPlan.Schema().Columns[j]
+	for j := 0; j < len(rightKeys); j++ {
+		leftCol, rightCol := leftKeys[j], rightKeys[j]
 		eqCond, err := expression.NewFunction(b.ctx, ast.NullEQ, types.NewFieldType(mysql.TypeTiny), leftCol, rightCol)
 		if err != nil {
 			return nil, err
@@ -1553,18 +1683,13 @@ func (b *PlanBuilder) buildIntersect(ctx context.Context, selects []ast.Node) (L
 	columnNums := leftPlan.Schema().Len()
 	for i := 1; i < len(selects); i++ {
 		var rightPlan LogicalPlan
+		var setOprType *ast.SetOprType
 		switch x := selects[i].(type) {
 		case *ast.SelectStmt:
-			if *x.AfterSetOperator == ast.IntersectAll {
-				// TODO: support intersect all
-				return nil, nil, errors.Errorf("TiDB do not support intersect all")
-			}
+			setOprType = x.AfterSetOperator
 			rightPlan, err = b.buildSelect(ctx, x)
 		case *ast.SetOprSelectList:
-			if *x.AfterSetOperator == ast.IntersectAll {
-				// TODO: support intersect all
-				return nil, nil, errors.Errorf("TiDB do not support intersect all")
-			}
+			setOprType = x.AfterSetOperator
 			rightPlan, err = b.buildSetOpr(ctx, &ast.SetOprStmt{SelectList: x})
 		}
 		if err != nil {
@@ -1573,7 +1698,11 @@ func (b *PlanBuilder) buildIntersect(ctx context.Context, selects []ast.Node) (L
 		if rightPlan.Schema().Len() != columnNums {
 			return nil, nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
 		}
-		leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, SemiJoin)
+		if *setOprType == ast.IntersectAll {
+			leftPlan, err = b.buildSemiJoinForSetOperatorAll(leftPlan, rightPlan, SemiJoin)
+		} else {
+			leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, SemiJoin)
+		}
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1592,20 +1721,21 @@ func (b *PlanBuilder) buildExcept(ctx context.Context, selects []LogicalPlan, af
 		if rightPlan.Schema().Len() != columnNums {
 			return nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
 		}
-		if *afterSetOpts[i] == ast.Except {
+		if *afterSetOpts[i] == ast.Except || *afterSetOpts[i] == ast.ExceptAll {
 			leftPlan, err := b.buildUnion(ctx, unionPlans, tmpAfterSetOpts)
 			if err != nil {
 				return nil, err
 			}
-			leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, AntiSemiJoin)
+			if *afterSetOpts[i] == ast.ExceptAll {
+				leftPlan, err = b.buildSemiJoinForSetOperatorAll(leftPlan, rightPlan, AntiSemiJoin)
+			} else {
+				leftPlan, err = b.buildSemiJoinForSetOperator(leftPlan, rightPlan, AntiSemiJoin)
+			}
 			if err != nil {
 				return nil, err
 			}
 			unionPlans = []LogicalPlan{leftPlan}
 			tmpAfterSetOpts = []*ast.SetOprType{nil}
-		} else if *afterSetOpts[i] == ast.ExceptAll {
-			// TODO: support except all.
-			return nil, errors.Errorf("TiDB do not support except all")
 		} else {
 			unionPlans = append(unionPlans, rightPlan)
 			tmpAfterSetOpts = append(tmpAfterSetOpts, afterSetOpts[i])
@@ -2325,7 +2455,7 @@ func (r *correlatedAggregateResolver) resolveSelect(sel *ast.SelectStmt) (err er
 		defer func() {
 			r.b.outerCTEs = r.b.outerCTEs[:l]
 		}()
-		err := r.b.buildWith(r.ctx, sel.With)
+		err := r.b.buildWith(r.ctx, sel.With, sel)
 		if err != nil {
 			return err
 		}
@@ -3242,6 +3372,9 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		sortMergeTables, INLJTables, INLHJTables, INLMJTables, hashJoinTables, BCTables, BCJPreferLocalTables []hintTableInfo
 		indexHintList, indexMergeHintList                                                                     []indexHintInfo
 		tiflashTables, tikvTables                                                                             []hintTableInfo
+		mergeTables, noMergeTables                                                                            []hintTableInfo
+		leadingJoinOrder                                                                                      *leadingHintInfo
+		leadingHintCnt                                                                                        int
 		aggHints                                                                                              aggHintInfo
 		timeRangeHint                                                                                         ast.HintTimeRange
 		limitHints                                                                                            limitHintInfo
@@ -3349,6 +3482,16 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 			timeRangeHint = hint.HintData.(ast.HintTimeRange)
 		case HintLimitToCop:
 			limitHints.preferLimitToCop = true
+		case HintMerge:
+			// MERGE() without table names is handled when building the CTE definition, see getCTEMergeHints.
+			mergeTables = append(mergeTables, tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel)...)
+		case HintNoMerge:
+			noMergeTables = append(noMergeTables, tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel)...)
+		case HintLeading:
+			leadingHintCnt++
+			if leadingHintCnt == 1 {
+				leadingJoinOrder = b.leadingHint2Info(hint, currentLevel)
+			}
 		default:
 			// ignore hints that not implemented
 		}
@@ -3366,7 +3509,48 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		indexMergeHintList:          indexMergeHintList,
 		timeRangeHint:               timeRangeHint,
 		limitHints:                  limitHints,
+		mergeTables:                 mergeTables,
+		noMergeTables:               noMergeTables,
+		leadingJoinOrder:            leadingJoinOrder,
 	})
+	if leadingHintCnt > 1 {
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+			"We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid"))
+		b.tableHintInfo[len(b.tableHintInfo)-1].leadingJoinOrder = nil
+	}
+}
+
+// leadingHint2Info converts a LEADING hint to leadingHintInfo, it returns nil if the hint is invalid.
+func (b *PlanBuilder) leadingHint2Info(leadingHint *ast.TableOptimizerHint, currentLevel int) *leadingHintInfo {
+	list, ok := leadingHint.HintData.(*ast.LeadingList)
+	if !ok || list == nil {
+		list = ast.NewLeadingList(leadingHint.Tables)
+	}
+	return b.buildLeadingHintInfo(list, currentLevel)
+}
+
+func (b *PlanBuilder) buildLeadingHintInfo(list *ast.LeadingList, currentLevel int) *leadingHintInfo {
+	info := &leadingHintInfo{items: make([]*leadingHintInfo, 0, len(list.Items))}
+	for _, item := range list.Items {
+		switch x := item.(type) {
+		case *ast.HintTable:
+			tables := tableNames2HintTableInfo(b.ctx, HintLeading, []ast.HintTable{*x}, b.hintProcessor, currentLevel)
+			if len(tables) == 0 {
+				return nil
+			}
+			info.items = append(info.items, &leadingHintInfo{table: &tables[0]})
+		case *ast.LeadingList:
+			subInfo := b.buildLeadingHintInfo(x, currentLevel)
+			if subInfo == nil {
+				return nil
+			}
+			info.items = append(info.items, subInfo)
+		}
+	}
+	if len(info.items) == 0 {
+		return nil
+	}
+	return info
 }
 
 func (b *PlanBuilder) popVisitInfo() {
@@ -3388,6 +3572,8 @@ func (b *PlanBuilder) popTableHints() {
 	b.appendUnmatchedJoinHintWarning(HintBCJPreferLocal, "", hintInfo.broadcastJoinPreferredLocal)
 	b.appendUnmatchedJoinHintWarning(HintHJ, TiDBHashJoin, hintInfo.hashJoinTables)
 	b.appendUnmatchedStorageHintWarning(hintInfo.tiflashTables, hintInfo.tikvTables)
+	b.appendUnmatchedJoinHintWarning(HintMerge, "", hintInfo.mergeTables)
+	b.appendUnmatchedJoinHintWarning(HintNoMerge, "", hintInfo.noMergeTables)
 	b.tableHintInfo = b.tableHintInfo[:len(b.tableHintInfo)-1]
 }
 
@@ -3474,6 +3660,11 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 		origin := b.inStraightJoin
 		b.inStraightJoin = sel.SelectStmtOpts.StraightJoin
 		defer func() { b.inStraightJoin = origin }()
+		if hintInfo := b.TableHints(); b.inStraightJoin && hintInfo != nil && hintInfo.leadingJoinOrder != nil {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"We can only use the straight_join hint, when we use the leading hint and straight_join hint at the same time, all leading hints will be invalid"))
+			hintInfo.leadingJoinOrder = nil
+		}
 	}
 
 	var (
@@ -3495,7 +3686,7 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 		defer func() {
 			b.outerCTEs = b.outerCTEs[:l]
 		}()
-		err = b.buildWith(ctx, sel.With)
+		err = b.buildWith(ctx, sel.With, sel)
 		if err != nil {
 			return nil, err
 		}
@@ -3825,6 +4016,10 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 				return p, nil
 			}
 
+			if b.shouldInlineCTE(cte, i, tn, asName) {
+				return b.buildInlinedCTE(ctx, cte, i, asName)
+			}
+
 			b.handleHelper.pushMap(nil)
 
 			hasLimit := false
@@ -3847,8 +4042,9 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 				cte.cteClass = &CTEClass{IsDistinct: cte.isDistinct, seedPartLogicalPlan: cte.seedLP,
 					recursivePartLogicalPlan: cte.recurLP, IDForStorage: cte.storageID,
 					optFlag: cte.optFlag, HasLimit: hasLimit, LimitBeg: limitBeg,
-					LimitEnd: limitEnd}
+					LimitEnd: limitEnd, pushDownPredicates: make(map[int]expression.Expression)}
 			}
+			cte.cteClass.consumerCount++
 			var p LogicalPlan
 			lp := LogicalCTE{cteAsName: tn.Name, cte: cte.cteClass, seedStat: cte.seedStat}.Init(b.ctx, b.getSelectOffset())
 			lp.SetSchema(getResultCTESchema(cte.seedLP.Schema(), b.ctx.GetSessionVars()))
@@ -3871,6 +4067,75 @@ func (b *PlanBuilder) tryBuildCTE(ctx context.Context, tn *ast.TableName, asName
 	return nil, nil
 }
 
+// shouldInlineCTE decides whether the idx-th CTE of outerCTEs is inlined as a derived table at the
+// reference tn. A non-recursive CTE is inlined if it's referenced only once, which enables predicate
+// push down, column pruning and index selection through it. The MERGE() and NO_MERGE() hints can
+// force either behavior.
+func (b *PlanBuilder) shouldInlineCTE(cte *cteInfo, idx int, tn *ast.TableName, asName *model.CIStr) bool {
+	tblName := tn.Name
+	if asName != nil && asName.L != "" {
+		tblName = *asName
+	}
+	tableName := &hintTableInfo{
+		dbName:       model.NewCIStr(b.ctx.GetSessionVars().CurrentDB),
+		tblName:      tblName,
+		selectOffset: b.getSelectOffset(),
+	}
+	preferMerge, preferNoMerge := cte.preferMerge, cte.preferNoMerge
+	if hintInfo := b.TableHints(); hintInfo != nil {
+		preferMerge = hintInfo.ifPreferMerge(tableName) || preferMerge
+		preferNoMerge = hintInfo.ifPreferNoMerge(tableName) || preferNoMerge
+	}
+	if cte.recurLP != nil {
+		if preferMerge {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				fmt.Sprintf("Recursive CTE %s can not be inlined by merge()", cte.def.Name.O)))
+		}
+		return false
+	}
+	// The CTE definition is built again when inlining, which can't be done
+	// while the CTEs visible to it are still being built.
+	for _, outerCTE := range b.outerCTEs[:idx] {
+		if outerCTE.isBuilding {
+			return false
+		}
+	}
+	if preferNoMerge {
+		return false
+	}
+	return preferMerge || cte.consumerCount <= 1
+}
+
+// buildInlinedCTE builds the definition of the idx-th CTE of outerCTEs as a derived table.
+func (b *PlanBuilder) buildInlinedCTE(ctx context.Context, cte *cteInfo, idx int, asName *model.CIStr) (LogicalPlan, error) {
+	// Resolve the CTE definition in the scope where it is defined.
+	saveCTEs, saveSchemas, saveNames := b.outerCTEs, b.outerSchemas, b.outerNames
+	saveBuildingRecursivePart := b.buildingRecursivePartForCTE
+	b.outerCTEs = b.outerCTEs[:idx:idx]
+	b.outerSchemas = b.outerSchemas[:cte.outerScopeLen:cte.outerScopeLen]
+	b.outerNames = b.outerNames[:cte.outerScopeLen:cte.outerScopeLen]
+	b.buildingRecursivePartForCTE = false
+	defer func() {
+		b.outerCTEs, b.outerSchemas, b.outerNames = saveCTEs, saveSchemas, saveNames
+		b.buildingRecursivePartForCTE = saveBuildingRecursivePart
+	}()
+
+	p, err := b.buildResultSetNode(ctx, cte.def.Query.Query)
+	if err != nil {
+		return nil, err
+	}
+	p, err = b.adjustCTEPlanOutputName(p, cte.def)
+	if err != nil {
+		return nil, err
+	}
+	if asName != nil && asName.L != "" {
+		for _, name := range p.OutputNames() {
+			name.TblName = *asName
+		}
+	}
+	return p, nil
+}
+
 func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, asName *model.CIStr) (LogicalPlan, error) {
 	dbName := tn.Schema
 	sessionVars := b.ctx.GetSessionVars()
@@ -4131,7 +4396,8 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 
 	var result LogicalPlan = ds
 	dirty := tableHasDirtyContent(b.ctx, tableInfo)
-	if dirty || tableInfo.TempTableType == model.TempTableLocal {
+	// The cached tables are read by the union scan too, it merges the cache with the writes of the transaction.
+	if dirty || tableInfo.TempTableType == model.TempTableLocal || tableInfo.TableCacheStatusType == model.TableCacheStatusEnable {
 		us := LogicalUnionScan{handleCols: handleCols}.Init(b.ctx, b.getSelectOffset())
 		us.SetChildren(ds)
 		result = us
@@ -4585,7 +4851,7 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		defer func() {
 			b.outerCTEs = b.outerCTEs[:l]
 		}()
-		err := b.buildWith(ctx, update.With)
+		err := b.buildWith(ctx, update.With, update)
 		if err != nil {
 			return nil, err
 		}
@@ -4947,7 +5213,7 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 		defer func() {
 			b.outerCTEs = b.outerCTEs[:l]
 		}()
-		err := b.buildWith(ctx, delete.With)
+		err := b.buildWith(ctx, delete.With, delete)
 		if err != nil {
 			return nil, err
 		}
@@ -6148,7 +6414,7 @@ func (b *PlanBuilder) buildRecursiveCTE(ctx context.Context, cte ast.ResultSetNo
 				sw := x.With
 				x.With = sw
 			}()
-			err := b.buildWith(ctx, x.With)
+			err := b.buildWith(ctx, x.With, x)
 			if err != nil {
 				return err
 			}
@@ -6346,7 +6612,8 @@ func (b *PlanBuilder) genCTETableNameForError() string {
 	return name
 }
 
-func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause) error {
+// buildWith builds the CTEs in the with clause w of the statement stmt.
+func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause, stmt ast.Node) error {
 	// Check CTE name must be unique.
 	nameMap := make(map[string]struct{})
 	for _, cte := range w.CTEs {
@@ -6355,8 +6622,12 @@ func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause) error {
 		}
 		nameMap[cte.Name.L] = struct{}{}
 	}
+	counter := &cteConsumerCounter{counts: make(map[string]int, len(w.CTEs))}
+	stmt.Accept(counter)
 	for _, cte := range w.CTEs {
-		b.outerCTEs = append(b.outerCTEs, &cteInfo{def: cte, nonRecursive: !w.IsRecursive, isBuilding: true, storageID: b.allocIDForCTEStorage, seedStat: &property.StatsInfo{}})
+		preferMerge, preferNoMerge := getCTEMergeHints(cte)
+		b.outerCTEs = append(b.outerCTEs, &cteInfo{def: cte, nonRecursive: !w.IsRecursive, isBuilding: true, storageID: b.allocIDForCTEStorage, seedStat: &property.StatsInfo{},
+			consumerCount: counter.counts[cte.Name.L], outerScopeLen: len(b.outerSchemas), preferMerge: preferMerge, preferNoMerge: preferNoMerge})
 		b.allocIDForCTEStorage++
 		saveFlag := b.optFlag
 		// Init the flag to flagPrunColumns, otherwise it's missing.
@@ -6372,6 +6643,46 @@ func (b *PlanBuilder) buildWith(ctx context.Context, w *ast.WithClause) error {
 	return nil
 }
 
+// cteConsumerCounter counts the table names which may refer to a CTE in a statement. The count
+// may be larger than the real number of references, e.g. a CTE shadowed by another one with the same
+// name, which only prevents the CTE from being inlined.
+type cteConsumerCounter struct {
+	counts map[string]int
+}
+
+// Enter implements Visitor interface.
+func (c *cteConsumerCounter) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok && tn.Schema.L == "" {
+		c.counts[tn.Name.L]++
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (c *cteConsumerCounter) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// getCTEMergeHints returns whether the MERGE() or NO_MERGE() hint is specified in the CTE definition.
+func getCTEMergeHints(cte *ast.CommonTableExpression) (preferMerge, preferNoMerge bool) {
+	sel, ok := cte.Query.Query.(*ast.SelectStmt)
+	if !ok {
+		return false, false
+	}
+	for _, hint := range sel.TableHints {
+		if len(hint.Tables) > 0 {
+			continue
+		}
+		switch hint.HintName.L {
+		case HintMerge:
+			preferMerge = true
+		case HintNoMerge:
+			preferNoMerge = true
+		}
+	}
+	return preferMerge, preferNoMerge
+}
+
 func (b *PlanBuilder) buildProjection4CTEUnion(ctx context.Context, seed LogicalPlan, recur LogicalPlan) (LogicalPlan, error) {
 	if seed.Schema().Len() != recur.Schema().Len() {
 		return nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
@@ -2002,3 +2002,145 @@ func (s *testPlanSuite) TestWindowLogicalPlanAmbiguous(c *C) {
 		}
 	}
 }
+
+func hasLogicalCTE(p LogicalPlan) bool {
+	if _, ok := p.(*LogicalCTE); ok {
+		return true
+	}
+	for _, child := range p.Children() {
+		if hasLogicalCTE(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *testPlanSuite) TestCTEMergeHints(c *C) {
+	defer testleak.AfterTest(c)()
+	tests := []struct {
+		sql       string
+		hasCTE    bool
+		warnCount int
+	}{
+		{sql: "with cte as (select a from t) select * from cte", hasCTE: false},
+		{sql: "with cte as (select a from t) select * from cte c1, cte c2", hasCTE: true},
+		{sql: "with cte as (select /*+ merge() */ a from t) select * from cte c1, cte c2", hasCTE: false},
+		{sql: "with cte as (select a from t) select /*+ merge(c1, c2) */ * from cte c1, cte c2", hasCTE: false},
+		{sql: "with cte as (select a from t) select /*+ merge(c1) */ * from cte c1, cte c2", hasCTE: true},
+		{sql: "with cte as (select /*+ no_merge() */ a from t) select * from cte", hasCTE: true},
+		{sql: "with cte as (select a from t) select /*+ no_merge(cte) */ * from cte", hasCTE: true},
+		{sql: "with cte as (select a from t) select /*+ no_merge(t1) */ * from cte", hasCTE: false, warnCount: 1},
+		{sql: "with recursive cte(a) as (select 1 union all select a + 1 from cte where a < 5) select /*+ merge(cte) */ * from cte", hasCTE: true, warnCount: 1},
+	}
+	ctx := context.TODO()
+	for i, tt := range tests {
+		comment := Commentf("case:%v sql:%s", i, tt.sql)
+		stmt, err := s.ParseOneStmt(tt.sql, "", "")
+		c.Assert(err, IsNil, comment)
+		err = Preprocess(s.ctx, stmt, WithPreprocessorReturn(&PreprocessorReturn{InfoSchema: s.is}))
+		c.Assert(err, IsNil, comment)
+		sctx := MockContext()
+		hintProcessor := &hint.BlockHintProcessor{Ctx: sctx}
+		stmt.Accept(hintProcessor)
+		builder, _ := NewPlanBuilder().Init(sctx, s.is, hintProcessor)
+		p, err := builder.Build(ctx, stmt)
+		c.Assert(err, IsNil, comment)
+		c.Assert(hasLogicalCTE(p.(LogicalPlan)), Equals, tt.hasCTE, comment)
+		c.Assert(sctx.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(tt.warnCount), comment)
+	}
+}
+
+func (s *testPlanSuite) TestLeadingHint(c *C) {
+	defer testleak.AfterTest(c)()
+	inapplicable := "leading hint is inapplicable, check if the leading hint table is valid: "
+	tests := []struct {
+		sql     string
+		restore string
+		greedy  string
+		dp      string
+		warning string
+	}{
+		{
+			sql:     "select /*+ leading(t3, t2) */ * from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b",
+			restore: "leading(`t3`, `t2`)",
+			greedy:  "Join{Join{DataScan(t3)->DataScan(t2)}(test.t.b,test.t.b)->DataScan(t1)}(test.t.a,test.t.a)->Projection->Projection",
+		},
+		{
+			// The tables in the hint are joined even if they are not connected.
+			sql:     "select /*+ leading(t1, t3) */ * from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b",
+			restore: "leading(`t1`, `t3`)",
+			greedy:  "Join{Join{DataScan(t1)->DataScan(t3)}->DataScan(t2)}(test.t.b,test.t.b)(test.t.a,test.t.a)->Projection->Projection",
+		},
+		{
+			sql:     "select /*+ leading(t4, (t1, t2)) */ * from t t1, t t2, t t3, t t4 where t1.a = t2.a and t2.b = t3.b and t3.c = t4.c",
+			restore: "leading(`t4`, (`t1`, `t2`))",
+			greedy:  "Join{Join{DataScan(t4)->Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)}->DataScan(t3)}(test.t.c,test.t.c)(test.t.b,test.t.b)->Projection->Projection",
+		},
+		{
+			// The other tables are still reordered, but they are joined after the leading table.
+			sql:     "select /*+ leading(t3) */ * from t t1, t t2, t t3, t t4 where t1.a = t2.a and t2.b = t3.b and t3.c = t4.c",
+			restore: "leading(`t3`)",
+			greedy:  "Join{Join{Join{DataScan(t3)->DataScan(t2)}(test.t.b,test.t.b)->DataScan(t1)}(test.t.a,test.t.a)->DataScan(t4)}(test.t.c,test.t.c)->Projection->Projection",
+			dp:      "Join{Join{Join{DataScan(t3)->DataScan(t4)}(test.t.c,test.t.c)->DataScan(t2)}(test.t.b,test.t.b)->DataScan(t1)}(test.t.a,test.t.a)->Projection->Projection",
+		},
+		{
+			sql:     "select /*+ leading(t4) */ * from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b",
+			restore: "leading(`t4`)",
+			greedy:  "Join{Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)->DataScan(t3)}(test.t.b,test.t.b)->Projection",
+			warning: inapplicable + "LEADING(t4)",
+		},
+		{
+			// t2 is the inner side of an outer join, it's not in the same join group with t3.
+			sql:     "select /*+ leading(t2, t3) */ * from t t1 left join t t2 on t1.a = t2.a join t t3 on t1.b = t3.b",
+			restore: "leading(`t2`, `t3`)",
+			greedy:  "Join{DataScan(t3)->Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)}(test.t.b,test.t.b)->Projection->Projection",
+			dp:      "Join{Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)->DataScan(t3)}(test.t.b,test.t.b)->Projection",
+			warning: inapplicable + "LEADING(t2, t3)",
+		},
+		{
+			sql:     "select /*+ leading(t3, t2) leading(t1, t2) */ * from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b",
+			restore: "leading(`t3`, `t2`)",
+			greedy:  "Join{Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)->DataScan(t3)}(test.t.b,test.t.b)->Projection",
+			warning: "We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid",
+		},
+		{
+			sql:     "select /*+ leading(t3, t2) */ straight_join * from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b",
+			restore: "leading(`t3`, `t2`)",
+			greedy:  "Join{Join{DataScan(t1)->DataScan(t2)}(test.t.a,test.t.a)->DataScan(t3)}(test.t.b,test.t.b)->Projection",
+			warning: "We can only use the straight_join hint, when we use the leading hint and straight_join hint at the same time, all leading hints will be invalid",
+		},
+	}
+	ctx := context.TODO()
+	for i, tt := range tests {
+		// The greedy algorithm is used when the threshold is 0, otherwise the DP algorithm is used.
+		for _, threshold := range []int{0, 10} {
+			comment := Commentf("case:%v sql:%s threshold:%v", i, tt.sql, threshold)
+			stmt, err := s.ParseOneStmt(tt.sql, "", "")
+			c.Assert(err, IsNil, comment)
+			c.Assert(hint.RestoreTableOptimizerHint(stmt.(*ast.SelectStmt).TableHints[0]), Equals, tt.restore, comment)
+			err = Preprocess(s.ctx, stmt, WithPreprocessorReturn(&PreprocessorReturn{InfoSchema: s.is}))
+			c.Assert(err, IsNil, comment)
+			sctx := MockContext()
+			sctx.GetSessionVars().TiDBOptJoinReorderThreshold = threshold
+			hintProcessor := &hint.BlockHintProcessor{Ctx: sctx}
+			stmt.Accept(hintProcessor)
+			builder, _ := NewPlanBuilder().Init(sctx, s.is, hintProcessor)
+			p, err := builder.Build(ctx, stmt)
+			c.Assert(err, IsNil, comment)
+			p, err = logicalOptimize(ctx, flagPredicatePushDown|flagJoinReOrder, p.(LogicalPlan))
+			c.Assert(err, IsNil, comment)
+			best := tt.greedy
+			if threshold > 0 && tt.dp != "" {
+				best = tt.dp
+			}
+			c.Assert(ToString(p), Equals, best, comment)
+			warnings := sctx.GetSessionVars().StmtCtx.GetWarnings()
+			if tt.warning == "" {
+				c.Assert(warnings, HasLen, 0, comment)
+			} else {
+				c.Assert(warnings, HasLen, 1, comment)
+				c.Assert(warnings[0].Err.Error(), Equals, "[planner:1815]"+tt.warning, comment)
+			}
+		}
+	}
+}
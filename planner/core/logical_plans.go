@@ -16,6 +16,7 @@ package core
 
 import (
 	"math"
+	"sort"
 
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/auth"
@@ -131,6 +132,8 @@ type LogicalJoin struct {
 	// hintInfo stores the join algorithm hint information specified by client.
 	hintInfo       *tableHintInfo
 	preferJoinType uint
+	// leadingJoinOrder is the LEADING hint of the query block, it's used by join reorder.
+	leadingJoinOrder *leadingHintInfo
 
 	EqualConditions []*expression.ScalarFunction
 	LeftConditions  expression.CNFExprs
@@ -1274,6 +1277,42 @@ type CTEClass struct {
 	HasLimit bool
 	LimitBeg uint64
 	LimitEnd uint64
+	// consumerCount is the number of LogicalCTEs sharing this CTE.
+	consumerCount int
+	// pushDownPredicates records the predicates of each consumer that can be pushed into the
+	// CTE definition, keyed by the ID of the consumer. A nil predicate means the consumer needs all rows.
+	pushDownPredicates map[int]expression.Expression
+}
+
+// pushDownPredicatesToSeed adds the disjunction of the consumers' predicates on the seed part
+// if all the consumers of this non-recursive CTE have predicates to push down.
+func (cte *CTEClass) pushDownPredicatesToSeed(ctx sessionctx.Context) {
+	if cte.recursivePartLogicalPlan != nil || len(cte.pushDownPredicates) < cte.consumerCount {
+		return
+	}
+	ids := make([]int, 0, len(cte.pushDownPredicates))
+	for id, cond := range cte.pushDownPredicates {
+		if cond == nil {
+			return
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	conds := make([]expression.Expression, 0, len(ids))
+	hashCodes := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		cond := cte.pushDownPredicates[id]
+		hashCode := string(cond.HashCode(ctx.GetSessionVars().StmtCtx))
+		if _, ok := hashCodes[hashCode]; ok {
+			continue
+		}
+		hashCodes[hashCode] = struct{}{}
+		conds = append(conds, cond)
+	}
+	sel := LogicalSelection{Conditions: []expression.Expression{expression.ComposeDNFCondition(ctx, conds...)}}.Init(ctx, cte.seedPartLogicalPlan.SelectBlockOffset())
+	sel.SetChildren(cte.seedPartLogicalPlan)
+	cte.seedPartLogicalPlan = sel
+	cte.optFlag |= flagPredicatePushDown
 }
 
 // LogicalCTE is for CTE.
@@ -122,12 +122,14 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 
 // CheckTableLock checks the table lock.
 func CheckTableLock(ctx sessionctx.Context, is infoschema.InfoSchema, vs []visitInfo) error {
-	if !config.TableLockEnabled() {
-		return nil
-	}
 	checker := lock.NewChecker(ctx, is)
 	for i := range vs {
-		err := checker.CheckTableLock(vs[i].db, vs[i].table, vs[i].privilege, vs[i].alterWritable)
+		var err error
+		if config.TableLockEnabled() {
+			err = checker.CheckTableLock(vs[i].db, vs[i].table, vs[i].privilege, vs[i].alterWritable)
+		} else {
+			err = checker.CheckTableReadOnly(vs[i].db, vs[i].table, vs[i].privilege)
+		}
 		if err != nil {
 			return err
 		}
@@ -327,6 +327,9 @@ type PhysicalIndexMergeReader struct {
 	partialPlans []PhysicalPlan
 	// tablePlan is a PhysicalTableScan to get the table tuples. Current, it must be not nil.
 	tablePlan PhysicalPlan
+	// IsIntersectionType means whether it's intersection type or union type.
+	// Intersection type is for expressions connected by `AND` and union type is for `OR`.
+	IsIntersectionType bool
 
 	// Used by partition table.
 	PartitionInfo PartitionInfo
@@ -984,6 +987,9 @@ type PhysicalUnionAll struct {
 	physicalSchemaProducer
 
 	mpp bool
+	// ByItems is not empty if the children are ordered by it, and the union
+	// keeps the order by merging them.
+	ByItems []*util.ByItems
 }
 
 // Clone implements PhysicalPlan interface.
@@ -994,6 +1000,10 @@ func (p *PhysicalUnionAll) Clone() (PhysicalPlan, error) {
 		return nil, err
 	}
 	cloned.physicalSchemaProducer = *base
+	cloned.mpp = p.mpp
+	for _, it := range p.ByItems {
+		cloned.ByItems = append(cloned.ByItems, it.Clone())
+	}
 	return cloned, nil
 }
 
@@ -94,6 +94,32 @@ type tableHintInfo struct {
 	indexMergeHintList          []indexHintInfo
 	timeRangeHint               ast.HintTimeRange
 	limitHints                  limitHintInfo
+	mergeTables                 []hintTableInfo
+	noMergeTables               []hintTableInfo
+	leadingJoinOrder            *leadingHintInfo
+}
+
+// leadingHintInfo is the join order specified by a LEADING hint, e.g.
+// LEADING(t1, (t2, t3)). It is either a single table or a group of items
+// which are joined in order.
+type leadingHintInfo struct {
+	table *hintTableInfo
+	items []*leadingHintInfo
+}
+
+func (info *leadingHintInfo) String() string {
+	if info.table != nil {
+		return restore2TableHint(*info.table)
+	}
+	items := make([]string, 0, len(info.items))
+	for _, item := range info.items {
+		if item.table != nil {
+			items = append(items, item.String())
+		} else {
+			items = append(items, "("+item.String()+")")
+		}
+	}
+	return strings.Join(items, ", ")
 }
 
 type limitHintInfo struct {
@@ -261,6 +287,16 @@ func (info *tableHintInfo) ifPreferTiKV(tableName *hintTableInfo) *hintTableInfo
 	return nil
 }
 
+// ifPreferMerge checks whether the CTE is specified to be inlined by the MERGE hint.
+func (info *tableHintInfo) ifPreferMerge(tableName *hintTableInfo) bool {
+	return info.matchTableName([]*hintTableInfo{tableName}, info.mergeTables)
+}
+
+// ifPreferNoMerge checks whether the CTE is specified to be materialized by the NO_MERGE hint.
+func (info *tableHintInfo) ifPreferNoMerge(tableName *hintTableInfo) bool {
+	return info.matchTableName([]*hintTableInfo{tableName}, info.noMergeTables)
+}
+
 // matchTableName checks whether the hint hit the need.
 // Only need either side matches one on the list.
 // Even though you can put 2 tables on the list,
@@ -439,6 +475,15 @@ type cteInfo struct {
 	seedStat *property.StatsInfo
 	// The LogicalCTEs that reference the same table should share the same CteClass.
 	cteClass *CTEClass
+	// consumerCount is the number of references to this CTE in the statement.
+	// A non-recursive CTE referenced only once is inlined as a derived table.
+	consumerCount int
+	// outerScopeLen is the length of outerSchemas when this CTE is defined. It's used to
+	// resolve the CTE definition in the right scope when inlining the CTE.
+	outerScopeLen int
+	// preferMerge and preferNoMerge are set by the MERGE() and NO_MERGE() hints in the CTE definition.
+	preferMerge   bool
+	preferNoMerge bool
 }
 
 // PlanBuilder builds Plan from an ast.Node.
@@ -598,6 +643,14 @@ func (b *PlanBuilder) GetOptFlag() uint64 {
 	return b.optFlag
 }
 
+// evalSubqueryLazily returns whether the uncorrelated subqueries should be
+// evaluated during execution instead of plan building. EXPLAIN doesn't need to
+// run them, and a cached plan must not depend on their results.
+func (b *PlanBuilder) evalSubqueryLazily() bool {
+	sc := b.ctx.GetSessionVars().StmtCtx
+	return (sc.InExplainStmt && !sc.InExplainAnalyzeStmt) || sc.UseCache
+}
+
 func (b *PlanBuilder) getSelectOffset() int {
 	if len(b.selectOffset) > 0 {
 		return b.selectOffset[len(b.selectOffset)-1]
@@ -1328,6 +1381,24 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		p := &CancelDDLJobs{JobIDs: as.JobIDs}
 		p.setSchemaAndNames(buildCancelDDLJobsFields())
 		ret = p
+	case ast.AdminPauseDDLJobs, ast.AdminResumeDDLJobs, ast.AdminAlterDDLJob:
+		p := &ControlDDLJobs{Tp: as.Tp, JobIDs: as.JobIDs}
+		for _, option := range as.DDLJobOptions {
+			switch option.Tp {
+			case ast.AlterDDLJobThread:
+				if option.UintValue < 1 || option.UintValue > uint64(variable.GetMaxDDLReorgWorkerCount()) {
+					return nil, errors.Errorf("THREAD must be in [1, %d]", variable.GetMaxDDLReorgWorkerCount())
+				}
+				p.Thread = int(option.UintValue)
+			case ast.AlterDDLJobBatchSize:
+				if option.UintValue < uint64(variable.MinDDLReorgBatchSize) || option.UintValue > uint64(variable.MaxDDLReorgBatchSize) {
+					return nil, errors.Errorf("BATCH_SIZE must be in [%d, %d]", variable.MinDDLReorgBatchSize, variable.MaxDDLReorgBatchSize)
+				}
+				p.BatchSize = int(option.UintValue)
+			}
+		}
+		p.setSchemaAndNames(buildCancelDDLJobsFields())
+		ret = p
 	case ast.AdminCheckIndexRange:
 		schema, names, err := b.buildCheckIndexSchema(as.Tables[0], as.Index)
 		if err != nil {
@@ -1374,6 +1445,9 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		return &AdminResetTelemetryID{}, nil
 	case ast.AdminReloadStatistics:
 		return &Simple{Statement: as}, nil
+	case ast.AdminCalibrateCost:
+		// The calibration creates a table in the mysql schema, so it can only be executed by administrator.
+		ret = &Simple{Statement: as}
 	default:
 		return nil, ErrUnsupportedType.GenWithStack("Unsupported ast.AdminStmt(%T) for buildAdmin", as)
 	}
@@ -2534,7 +2608,7 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	case *ast.BeginStmt:
 		readTS := b.ctx.GetSessionVars().TxnReadTS.PeakTxnReadTS()
 		if raw.AsOf != nil {
-			startTS, err := calculateTsExpr(b.ctx, raw.AsOf)
+			startTS, err := CalculateAsOfTsExpr(b.ctx, raw.AsOf)
 			if err != nil {
 				return nil, err
 			}
@@ -2551,8 +2625,8 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	return p, nil
 }
 
-// calculateTsExpr calculates the TsExpr of AsOfClause to get a StartTS.
-func calculateTsExpr(sctx sessionctx.Context, asOfClause *ast.AsOfClause) (uint64, error) {
+// CalculateAsOfTsExpr calculates the TsExpr of AsOfClause to get a StartTS.
+func CalculateAsOfTsExpr(sctx sessionctx.Context, asOfClause *ast.AsOfClause) (uint64, error) {
 	tsVal, err := evalAstExpr(sctx, asOfClause.TsExpr)
 	if err != nil {
 		return 0, err
@@ -3610,7 +3684,7 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 				}
 				b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, dbName,
 					spec.NewTable.Name.L, "", authErr)
-			} else if spec.Tp == ast.AlterTableDropPartition {
+			} else if spec.Tp == ast.AlterTableDropPartition || spec.Tp == ast.AlterTableFirstPartition {
 				if b.ctx.GetSessionVars().User != nil {
 					authErr = ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
 						b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
@@ -3798,7 +3872,7 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, v.TableToTables[0].NewTable.Schema.L,
 			v.TableToTables[0].NewTable.Name.L, "", authErr)
-	case *ast.RecoverTableStmt, *ast.FlashBackTableStmt:
+	case *ast.RecoverTableStmt, *ast.FlashBackTableStmt, *ast.FlashBackDatabaseStmt, *ast.FlashBackToTimestampStmt:
 		// Recover table command can only be executed by administrator.
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
 	case *ast.LockTablesStmt, *ast.UnlockTablesStmt:
@@ -1026,6 +1026,56 @@ func (s *testPrepareSerialSuite) TestPrepareCacheWithJoinTable(c *C) {
 	tk.MustQuery("execute stmt using @b").Check(testkit.Rows("a <nil> <nil>"))
 }
 
+func (s *testPrepareSerialSuite) TestPrepareCacheWithScalarSubquery(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int, key(a))")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into t2 values (1, 10), (2, 20)")
+
+	// The subquery is evaluated in every execution of the cached plan.
+	tk.MustExec("prepare stmt from 'select * from t1 where a = (select max(a) from t2 where b > ?)'")
+	tk.MustExec("set @p = 10")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("insert into t2 values (3, 30)")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("set @p = 0")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("3 3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("set @p = 100")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	tk.MustExec("prepare stmt from 'select a from t1 where exists (select 1 from t2 where a = ?)'")
+	tk.MustExec("set @p = 1")
+	tk.MustQuery("execute stmt using @p").Sort().Check(testkit.Rows("1", "2", "3"))
+	tk.MustExec("set @p = 5")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+}
+
 func (s *testPlanSerialSuite) TestPlanCacheSnapshot(c *C) {
 	store, dom, err := newStoreWithBootstrap()
 	c.Assert(err, IsNil)
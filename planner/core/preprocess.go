@@ -252,7 +252,7 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 			p.checkBindGrammar(node.OriginNode, node.HintedNode, p.ctx.GetSessionVars().CurrentDB)
 		}
 		return in, true
-	case *ast.RecoverTableStmt, *ast.FlashBackTableStmt:
+	case *ast.RecoverTableStmt, *ast.FlashBackTableStmt, *ast.FlashBackDatabaseStmt:
 		// The specified table in recover table statement maybe already been dropped.
 		// So skip check table name here, otherwise, recover table [table_name] syntax will return
 		// table not exists error. But recover table statement is use to recover the dropped table. So skip children here.
@@ -1563,7 +1563,7 @@ func (p *preprocessor) handleAsOfAndReadTS(node *ast.AsOfClause) {
 		// for stale read
 		// It means we meet following case:
 		// select statement with as of timestamp
-		ts, p.err = calculateTsExpr(p.ctx, node)
+		ts, p.err = CalculateAsOfTsExpr(p.ctx, node)
 		if p.err != nil {
 			return
 		}
@@ -1573,7 +1573,7 @@ func (p *preprocessor) handleAsOfAndReadTS(node *ast.AsOfClause) {
 		}
 		if !p.initedLastSnapshotTS {
 			p.SnapshotTSEvaluator = func(ctx sessionctx.Context) (uint64, error) {
-				return calculateTsExpr(ctx, node)
+				return CalculateAsOfTsExpr(ctx, node)
 			}
 			p.LastSnapshotTS = ts
 			p.IsStaleness = true
@@ -425,6 +425,22 @@ func (p *PhysicalSort) ResolveIndices() (err error) {
 	return err
 }
 
+// ResolveIndices implements Plan interface.
+func (p *PhysicalUnionAll) ResolveIndices() (err error) {
+	err = p.physicalSchemaProducer.ResolveIndices()
+	if err != nil {
+		return err
+	}
+	// The output rows of the union have the same layout with the rows of its children.
+	for _, item := range p.ByItems {
+		item.Expr, err = item.Expr.ResolveIndices(p.schema)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // ResolveIndices implements Plan interface.
 func (p *PhysicalWindow) ResolveIndices() (err error) {
 	err = p.physicalSchemaProducer.ResolveIndices()
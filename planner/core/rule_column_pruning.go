@@ -163,7 +163,9 @@ func pruneByItems(old []*util.ByItems) (new []*util.ByItems, parentUsedCols []*e
 		if hashMatch {
 			// do nothing, should be filtered
 		} else if len(cols) == 0 {
-			if !expression.IsRuntimeConstExpr(byItem.Expr) {
+			// The lazily evaluated subquery is a constant in execution stage as well.
+			_, isSubQuery := byItem.Expr.(*ScalarSubQueryExpr)
+			if !expression.IsRuntimeConstExpr(byItem.Expr) && !isSubQuery {
 				new = append(new, byItem)
 			}
 		} else if byItem.Expr.GetType().Tp == mysql.TypeNull {
@@ -16,6 +16,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/sessionctx"
@@ -27,14 +28,16 @@ import (
 //
 // For example: "InnerJoin(InnerJoin(a, b), LeftJoin(c, d))"
 // results in a join group {a, b, LeftJoin(c, d)}.
-func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression.ScalarFunction, otherConds []expression.Expression) {
+//
+// The LEADING hints of the joins in the group are collected as well.
+func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression.ScalarFunction, otherConds []expression.Expression, leadingHints []*leadingHintInfo) {
 	join, isJoin := p.(*LogicalJoin)
 	if !isJoin || join.preferJoinType > uint(0) || join.JoinType != InnerJoin || join.StraightJoin {
-		return []LogicalPlan{p}, nil, nil
+		return []LogicalPlan{p}, nil, nil, nil
 	}
 
-	lhsGroup, lhsEqualConds, lhsOtherConds := extractJoinGroup(join.children[0])
-	rhsGroup, rhsEqualConds, rhsOtherConds := extractJoinGroup(join.children[1])
+	lhsGroup, lhsEqualConds, lhsOtherConds, lhsLeadingHints := extractJoinGroup(join.children[0])
+	rhsGroup, rhsEqualConds, rhsOtherConds, rhsLeadingHints := extractJoinGroup(join.children[1])
 
 	group = append(group, lhsGroup...)
 	group = append(group, rhsGroup...)
@@ -44,28 +47,66 @@ func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression
 	otherConds = append(otherConds, join.OtherConditions...)
 	otherConds = append(otherConds, lhsOtherConds...)
 	otherConds = append(otherConds, rhsOtherConds...)
-	return group, eqEdges, otherConds
+	if join.leadingJoinOrder != nil {
+		leadingHints = append(leadingHints, join.leadingJoinOrder)
+	}
+	for _, leadingHint := range append(lhsLeadingHints, rhsLeadingHints...) {
+		if !containsLeadingHint(leadingHints, leadingHint) {
+			leadingHints = append(leadingHints, leadingHint)
+		}
+	}
+	return group, eqEdges, otherConds, leadingHints
+}
+
+func containsLeadingHint(leadingHints []*leadingHintInfo, leadingHint *leadingHintInfo) bool {
+	for _, h := range leadingHints {
+		if h == leadingHint {
+			return true
+		}
+	}
+	return false
 }
 
 type joinReOrderSolver struct {
 }
 
+// leadingHintTracker records the LEADING hints met by join reorder and whether they are applied.
+type leadingHintTracker struct {
+	hints   []*leadingHintInfo
+	applied map[*leadingHintInfo]bool
+}
+
+func (t *leadingHintTracker) appendInapplicableWarnings(ctx sessionctx.Context) {
+	for _, leadingHint := range t.hints {
+		if !t.applied[leadingHint] {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				fmt.Sprintf("leading hint is inapplicable, check if the leading hint table is valid: LEADING(%s)", leadingHint)))
+		}
+	}
+}
+
 type jrNode struct {
 	p       LogicalPlan
 	cumCost float64
 }
 
 func (s *joinReOrderSolver) optimize(ctx context.Context, p LogicalPlan) (LogicalPlan, error) {
-	return s.optimizeRecursive(p.SCtx(), p)
+	tracker := &leadingHintTracker{applied: make(map[*leadingHintInfo]bool)}
+	p, err := s.optimizeRecursive(p.SCtx(), p, tracker)
+	if err != nil {
+		return nil, err
+	}
+	tracker.appendInapplicableWarnings(p.SCtx())
+	return p, nil
 }
 
 // optimizeRecursive recursively collects join groups and applies join reorder algorithm for each group.
-func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan) (LogicalPlan, error) {
+func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan, tracker *leadingHintTracker) (LogicalPlan, error) {
 	var err error
-	curJoinGroup, eqEdges, otherConds := extractJoinGroup(p)
+	curJoinGroup, eqEdges, otherConds, leadingHints := extractJoinGroup(p)
 	if len(curJoinGroup) > 1 {
 		for i := range curJoinGroup {
-			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i])
+			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i], tracker)
 			if err != nil {
 				return nil, err
 			}
@@ -74,6 +115,23 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 			ctx:        ctx,
 			otherConds: otherConds,
 		}
+		for _, leadingHint := range leadingHints {
+			if !containsLeadingHint(tracker.hints, leadingHint) {
+				tracker.hints = append(tracker.hints, leadingHint)
+			}
+		}
+		if len(leadingHints) > 1 {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+				"We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid"))
+			for _, leadingHint := range leadingHints {
+				tracker.applied[leadingHint] = true
+			}
+		} else if len(leadingHints) == 1 {
+			var ok bool
+			if ok, curJoinGroup, eqEdges = baseGroupSolver.generateLeadingJoinGroup(curJoinGroup, eqEdges, leadingHints[0]); ok {
+				tracker.applied[leadingHints[0]] = true
+			}
+		}
 		originalSchema := p.Schema()
 		if len(curJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
 			groupSolver := &joinReorderGreedySolver{
@@ -114,7 +172,7 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 	}
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
-		newChild, err := s.optimizeRecursive(ctx, child)
+		newChild, err := s.optimizeRecursive(ctx, child, tracker)
 		if err != nil {
 			return nil, err
 		}
@@ -129,6 +187,80 @@ type baseSingleGroupJoinOrderSolver struct {
 	ctx          sessionctx.Context
 	curJoinGroup []*jrNode
 	otherConds   []expression.Expression
+	// hasLeadingJoin means the first node of the join group is built from
+	// the LEADING hint, so it must be joined before the other nodes.
+	hasLeadingJoin bool
+}
+
+// generateLeadingJoinGroup joins the nodes specified by the LEADING hint in
+// order, and returns the new join group which starts with the leading join.
+// If some table in the hint can't be found in the join group, ok is false
+// and the join group is returned unchanged.
+func (s *baseSingleGroupJoinOrderSolver) generateLeadingJoinGroup(curJoinGroup []LogicalPlan, eqEdges []*expression.ScalarFunction,
+	leadingHint *leadingHintInfo) (ok bool, newJoinGroup []LogicalPlan, remainEdges []*expression.ScalarFunction) {
+	remainNodes := append([]LogicalPlan(nil), curJoinGroup...)
+	remainEdges = append([]*expression.ScalarFunction(nil), eqEdges...)
+	remainOtherConds := append([]expression.Expression(nil), s.otherConds...)
+	var buildLeadingJoin func(info *leadingHintInfo) LogicalPlan
+	buildLeadingJoin = func(info *leadingHintInfo) LogicalPlan {
+		if info.table != nil {
+			for i, node := range remainNodes {
+				alias := extractTableAlias(node, info.table.selectOffset)
+				if alias != nil && alias.dbName.L == info.table.dbName.L && alias.tblName.L == info.table.tblName.L &&
+					alias.selectOffset == info.table.selectOffset {
+					remainNodes = append(remainNodes[:i], remainNodes[i+1:]...)
+					return node
+				}
+			}
+			return nil
+		}
+		var leadingJoin LogicalPlan
+		for _, item := range info.items {
+			node := buildLeadingJoin(item)
+			if node == nil {
+				return nil
+			}
+			if leadingJoin == nil {
+				leadingJoin = node
+				continue
+			}
+			var usedEdges []*expression.ScalarFunction
+			usedEdges, remainEdges = s.splitConnectingEdges(leadingJoin, node, remainEdges)
+			var otherConds []expression.Expression
+			mergedSchema := expression.MergeSchema(leadingJoin.Schema(), node.Schema())
+			remainOtherConds, otherConds = expression.FilterOutInPlace(remainOtherConds, func(expr expression.Expression) bool {
+				return expression.ExprFromSchema(expr, mergedSchema)
+			})
+			leadingJoin = s.newJoinWithEdges(leadingJoin, node, usedEdges, otherConds)
+		}
+		return leadingJoin
+	}
+	leadingJoin := buildLeadingJoin(leadingHint)
+	if leadingJoin == nil {
+		return false, curJoinGroup, eqEdges
+	}
+	s.otherConds = remainOtherConds
+	s.hasLeadingJoin = true
+	return true, append([]LogicalPlan{leadingJoin}, remainNodes...), remainEdges
+}
+
+// splitConnectingEdges picks the equal edges connecting the two plans and
+// arranges their arguments as (left, right), the other edges are returned as remainEdges.
+func (s *baseSingleGroupJoinOrderSolver) splitConnectingEdges(leftPlan, rightPlan LogicalPlan,
+	eqEdges []*expression.ScalarFunction) (usedEdges, remainEdges []*expression.ScalarFunction) {
+	for _, edge := range eqEdges {
+		lCol := edge.GetArgs()[0].(*expression.Column)
+		rCol := edge.GetArgs()[1].(*expression.Column)
+		if leftPlan.Schema().Contains(lCol) && rightPlan.Schema().Contains(rCol) {
+			usedEdges = append(usedEdges, edge)
+		} else if rightPlan.Schema().Contains(lCol) && leftPlan.Schema().Contains(rCol) {
+			newSf := expression.NewFunctionInternal(s.ctx, edge.FuncName.L, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
+			usedEdges = append(usedEdges, newSf)
+		} else {
+			remainEdges = append(remainEdges, edge)
+		}
+	}
+	return usedEdges, remainEdges
 }
 
 // baseNodeCumCost calculate the cumulative cost of the node in the join group.
@@ -17,7 +17,6 @@ package core
 import (
 	"math/bits"
 
-	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/tidb/expression"
 )
 
@@ -166,11 +165,20 @@ func (s *joinReorderDPSolver) dpGraph(visitID2NodeID, nodeID2VisitID []int, join
 	for i := uint(0); i < nodeCnt; i++ {
 		bestPlan[1<<i] = s.curJoinGroup[visitID2NodeID[i]]
 	}
+	// The leading join built from the LEADING hint is the first node of the join group,
+	// the other nodes can only be joined to the sub plans containing it.
+	leadingMask := uint(0)
+	if s.hasLeadingJoin && visitID2NodeID[0] == 0 {
+		leadingMask = 1
+	}
 	// Enumerate the nodeBitmap from small to big, make sure that S1 must be enumerated before S2 if S1 belongs to S2.
 	for nodeBitmap := uint(1); nodeBitmap < (1 << nodeCnt); nodeBitmap++ {
 		if bits.OnesCount(nodeBitmap) == 1 {
 			continue
 		}
+		if nodeBitmap&leadingMask != leadingMask {
+			continue
+		}
 		// This loop can iterate all its subset.
 		for sub := (nodeBitmap - 1) & nodeBitmap; sub > 0; sub = (sub - 1) & nodeBitmap {
 			remain := nodeBitmap ^ sub
@@ -187,11 +195,15 @@ func (s *joinReorderDPSolver) dpGraph(visitID2NodeID, nodeID2VisitID []int, join
 			if len(usedEdges) == 0 {
 				continue
 			}
-			join, err := s.newJoinWithEdge(bestPlan[sub].p, bestPlan[remain].p, usedEdges, otherConds)
+			lNode, rNode := bestPlan[sub], bestPlan[remain]
+			if remain&leadingMask != 0 {
+				lNode, rNode = rNode, lNode
+			}
+			join, err := s.newJoinWithEdge(lNode.p, rNode.p, usedEdges, otherConds)
 			if err != nil {
 				return nil, err
 			}
-			curCost := s.calcJoinCumCost(join, bestPlan[sub], bestPlan[remain])
+			curCost := s.calcJoinCumCost(join, lNode, rNode)
 			if bestPlan[nodeBitmap] == nil {
 				bestPlan[nodeBitmap] = &jrNode{
 					p:       join,
@@ -241,7 +253,7 @@ func (s *joinReorderDPSolver) newJoinWithEdge(leftPlan, rightPlan LogicalPlan, e
 		if leftPlan.Schema().Contains(lCol) {
 			eqConds = append(eqConds, edge.edge)
 		} else {
-			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
+			newSf := expression.NewFunctionInternal(s.ctx, edge.edge.FuncName.L, edge.edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
 			eqConds = append(eqConds, newSf)
 		}
 	}
@@ -18,7 +18,6 @@ import (
 	"math"
 	"sort"
 
-	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/tidb/expression"
 )
 
@@ -41,6 +40,9 @@ type joinReorderGreedySolver struct {
 //
 // For the nodes and join trees which don't have a join equal condition to
 // connect them, we make a bushy join tree to do the cartesian joins finally.
+//
+// If the group has a leading join built from the LEADING hint, the join tree
+// always starts from it.
 func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan) (LogicalPlan, error) {
 	for _, node := range joinNodePlans {
 		_, err := node.recursiveDeriveStats(nil)
@@ -52,8 +54,13 @@ func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan) (LogicalPla
 			cumCost: s.baseNodeCumCost(node),
 		})
 	}
-	sort.SliceStable(s.curJoinGroup, func(i, j int) bool {
-		return s.curJoinGroup[i].cumCost < s.curJoinGroup[j].cumCost
+	// The leading join is kept at the head of the group, so the join tree starts from it.
+	sortedNodes := s.curJoinGroup
+	if s.hasLeadingJoin {
+		sortedNodes = s.curJoinGroup[1:]
+	}
+	sort.SliceStable(sortedNodes, func(i, j int) bool {
+		return sortedNodes[i].cumCost < sortedNodes[j].cumCost
 	})
 
 	var cartesianGroup []LogicalPlan
@@ -117,7 +124,7 @@ func (s *joinReorderGreedySolver) checkConnectionAndMakeJoin(leftNode, rightNode
 		if leftNode.Schema().Contains(lCol) && rightNode.Schema().Contains(rCol) {
 			usedEdges = append(usedEdges, edge)
 		} else if rightNode.Schema().Contains(lCol) && leftNode.Schema().Contains(rCol) {
-			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
+			newSf := expression.NewFunctionInternal(s.ctx, edge.FuncName.L, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
 			usedEdges = append(usedEdges, newSf)
 		}
 	}
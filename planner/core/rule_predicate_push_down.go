@@ -66,6 +66,34 @@ func (p *baseLogicalPlan) PredicatePushDown(predicates []expression.Expression)
 	return nil, p.self
 }
 
+// PredicatePushDown implements LogicalPlan PredicatePushDown interface.
+// The predicates are kept above the CTE since it may be shared by other consumers, but they are recorded
+// and pushed into the CTE definition if all the consumers have predicates, see pushDownPredicatesToSeed.
+func (p *LogicalCTE) PredicatePushDown(predicates []expression.Expression) ([]expression.Expression, LogicalPlan) {
+	if p.cte.recursivePartLogicalPlan != nil {
+		return predicates, p
+	}
+	seedCols := p.cte.seedPartLogicalPlan.Schema().Columns
+	seedExprs := make([]expression.Expression, 0, len(seedCols))
+	for _, col := range seedCols {
+		seedExprs = append(seedExprs, col)
+	}
+	pushed := make([]expression.Expression, 0, len(predicates))
+	for _, cond := range predicates {
+		// The correlated or non-deterministic predicates can't be evaluated in the CTE definition.
+		if len(expression.ExtractCorColumns(cond)) > 0 || expression.IsMutableEffectsExpr(cond) || expression.HasGetSetVarFunc(cond) {
+			continue
+		}
+		pushed = append(pushed, expression.ColumnSubstitute(cond.Clone(), p.schema, seedExprs))
+	}
+	if len(pushed) > 0 {
+		p.cte.pushDownPredicates[p.ID()] = expression.ComposeCNFCondition(p.ctx, pushed...)
+	} else {
+		p.cte.pushDownPredicates[p.ID()] = nil
+	}
+	return predicates, p
+}
+
 func splitSetGetVarFunc(filters []expression.Expression) ([]expression.Expression, []expression.Expression) {
 	canBePushDown := make([]expression.Expression, 0, len(filters))
 	canNotBePushDown := make([]expression.Expression, 0, len(filters))
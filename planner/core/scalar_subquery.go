@@ -0,0 +1,346 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/plancodec"
+)
+
+// ScalarSubqueryEvalCtx holds the physical plan of an uncorrelated scalar or
+// EXISTS subquery which is evaluated lazily during execution instead of plan
+// building. It is explained as a separate plan tree after the main plan.
+type ScalarSubqueryEvalCtx struct {
+	baseSchemaProducer
+
+	// scalarSubQuery is the physical plan of the subquery.
+	scalarSubQuery PhysicalPlan
+	// outputColIDs are the IDs of the ScalarSubQueryExprs referring to the
+	// output columns of the subquery.
+	outputColIDs []int64
+	// isExists indicates the output is whether the subquery returns any row.
+	isExists bool
+
+	mu struct {
+		sync.Mutex
+		// evaluatedIn is the task ID of the statement in which the result is
+		// evaluated, so a cached plan evaluates the subquery again in every execution.
+		// The statement context is reused among statements, so it can't be the key.
+		evaluated   bool
+		evaluatedIn uint64
+		row         []types.Datum
+		err         error
+	}
+}
+
+func newScalarSubqueryEvalCtx(ctx sessionctx.Context, offset int, p PhysicalPlan, isExists bool) *ScalarSubqueryEvalCtx {
+	s := &ScalarSubqueryEvalCtx{scalarSubQuery: p, isExists: isExists}
+	s.basePlan = newBasePlan(ctx, plancodec.TypeScalarSubQuery, offset)
+	s.schema = p.Schema()
+	return s
+}
+
+// ExplainInfo implements Plan interface.
+func (s *ScalarSubqueryEvalCtx) ExplainInfo() string {
+	buffer := bytes.NewBufferString("Output: ")
+	for i, id := range s.outputColIDs {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		fmt.Fprintf(buffer, "ScalarQueryCol#%d", id)
+	}
+	return buffer.String()
+}
+
+// evalFirstRow executes the subquery at most once in a statement and returns
+// the first row of its result.
+func (s *ScalarSubqueryEvalCtx) evalFirstRow() ([]types.Datum, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc := s.ctx.GetSessionVars().StmtCtx
+	if s.mu.evaluated && s.mu.evaluatedIn == sc.TaskID {
+		return s.mu.row, s.mu.err
+	}
+	is := s.ctx.GetInfoSchema().(infoschema.InfoSchema)
+	row, err := EvalSubqueryFirstRow(context.Background(), s.scalarSubQuery, is, s.ctx)
+	s.mu.evaluated, s.mu.evaluatedIn = true, sc.TaskID
+	s.mu.row, s.mu.err = row, err
+	return row, err
+}
+
+// registerScalarSubQuery records the lazily evaluated subquery in the statement
+// context, so that EXPLAIN and the plan cache can find it.
+func registerScalarSubQuery(sctx sessionctx.Context, s *ScalarSubqueryEvalCtx) {
+	sc := sctx.GetSessionVars().StmtCtx
+	sc.ScalarSubQueries = append(sc.ScalarSubQueries, s)
+}
+
+// scalarSubQueries returns the lazily evaluated subqueries of current statement.
+func scalarSubQueries(sctx sessionctx.Context) []*ScalarSubqueryEvalCtx {
+	registered := sctx.GetSessionVars().StmtCtx.ScalarSubQueries
+	subQueries := make([]*ScalarSubqueryEvalCtx, 0, len(registered))
+	for _, s := range registered {
+		subQueries = append(subQueries, s.(*ScalarSubqueryEvalCtx))
+	}
+	return subQueries
+}
+
+// ScalarSubQueryExpr is the placeholder of an output column of the subquery in
+// ScalarSubqueryEvalCtx. The subquery is executed when the expression is
+// evaluated for the first time, so the planner treats it as an opaque
+// expression rather than a constant.
+type ScalarSubQueryExpr struct {
+	// Constant is embedded to implement the unexported methods of
+	// expression.Expression, all the evaluation methods are overridden.
+	expression.Constant
+
+	scalarSubqueryColID int64
+	// outputIdx is the offset of the column in the output of the subquery.
+	outputIdx int
+	evalCtx   *ScalarSubqueryEvalCtx
+	hashcode  []byte
+}
+
+// evalConstant evaluates the subquery and returns the result as a constant.
+func (s *ScalarSubQueryExpr) evalConstant() (*expression.Constant, error) {
+	row, err := s.evalCtx.evalFirstRow()
+	if err != nil {
+		return nil, err
+	}
+	var value types.Datum
+	if s.evalCtx.isExists {
+		if row != nil {
+			value.SetInt64(1)
+		} else {
+			value.SetInt64(0)
+		}
+	} else if row != nil {
+		value = row[s.outputIdx]
+	}
+	return &expression.Constant{Value: value, RetType: s.RetType}, nil
+}
+
+// Eval implements Expression interface.
+func (s *ScalarSubQueryExpr) Eval(row chunk.Row) (types.Datum, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return c.Eval(row)
+}
+
+// EvalInt implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalInt(ctx sessionctx.Context, row chunk.Row) (int64, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return 0, false, err
+	}
+	return c.EvalInt(ctx, row)
+}
+
+// EvalReal implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalReal(ctx sessionctx.Context, row chunk.Row) (float64, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return 0, false, err
+	}
+	return c.EvalReal(ctx, row)
+}
+
+// EvalString implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalString(ctx sessionctx.Context, row chunk.Row) (string, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return "", false, err
+	}
+	return c.EvalString(ctx, row)
+}
+
+// EvalDecimal implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalDecimal(ctx sessionctx.Context, row chunk.Row) (*types.MyDecimal, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return nil, false, err
+	}
+	return c.EvalDecimal(ctx, row)
+}
+
+// EvalTime implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalTime(ctx sessionctx.Context, row chunk.Row) (types.Time, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return types.ZeroTime, false, err
+	}
+	return c.EvalTime(ctx, row)
+}
+
+// EvalDuration implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalDuration(ctx sessionctx.Context, row chunk.Row) (types.Duration, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return types.Duration{}, false, err
+	}
+	return c.EvalDuration(ctx, row)
+}
+
+// EvalJSON implements Expression interface.
+func (s *ScalarSubQueryExpr) EvalJSON(ctx sessionctx.Context, row chunk.Row) (json.BinaryJSON, bool, error) {
+	c, err := s.evalConstant()
+	if err != nil {
+		return json.BinaryJSON{}, false, err
+	}
+	return c.EvalJSON(ctx, row)
+}
+
+// VecEvalInt implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalInt(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalInt(ctx, input, result)
+}
+
+// VecEvalReal implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalReal(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalReal(ctx, input, result)
+}
+
+// VecEvalString implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalString(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalString(ctx, input, result)
+}
+
+// VecEvalDecimal implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalDecimal(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalDecimal(ctx, input, result)
+}
+
+// VecEvalTime implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalTime(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalTime(ctx, input, result)
+}
+
+// VecEvalDuration implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalDuration(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalDuration(ctx, input, result)
+}
+
+// VecEvalJSON implements Expression interface.
+func (s *ScalarSubQueryExpr) VecEvalJSON(ctx sessionctx.Context, input *chunk.Chunk, result *chunk.Column) error {
+	c, err := s.evalConstant()
+	if err != nil {
+		return err
+	}
+	return c.VecEvalJSON(ctx, input, result)
+}
+
+// SupportReverseEval implements Expression interface.
+func (s *ScalarSubQueryExpr) SupportReverseEval() bool {
+	return false
+}
+
+// String implements fmt.Stringer interface.
+func (s *ScalarSubQueryExpr) String() string {
+	return fmt.Sprintf("ScalarQueryCol#%d", s.scalarSubqueryColID)
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (s *ScalarSubQueryExpr) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", s)), nil
+}
+
+// ExplainInfo implements Expression interface.
+func (s *ScalarSubQueryExpr) ExplainInfo() string {
+	return s.String()
+}
+
+// ExplainNormalizedInfo implements Expression interface.
+func (s *ScalarSubQueryExpr) ExplainNormalizedInfo() string {
+	return s.String()
+}
+
+// Clone implements Expression interface.
+func (s *ScalarSubQueryExpr) Clone() expression.Expression {
+	cloned := *s
+	cloned.RetType = s.RetType.Clone()
+	return &cloned
+}
+
+// Equal implements Expression interface.
+func (s *ScalarSubQueryExpr) Equal(_ sessionctx.Context, e expression.Expression) bool {
+	other, ok := e.(*ScalarSubQueryExpr)
+	return ok && s.scalarSubqueryColID == other.scalarSubqueryColID
+}
+
+// ConstItem implements Expression interface.
+func (s *ScalarSubQueryExpr) ConstItem(_ *stmtctx.StatementContext) bool {
+	return false
+}
+
+// Decorrelate implements Expression interface.
+func (s *ScalarSubQueryExpr) Decorrelate(_ *expression.Schema) expression.Expression {
+	return s
+}
+
+// ResolveIndices implements Expression interface.
+func (s *ScalarSubQueryExpr) ResolveIndices(_ *expression.Schema) (expression.Expression, error) {
+	return s.Clone(), nil
+}
+
+// ResolveIndicesByVirtualExpr implements Expression interface.
+func (s *ScalarSubQueryExpr) ResolveIndicesByVirtualExpr(_ *expression.Schema) (expression.Expression, bool) {
+	return s.Clone(), true
+}
+
+// HashCode implements Expression interface.
+func (s *ScalarSubQueryExpr) HashCode(_ *stmtctx.StatementContext) []byte {
+	if len(s.hashcode) == 0 {
+		s.hashcode = codec.EncodeInt([]byte("ScalarQueryCol#"), s.scalarSubqueryColID)
+	}
+	return s.hashcode
+}
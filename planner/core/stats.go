@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/planner/property"
 	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/statistics"
@@ -411,30 +412,22 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		return nil, err
 	}
 
-	// TODO: implement UnionScan + IndexMerge
-	isReadOnlyTxn := true
-	txn, err := ds.ctx.Txn(false)
-	if err != nil {
-		return nil, err
-	}
-	if txn.Valid() && !txn.IsReadOnly() {
-		isReadOnlyTxn = false
-	}
-	// Consider the IndexMergePath. Now, we just generate `IndexMergePath` in DNF case.
+	// Consider the IndexMergePath. The union type `IndexMergePath` is generated in DNF case,
+	// and the intersection type `IndexMergePath` is generated in CNF case.
 	isPossibleIdxMerge := len(ds.pushedDownConds) > 0 && len(ds.possibleAccessPaths) > 1
 	sessionAndStmtPermission := (ds.ctx.GetSessionVars().GetEnableIndexMerge() || len(ds.indexMergeHints) > 0) && !ds.ctx.GetSessionVars().StmtCtx.NoIndexMergeHint
-	// If there is an index path, we current do not consider `IndexMergePath`.
-	needConsiderIndexMerge := true
+	// If there is an index path, we current do not consider the union type `IndexMergePath`.
+	needConsiderIndexMergeOr := true
 	if len(ds.indexMergeHints) == 0 {
 		for i := 1; i < len(ds.possibleAccessPaths); i++ {
 			if len(ds.possibleAccessPaths[i].AccessConds) != 0 {
-				needConsiderIndexMerge = false
+				needConsiderIndexMergeOr = false
 				break
 			}
 		}
 	}
-	if isPossibleIdxMerge && sessionAndStmtPermission && needConsiderIndexMerge && isReadOnlyTxn && ds.tableInfo.TempTableType != model.TempTableLocal {
-		err := ds.generateAndPruneIndexMergePath(ds.indexMergeHints != nil)
+	if isPossibleIdxMerge && sessionAndStmtPermission && ds.tableInfo.TempTableType != model.TempTableLocal {
+		err := ds.generateAndPruneIndexMergePath(needConsiderIndexMergeOr, ds.indexMergeHints != nil)
 		if err != nil {
 			return nil, err
 		}
@@ -445,11 +438,16 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 	return ds.stats, nil
 }
 
-func (ds *DataSource) generateAndPruneIndexMergePath(needPrune bool) error {
+func (ds *DataSource) generateAndPruneIndexMergePath(needConsiderIndexMergeOr, needPrune bool) error {
 	regularPathCount := len(ds.possibleAccessPaths)
-	err := ds.generateIndexMergeOrPaths()
-	if err != nil {
-		return err
+	if needConsiderIndexMergeOr {
+		err := ds.generateIndexMergeOrPaths()
+		if err != nil {
+			return err
+		}
+	}
+	if andPath := ds.generateIndexMergeAndPaths(regularPathCount); andPath != nil {
+		ds.possibleAccessPaths = append(ds.possibleAccessPaths, andPath)
 	}
 	// If without hints, it means that `enableIndexMerge` is true
 	if len(ds.indexMergeHints) == 0 {
@@ -713,6 +711,86 @@ func (ds *DataSource) buildIndexMergeOrPath(partialPaths []*util.AccessPath, cur
 	return indexMergePath
 }
 
+// generateIndexMergeAndPaths generates the intersection type IndexMergePath from the regular index paths.
+// The index paths are chosen greedily by the estimated row count, and each chosen path must access
+// some conditions which are not accessed by the paths chosen before.
+func (ds *DataSource) generateIndexMergeAndPaths(normalPathCnt int) *util.AccessPath {
+	sc := ds.ctx.GetSessionVars().StmtCtx
+	candidates := make([]*util.AccessPath, 0, normalPathCnt)
+	for i := 0; i < normalPathCnt; i++ {
+		path := ds.possibleAccessPaths[i]
+		if path.IsTablePath() || path.Index == nil || len(path.AccessConds) == 0 || path.StoreType == kv.TiFlash {
+			continue
+		}
+		// If we have empty range, or point range on unique index, the single index path is good enough.
+		if len(path.Ranges) == 0 || (path.Index.Unique && path.OnlyPointRange(sc)) {
+			return nil
+		}
+		if !ds.isInIndexMergeHints(path.Index.Name.L) {
+			continue
+		}
+		candidates = append(candidates, path)
+	}
+	if len(candidates) < 2 {
+		return nil
+	}
+	estRowCount := func(path *util.AccessPath) float64 {
+		if len(path.IndexFilters) > 0 {
+			return path.CountAfterIndex
+		}
+		return path.CountAfterAccess
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return estRowCount(candidates[i]) < estRowCount(candidates[j])
+	})
+
+	coveredConds := make(map[string]struct{}, len(ds.pushedDownConds))
+	var partialPaths []*util.AccessPath
+	var accessConds []expression.Expression
+	for _, path := range candidates {
+		newlyCovered := false
+		for _, cond := range path.AccessConds {
+			if _, ok := coveredConds[string(cond.HashCode(sc))]; !ok {
+				newlyCovered = true
+				break
+			}
+		}
+		if !newlyCovered {
+			continue
+		}
+		for _, conds := range [][]expression.Expression{path.AccessConds, path.IndexFilters} {
+			for _, cond := range conds {
+				hashCode := string(cond.HashCode(sc))
+				if _, ok := coveredConds[hashCode]; !ok {
+					coveredConds[hashCode] = struct{}{}
+					accessConds = append(accessConds, cond)
+				}
+			}
+		}
+		// The table filters of the partial path are evaluated after the intersection.
+		partialPath := *path
+		partialPath.TableFilters = nil
+		partialPaths = append(partialPaths, &partialPath)
+	}
+	if len(partialPaths) < 2 {
+		return nil
+	}
+
+	indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths, IndexMergeIsIntersection: true}
+	for _, cond := range ds.pushedDownConds {
+		if _, ok := coveredConds[string(cond.HashCode(sc))]; !ok {
+			indexMergePath.TableFilters = append(indexMergePath.TableFilters, cond)
+		}
+	}
+	sel, _, err := ds.tableStats.HistColl.Selectivity(ds.ctx, accessConds, nil)
+	if err != nil {
+		logutil.BgLogger().Debug("something wrong happened, use the default selectivity", zap.Error(err))
+		sel = SelectionFactor
+	}
+	indexMergePath.CountAfterAccess = sel * ds.tableStats.RowCount
+	return indexMergePath
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalSelection) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
@@ -1218,6 +1296,7 @@ func (p *LogicalCTE) DeriveStats(childStats []*property.StatsInfo, selfSchema *e
 
 	var err error
 	if p.cte.seedPartPhysicalPlan == nil {
+		p.cte.pushDownPredicatesToSeed(p.ctx)
 		p.cte.seedPartPhysicalPlan, _, err = DoOptimize(context.TODO(), p.ctx, p.cte.optFlag, p.cte.seedPartLogicalPlan)
 		if err != nil {
 			return nil, err
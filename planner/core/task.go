@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
@@ -83,6 +84,8 @@ type copTask struct {
 	// is used to compute average row width when computing scan cost.
 	tblCols           []*expression.Column
 	idxMergePartPlans []PhysicalPlan
+	// idxMergeIsIntersection indicates whether the handles of idxMergePartPlans are intersected.
+	idxMergeIsIntersection bool
 	// rootTaskConds stores select conditions containing virtual columns.
 	// These conditions can't push to TiKV, so we have to add a selection for rootTask
 	rootTaskConds []expression.Expression
@@ -177,6 +180,49 @@ func (t *copTask) finishIndexPlan() {
 	}
 	rowSize := t.tblColHists.GetIndexAvgRowSize(t.indexPlan.SCtx(), t.tblCols, p.(*PhysicalIndexScan).Index.Unique)
 	t.cst += cnt * rowSize * sessVars.GetScanFactor(tableInfo)
+	if sessVars.CostModelVersion == 2 {
+		// The rows of table side are read randomly, every handle is a point range, so TiKV seeks once for each row.
+		t.cst += cnt * sessVars.GetSeekFactor(tableInfo)
+		// The handles are sent back to TiKV as ranges, each of which consists of a start key and an end key.
+		t.cst += cnt * 2 * t.handleKeySize() * sessVars.GetNetworkFactor(tableInfo)
+	}
+}
+
+// handleKeySize returns the average size of the row keys read by the table side of the double read.
+func (t *copTask) handleKeySize() float64 {
+	if len(t.commonHandleCols) == 0 {
+		return tablecodec.RecordRowKeyLen
+	}
+	handleSize := t.tblColHists.GetAvgRowSize(t.indexPlan.SCtx(), t.commonHandleCols, true, false)
+	return tablecodec.RecordRowKeyLen - 8 + handleSize
+}
+
+// regionSizeForCost is the default size of a region, it is used to estimate the number of cop tasks.
+const regionSizeForCost = 96 * 1024 * 1024
+
+// copTaskWorkers estimates the number of workers that run the cop tasks in parallel.
+// According to `CopClient::Send`, the concurrency is Min(DistSQLScanConcurrency, numRegionsInvolvedInScan).
+// The cost model version 1 cannot infer the number of regions involved, so it simply uses DistSQLScanConcurrency,
+// while the version 2 estimates the number of regions by the size of data scanned on TiKV.
+func (t *copTask) copTaskWorkers(ctx sessionctx.Context) float64 {
+	sessVars := ctx.GetSessionVars()
+	workers := float64(sessVars.DistSQLScanConcurrency())
+	if sessVars.CostModelVersion != 2 || t.getStoreType() != kv.TiKV {
+		return workers
+	}
+	scanPlan, isIndex := t.tablePlan, false
+	if t.indexPlan != nil {
+		scanPlan, isIndex = t.indexPlan, true
+	}
+	for len(scanPlan.Children()) > 0 {
+		scanPlan = scanPlan.Children()[0]
+	}
+	rowSize := t.tblColHists.GetAvgRowSize(ctx, scanPlan.Schema().Columns, isIndex, false)
+	if !isIndex {
+		rowSize = t.tblColHists.GetTableAvgRowSize(ctx, t.tblCols, kv.TiKV, true)
+	}
+	regions := math.Ceil(scanPlan.statsInfo().RowCount * rowSize / regionSizeForCost)
+	return math.Max(1, math.Min(workers, regions))
 }
 
 func (t *copTask) getStoreType() kv.StoreType {
@@ -963,10 +1009,8 @@ func (t *copTask) convertToRootTask(ctx sessionctx.Context) *rootTask {
 func (t *copTask) convertToRootTaskImpl(ctx sessionctx.Context) *rootTask {
 	sessVars := ctx.GetSessionVars()
 	// copTasks are run in parallel, to make the estimated cost closer to execution time, we amortize
-	// the cost to cop iterator workers. According to `CopClient::Send`, the concurrency
-	// is Min(DistSQLScanConcurrency, numRegionsInvolvedInScan), since we cannot infer
-	// the number of regions involved, we simply use DistSQLScanConcurrency.
-	copIterWorkers := float64(t.plan().SCtx().GetSessionVars().DistSQLScanConcurrency())
+	// the cost to cop iterator workers.
+	copIterWorkers := t.copTaskWorkers(ctx)
 	t.finishIndexPlan()
 	needExtraProj := false
 	var prevSchema *expression.Schema
@@ -998,8 +1042,9 @@ func (t *copTask) convertToRootTaskImpl(ctx sessionctx.Context) *rootTask {
 	}
 	if t.idxMergePartPlans != nil {
 		p := PhysicalIndexMergeReader{
-			partialPlans: t.idxMergePartPlans,
-			tablePlan:    t.tablePlan,
+			partialPlans:       t.idxMergePartPlans,
+			tablePlan:          t.tablePlan,
+			IsIntersectionType: t.idxMergeIsIntersection,
 		}.Init(ctx, t.idxMergePartPlans[0].SelectBlockOffset())
 		p.PartitionInfo = t.partitionInfo
 		setTableScanToTableRowIDScan(p.tablePlan)
@@ -1415,6 +1460,11 @@ func (p *PhysicalUnionAll) attach2Task(tasks ...task) task {
 	sessVars := p.ctx.GetSessionVars()
 	// Children of UnionExec are executed in parallel.
 	t.cst = childMaxCost + float64(1+len(tasks))*sessVars.ConcurrencyFactor
+	if len(p.ByItems) > 0 {
+		// The readers under MergeUnionExec fetch data concurrently as well, and
+		// each output row needs to be compared with the heads of other children.
+		t.cst += p.statsInfo().RowCount * math.Log2(float64(len(tasks))) * sessVars.CPUFactor
+	}
 	p.cost = t.cost()
 	return t
 }
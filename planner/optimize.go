@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/parser"
 	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
 	"github.com/pingcap/tidb/bindinfo"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/infoschema"
@@ -171,6 +172,10 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			chosenBinding bindinfo.Binding
 		)
 		originHints := hint.CollectHint(stmtNode)
+		// Only the lazily evaluated subqueries of the chosen plan are kept.
+		originSubQueries := sessVars.StmtCtx.ScalarSubQueries
+		originSubQueries = originSubQueries[:len(originSubQueries):len(originSubQueries)]
+		bestSubQueries := originSubQueries
 		// bindRecord must be not nil when coming here, try to find the best binding.
 		for _, binding := range bindRecord.Bindings {
 			if binding.Status != bindinfo.Using {
@@ -180,6 +185,7 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			hint.BindHint(stmtNode, binding.Hint)
 			curStmtHints, _, curWarns := handleStmtHints(binding.Hint.GetFirstTableHints())
 			sessVars.StmtCtx.StmtHints = curStmtHints
+			sessVars.StmtCtx.ScalarSubQueries = originSubQueries
 			plan, curNames, cost, err := optimize(ctx, sctx, node, is)
 			if err != nil {
 				binding.Status = bindinfo.Invalid
@@ -192,8 +198,10 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			}
 			if cost < minCost {
 				bindStmtHints, warns, minCost, names, bestPlanFromBind, chosenBinding = curStmtHints, curWarns, cost, curNames, plan, binding
+				bestSubQueries = sessVars.StmtCtx.ScalarSubQueries
 			}
 		}
+		sessVars.StmtCtx.ScalarSubQueries = bestSubQueries
 		if bestPlanFromBind == nil {
 			sessVars.StmtCtx.AppendWarning(errors.New("no plan generated from bindings"))
 		} else {
@@ -228,8 +236,11 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	// 4. the plan when ignoring bindings contains no tiflash hint;
 	// 5. the pending verified binding has not been added already;
 	savedStmtHints := sessVars.StmtCtx.StmtHints
+	savedSubQueries := sessVars.StmtCtx.ScalarSubQueries
+	savedSubQueries = savedSubQueries[:len(savedSubQueries):len(savedSubQueries)]
 	defer func() {
 		sessVars.StmtCtx.StmtHints = savedStmtHints
+		sessVars.StmtCtx.ScalarSubQueries = savedSubQueries
 	}()
 	if sessVars.EvolvePlanBaselines && bestPlanFromBind != nil {
 		// Check bestPlanFromBind firstly to avoid nil stmtNode.
@@ -503,7 +514,9 @@ func useMaxTS(ctx sessionctx.Context, p plannercore.Plan) bool {
 	}
 
 	v, ok := p.(*plannercore.PointGetPlan)
-	return ok && (v.IndexInfo == nil || (v.IndexInfo.Primary && v.TblInfo.IsCommonHandle))
+	// The cached tables are read with a TSO, it's compared with the lease of the cache.
+	return ok && (v.IndexInfo == nil || (v.IndexInfo.Primary && v.TblInfo.IsCommonHandle)) &&
+		v.TblInfo.TableCacheStatusType != model.TableCacheStatusEnable
 }
 
 // OptimizeExecStmt to optimize prepare statement protocol "execute" statement
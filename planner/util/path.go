@@ -50,6 +50,9 @@ type AccessPath struct {
 	// PartialIndexPaths store all index access paths.
 	// If there are extra filters, store them in TableFilters.
 	PartialIndexPaths []*AccessPath
+	// IndexMergeIsIntersection indicates whether the handles of PartialIndexPaths are intersected
+	// rather than unioned. It is only meaningful for IndexMerge path.
+	IndexMergeIsIntersection bool
 
 	StoreType kv.StoreType
 
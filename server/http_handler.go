@@ -85,6 +85,8 @@ const (
 	pColumnLen  = "colLen"
 	pRowBin     = "rowBin"
 	pSnapshot   = "snapshot"
+	pJobID      = "jobID"
+	pJobOp      = "jobOp"
 )
 
 // For query string
@@ -367,6 +369,11 @@ type ddlResignOwnerHandler struct {
 	store kv.Storage
 }
 
+// ddlJobControlHandler is the handler for pausing, resuming and altering ddl jobs.
+type ddlJobControlHandler struct {
+	store kv.Storage
+}
+
 type serverInfoHandler struct {
 	*tikvHandlerTool
 }
@@ -1244,6 +1251,74 @@ func (h ddlResignOwnerHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 	writeData(w, "success!")
 }
 
+const (
+	opPauseDDLJob  = "pause"
+	opResumeDDLJob = "resume"
+	opAlterDDLJob  = "alter"
+
+	qThread    = "thread"
+	qBatchSize = "batch_size"
+)
+
+// ServeHTTP handles request of pausing, resuming or altering a ddl job.
+func (h ddlJobControlHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		writeError(w, errors.Errorf("This api only support POST method."))
+		return
+	}
+
+	params := mux.Vars(req)
+	jobID, err := strconv.ParseInt(params[pJobID], 0, 64)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var control func(txn kv.Transaction, ids []int64) ([]error, error)
+	switch params[pJobOp] {
+	case opPauseDDLJob:
+		control = admin.PauseJobs
+	case opResumeDDLJob:
+		control = admin.ResumeJobs
+	case opAlterDDLJob:
+		thread, batchSize := 0, 0
+		if v := req.FormValue(qThread); len(v) > 0 {
+			if thread, err = strconv.Atoi(v); err != nil || thread < 1 || thread > int(variable.GetMaxDDLReorgWorkerCount()) {
+				writeError(w, errors.Errorf("thread must be in [1, %d]", variable.GetMaxDDLReorgWorkerCount()))
+				return
+			}
+		}
+		if v := req.FormValue(qBatchSize); len(v) > 0 {
+			if batchSize, err = strconv.Atoi(v); err != nil || batchSize < int(variable.MinDDLReorgBatchSize) || batchSize > int(variable.MaxDDLReorgBatchSize) {
+				writeError(w, errors.Errorf("batch_size must be in [%d, %d]", variable.MinDDLReorgBatchSize, variable.MaxDDLReorgBatchSize))
+				return
+			}
+		}
+		if thread == 0 && batchSize == 0 {
+			writeError(w, errors.New("thread or batch_size should be specified"))
+			return
+		}
+		control = func(txn kv.Transaction, ids []int64) ([]error, error) {
+			return admin.AlterJobs(txn, ids, thread, batchSize)
+		}
+	default:
+		writeError(w, errors.Errorf("unknown operation %s", params[pJobOp]))
+		return
+	}
+
+	err = kv.RunInNewTxn(context.Background(), h.store, true, func(ctx context.Context, txn kv.Transaction) error {
+		errs, err := control(txn, []int64{jobID})
+		if err != nil {
+			return err
+		}
+		return errs[0]
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeData(w, "success!")
+}
+
 func (h tableHandler) getPDAddr() ([]string, error) {
 	etcd, ok := h.Store.(kv.EtcdBackend)
 	if !ok {
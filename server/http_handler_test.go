@@ -1193,6 +1193,40 @@ func (ts *HTTPHandlerTestSuite) TestAllHistory(c *C) {
 	c.Assert(jobs, DeepEquals, data)
 }
 
+func (ts *HTTPHandlerTestSuite) TestDDLJobControl(c *C) {
+	ts.startServer(c)
+	ts.prepareData(c)
+	defer ts.stopServer(c)
+
+	testCases := []struct {
+		path   string
+		errMsg string
+	}{
+		{"/ddl/jobs/1/stop", "unknown operation stop"},
+		{"/ddl/jobs/abc/pause", "strconv.ParseInt: parsing \"abc\": invalid syntax"},
+		{"/ddl/jobs/1/alter", "thread or batch_size should be specified"},
+		{"/ddl/jobs/1/alter?thread=0", "thread must be in [1, 128]"},
+		{"/ddl/jobs/1/alter?batch_size=1", "batch_size must be in [32, 10240]"},
+		{"/ddl/jobs/-1/pause", "[admin:8224]DDL Job:-1 not found"},
+		{"/ddl/jobs/-1/resume", "[admin:8224]DDL Job:-1 not found"},
+		{"/ddl/jobs/-1/alter?thread=4&batch_size=64", "[admin:8224]DDL Job:-1 not found"},
+	}
+	for _, tc := range testCases {
+		resp, err := ts.postStatus(tc.path, "application/x-www-form-urlencoded", nil)
+		c.Assert(err, IsNil)
+		c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+		body, err := io.ReadAll(resp.Body)
+		c.Assert(err, IsNil)
+		c.Assert(resp.Body.Close(), IsNil)
+		c.Assert(string(body), Equals, tc.errMsg, Commentf("path: %s", tc.path))
+	}
+
+	resp, err := ts.fetchStatus("/ddl/jobs/1/pause")
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, http.StatusBadRequest)
+	c.Assert(resp.Body.Close(), IsNil)
+}
+
 func dummyRecord() *deadlockhistory.DeadlockRecord {
 	return &deadlockhistory.DeadlockRecord{}
 }
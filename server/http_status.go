@@ -133,6 +133,7 @@ func (s *Server) startHTTPServer() {
 
 	router.Handle("/ddl/history", ddlHistoryJobHandler{tikvHandlerTool}).Name("DDL_History")
 	router.Handle("/ddl/owner/resign", ddlResignOwnerHandler{tikvHandlerTool.Store.(kv.Storage)}).Name("DDL_Owner_Resign")
+	router.Handle("/ddl/jobs/{jobID}/{jobOp}", ddlJobControlHandler{tikvHandlerTool.Store.(kv.Storage)}).Name("DDL_Job_Control")
 
 	// HTTP path for get the TiDB config
 	router.Handle("/config", fn.Wrap(func() (*config.Config, error) {
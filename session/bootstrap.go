@@ -348,6 +348,77 @@ const (
 		key idx(filter_type),
 		primary key(id)
 	);`
+	// CreatePlanHistoryTable stores the execution statistics of the historic plans of every bindable SQL on each instance.
+	CreatePlanHistoryTable = `CREATE TABLE IF NOT EXISTS mysql.plan_history (
+		sql_digest varchar(64) NOT NULL,
+		plan_digest varchar(64) NOT NULL,
+		instance varchar(64) NOT NULL,
+		original_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		sample_sql TEXT NOT NULL,
+		plan_hint TEXT NOT NULL,
+		charset TEXT NOT NULL,
+		collation TEXT NOT NULL,
+		exec_count BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		avg_latency BIGINT(64) UNSIGNED NOT NULL DEFAULT 0 COMMENT "in nanoseconds",
+		first_seen TIMESTAMP(3) NOT NULL,
+		last_seen TIMESTAMP(3) NOT NULL,
+		PRIMARY KEY (sql_digest, plan_digest, instance),
+		INDEX time_index(last_seen)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+	// CreatePlanRegressionGuardTable stores the audit records of the bindings created by the plan regression guard.
+	CreatePlanRegressionGuardTable = `CREATE TABLE IF NOT EXISTS mysql.plan_regression_guard (
+		id BIGINT(64) UNSIGNED NOT NULL AUTO_INCREMENT,
+		sql_digest varchar(64) NOT NULL,
+		original_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		regressed_plan_digest varchar(64) NOT NULL,
+		regressed_avg_latency BIGINT(64) UNSIGNED NOT NULL,
+		good_plan_digest varchar(64) NOT NULL,
+		good_avg_latency BIGINT(64) UNSIGNED NOT NULL,
+		bind_sql TEXT NOT NULL,
+		status varchar(16) NOT NULL COMMENT "applied or reverted",
+		create_time TIMESTAMP(3) NOT NULL,
+		update_time TIMESTAMP(3) NOT NULL,
+		PRIMARY KEY (id),
+		UNIQUE KEY digest_index(sql_digest, regressed_plan_digest)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+	// CreateCostCalibrationTable stores the cost factors recommended by the last ADMIN CALIBRATE COST.
+	CreateCostCalibrationTable = `CREATE TABLE IF NOT EXISTS mysql.cost_calibration (
+		factor varchar(64) NOT NULL,
+		current_value DOUBLE NOT NULL,
+		recommended_value DOUBLE NOT NULL,
+		nanoseconds DOUBLE NOT NULL COMMENT "the measured time of processing one unit",
+		unit varchar(16) NOT NULL,
+		calibrate_time TIMESTAMP(3) NOT NULL,
+		PRIMARY KEY (factor)
+	);`
+	// CreateTTLJobHistoryTable stores the jobs which delete the expired rows of TTL tables.
+	CreateTTLJobHistoryTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_ttl_job_history (
+		job_id varchar(64) NOT NULL,
+		table_id BIGINT(64) NOT NULL,
+		table_schema varchar(64) NOT NULL,
+		table_name varchar(64) NOT NULL,
+		expire_time DATETIME NOT NULL COMMENT "the rows whose TTL column is before it are expired",
+		create_time TIMESTAMP(3) NOT NULL,
+		finish_time TIMESTAMP(3) NULL DEFAULT NULL,
+		scanned_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		deleted_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		error_delete_rows BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		status varchar(16) NOT NULL COMMENT "running, finished or failed",
+		message TEXT,
+		PRIMARY KEY (job_id),
+		KEY table_create_time (table_id, create_time),
+		KEY create_time (create_time)
+	);`
+	// CreateTableCacheMetaTable stores the locks and the leases of the cached tables.
+	CreateTableCacheMetaTable = `CREATE TABLE IF NOT EXISTS mysql.table_cache_meta (
+		tid BIGINT(20) NOT NULL DEFAULT 0,
+		lock_type ENUM('NONE', 'READ', 'INTEND', 'WRITE') NOT NULL DEFAULT 'NONE',
+		lease BIGINT(20) NOT NULL DEFAULT 0,
+		oldReadLease BIGINT(20) NOT NULL DEFAULT 0,
+		PRIMARY KEY (tid)
+	);`
 )
 
 // bootstrap initiates system DB for a store.
@@ -513,11 +584,19 @@ const (
 	version74 = 74
 	// version75 update mysql.*.host from char(60) to char(255)
 	version75 = 75
+	// version76 adds mysql.plan_history and mysql.plan_regression_guard tables
+	version76 = 76
+	// version77 adds mysql.cost_calibration table
+	version77 = 77
+	// version78 adds mysql.tidb_ttl_job_history table
+	version78 = 78
+	// version79 adds mysql.table_cache_meta table
+	version79 = 79
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version75
+var currentBootstrapVersion int64 = version79
 
 var (
 	bootstrapVersion = []func(Session, int64){
@@ -596,6 +675,10 @@ var (
 		upgradeToVer73,
 		upgradeToVer74,
 		upgradeToVer75,
+		upgradeToVer76,
+		upgradeToVer77,
+		upgradeToVer78,
+		upgradeToVer79,
 	}
 )
 
@@ -1571,6 +1654,35 @@ func upgradeToVer75(s Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.columns_priv MODIFY COLUMN Host CHAR(255)")
 }
 
+func upgradeToVer76(s Session, ver int64) {
+	if ver >= version76 {
+		return
+	}
+	doReentrantDDL(s, CreatePlanHistoryTable)
+	doReentrantDDL(s, CreatePlanRegressionGuardTable)
+}
+
+func upgradeToVer77(s Session, ver int64) {
+	if ver >= version77 {
+		return
+	}
+	doReentrantDDL(s, CreateCostCalibrationTable)
+}
+
+func upgradeToVer78(s Session, ver int64) {
+	if ver >= version78 {
+		return
+	}
+	doReentrantDDL(s, CreateTTLJobHistoryTable)
+}
+
+func upgradeToVer79(s Session, ver int64) {
+	if ver >= version79 {
+		return
+	}
+	doReentrantDDL(s, CreateTableCacheMetaTable)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -1651,6 +1763,16 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateGlobalGrantsTable)
 	// Create capture_plan_baselines_blacklist
 	mustExecute(s, CreateCapturePlanBaselinesBlacklist)
+	// Create plan_history
+	mustExecute(s, CreatePlanHistoryTable)
+	// Create plan_regression_guard
+	mustExecute(s, CreatePlanRegressionGuardTable)
+	// Create cost_calibration
+	mustExecute(s, CreateCostCalibrationTable)
+	// Create tidb_ttl_job_history
+	mustExecute(s, CreateTTLJobHistoryTable)
+	// Create table_cache_meta
+	mustExecute(s, CreateTableCacheMetaTable)
 }
 
 // doDMLWorks executes DML statements in bootstrap stage.
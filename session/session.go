@@ -45,6 +45,7 @@ import (
 	"github.com/pingcap/parser/model"
 	"github.com/pingcap/parser/mysql"
 	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/util/topsql"
 	"github.com/pingcap/tipb/go-binlog"
@@ -85,6 +86,7 @@ import (
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/kvcache"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/sli"
 	"github.com/pingcap/tidb/util/sqlexec"
 	"github.com/pingcap/tidb/util/tableutil"
@@ -560,11 +562,22 @@ func (s *session) doCommit(ctx context.Context) error {
 	if tables := sessVars.TxnCtx.TemporaryTables; len(tables) > 0 {
 		s.txn.SetOption(kv.KVFilter, temporaryTableKVFilter(tables))
 	}
+	if tables := sessVars.TxnCtx.CachedTables; len(tables) > 0 {
+		// The write locks are kept alive until the transaction commits, so the other instances
+		// don't load the cached tables before the writes are visible.
+		exit := make(chan struct{})
+		defer close(exit)
+		for _, tbl := range tables {
+			if err := tbl.(table.CachedTable).WriteLockAndKeepAlive(ctx, exit); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 
 	return s.commitTxnWithTemporaryData(tikvutil.SetSessionID(ctx, sessVars.ConnectionID), &s.txn)
 }
 
-func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transaction) error {
+func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transaction) (err error) {
 	sessVars := s.sessionVars
 	txnTempTables := sessVars.TxnCtx.TemporaryTables
 	if len(txnTempTables) == 0 {
@@ -583,6 +596,17 @@ func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transac
 		localTempTables = new(infoschema.LocalTemporaryTables)
 	}
 
+	defer func() {
+		// The session data exceeding the memory quota of the session panics, the transaction fails then.
+		r := recover()
+		if r == nil {
+			return
+		}
+		if str, ok := r.(string); !ok || !strings.Contains(str, memory.PanicMemoryExceed) {
+			panic(r)
+		}
+		err = errors.Errorf("%v", r)
+	}()
 	defer func() {
 		// stage != kv.InvalidStagingHandle means error occurs, we need to cleanup sessionData
 		if stage != kv.InvalidStagingHandle {
@@ -639,7 +663,7 @@ func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transac
 		}
 	}
 
-	err := txn.Commit(ctx)
+	err = txn.Commit(ctx)
 	if err != nil {
 		return err
 	}
@@ -2189,6 +2213,11 @@ func (s *session) Close() {
 	s.RollbackTxn(ctx)
 	if s.sessionVars != nil {
 		s.sessionVars.WithdrawAllPreparedStmt()
+		if s.sessionVars.TemporaryTableData != nil {
+			s.sessionVars.TemporaryTableData.Close()
+		}
+		s.sessionVars.MemTracker.DetachFromGlobalTracker()
+		s.sessionVars.DiskTracker.DetachFromGlobalTracker()
 	}
 	s.ClearDiskFullOpt()
 }
@@ -2549,6 +2578,12 @@ func BootstrapSession(store kv.Storage) (*domain.Domain, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	se8, err := createSession(store)
+	if err != nil {
+		return nil, err
+	}
+	dom.TTLJobManagerLoop(se8)
 	if raw, ok := store.(kv.EtcdBackend); ok {
 		err = raw.StartGCWorker()
 		if err != nil {
@@ -4813,6 +4813,79 @@ func (s *testSessionSuite) TestTMPTableSize(c *C) {
 	tk.MustExec("rollback")
 }
 
+func (s *testSessionSuite) TestTMPTableSpill(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustQuery("select @@tidb_enable_tmp_table_spill, @@tidb_tmp_table_max_size").Check(testkit.Rows(fmt.Sprintf("0 %d", variable.DefTiDBTmpTableMaxSize)))
+	tk.MustExec("set @@tidb_tmp_table_max_size = 1024")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1292 Truncated incorrect tidb_tmp_table_max_size value: '1024'"))
+	tk.MustQuery("select @@tidb_tmp_table_max_size").Check(testkit.Rows("1048576"))
+
+	tk.MustExec("set @@tmp_table_size = 1024")
+	tk.MustExec("create temporary table tl (c1 int primary key, c2 varchar(512), key(c2))")
+	tk.MustExec("insert into tl values (1, repeat('x', 512))")
+	tk.MustGetErrCode("insert into tl values (2, repeat('x', 512)), (3, repeat('x', 512))", errno.ErrRecordFileFull)
+
+	// The data exceeding tmp_table_size is spilled to disk.
+	tk.MustExec("set @@tidb_enable_tmp_table_spill = 1")
+	for i := 2; i <= 20; i++ {
+		tk.MustExec(fmt.Sprintf("insert into tl values (%d, repeat('%d', 512))", i, i%10))
+	}
+	tk.MustExec("update tl set c2 = 'y' where c1 = 2")
+	tk.MustExec("delete from tl where c1 = 3")
+	tk.MustQuery("select count(*), sum(c1) from tl").Check(testkit.Rows("19 207"))
+	tk.MustQuery("select c1 from tl where c2 = 'y'").Check(testkit.Rows("2"))
+	tk.MustQuery("select c1 from tl order by c1 desc limit 2").Check(testkit.Rows("20", "19"))
+	tk.MustQuery("select c1 from tl where c1 < 5 order by c1").Check(testkit.Rows("1", "2", "4"))
+
+	// The table is still limited by tidb_tmp_table_max_size.
+	tk.MustExec("set @@tidb_tmp_table_max_size = 1048576")
+	tk.MustGetErrCode("insert into tl select a.c1 * 10000 + b.c1 * 100 + c.c1, repeat('z', 512) from tl a, tl b, tl c", errno.ErrRecordFileFull)
+	tk.MustQuery("select count(*) from tl").Check(testkit.Rows("19"))
+	tk.MustExec("drop table tl")
+}
+
+func (s *testSessionSerialSuite) TestTemporaryTableMemTracker(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_global_temporary_table = on")
+	tk.MustExec("create temporary table tl (c1 int primary key, c2 varchar(512))")
+	defer tk.MustExec("drop table tl")
+	tk.MustExec("create global temporary table tg (c1 int primary key, c2 varchar(512)) on commit delete rows")
+	defer tk.MustExec("drop table tg")
+
+	// The committed data of local temporary tables is tracked by the session until it's closed.
+	memTracker := tk.Se.GetSessionVars().MemTracker
+	tk.MustExec("insert into tl values (1, repeat('x', 512))")
+	sessionDataSize := memTracker.BytesConsumed()
+	c.Assert(sessionDataSize, Greater, int64(512))
+
+	// The data of global temporary tables is tracked by the session until the transaction ends.
+	tk.MustExec("begin")
+	tk.MustExec("insert into tg values (1, repeat('x', 512))")
+	c.Assert(memTracker.BytesConsumed(), Greater, sessionDataSize+512)
+	tk.MustExec("commit")
+	c.Assert(memTracker.BytesConsumed(), Equals, sessionDataSize)
+
+	// The memory quota and the OOM action apply to the data of temporary tables.
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.OOMAction = config.OOMActionCancel
+	})
+	tk.MustExec(fmt.Sprintf("set @@tidb_mem_quota_query = %d", sessionDataSize+1024))
+	err := tk.ExecToErr("insert into tl values (2, repeat('x', 512)), (3, repeat('x', 512))")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, "Out Of Memory Quota!.*")
+	err = tk.ExecToErr("insert into tg values (2, repeat('x', 512)), (3, repeat('x', 512))")
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Matches, "Out Of Memory Quota!.*")
+	tk.MustQuery("select c1 from tl").Check(testkit.Rows("1"))
+	c.Assert(memTracker.BytesConsumed(), Equals, sessionDataSize)
+	tk.MustExec("set @@tidb_mem_quota_query = default")
+	tk.MustExec("insert into tl values (2, repeat('x', 512)), (3, repeat('x', 512))")
+	c.Assert(memTracker.BytesConsumed(), Greater, sessionDataSize+1024)
+}
+
 func (s *testSessionSuite) TestTiDBEnableGlobalTemporaryTable(c *C) {
 	// Test the @@tidb_enable_global_temporary_table system variable.
 	tk := testkit.NewTestKit(c, s.store)
@@ -71,6 +71,7 @@ type StatementContext struct {
 	InSelectStmt                 bool
 	InLoadDataStmt               bool
 	InExplainStmt                bool
+	InExplainAnalyzeStmt         bool
 	InCreateOrAlterStmt          bool
 	IgnoreTruncate               bool
 	IgnoreZeroInDate             bool
@@ -172,6 +173,9 @@ type StatementContext struct {
 	// Map to store all CTE storages of current SQL.
 	// Will clean up at the end of the execution.
 	CTEStorageMap interface{}
+	// ScalarSubQueries stores the uncorrelated subqueries of current SQL which
+	// are evaluated lazily during execution, they are shown by EXPLAIN.
+	ScalarSubQueries []interface{}
 
 	// cache is used to reduce object allocation.
 	cache struct {
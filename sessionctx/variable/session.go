@@ -45,7 +45,9 @@ import (
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/disk"
 	"github.com/pingcap/tidb/util/execdetails"
+	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/pingcap/tidb/util/tableutil"
@@ -178,6 +180,13 @@ type TransactionContext struct {
 	// TemporaryTables is used to store transaction-specific information for global temporary tables.
 	// It can also be stored in sessionCtx with local temporary tables, but it's easier to clean this data after transaction ends.
 	TemporaryTables map[int64]tableutil.TempTable
+	// TemporaryTableMemTracker tracks the memory of the temporary table data in the transaction MemBuffer,
+	// it's attached to the tracker of the session and released when the transaction ends.
+	TemporaryTableMemTracker *memory.Tracker
+
+	// CachedTables is the cached tables written in the transaction, they're table.CachedTable.
+	// The transaction takes the write locks of them before it commits.
+	CachedTables map[int64]interface{}
 }
 
 // GetShard returns the shard prefix for the next `count` rowids.
@@ -274,6 +283,11 @@ func (tc *TransactionContext) Cleanup() {
 	tc.tdmLock.Unlock()
 	tc.pessimisticLockCache = nil
 	tc.IsStaleness = false
+	if tc.TemporaryTableMemTracker != nil {
+		tc.TemporaryTableMemTracker.Consume(-tc.TemporaryTableMemTracker.BytesConsumed())
+		tc.TemporaryTableMemTracker.Detach()
+		tc.TemporaryTableMemTracker = nil
+	}
 }
 
 // ClearDelta clears the delta map.
@@ -374,49 +388,10 @@ type TemporaryTableData interface {
 	DeleteTableKey(tblID int64, k kv.Key) error
 	// SetTableKey sets the entry for k from table
 	SetTableKey(tblID int64, k kv.Key, val []byte) error
-}
-
-// temporaryTableData is used for store temporary table data in session
-type temporaryTableData struct {
-	kv.MemBuffer
-	tblSize map[int64]int64
-}
-
-// NewTemporaryTableData creates a new TemporaryTableData
-func NewTemporaryTableData(memBuffer kv.MemBuffer) TemporaryTableData {
-	return &temporaryTableData{
-		MemBuffer: memBuffer,
-		tblSize:   make(map[int64]int64),
-	}
-}
-
-// GetTableSize get the size of a table
-func (d *temporaryTableData) GetTableSize(tblID int64) int64 {
-	if tblSize, ok := d.tblSize[tblID]; ok {
-		return tblSize
-	}
-	return 0
-}
-
-// DeleteTableKey removes the entry for key k from table
-func (d *temporaryTableData) DeleteTableKey(tblID int64, k kv.Key) error {
-	bufferSize := d.MemBuffer.Size()
-	defer d.updateTblSize(tblID, bufferSize)
-
-	return d.MemBuffer.Delete(k)
-}
-
-// SetTableKey sets the entry for k from table
-func (d *temporaryTableData) SetTableKey(tblID int64, k kv.Key, val []byte) error {
-	bufferSize := d.MemBuffer.Size()
-	defer d.updateTblSize(tblID, bufferSize)
-
-	return d.MemBuffer.Set(k, val)
-}
-
-func (d *temporaryTableData) updateTblSize(tblID int64, beforeSize int) {
-	delta := int64(d.MemBuffer.Size() - beforeSize)
-	d.tblSize[tblID] = d.GetTableSize(tblID) + delta
+	// AttachTo attaches the memory and disk usage of the data to the trackers of the session.
+	AttachTo(memTracker *memory.Tracker, diskTracker *disk.Tracker)
+	// Close releases the memory and the spilled files of the data.
+	Close()
 }
 
 const (
@@ -633,6 +608,10 @@ type SessionVars struct {
 	DiskFactor float64
 	// ConcurrencyFactor is the CPU cost of additional one goroutine.
 	ConcurrencyFactor float64
+	// CostModelVersion is the version of the cost model used by the optimizer.
+	// Version 2 takes the number of regions, the random reads of IndexLookUp and
+	// the handles sent back to TiKV into account.
+	CostModelVersion int
 
 	// CurrInsertValues is used to record current ValuesExpr's values.
 	// See http://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_values
@@ -924,10 +903,17 @@ type SessionVars struct {
 
 	// The temporary table size threshold
 	// In MySQL, when a temporary table exceed this size, it spills to disk.
-	// In TiDB, as we do not support spill to disk for now, an error is reported.
+	// In TiDB, an error is reported unless EnableTMPTableSpill is set, then the data of local temporary tables
+	// exceeding this size is spilled to disk.
 	// See https://dev.mysql.com/doc/refman/8.0/en/server-system-variables.html#sysvar_tmp_table_size
 	TMPTableSize int64
 
+	// EnableTMPTableSpill indicates whether the data of local temporary tables can be spilled to disk.
+	EnableTMPTableSpill bool
+
+	// TMPTableMaxSize is the max size of a local temporary table when EnableTMPTableSpill is set.
+	TMPTableMaxSize int64
+
 	// EnableGlobalTemporaryTable indicates whether to enable global temporary table
 	EnableGlobalTemporaryTable bool
 
@@ -941,6 +927,13 @@ type SessionVars struct {
 	// TemporaryTableData stores committed kv values for temporary table for current session.
 	TemporaryTableData TemporaryTableData
 
+	// MemTracker tracks the memory of the data living across statements, such as the data of temporary tables.
+	// It's limited by MemQuotaQuery like the statements.
+	MemTracker *memory.Tracker
+
+	// DiskTracker tracks the disk usage of the data living across statements.
+	DiskTracker *disk.Tracker
+
 	// MPPStoreLastFailTime records the lastest fail time that a TiFlash store failed.
 	MPPStoreLastFailTime map[string]time.Time
 
@@ -1142,6 +1135,7 @@ func NewSessionVars() *SessionVars {
 		MemoryFactor:                DefOptMemoryFactor,
 		DiskFactor:                  DefOptDiskFactor,
 		ConcurrencyFactor:           DefOptConcurrencyFactor,
+		CostModelVersion:            DefTiDBCostModelVersion,
 		EnableVectorizedExpression:  DefEnableVectorizedExpression,
 		CommandValue:                uint32(mysql.ComSleep),
 		TiDBOptJoinReorderThreshold: DefTiDBOptJoinReorderThreshold,
@@ -1185,7 +1179,11 @@ func NewSessionVars() *SessionVars {
 		AllowFallbackToTiKV:         make(map[kv.StoreType]struct{}),
 		CTEMaxRecursionDepth:        DefCTEMaxRecursionDepth,
 		TMPTableSize:                DefTMPTableSize,
+		EnableTMPTableSpill:         DefTiDBEnableTmpTableSpill,
+		TMPTableMaxSize:             DefTiDBTmpTableMaxSize,
 		EnableGlobalTemporaryTable:  DefTiDBEnableGlobalTemporaryTable,
+		MemTracker:                  memory.NewTracker(memory.LabelForSession, -1),
+		DiskTracker:                 disk.NewTracker(memory.LabelForSession, -1),
 		MPPStoreLastFailTime:        make(map[string]time.Time),
 		MPPStoreFailTTL:             DefTiDBMPPStoreFailTTL,
 	}
@@ -1609,6 +1607,12 @@ func (s *SessionVars) GetTemporaryTable(tblInfo *model.TableInfo) tableutil.Temp
 		if s.TxnCtx.TemporaryTables == nil {
 			s.TxnCtx.TemporaryTables = make(map[int64]tableutil.TempTable)
 		}
+		if s.TxnCtx.TemporaryTableMemTracker == nil {
+			s.TxnCtx.TemporaryTableMemTracker = memory.NewTracker(memory.LabelForTemporaryTableData, -1)
+			if s.MemTracker != nil {
+				s.TxnCtx.TemporaryTableMemTracker.AttachTo(s.MemTracker)
+			}
+		}
 		tempTables := s.TxnCtx.TemporaryTables
 		tempTable, ok := tempTables[tblInfo.ID]
 		if !ok {
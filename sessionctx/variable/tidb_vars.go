@@ -179,6 +179,35 @@ const (
 	// TiDBEvolvePlanTaskEndTime is the end time of evolution task.
 	TiDBEvolvePlanTaskEndTime = "tidb_evolve_plan_task_end_time"
 
+	// TiDBPlanRegressionRatio is the ratio of the average latency, above which a new plan is regarded
+	// as a regression of the historic plans of the same SQL.
+	TiDBPlanRegressionRatio = "tidb_plan_regression_ratio"
+
+	// TiDBPlanHistoryRetention is the duration to keep a historic plan which is not executed any more.
+	TiDBPlanHistoryRetention = "tidb_plan_history_retention"
+
+	// TiDBTTLJobEnable indicates whether to run the background jobs which delete the expired rows of TTL tables.
+	TiDBTTLJobEnable = "tidb_ttl_job_enable"
+	// TiDBTTLJobInterval is the minimum interval between two TTL jobs of the same table.
+	TiDBTTLJobInterval = "tidb_ttl_job_interval"
+	// TiDBTTLJobScheduleWindowStartTime is the start time of the window in which TTL jobs are started.
+	TiDBTTLJobScheduleWindowStartTime = "tidb_ttl_job_schedule_window_start_time"
+	// TiDBTTLJobScheduleWindowEndTime is the end time of the window in which TTL jobs are started.
+	TiDBTTLJobScheduleWindowEndTime = "tidb_ttl_job_schedule_window_end_time"
+	// TiDBTTLScanBatchSize is the number of expired rows read by one scan query of a TTL job.
+	TiDBTTLScanBatchSize = "tidb_ttl_scan_batch_size"
+	// TiDBTTLDeleteBatchSize is the number of expired rows deleted in one transaction of a TTL job.
+	TiDBTTLDeleteBatchSize = "tidb_ttl_delete_batch_size"
+	// TiDBTTLDeleteRateLimit is the maximum number of rows deleted by TTL jobs per second, 0 means no limit.
+	TiDBTTLDeleteRateLimit = "tidb_ttl_delete_rate_limit"
+
+	// TiDBTableCacheLease is the lease of the cached tables in seconds. The writes to a cached table
+	// wait for the lease of the cache to expire.
+	TiDBTableCacheLease = "tidb_table_cache_lease"
+	// TiDBTableCacheMaxSize is the maximum size of the data of a cached table in bytes. The tables
+	// with more data aren't read from the cache.
+	TiDBTableCacheMaxSize = "tidb_table_cache_max_size"
+
 	// tidb_slow_log_threshold is used to set the slow log threshold in the server.
 	TiDBSlowLogThreshold = "tidb_slow_log_threshold"
 
@@ -263,6 +292,8 @@ const (
 	TiDBOptDiskFactor = "tidb_opt_disk_factor"
 	// tidb_opt_concurrency_factor is the CPU cost of additional one goroutine.
 	TiDBOptConcurrencyFactor = "tidb_opt_concurrency_factor"
+	// tidb_cost_model_version is the version of the cost model used by the optimizer.
+	TiDBCostModelVersion = "tidb_cost_model_version"
 
 	// tidb_index_join_batch_size is used to set the batch size of a index lookup join.
 	// The index lookup join fetches batches of data from outer executor and constructs ranges for inner executor.
@@ -390,6 +421,10 @@ const (
 	// tidb_ddl_error_count_limit defines the count of ddl error limit.
 	TiDBDDLErrorCountLimit = "tidb_ddl_error_count_limit"
 
+	// TiDBDDLEnableFastReorg indicates whether to backfill indexes by sorting the index KV pairs
+	// locally and ingesting them into TiKV as SST files.
+	TiDBDDLEnableFastReorg = "tidb_ddl_enable_fast_reorg"
+
 	// tidb_ddl_reorg_priority defines the operations priority of adding indices.
 	// It can be: PRIORITY_LOW, PRIORITY_NORMAL, PRIORITY_HIGH
 	TiDBDDLReorgPriority = "tidb_ddl_reorg_priority"
@@ -486,6 +521,10 @@ const (
 	// TiDBEvolvePlanBaselines indicates whether the evolution of plan baselines is enabled.
 	TiDBEvolvePlanBaselines = "tidb_evolve_plan_baselines"
 
+	// TiDBEnablePlanRegressionGuard indicates whether to record the historic plans and bind the regressed
+	// SQLs to their previous good plans automatically.
+	TiDBEnablePlanRegressionGuard = "tidb_enable_plan_regression_guard"
+
 	// TiDBEnableExtendedStats indicates whether the extended statistics feature is enabled.
 	TiDBEnableExtendedStats = "tidb_enable_extended_stats"
 
@@ -579,6 +618,11 @@ const (
 	TiDBTopSQLReportIntervalSeconds = "tidb_top_sql_report_interval_seconds"
 	// TiDBEnableGlobalTemporaryTable indicates whether to enable global temporary table
 	TiDBEnableGlobalTemporaryTable = "tidb_enable_global_temporary_table"
+	// TiDBEnableTmpTableSpill indicates whether the data of local temporary tables can be spilled to the disk
+	// when its memory usage exceeds `tmp_table_size`.
+	TiDBEnableTmpTableSpill = "tidb_enable_tmp_table_spill"
+	// TiDBTmpTableMaxSize is the max size of a local temporary table when its data can be spilled to the disk.
+	TiDBTmpTableMaxSize = "tidb_tmp_table_max_size"
 	// TiDBEnableLocalTxn indicates whether to enable Local Txn.
 	TiDBEnableLocalTxn = "tidb_enable_local_txn"
 
@@ -640,6 +684,7 @@ const (
 	DefOptMemoryFactor                    = 0.001
 	DefOptDiskFactor                      = 1.5
 	DefOptConcurrencyFactor               = 3.0
+	DefTiDBCostModelVersion               = 1
 	DefOptInSubqToJoinAndAgg              = true
 	DefOptPreferRangeScan                 = false
 	DefBatchInsert                        = false
@@ -680,6 +725,7 @@ const (
 	DefTiDBDDLReorgWorkerCount            = 4
 	DefTiDBDDLReorgBatchSize              = 256
 	DefTiDBDDLErrorCountLimit             = 512
+	DefTiDBDDLEnableFastReorg             = false
 	DefTiDBMaxDeltaSchemaCount            = 1024
 	DefTiDBChangeMultiSchema              = false
 	DefTiDBPointGetCache                  = false
@@ -710,6 +756,18 @@ const (
 	DefTiDBEvolvePlanTaskMaxTime          = 600 // 600s
 	DefTiDBEvolvePlanTaskStartTime        = "00:00 +0000"
 	DefTiDBEvolvePlanTaskEndTime          = "23:59 +0000"
+	DefTiDBEnablePlanRegressionGuard      = false
+	DefTiDBPlanRegressionRatio            = 2.0
+	DefTiDBPlanHistoryRetention           = "168h0m0s"
+	DefTiDBTTLJobEnable                   = true
+	DefTiDBTTLJobInterval                 = "1h0m0s"
+	DefTiDBTTLJobScheduleWindowStartTime  = "00:00 +0000"
+	DefTiDBTTLJobScheduleWindowEndTime    = "23:59 +0000"
+	DefTiDBTTLScanBatchSize               = 500
+	DefTiDBTTLDeleteBatchSize             = 100
+	DefTiDBTTLDeleteRateLimit             = 0
+	DefTiDBTableCacheLease                = 3 // 3s
+	DefTiDBTableCacheMaxSize              = 64 * 1024 * 1024
 	DefInnodbLockWaitTimeout              = 50 // 50s
 	DefTiDBStoreLimit                     = 0
 	DefTiDBMetricSchemaStep               = 60 // 60s
@@ -733,7 +791,7 @@ const (
 	DefTiDBAnalyzeVersion                 = 2
 	DefTiDBEnableIndexMergeJoin           = false
 	DefTiDBTrackAggregateMemoryUsage      = true
-	DefTiDBEnableExchangePartition        = false
+	DefTiDBEnableExchangePartition        = true
 	DefCTEMaxRecursionDepth               = 1000
 	DefTiDBTopSQLEnable                   = false
 	DefTiDBTopSQLPrecisionSeconds         = 1
@@ -742,6 +800,8 @@ const (
 	DefTiDBTopSQLReportIntervalSeconds    = 60
 	DefTiDBEnableGlobalTemporaryTable     = false
 	DefTMPTableSize                       = 16777216
+	DefTiDBEnableTmpTableSpill            = false
+	DefTiDBTmpTableMaxSize                = 64 << 20
 	DefTiDBEnableLocalTxn                 = false
 	DefTiDBEnableOrderedResultMode        = false
 )
@@ -775,8 +835,12 @@ var (
 		MaxCollect:            atomic.NewInt64(DefTiDBTopSQLMaxCollect),
 		ReportIntervalSeconds: atomic.NewInt64(DefTiDBTopSQLReportIntervalSeconds),
 	}
-	EnableLocalTxn     = atomic.NewBool(DefTiDBEnableLocalTxn)
-	RestrictedReadOnly = atomic.NewBool(DefTiDBRestrictedReadOnly)
+	EnableLocalTxn            = atomic.NewBool(DefTiDBEnableLocalTxn)
+	RestrictedReadOnly        = atomic.NewBool(DefTiDBRestrictedReadOnly)
+	EnablePlanRegressionGuard = atomic.NewBool(DefTiDBEnablePlanRegressionGuard)
+	EnableFastReorg           = atomic.NewBool(DefTiDBDDLEnableFastReorg)
+	TableCacheLease           = atomic.NewInt64(DefTiDBTableCacheLease)
+	TableCacheMaxSize         = atomic.NewInt64(DefTiDBTableCacheMaxSize)
 )
 
 // TopSQL is the variable for control top sql feature.
@@ -48,6 +48,11 @@ func GetDDLReorgWorkerCounter() int32 {
 	return atomic.LoadInt32(&ddlReorgWorkerCounter)
 }
 
+// GetMaxDDLReorgWorkerCount gets the max count of the backfill workers of a DDL job.
+func GetMaxDDLReorgWorkerCount() int32 {
+	return maxDDLReorgWorkerCount
+}
+
 // SetDDLReorgBatchSize sets ddlReorgBatchSize size.
 // Max batch size is MaxDDLReorgBatchSize.
 func SetDDLReorgBatchSize(cnt int32) {
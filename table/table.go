@@ -21,6 +21,7 @@ package table
 import (
 	"context"
 
+	"github.com/ngaut/pools"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pingcap/parser/model"
 	mysql "github.com/pingcap/tidb/errno"
@@ -239,6 +240,29 @@ type PartitionedTable interface {
 	GetAllPartitionIDs() []int64
 }
 
+// CachedTable is a Table, and its data is cached in the memory of every TiDB instance.
+type CachedTable interface {
+	Table
+
+	// TryReadFromCache returns the cached data of the table if the read at ts can be served by the
+	// cache, otherwise it returns nil, and the cache is loaded in the background.
+	TryReadFromCache(ts uint64) kv.MemBuffer
+
+	// WriteLockAndKeepAlive takes the write lock of the table and keeps it alive until exit is closed.
+	// The transactions which write the table commit after it returns.
+	WriteLockAndKeepAlive(ctx context.Context, exit chan struct{}) error
+
+	// Init sets the storage to load the table from, and the pool of the sessions which access the lock
+	// of the table. The table is never read from the cache without the pool.
+	Init(store kv.Storage, pool SessionPool)
+}
+
+// SessionPool is the pool of the sessions which run the internal SQLs.
+type SessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
 // TableFromMeta builds a table.Table from *model.TableInfo.
 // Currently, it is assigned to tables.TableFromMeta in tidb package's init function.
 var TableFromMeta func(allocators autoid.Allocators, tblInfo *model.TableInfo) (Table, error)
@@ -0,0 +1,266 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/tikv/client-go/v2/oracle"
+	"github.com/tikv/client-go/v2/tikv"
+	"go.uber.org/zap"
+)
+
+var _ table.CachedTable = &cachedTable{}
+
+// cachedTable is a table whose data is loaded into the memory of TiDB. The cache is read by the
+// transactions whose read TS is in [Start, Lease), the writers wait for the lease to expire.
+type cachedTable struct {
+	TableCommon
+
+	store  kv.Storage
+	handle StateRemote
+	// cacheData is *cacheData.
+	cacheData atomic.Value
+	// updating is 1 when the cache is being loaded or its lease is being renewed.
+	updating int32
+}
+
+// cacheData is the data of the table loaded at Start, and it can be read until Lease.
+// MemBuffer is nil if the table is too large to be cached.
+type cacheData struct {
+	Start uint64
+	Lease uint64
+	kv.MemBuffer
+}
+
+func newCachedTable(tbl *TableCommon) (table.Table, error) {
+	return &cachedTable{TableCommon: *tbl}, nil
+}
+
+func leaseDuration() time.Duration {
+	return time.Duration(variable.TableCacheLease.Load()) * time.Second
+}
+
+// Init implements the CachedTable interface.
+func (c *cachedTable) Init(store kv.Storage, pool table.SessionPool) {
+	if store == nil || pool == nil {
+		return
+	}
+	c.store = store
+	c.handle = NewStateRemote(pool)
+}
+
+// TryReadFromCache implements the CachedTable interface. It returns nil if the cache can't be read
+// at ts, and loads the cache or renews its lease in the background for the later reads.
+func (c *cachedTable) TryReadFromCache(ts uint64) kv.MemBuffer {
+	if c.handle == nil || c.meta.TableCacheStatusType != model.TableCacheStatusEnable {
+		return nil
+	}
+	data, _ := c.cacheData.Load().(*cacheData)
+	if data != nil && data.MemBuffer != nil && data.Start <= ts && ts < data.Lease {
+		metrics.TableCacheReadCounter.WithLabelValues(metrics.TableCacheHit).Inc()
+		if time.Until(oracle.GetTimeFromTS(data.Lease)) < leaseDuration()/2 {
+			c.updateInBackground(data)
+		}
+		return data.MemBuffer
+	}
+	metrics.TableCacheReadCounter.WithLabelValues(metrics.TableCacheMiss).Inc()
+	if data == nil || ts >= data.Lease {
+		c.updateInBackground(nil)
+	}
+	return nil
+}
+
+// updateInBackground renews the lease of old if it isn't nil, otherwise loads the cache again.
+// Only one update of the table runs at a time.
+func (c *cachedTable) updateInBackground(old *cacheData) {
+	if !atomic.CompareAndSwapInt32(&c.updating, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&c.updating, 0)
+		defer util.Recover(metrics.LabelSession, "cachedTable.update", nil, false)
+		var err error
+		if old != nil {
+			err = c.renewReadLease(old)
+		} else {
+			err = c.load()
+		}
+		if err != nil {
+			logutil.BgLogger().Warn("update the cached table failed", zap.Int64("tableID", c.meta.ID), zap.Error(err))
+		}
+	}()
+}
+
+func (c *cachedTable) renewReadLease(old *cacheData) error {
+	ver, err := c.store.CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newLease := leaseFromTS(ver.Ver, leaseDuration())
+	succ, err := c.handle.RenewReadLease(context.Background(), c.meta.ID, old.Lease, newLease)
+	if err != nil || !succ {
+		// The cache is read until the old lease, and loaded again after it.
+		return err
+	}
+	c.cacheData.Store(&cacheData{Start: old.Start, Lease: newLease, MemBuffer: old.MemBuffer})
+	return nil
+}
+
+func (c *cachedTable) load() (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.TableCacheLoadDuration.WithLabelValues(metrics.RetLabel(err)).Observe(time.Since(start).Seconds())
+	}()
+	lease, succ, err := c.handle.LockForRead(context.Background(), c.meta.ID, leaseDuration())
+	if err != nil || !succ {
+		// A writer holds the lock, the cache is loaded again by the next read.
+		return err
+	}
+	// The data is read after the lease is taken, so no write commits in [Start, Lease).
+	buffTxn, err := c.store.BeginWithOption(tikv.DefaultStartTSOption().SetStartTS(0))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buffer := buffTxn.GetMemBuffer()
+	var startTS uint64
+	var size int64
+	tooLarge := false
+	err = kv.RunInNewTxn(context.Background(), c.store, false, func(ctx context.Context, txn kv.Transaction) error {
+		startTS = txn.StartTS()
+		prefix := tablecodec.GenTablePrefix(c.tableID)
+		it, err := txn.Iter(prefix, prefix.PrefixNext())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer it.Close()
+		maxSize := variable.TableCacheMaxSize.Load()
+		for it.Valid() && it.Key().HasPrefix(prefix) {
+			size += int64(len(it.Key()) + len(it.Value()))
+			if size > maxSize {
+				tooLarge = true
+				return nil
+			}
+			if err = buffer.Set(it.Key(), it.Value()); err != nil {
+				return errors.Trace(err)
+			}
+			if err = it.Next(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if tooLarge {
+		// The table isn't loaded again until the lease expires, and it's read from TiKV meanwhile.
+		logutil.BgLogger().Warn("the cached table is larger than tidb_table_cache_max_size, it's read from TiKV",
+			zap.Int64("tableID", c.meta.ID), zap.Int64("maxSize", variable.TableCacheMaxSize.Load()))
+		buffer = nil
+	}
+	if startTS >= lease {
+		// The lease has expired while loading.
+		return nil
+	}
+	c.cacheData.Store(&cacheData{Start: startTS, Lease: lease, MemBuffer: buffer})
+	return nil
+}
+
+// WriteLockAndKeepAlive implements the CachedTable interface.
+func (c *cachedTable) WriteLockAndKeepAlive(ctx context.Context, exit chan struct{}) error {
+	if c.handle == nil {
+		return nil
+	}
+	start := time.Now()
+	lease, err := c.handle.LockForWrite(ctx, c.meta.ID, leaseDuration())
+	metrics.TableCacheWriteLockDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	go c.keepWriteLockAlive(exit, lease)
+	return nil
+}
+
+// keepWriteLockAlive renews the write lease until exit is closed, so no reader loads the table
+// before the transaction commits.
+func (c *cachedTable) keepWriteLockAlive(exit chan struct{}, lease uint64) {
+	defer util.Recover(metrics.LabelSession, "cachedTable.keepWriteLockAlive", nil, false)
+	ticker := time.NewTicker(leaseDuration() / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-exit:
+			return
+		case <-ticker.C:
+		}
+		ver, err := c.store.CurrentVersion(kv.GlobalTxnScope)
+		if err != nil {
+			logutil.BgLogger().Warn("renew the write lease of the cached table failed", zap.Int64("tableID", c.meta.ID), zap.Error(err))
+			continue
+		}
+		newLease := leaseFromTS(ver.Ver, leaseDuration())
+		succ, err := c.handle.RenewWriteLease(context.Background(), c.meta.ID, newLease)
+		if err != nil {
+			logutil.BgLogger().Warn("renew the write lease of the cached table failed", zap.Int64("tableID", c.meta.ID), zap.Error(err))
+			continue
+		}
+		if !succ {
+			logutil.BgLogger().Warn("the write lease of the cached table is lost", zap.Int64("tableID", c.meta.ID), zap.Uint64("lease", lease))
+			return
+		}
+		lease = newLease
+	}
+}
+
+// markWritten records the table in the transaction, the transaction takes the write lock before it commits.
+func (c *cachedTable) markWritten(sctx sessionctx.Context) {
+	txnCtx := sctx.GetSessionVars().TxnCtx
+	if txnCtx.CachedTables == nil {
+		txnCtx.CachedTables = make(map[int64]interface{})
+	}
+	txnCtx.CachedTables[c.meta.ID] = c
+}
+
+// AddRecord implements the table.Table interface.
+func (c *cachedTable) AddRecord(sctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (recordID kv.Handle, err error) {
+	c.markWritten(sctx)
+	return c.TableCommon.AddRecord(sctx, r, opts...)
+}
+
+// UpdateRecord implements the table.Table interface.
+func (c *cachedTable) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	c.markWritten(sctx)
+	return c.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched)
+}
+
+// RemoveRecord implements the table.Table interface.
+func (c *cachedTable) RemoveRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	c.markWritten(sctx)
+	return c.TableCommon.RemoveRecord(sctx, h, r)
+}
@@ -0,0 +1,267 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// CachedTableLockType is the type of the lock of a cached table.
+type CachedTableLockType int
+
+const (
+	// CachedTableLockNone means nobody holds the lock.
+	CachedTableLockNone CachedTableLockType = iota
+	// CachedTableLockRead means the readers hold the lock, the cache can be read until the lease expires.
+	CachedTableLockRead
+	// CachedTableLockIntend means a writer waits for the read lease to expire, no new read lease is granted.
+	CachedTableLockIntend
+	// CachedTableLockWrite means the writers hold the lock, the cache can't be loaded until the lease expires.
+	CachedTableLockWrite
+)
+
+var cachedTableLockTypeNames = []string{"NONE", "READ", "INTEND", "WRITE"}
+
+func (l CachedTableLockType) String() string {
+	return cachedTableLockTypeNames[l]
+}
+
+func parseCachedTableLockType(s string) (CachedTableLockType, error) {
+	for i, name := range cachedTableLockTypeNames {
+		if name == s {
+			return CachedTableLockType(i), nil
+		}
+	}
+	return CachedTableLockNone, errors.Errorf("unknown cached table lock type %s", s)
+}
+
+// StateRemote is the lock of the cached tables shared by all the TiDB instances. The leases are TSOs,
+// so they're compared with the read and commit TS of the transactions rather than the local clocks.
+type StateRemote interface {
+	// Load returns the lock type and the lease of the table.
+	Load(ctx context.Context, tid int64) (CachedTableLockType, uint64, error)
+
+	// LockForRead takes the read lock of the table with a lease of leaseDuration. It returns false if a
+	// writer holds the lock or waits for it. Otherwise it returns the lease, which may be longer than
+	// leaseDuration if other readers hold the lock too.
+	LockForRead(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, bool, error)
+
+	// RenewReadLease extends the read lease from oldLease to newLease. It returns false if the lock
+	// has changed since oldLease was taken.
+	RenewReadLease(ctx context.Context, tid int64, oldLease, newLease uint64) (bool, error)
+
+	// LockForWrite takes the write lock of the table with a lease of leaseDuration. It waits for the
+	// read lease to expire, so the cache isn't read after the writes commit. It returns the lease.
+	LockForWrite(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, error)
+
+	// RenewWriteLease extends the write lease to newLease. It returns false if the write lock is lost.
+	RenewWriteLease(ctx context.Context, tid int64, newLease uint64) (bool, error)
+}
+
+type stateRemoteHandle struct {
+	pool table.SessionPool
+}
+
+// NewStateRemote creates a StateRemote which stores the locks in mysql.table_cache_meta.
+func NewStateRemote(pool table.SessionPool) StateRemote {
+	return &stateRemoteHandle{pool: pool}
+}
+
+func (h *stateRemoteHandle) Load(ctx context.Context, tid int64) (lockType CachedTableLockType, lease uint64, err error) {
+	err = h.runInTxn(ctx, func(exec sqlexec.SQLExecutor, _ uint64) error {
+		lockType, lease, _, err = h.loadRow(ctx, exec, tid)
+		return err
+	})
+	return lockType, lease, err
+}
+
+func (h *stateRemoteHandle) LockForRead(ctx context.Context, tid int64, leaseDuration time.Duration) (lease uint64, succ bool, err error) {
+	err = h.runInTxn(ctx, func(exec sqlexec.SQLExecutor, now uint64) error {
+		lockType, oldLease, _, err := h.loadRow(ctx, exec, tid)
+		if err != nil {
+			return err
+		}
+		lease = leaseFromTS(now, leaseDuration)
+		switch lockType {
+		case CachedTableLockNone:
+		case CachedTableLockRead:
+			if oldLease > lease {
+				lease = oldLease
+			}
+		case CachedTableLockIntend, CachedTableLockWrite:
+			// The writer which doesn't renew its lease is gone.
+			if now <= oldLease {
+				return nil
+			}
+		}
+		succ = true
+		return h.updateRow(ctx, exec, tid, CachedTableLockRead, lease, 0)
+	})
+	return lease, succ, err
+}
+
+func (h *stateRemoteHandle) RenewReadLease(ctx context.Context, tid int64, oldLease, newLease uint64) (succ bool, err error) {
+	err = h.runInTxn(ctx, func(exec sqlexec.SQLExecutor, _ uint64) error {
+		lockType, lease, _, err := h.loadRow(ctx, exec, tid)
+		if err != nil {
+			return err
+		}
+		// Other instances may have extended the lease, or a writer may have taken the lock and
+		// released it, the data loaded with oldLease can't be read after oldLease any more.
+		if lockType != CachedTableLockRead || lease != oldLease {
+			return nil
+		}
+		succ = true
+		if newLease <= lease {
+			return nil
+		}
+		return h.updateRow(ctx, exec, tid, CachedTableLockRead, newLease, 0)
+	})
+	return succ, err
+}
+
+func (h *stateRemoteHandle) LockForWrite(ctx context.Context, tid int64, leaseDuration time.Duration) (uint64, error) {
+	for {
+		lease, waitUntil, err := h.lockForWriteOnce(ctx, tid, leaseDuration)
+		if err != nil {
+			return 0, err
+		}
+		if waitUntil == 0 {
+			return lease, nil
+		}
+		// Wait for the read lease to expire, and take the lock again.
+		select {
+		case <-ctx.Done():
+			return 0, errors.Trace(ctx.Err())
+		case <-time.After(time.Until(oracle.GetTimeFromTS(waitUntil)) + time.Millisecond):
+		}
+	}
+}
+
+// lockForWriteOnce takes the write lock if the read lease has expired. Otherwise it marks the lock
+// as INTEND so no reader renews the lease, and returns the read lease to wait for.
+func (h *stateRemoteHandle) lockForWriteOnce(ctx context.Context, tid int64, leaseDuration time.Duration) (lease, waitUntil uint64, err error) {
+	err = h.runInTxn(ctx, func(exec sqlexec.SQLExecutor, now uint64) error {
+		lockType, oldLease, oldReadLease, err := h.loadRow(ctx, exec, tid)
+		if err != nil {
+			return err
+		}
+		lease = leaseFromTS(now, leaseDuration)
+		switch lockType {
+		case CachedTableLockRead:
+			if now <= oldLease {
+				waitUntil = oldLease
+				// The lease of INTEND is longer than the read lease, so the readers can't take the lock
+				// again right after the read lease expires, before the writer takes it.
+				return h.updateRow(ctx, exec, tid, CachedTableLockIntend, leaseFromTS(oldLease, leaseDuration), oldLease)
+			}
+		case CachedTableLockIntend:
+			if now <= oldReadLease {
+				waitUntil = oldReadLease
+				return nil
+			}
+		case CachedTableLockWrite:
+			// Other writers hold the lock, there's no reader anyway.
+			if oldLease > lease {
+				lease = oldLease
+			}
+		}
+		return h.updateRow(ctx, exec, tid, CachedTableLockWrite, lease, 0)
+	})
+	return lease, waitUntil, err
+}
+
+func (h *stateRemoteHandle) RenewWriteLease(ctx context.Context, tid int64, newLease uint64) (succ bool, err error) {
+	err = h.runInTxn(ctx, func(exec sqlexec.SQLExecutor, _ uint64) error {
+		lockType, lease, _, err := h.loadRow(ctx, exec, tid)
+		if err != nil {
+			return err
+		}
+		if lockType != CachedTableLockWrite {
+			return nil
+		}
+		succ = true
+		if newLease <= lease {
+			return nil
+		}
+		return h.updateRow(ctx, exec, tid, CachedTableLockWrite, newLease, 0)
+	})
+	return succ, err
+}
+
+// runInTxn runs fn in a pessimistic transaction, now is the start TS of the transaction.
+func (h *stateRemoteHandle) runInTxn(ctx context.Context, fn func(exec sqlexec.SQLExecutor, now uint64) error) (err error) {
+	se, err := h.pool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer h.pool.Put(se)
+	exec := se.(sqlexec.SQLExecutor)
+	if _, err = exec.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err == nil {
+			_, err = exec.ExecuteInternal(ctx, "COMMIT")
+		} else {
+			_, err1 := exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(errors.Trace(err1))
+		}
+		err = errors.Trace(err)
+	}()
+	txn, err := se.(sessionctx.Context).Txn(true)
+	if err != nil {
+		return err
+	}
+	return fn(exec, txn.StartTS())
+}
+
+func (h *stateRemoteHandle) loadRow(ctx context.Context, exec sqlexec.SQLExecutor, tid int64) (CachedTableLockType, uint64, uint64, error) {
+	rs, err := exec.ExecuteInternal(ctx, "SELECT lock_type, lease, oldReadLease FROM mysql.table_cache_meta WHERE tid = %? FOR UPDATE", tid)
+	if err != nil {
+		return CachedTableLockNone, 0, 0, errors.Trace(err)
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, 1)
+	terror.Log(rs.Close())
+	if err != nil {
+		return CachedTableLockNone, 0, 0, errors.Trace(err)
+	}
+	if len(rows) == 0 {
+		return CachedTableLockNone, 0, 0, errors.Errorf("the lock of the cached table %d doesn't exist", tid)
+	}
+	lockType, err := parseCachedTableLockType(rows[0].GetEnum(0).String())
+	if err != nil {
+		return CachedTableLockNone, 0, 0, err
+	}
+	return lockType, uint64(rows[0].GetInt64(1)), uint64(rows[0].GetInt64(2)), nil
+}
+
+func (h *stateRemoteHandle) updateRow(ctx context.Context, exec sqlexec.SQLExecutor, tid int64, lockType CachedTableLockType, lease, oldReadLease uint64) error {
+	_, err := exec.ExecuteInternal(ctx, "UPDATE mysql.table_cache_meta SET lock_type = %?, lease = %?, oldReadLease = %? WHERE tid = %?",
+		lockType.String(), lease, oldReadLease, tid)
+	return errors.Trace(err)
+}
+
+func leaseFromTS(ts uint64, leaseDuration time.Duration) uint64 {
+	return oracle.GoTimeToTS(oracle.GetTimeFromTS(ts).Add(leaseDuration))
+}
@@ -0,0 +1,91 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func TestStateRemote(t *testing.T) {
+	t.Parallel()
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("insert into mysql.table_cache_meta values (5, 'NONE', 0, 0)")
+
+	ctx := context.Background()
+	h := tables.NewStateRemote(dom.SysSessionPool())
+	lockType, lease, err := h.Load(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, tables.CachedTableLockNone, lockType)
+	require.Equal(t, uint64(0), lease)
+
+	// The readers share the lock.
+	readLease, succ, err := h.LockForRead(ctx, 5, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, succ)
+	lockType, lease, err = h.Load(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, tables.CachedTableLockRead, lockType)
+	require.Equal(t, readLease, lease)
+	_, succ, err = h.LockForRead(ctx, 5, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, succ)
+
+	// The lease is renewed only if nobody else has changed it.
+	_, lease, err = h.Load(ctx, 5)
+	require.NoError(t, err)
+	succ, err = h.RenewReadLease(ctx, 5, lease-1, lease+1)
+	require.NoError(t, err)
+	require.False(t, succ)
+	succ, err = h.RenewReadLease(ctx, 5, lease, lease+1)
+	require.NoError(t, err)
+	require.True(t, succ)
+	readLease = lease + 1
+
+	// The writer waits for the read lease to expire.
+	writeLease, err := h.LockForWrite(ctx, 5, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.Greater(t, writeLease, readLease)
+	require.False(t, time.Now().Before(oracle.GetTimeFromTS(readLease)))
+	lockType, lease, err = h.Load(ctx, 5)
+	require.NoError(t, err)
+	require.Equal(t, tables.CachedTableLockWrite, lockType)
+	require.Equal(t, writeLease, lease)
+
+	// The readers can't take the lock before the write lease expires.
+	_, succ, err = h.LockForRead(ctx, 5, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.False(t, succ)
+	succ, err = h.RenewWriteLease(ctx, 5, writeLease+1)
+	require.NoError(t, err)
+	require.True(t, succ)
+
+	// The write lock is released by expiring.
+	time.Sleep(time.Until(oracle.GetTimeFromTS(writeLease+1)) + time.Millisecond)
+	_, succ, err = h.LockForRead(ctx, 5, 500*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, succ)
+	succ, err = h.RenewWriteLease(ctx, 5, writeLease+2)
+	require.NoError(t, err)
+	require.False(t, succ)
+}
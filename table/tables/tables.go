@@ -145,6 +145,9 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 		if err := initTableIndices(&t); err != nil {
 			return nil, err
 		}
+		if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
+			return newCachedTable(&t)
+		}
 		return &t, nil
 	}
 
@@ -334,7 +337,7 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 			if err := checkTempTableSize(sctx, tmpTable, m); err != nil {
 				return err
 			}
-			defer handleTempTableSize(tmpTable, txn.Size(), txn)
+			defer handleTempTableSize(sctx, tmpTable, txn.Size(), txn)
 		}
 	}
 
@@ -608,13 +611,26 @@ func addTemporaryTable(sctx sessionctx.Context, tblInfo *model.TableInfo) tableu
 }
 
 // The size of a temporary table is calculated by accumulating the transaction size delta.
-func handleTempTableSize(t tableutil.TempTable, txnSizeBefore int, txn kv.Transaction) {
+func handleTempTableSize(ctx sessionctx.Context, t tableutil.TempTable, txnSizeBefore int, txn kv.Transaction) {
 	txnSizeNow := txn.Size()
 	delta := txnSizeNow - txnSizeBefore
 
 	oldSize := t.GetSize()
 	newSize := oldSize + int64(delta)
 	t.SetSize(newSize)
+	if memTracker := ctx.GetSessionVars().TxnCtx.TemporaryTableMemTracker; memTracker != nil {
+		memTracker.Consume(int64(delta))
+	}
+}
+
+// tempTableSizeLimit returns the max size of the temporary table. The data of local temporary tables can be
+// spilled to disk, so it can exceed `tmp_table_size` and is limited by `tidb_tmp_table_max_size` instead.
+func tempTableSizeLimit(vars *variable.SessionVars, tblInfo *model.TableInfo) int64 {
+	limit := vars.TMPTableSize
+	if tblInfo.TempTableType == model.TempTableLocal && vars.EnableTMPTableSpill && vars.TMPTableMaxSize > limit {
+		limit = vars.TMPTableMaxSize
+	}
+	return limit
 }
 
 func checkTempTableSize(ctx sessionctx.Context, tmpTable tableutil.TempTable, tblInfo *model.TableInfo) error {
@@ -623,7 +639,7 @@ func checkTempTableSize(ctx sessionctx.Context, tmpTable tableutil.TempTable, tb
 		tmpTableSize += tempTableData.GetTableSize(tblInfo.ID)
 	}
 
-	if tmpTableSize > ctx.GetSessionVars().TMPTableSize {
+	if tmpTableSize > tempTableSizeLimit(ctx.GetSessionVars(), tblInfo) {
 		return table.ErrTempTableFull.GenWithStackByArgs(tblInfo.Name.O)
 	}
 
@@ -647,7 +663,7 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			if err := checkTempTableSize(sctx, tmpTable, m); err != nil {
 				return nil, err
 			}
-			defer handleTempTableSize(tmpTable, txn.Size(), txn)
+			defer handleTempTableSize(sctx, tmpTable, txn.Size(), txn)
 		}
 	}
 
@@ -1060,7 +1076,7 @@ func (t *TableCommon) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r []type
 			if err := checkTempTableSize(ctx, tmpTable, m); err != nil {
 				return err
 			}
-			defer handleTempTableSize(tmpTable, txn.Size(), txn)
+			defer handleTempTableSize(ctx, tmpTable, txn.Size(), txn)
 		}
 	}
 
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/parser/ast"
 	"github.com/pingcap/parser/model"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
@@ -30,6 +31,8 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/tikv/client-go/v2/tikv"
 )
 
@@ -38,6 +41,8 @@ type TemporaryTableDDL interface {
 	CreateLocalTemporaryTable(schema model.CIStr, info *model.TableInfo) error
 	DropLocalTemporaryTable(schema model.CIStr, tblName model.CIStr) error
 	TruncateLocalTemporaryTable(schema model.CIStr, tblName model.CIStr) error
+	AlterLocalTemporaryTable(schema model.CIStr, tblName model.CIStr, info *model.TableInfo) error
+	RenameLocalTemporaryTable(oldSchema, oldTblName, newSchema, newTblName model.CIStr) error
 }
 
 // temporaryTableDDL implements temptable.TemporaryTableDDL
@@ -89,6 +94,173 @@ func (d *temporaryTableDDL) TruncateLocalTemporaryTable(schema model.CIStr, tblN
 	return d.clearTemporaryTableRecords(oldTblInfo.ID)
 }
 
+func (d *temporaryTableDDL) AlterLocalTemporaryTable(schema model.CIStr, tblName model.CIStr, info *model.TableInfo) error {
+	oldTbl, err := checkLocalTemporaryExistsAndReturn(d.sctx, schema, tblName)
+	if err != nil {
+		return err
+	}
+
+	localTempTables := getLocalTemporaryTables(d.sctx)
+	if info.Name.L != tblName.L && localTempTables.TableExists(schema, info.Name) {
+		return infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: schema, Name: info.Name})
+	}
+
+	oldTblInfo := oldTbl.Meta()
+	if !needRebuildTemporaryTable(oldTblInfo, info) {
+		// The records are still valid, so keep the table ID and the allocators.
+		info.ID = oldTblInfo.ID
+		newTbl, err := tables.TableFromMeta(oldTbl.Allocators(nil), info)
+		if err != nil {
+			return err
+		}
+		localTempTables.RemoveTable(schema, tblName)
+		return localTempTables.AddTable(schema, newTbl)
+	}
+
+	newTbl, err := newTemporaryTableFromTableInfo(d.sctx, info)
+	if err != nil {
+		return err
+	}
+	if oldAlloc := oldTbl.Allocators(nil).Get(autoid.RowIDAllocType); oldAlloc != nil {
+		if newAlloc := newTbl.Allocators(nil).Get(autoid.RowIDAllocType); newAlloc != nil {
+			if err = newAlloc.Rebase(oldAlloc.Base(), false); err != nil {
+				return err
+			}
+		}
+	}
+	if err = d.rebuildTemporaryTableRecords(oldTbl, newTbl); err != nil {
+		return err
+	}
+
+	localTempTables.RemoveTable(schema, tblName)
+	if err = localTempTables.AddTable(schema, newTbl); err != nil {
+		return err
+	}
+	return d.clearTemporaryTableRecords(oldTblInfo.ID)
+}
+
+func (d *temporaryTableDDL) RenameLocalTemporaryTable(oldSchema, oldTblName, newSchema, newTblName model.CIStr) error {
+	tbl, err := checkLocalTemporaryExistsAndReturn(d.sctx, oldSchema, oldTblName)
+	if err != nil {
+		return err
+	}
+
+	localTempTables := getLocalTemporaryTables(d.sctx)
+	if localTempTables.TableExists(newSchema, newTblName) {
+		return infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: newSchema, Name: newTblName})
+	}
+
+	// The records are encoded with the table ID, which isn't changed by renaming.
+	newTblInfo := tbl.Meta().Clone()
+	newTblInfo.Name = newTblName
+	newTbl, err := tables.TableFromMeta(tbl.Allocators(nil), newTblInfo)
+	if err != nil {
+		return err
+	}
+
+	localTempTables.RemoveTable(oldSchema, oldTblName)
+	return localTempTables.AddTable(newSchema, newTbl)
+}
+
+// rebuildTemporaryTableRecords writes the records of the old table into the current transaction with the layout of
+// the new table. They are moved to the session data when the transaction commits.
+func (d *temporaryTableDDL) rebuildTemporaryTableRecords(oldTbl, newTbl table.Table) error {
+	sessionData := getSessionData(d.sctx)
+	if sessionData == nil {
+		return nil
+	}
+
+	newTblInfo := newTbl.Meta()
+	newCols := newTbl.Cols()
+	genExprs := make(map[int]expression.Expression)
+	for _, col := range newCols {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := expression.ParseSimpleExprWithTableInfo(d.sctx, col.GeneratedExprString, newTblInfo)
+		if err != nil {
+			return err
+		}
+		genExprs[col.Offset] = expr
+	}
+
+	oldTblInfo := oldTbl.Meta()
+	oldCols := oldTbl.Cols()
+	oldColsByID := make(map[int64]int, len(oldCols))
+	for i, col := range oldCols {
+		oldColsByID[col.ID] = i
+	}
+	hasRowID := !newTblInfo.PKIsHandle && !newTblInfo.IsCommonHandle
+
+	recordPrefix := tablecodec.GenTableRecordPrefix(oldTblInfo.ID)
+	iter, err := sessionData.Iter(recordPrefix, recordPrefix.PrefixNext())
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); err = iter.Next() {
+		if err != nil {
+			return err
+		}
+		if len(iter.Value()) == 0 {
+			continue
+		}
+
+		handle, err := tablecodec.DecodeRowKey(iter.Key())
+		if err != nil {
+			return err
+		}
+		oldRow, _, err := tables.DecodeRawRowData(d.sctx, oldTblInfo, handle, oldCols, iter.Value())
+		if err != nil {
+			return err
+		}
+
+		newRow := make([]types.Datum, len(newCols), len(newCols)+1)
+		for _, col := range newCols {
+			if _, ok := genExprs[col.Offset]; ok {
+				continue
+			}
+			idx, ok := oldColsByID[col.ID]
+			if !ok {
+				if newRow[col.Offset], err = table.GetColOriginDefaultValue(d.sctx, col.ToInfo()); err != nil {
+					return err
+				}
+				continue
+			}
+			newRow[col.Offset] = oldRow[idx]
+			if !oldCols[idx].FieldType.Equal(&col.FieldType) {
+				if newRow[col.Offset], err = table.CastValue(d.sctx, oldRow[idx], col.ToInfo(), false, false); err != nil {
+					return err
+				}
+			}
+		}
+		for _, col := range newCols {
+			if expr, ok := genExprs[col.Offset]; ok {
+				val, err := expr.Eval(chunk.MutRowFromDatums(newRow).ToRow())
+				if err != nil {
+					return err
+				}
+				if newRow[col.Offset], err = table.CastValue(d.sctx, val, col.ToInfo(), false, false); err != nil {
+					return err
+				}
+			}
+			if err = col.CheckNotNull(&newRow[col.Offset]); err != nil {
+				return err
+			}
+		}
+		if hasRowID {
+			newRow = append(newRow, types.NewIntDatum(handle.IntValue()))
+		}
+
+		if _, err = newTbl.AddRecord(d.sctx, newRow); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
 func (d *temporaryTableDDL) clearTemporaryTableRecords(tblID int64) error {
 	sessionData := getSessionData(d.sctx)
 	if sessionData == nil {
@@ -144,13 +316,20 @@ func getSessionData(sctx sessionctx.Context) variable.TemporaryTableData {
 func ensureSessionData(sctx sessionctx.Context) (variable.TemporaryTableData, error) {
 	sessVars := sctx.GetSessionVars()
 	if sessVars.TemporaryTableData == nil {
-		// Create this txn just for getting a MemBuffer. It's a little tricky
-		bufferTxn, err := sctx.GetStore().BeginWithOption(tikv.DefaultStartTSOption().SetStartTS(0))
+		store := sctx.GetStore()
+		data, err := newSessionData(sessVars, func() (kv.MemBuffer, error) {
+			// Create this txn just for getting a MemBuffer. It's a little tricky
+			bufferTxn, err := store.BeginWithOption(tikv.DefaultStartTSOption().SetStartTS(0))
+			if err != nil {
+				return nil, err
+			}
+			return bufferTxn.GetMemBuffer(), nil
+		})
 		if err != nil {
 			return nil, err
 		}
 
-		sessVars.TemporaryTableData = variable.NewTemporaryTableData(bufferTxn.GetMemBuffer())
+		sessVars.TemporaryTableData = data
 	}
 
 	return sessVars.TemporaryTableData, nil
@@ -183,6 +362,35 @@ func newTemporaryTableFromTableInfo(sctx sessionctx.Context, tbInfo *model.Table
 	return tables.TableFromMeta(allocs, tbInfo)
 }
 
+// needRebuildTemporaryTable checks whether the records of the local temporary table should be rebuilt, that is,
+// some columns or indexes are added, dropped or changed.
+func needRebuildTemporaryTable(oldTblInfo, newTblInfo *model.TableInfo) bool {
+	if len(oldTblInfo.Columns) != len(newTblInfo.Columns) || len(oldTblInfo.Indices) != len(newTblInfo.Indices) {
+		return true
+	}
+
+	for i, oldCol := range oldTblInfo.Columns {
+		newCol := newTblInfo.Columns[i]
+		if oldCol.ID != newCol.ID || !oldCol.FieldType.Equal(&newCol.FieldType) || oldCol.Flag != newCol.Flag ||
+			oldCol.GeneratedExprString != newCol.GeneratedExprString {
+			return true
+		}
+	}
+
+	for i, oldIdx := range oldTblInfo.Indices {
+		newIdx := newTblInfo.Indices[i]
+		if oldIdx.ID != newIdx.ID || len(oldIdx.Columns) != len(newIdx.Columns) {
+			return true
+		}
+		for j, oldIdxCol := range oldIdx.Columns {
+			if oldIdxCol.Offset != newIdx.Columns[j].Offset || oldIdxCol.Length != newIdx.Columns[j].Length {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetTemporaryTableDDL gets the temptable.TemporaryTableDDL from session context
 func GetTemporaryTableDDL(sctx sessionctx.Context) TemporaryTableDDL {
 	return &temporaryTableDDL{
@@ -218,6 +218,44 @@ func TestTruncateLocalTemporaryTable(t *testing.T) {
 	require.Equal(t, []byte("v2"), val)
 }
 
+func TestRenameLocalTemporaryTable(t *testing.T) {
+	t.Parallel()
+
+	sctx, ddl, clean := createTestSuite(t)
+	defer clean()
+
+	sessVars := sctx.GetSessionVars()
+
+	// rename when empty
+	err := ddl.RenameLocalTemporaryTable(model.NewCIStr("db1"), model.NewCIStr("t1"), model.NewCIStr("db1"), model.NewCIStr("t2"))
+	require.True(t, infoschema.ErrTableNotExists.Equal(err))
+
+	tbl1 := newMockTable("t1")
+	err = ddl.CreateLocalTemporaryTable(model.NewCIStr("db1"), tbl1)
+	require.NoError(t, err)
+	tbl2 := newMockTable("t2")
+	err = ddl.CreateLocalTemporaryTable(model.NewCIStr("db1"), tbl2)
+	require.NoError(t, err)
+
+	// rename failed for table exists
+	err = ddl.RenameLocalTemporaryTable(model.NewCIStr("db1"), model.NewCIStr("t1"), model.NewCIStr("db1"), model.NewCIStr("t2"))
+	require.True(t, infoschema.ErrTableExists.Equal(err))
+
+	// rename success and the table ID is kept
+	err = ddl.RenameLocalTemporaryTable(model.NewCIStr("db1"), model.NewCIStr("t1"), model.NewCIStr("db2"), model.NewCIStr("t3"))
+	require.NoError(t, err)
+	localTempTables := sessVars.LocalTemporaryTables.(*infoschema.LocalTemporaryTables)
+	require.False(t, localTempTables.TableExists(model.NewCIStr("db1"), model.NewCIStr("t1")))
+	got, exists := localTempTables.TableByName(model.NewCIStr("db2"), model.NewCIStr("t3"))
+	require.True(t, exists)
+	require.Equal(t, tbl1.ID, got.Meta().ID)
+	require.Equal(t, "t3", got.Meta().Name.O)
+	require.Equal(t, "t1", tbl1.Name.O)
+	got, exists = localTempTables.TableByID(tbl1.ID)
+	require.True(t, exists)
+	require.Equal(t, "t3", got.Meta().Name.O)
+}
+
 func newMockTable(tblName string) *model.TableInfo {
 	c1 := &model.ColumnInfo{ID: 1, Name: model.NewCIStr("c1"), State: model.StatePublic, Offset: 0, FieldType: *types.NewFieldType(mysql.TypeLonglong)}
 	c2 := &model.ColumnInfo{ID: 2, Name: model.NewCIStr("c2"), State: model.StatePublic, Offset: 1, FieldType: *types.NewFieldType(mysql.TypeVarchar)}
@@ -0,0 +1,339 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temptable
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/driver/txn"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"go.uber.org/zap"
+)
+
+const tempTableDataDirPrefix = "tmp-table-data"
+
+// sessionData implements variable.TemporaryTableData, it stores the committed data of local temporary tables
+// in a MemBuffer. When `tidb_enable_tmp_table_spill` is set and the MemBuffer exceeds `tmp_table_size`,
+// all the data in the MemBuffer is moved to a pebble DB in the temporary storage path.
+type sessionData struct {
+	kv.MemBuffer
+	tblSize map[int64]int64
+
+	vars *variable.SessionVars
+	// newMemBuffer creates an empty MemBuffer to replace the spilled one.
+	newMemBuffer func() (kv.MemBuffer, error)
+	// stagingCnt is the count of the active staging buffers, the data can't be spilled if it isn't 0.
+	stagingCnt int
+	diskData   *diskData
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+}
+
+func newSessionData(vars *variable.SessionVars, newMemBuffer func() (kv.MemBuffer, error)) (*sessionData, error) {
+	memBuffer, err := newMemBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return &sessionData{
+		MemBuffer:    memBuffer,
+		tblSize:      make(map[int64]int64),
+		vars:         vars,
+		newMemBuffer: newMemBuffer,
+		memTracker:   memory.NewTracker(memory.LabelForTemporaryTableData, -1),
+		diskTracker:  disk.NewTracker(memory.LabelForTemporaryTableData, -1),
+	}, nil
+}
+
+// Get implements the kv.Retriever interface.
+func (d *sessionData) Get(ctx context.Context, k kv.Key) ([]byte, error) {
+	val, err := d.MemBuffer.Get(ctx, k)
+	if d.diskData == nil || !kv.IsErrNotFound(err) {
+		return val, err
+	}
+	return d.diskData.get(k)
+}
+
+// Iter implements the kv.Retriever interface.
+func (d *sessionData) Iter(k kv.Key, upperBound kv.Key) (kv.Iterator, error) {
+	memIter, err := d.MemBuffer.Iter(k, upperBound)
+	if err != nil || d.diskData == nil {
+		return memIter, err
+	}
+	return txn.NewUnionIter(memIter, d.diskData.iter(k, upperBound, false), false)
+}
+
+// IterReverse implements the kv.Retriever interface.
+func (d *sessionData) IterReverse(k kv.Key) (kv.Iterator, error) {
+	memIter, err := d.MemBuffer.IterReverse(k)
+	if err != nil || d.diskData == nil {
+		return memIter, err
+	}
+	return txn.NewUnionIter(memIter, d.diskData.iter(nil, k, true), true)
+}
+
+// Staging implements the variable.TemporaryTableData interface.
+func (d *sessionData) Staging() kv.StagingHandle {
+	d.stagingCnt++
+	return d.MemBuffer.Staging()
+}
+
+// Release implements the variable.TemporaryTableData interface.
+func (d *sessionData) Release(h kv.StagingHandle) {
+	d.MemBuffer.Release(h)
+	d.stagingCnt--
+	d.checkSpill()
+}
+
+// Cleanup implements the variable.TemporaryTableData interface.
+func (d *sessionData) Cleanup(h kv.StagingHandle) {
+	d.MemBuffer.Cleanup(h)
+	d.stagingCnt--
+	d.updateMemUsage()
+}
+
+// GetTableSize get the size of a table
+func (d *sessionData) GetTableSize(tblID int64) int64 {
+	if tblSize, ok := d.tblSize[tblID]; ok {
+		return tblSize
+	}
+	return 0
+}
+
+// DeleteTableKey removes the entry for key k from table
+func (d *sessionData) DeleteTableKey(tblID int64, k kv.Key) error {
+	bufferSize := d.MemBuffer.Size()
+	defer d.updateTblSize(tblID, bufferSize)
+
+	return d.MemBuffer.Delete(k)
+}
+
+// SetTableKey sets the entry for k from table
+func (d *sessionData) SetTableKey(tblID int64, k kv.Key, val []byte) error {
+	bufferSize := d.MemBuffer.Size()
+	defer d.updateTblSize(tblID, bufferSize)
+
+	return d.MemBuffer.Set(k, val)
+}
+
+func (d *sessionData) updateTblSize(tblID int64, beforeSize int) {
+	delta := int64(d.MemBuffer.Size() - beforeSize)
+	d.tblSize[tblID] = d.GetTableSize(tblID) + delta
+	d.checkSpill()
+}
+
+// AttachTo implements the variable.TemporaryTableData interface.
+func (d *sessionData) AttachTo(memTracker *memory.Tracker, diskTracker *disk.Tracker) {
+	d.memTracker.AttachTo(memTracker)
+	d.diskTracker.AttachTo(diskTracker)
+}
+
+// Close implements the variable.TemporaryTableData interface.
+func (d *sessionData) Close() {
+	d.memTracker.Consume(-d.memTracker.BytesConsumed())
+	d.memTracker.Detach()
+	d.diskTracker.Consume(-d.diskTracker.BytesConsumed())
+	d.diskTracker.Detach()
+	if d.diskData != nil {
+		d.diskData.close()
+		d.diskData = nil
+	}
+}
+
+func (d *sessionData) updateMemUsage() {
+	d.memTracker.Consume(int64(d.MemBuffer.Size()) - d.memTracker.BytesConsumed())
+}
+
+// checkSpill moves the data to the disk if the MemBuffer is too large. The staging buffers belong to the current
+// MemBuffer, so it's skipped until all of them are released or cleaned up.
+func (d *sessionData) checkSpill() {
+	d.updateMemUsage()
+	if d.stagingCnt > 0 || !d.vars.EnableTMPTableSpill || int64(d.MemBuffer.Size()) <= d.vars.TMPTableSize {
+		return
+	}
+
+	// The data is still available in the memory if spilling fails, so only log the error.
+	if err := d.spill(); err != nil {
+		logutil.BgLogger().Warn("spill temporary table data to disk failed", zap.Error(err))
+	}
+}
+
+func (d *sessionData) spill() error {
+	if d.diskData == nil {
+		diskData, err := newDiskData()
+		if err != nil {
+			return err
+		}
+		d.diskData = diskData
+	}
+
+	iter, err := d.MemBuffer.Iter(nil, nil)
+	if err != nil {
+		return err
+	}
+	written, err := d.diskData.write(iter)
+	iter.Close()
+	if err != nil {
+		return err
+	}
+
+	memBuffer, err := d.newMemBuffer()
+	if err != nil {
+		return err
+	}
+	d.MemBuffer = memBuffer
+	d.diskTracker.Consume(written)
+	d.updateMemUsage()
+	return nil
+}
+
+// diskData stores the spilled data of local temporary tables.
+type diskData struct {
+	dir string
+	db  *pebble.DB
+}
+
+func newDiskData() (*diskData, error) {
+	if err := disk.CheckAndInitTempDir(); err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir(config.GetGlobalConfig().TempStoragePath, tempTableDataDirPrefix)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	db, err := pebble.Open(dir, &pebble.Options{})
+	if err != nil {
+		removeDiskDataDir(dir)
+		return nil, errors.Trace(err)
+	}
+	return &diskData{dir: dir, db: db}, nil
+}
+
+// write writes all the entries of the iterator into the disk, an empty value means the key is deleted.
+// It returns the written bytes.
+func (d *diskData) write(iter kv.Iterator) (int64, error) {
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	var written int64
+	var err error
+	for ; iter.Valid(); err = iter.Next() {
+		if err != nil {
+			return 0, err
+		}
+		if len(iter.Value()) == 0 {
+			err = batch.Delete(iter.Key(), nil)
+		} else {
+			err = batch.Set(iter.Key(), iter.Value(), nil)
+			written += int64(len(iter.Key()) + len(iter.Value()))
+		}
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	return written, errors.Trace(batch.Commit(pebble.NoSync))
+}
+
+func (d *diskData) get(k kv.Key) ([]byte, error) {
+	val, closer, err := d.db.Get(k)
+	if err == pebble.ErrNotFound {
+		return nil, kv.ErrNotExist
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer closer.Close()
+	return append([]byte{}, val...), nil
+}
+
+func (d *diskData) iter(lowerBound, upperBound kv.Key, reverse bool) *diskIter {
+	opts := &pebble.IterOptions{}
+	if len(lowerBound) > 0 {
+		opts.LowerBound = lowerBound
+	}
+	if len(upperBound) > 0 {
+		opts.UpperBound = upperBound
+	}
+	it := &diskIter{iter: d.db.NewIter(opts), reverse: reverse}
+	if reverse {
+		it.iter.Last()
+	} else {
+		it.iter.First()
+	}
+	return it
+}
+
+func (d *diskData) close() {
+	if err := d.db.Close(); err != nil {
+		logutil.BgLogger().Warn("close temporary table data failed", zap.Error(err))
+	}
+	removeDiskDataDir(d.dir)
+}
+
+func removeDiskDataDir(dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		logutil.BgLogger().Warn("remove temporary table data failed", zap.String("dir", dir), zap.Error(err))
+	}
+}
+
+// diskIter implements the kv.Iterator interface on the spilled data.
+type diskIter struct {
+	iter    *pebble.Iterator
+	reverse bool
+}
+
+// Valid implements the kv.Iterator interface.
+func (it *diskIter) Valid() bool {
+	return it.iter.Valid()
+}
+
+// Key implements the kv.Iterator interface.
+func (it *diskIter) Key() kv.Key {
+	return append([]byte{}, it.iter.Key()...)
+}
+
+// Value implements the kv.Iterator interface.
+func (it *diskIter) Value() []byte {
+	return append([]byte{}, it.iter.Value()...)
+}
+
+// Next implements the kv.Iterator interface.
+func (it *diskIter) Next() error {
+	if it.reverse {
+		it.iter.Prev()
+	} else {
+		it.iter.Next()
+	}
+	return errors.Trace(it.iter.Error())
+}
+
+// Close implements the kv.Iterator interface.
+func (it *diskIter) Close() {
+	if err := it.iter.Close(); err != nil {
+		logutil.BgLogger().Warn("close temporary table data iterator failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temptable
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/store/mockstore"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+func TestSessionDataSpill(t *testing.T) {
+	t.Parallel()
+
+	store, err := mockstore.NewMockStore()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+
+	vars := variable.NewSessionVars()
+	vars.TMPTableSize = 1024
+	data, err := newSessionData(vars, func() (kv.MemBuffer, error) {
+		txn, err := store.BeginWithOption(tikv.DefaultStartTSOption().SetStartTS(0))
+		if err != nil {
+			return nil, err
+		}
+		return txn.GetMemBuffer(), nil
+	})
+	require.NoError(t, err)
+	memTracker, diskTracker := vars.MemTracker, vars.DiskTracker
+	data.AttachTo(memTracker, diskTracker)
+
+	key := func(i int) kv.Key {
+		return tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(i))
+	}
+	value := func(i int) []byte {
+		return []byte(fmt.Sprintf("%0100d", i))
+	}
+
+	// Data isn't spilled if it's disabled.
+	for i := 0; i < 20; i++ {
+		require.NoError(t, data.SetTableKey(1, key(i), value(i)))
+	}
+	require.Nil(t, data.diskData)
+	require.Greater(t, memTracker.BytesConsumed(), int64(2000))
+	require.Equal(t, int64(data.Size()), memTracker.BytesConsumed())
+
+	// Data isn't spilled until the staging buffer is released.
+	vars.EnableTMPTableSpill = true
+	h := data.Staging()
+	require.NoError(t, data.SetTableKey(1, key(20), value(20)))
+	require.Nil(t, data.diskData)
+	data.Release(h)
+	require.NotNil(t, data.diskData)
+	require.Equal(t, 0, data.Size())
+	require.Equal(t, int64(0), memTracker.BytesConsumed())
+	require.Greater(t, diskTracker.BytesConsumed(), int64(2000))
+	require.Greater(t, data.GetTableSize(1), int64(2000))
+	dir := data.diskData.dir
+	_, err = os.Stat(dir)
+	require.NoError(t, err)
+
+	// The data in memory overrides the spilled data.
+	require.NoError(t, data.DeleteTableKey(1, key(1)))
+	require.NoError(t, data.SetTableKey(1, key(2), []byte("v2")))
+	require.NoError(t, data.SetTableKey(1, key(30), []byte("v30")))
+
+	val, err := data.Get(context.Background(), key(0))
+	require.NoError(t, err)
+	require.Equal(t, value(0), val)
+	val, err = data.Get(context.Background(), key(1))
+	require.NoError(t, err)
+	require.Len(t, val, 0)
+	val, err = data.Get(context.Background(), key(2))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), val)
+	_, err = data.Get(context.Background(), key(31))
+	require.True(t, kv.IsErrNotFound(err))
+
+	iter, err := data.Iter(key(0), key(4))
+	require.NoError(t, err)
+	var keys []kv.Key
+	for ; iter.Valid(); err = iter.Next() {
+		require.NoError(t, err)
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	require.Equal(t, []kv.Key{key(0), key(2), key(3)}, keys)
+
+	iter, err = data.IterReverse(key(31))
+	require.NoError(t, err)
+	keys = keys[:0]
+	for ; iter.Valid() && len(keys) < 3; err = iter.Next() {
+		require.NoError(t, err)
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+	require.Equal(t, []kv.Key{key(30), key(20), key(19)}, keys)
+
+	// The trackers and the spilled files are released after closing.
+	data.Close()
+	require.Equal(t, int64(0), memTracker.BytesConsumed())
+	require.Equal(t, int64(0), diskTracker.BytesConsumed())
+	_, err = os.Stat(dir)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,278 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/parser/ast"
+	"github.com/pingcap/parser/model"
+	"github.com/pingcap/parser/mysql"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/owner"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	jobStatusRunning  = "running"
+	jobStatusFinished = "finished"
+	jobStatusFailed   = "failed"
+)
+
+// job deletes the expired rows of a table. It scans the expired rows in the order of the
+// handle page by page, and deletes every page in transactions of deleteBatchSize rows. The
+// expire time is fixed when the job starts, so the rows expired later are left to the next job.
+type job struct {
+	id      string
+	schema  model.CIStr
+	tbl     *model.TableInfo
+	params  *jobParams
+	limiter *rateLimiter
+
+	expireTime   string
+	scannedRows  uint64
+	deletedRows  uint64
+	failedDelete uint64
+}
+
+func newJob(schema model.CIStr, tbl *model.TableInfo, params *jobParams, limiter *rateLimiter) *job {
+	return &job{
+		id:      uuid.New().String(),
+		schema:  schema,
+		tbl:     tbl,
+		params:  params,
+		limiter: limiter,
+	}
+}
+
+// run runs the job and records it in mysql.tidb_ttl_job_history.
+func (j *job) run(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, owner owner.Manager) error {
+	interval, err := strconv.ParseInt(j.tbl.TTLInfo.IntervalExprStr, 10, 64)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The unit comes from the parser, so it's safe to be written into the SQL.
+	unit := ast.TimeUnitType(j.tbl.TTLInfo.IntervalTimeUnit).String()
+	rows, err := execRestrictedSQL(ctx, exec, "SELECT NOW() - INTERVAL %? "+unit, interval)
+	if err != nil {
+		return err
+	}
+	j.expireTime = rows[0].GetTime(0).String()
+	_, err = execRestrictedSQL(ctx, exec, "INSERT INTO mysql.tidb_ttl_job_history (job_id, table_id, table_schema, table_name, expire_time, create_time, status) VALUES (%?, %?, %?, %?, %?, NOW(3), %?)",
+		j.id, j.tbl.ID, j.schema.O, j.tbl.Name.O, j.expireTime, jobStatusRunning)
+	if err != nil {
+		return err
+	}
+
+	startTime := time.Now()
+	err = j.scanAndDelete(ctx, exec, owner)
+	status, message, result := jobStatusFinished, "", metrics.LblOK
+	if err != nil {
+		status, message, result = jobStatusFailed, err.Error(), metrics.LblError
+	}
+	metrics.TTLJobCounter.WithLabelValues(result).Inc()
+	metrics.TTLJobDuration.WithLabelValues(result).Observe(time.Since(startTime).Seconds())
+	// The job is recorded even if ctx is canceled.
+	_, updateErr := execRestrictedSQL(context.Background(), exec, "UPDATE mysql.tidb_ttl_job_history SET finish_time = NOW(3), scanned_rows = %?, deleted_rows = %?, error_delete_rows = %?, status = %?, message = %? WHERE job_id = %?",
+		j.scannedRows, j.deletedRows, j.failedDelete, status, message, j.id)
+	if err != nil {
+		return err
+	}
+	return updateErr
+}
+
+func (j *job) scanAndDelete(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, owner owner.Manager) error {
+	keyCols := keyColumns(j.tbl)
+	var lastKey []interface{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		if !owner.IsOwner() {
+			return errors.New("the owner is changed")
+		}
+		sql := new(strings.Builder)
+		sqlexec.MustFormatSQL(sql, "SELECT LOW_PRIORITY ")
+		writeColumns(sql, keyCols)
+		sqlexec.MustFormatSQL(sql, " FROM %n.%n WHERE %n < %?", j.schema.O, j.tbl.Name.O, j.tbl.TTLInfo.ColumnName.O, j.expireTime)
+		if lastKey != nil {
+			sqlexec.MustFormatSQL(sql, " AND (")
+			writeColumns(sql, keyCols)
+			sqlexec.MustFormatSQL(sql, ") > (")
+			writeValues(sql, lastKey)
+			sqlexec.MustFormatSQL(sql, ")")
+		}
+		sqlexec.MustFormatSQL(sql, " ORDER BY ")
+		writeColumns(sql, keyCols)
+		sqlexec.MustFormatSQL(sql, " LIMIT %?", j.params.scanBatchSize)
+		stmt, err := exec.ParseWithParams(ctx, sql.String())
+		if err != nil {
+			return err
+		}
+		rows, fields, err := exec.ExecRestrictedStmt(ctx, stmt)
+		if err != nil {
+			return err
+		}
+		keys := make([][]interface{}, 0, len(rows))
+		for _, row := range rows {
+			keys = append(keys, rowKey(row, fields))
+		}
+		j.scannedRows += uint64(len(keys))
+		metrics.TTLRowsCounter.WithLabelValues(metrics.TTLRowsScanned).Add(float64(len(keys)))
+
+		for start := 0; start < len(keys); start += j.params.deleteBatchSize {
+			end := start + j.params.deleteBatchSize
+			if end > len(keys) {
+				end = len(keys)
+			}
+			if err := j.limiter.wait(ctx, end-start); err != nil {
+				return err
+			}
+			j.delete(ctx, exec, keyCols, keys[start:end])
+		}
+		if len(keys) < j.params.scanBatchSize {
+			return nil
+		}
+		lastKey = keys[len(keys)-1]
+	}
+}
+
+// delete deletes the rows of keys in a transaction. The TTL column is checked again, so the
+// rows updated after the scan are kept. The rows failed to delete are left to the next job.
+func (j *job) delete(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, keyCols []string, keys [][]interface{}) {
+	sql := new(strings.Builder)
+	sqlexec.MustFormatSQL(sql, "DELETE LOW_PRIORITY FROM %n.%n WHERE (", j.schema.O, j.tbl.Name.O)
+	writeColumns(sql, keyCols)
+	sqlexec.MustFormatSQL(sql, ") IN (")
+	for i, key := range keys {
+		if i > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		sqlexec.MustFormatSQL(sql, "(")
+		writeValues(sql, key)
+		sqlexec.MustFormatSQL(sql, ")")
+	}
+	sqlexec.MustFormatSQL(sql, ") AND %n < %?", j.tbl.TTLInfo.ColumnName.O, j.expireTime)
+	stmt, err := exec.ParseWithParams(ctx, sql.String())
+	if err == nil {
+		_, _, err = exec.ExecRestrictedStmt(ctx, stmt)
+	}
+	if err != nil {
+		logutil.BgLogger().Warn("[ttl] delete expired rows failed", zap.String("jobID", j.id), zap.Int("rows", len(keys)), zap.Error(err))
+		j.failedDelete += uint64(len(keys))
+		metrics.TTLRowsCounter.WithLabelValues(metrics.TTLRowsFailed).Add(float64(len(keys)))
+		return
+	}
+	// The deleted rows are counted by the scanned keys, the rows updated or deleted after
+	// the scan are counted too.
+	j.deletedRows += uint64(len(keys))
+	metrics.TTLRowsCounter.WithLabelValues(metrics.TTLRowsDeleted).Add(float64(len(keys)))
+}
+
+// keyColumns returns the columns of the handle, which are the columns to scan and delete rows by.
+func keyColumns(tbl *model.TableInfo) []string {
+	if tbl.PKIsHandle {
+		for _, col := range tbl.Columns {
+			if mysql.HasPriKeyFlag(col.Flag) {
+				return []string{col.Name.O}
+			}
+		}
+	}
+	if tbl.IsCommonHandle {
+		idx := tables.FindPrimaryIndex(tbl)
+		cols := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			cols = append(cols, col.Name.O)
+		}
+		return cols
+	}
+	return []string{model.ExtraHandleName.O}
+}
+
+func rowKey(row chunk.Row, fields []*ast.ResultField) []interface{} {
+	key := make([]interface{}, 0, len(fields))
+	for i, field := range fields {
+		d := row.GetDatum(i, &field.Column.FieldType)
+		switch d.Kind() {
+		case types.KindInt64:
+			key = append(key, d.GetInt64())
+		case types.KindUint64:
+			key = append(key, d.GetUint64())
+		case types.KindBytes:
+			key = append(key, d.GetBytes())
+		default:
+			s, _ := d.ToString()
+			key = append(key, s)
+		}
+	}
+	return key
+}
+
+func writeColumns(sql *strings.Builder, cols []string) {
+	for i, col := range cols {
+		if i > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		sqlexec.MustFormatSQL(sql, "%n", col)
+	}
+}
+
+func writeValues(sql *strings.Builder, vals []interface{}) {
+	for i, val := range vals {
+		if i > 0 {
+			sqlexec.MustFormatSQL(sql, ", ")
+		}
+		sqlexec.MustFormatSQL(sql, "%?", val)
+	}
+}
+
+// rateLimiter limits the rows deleted per second, 0 means no limit.
+type rateLimiter struct {
+	limit     int64
+	startTime time.Time
+	rows      int64
+}
+
+func newRateLimiter(limit int64) *rateLimiter {
+	return &rateLimiter{limit: limit, startTime: time.Now()}
+}
+
+// wait waits until rows more rows can be deleted.
+func (l *rateLimiter) wait(ctx context.Context, rows int) error {
+	if l.limit <= 0 {
+		return nil
+	}
+	l.rows += int64(rows)
+	d := time.Duration(float64(l.rows)/float64(l.limit)*float64(time.Second)) - time.Since(l.startTime)
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/owner"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/timeutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// CheckInterval is the interval to check whether there are TTL jobs to run.
+	CheckInterval = time.Minute
+	// historyRetention is the duration to keep the finished jobs in mysql.tidb_ttl_job_history.
+	historyRetention = 90 * 24 * time.Hour
+)
+
+// JobManager runs the jobs which delete the expired rows of the tables with TTL. Only the
+// manager on the DDL owner runs jobs, one table after another. The jobs of a table aren't
+// split into tasks and distributed to other TiDB instances yet.
+type JobManager struct {
+	sctx  sessionctx.Context
+	is    func() infoschema.InfoSchema
+	owner owner.Manager
+	// wasOwner is whether the manager was the owner when it checked the jobs last time.
+	// The running jobs in the history are left by the former owner when it becomes the owner.
+	wasOwner bool
+}
+
+// NewJobManager creates a JobManager. The internal SQLs run in sctx, is returns the latest
+// information schema, and the jobs are only run when owner is the owner.
+func NewJobManager(sctx sessionctx.Context, is func() infoschema.InfoSchema, owner owner.Manager) *JobManager {
+	return &JobManager{
+		sctx:  sctx,
+		is:    is,
+		owner: owner,
+	}
+}
+
+// jobParams are the global variables of TTL jobs.
+type jobParams struct {
+	enable          bool
+	interval        time.Duration
+	windowStart     time.Time
+	windowEnd       time.Time
+	scanBatchSize   int
+	deleteBatchSize int
+	deleteRateLimit int64
+}
+
+func loadJobParams(exec sqlexec.RestrictedSQLExecutor) (*jobParams, error) {
+	rows, err := execRestrictedSQL(context.TODO(), exec, "SELECT variable_name, variable_value FROM mysql.global_variables WHERE variable_name IN (%?, %?, %?, %?, %?, %?, %?)",
+		variable.TiDBTTLJobEnable, variable.TiDBTTLJobInterval, variable.TiDBTTLJobScheduleWindowStartTime, variable.TiDBTTLJobScheduleWindowEndTime,
+		variable.TiDBTTLScanBatchSize, variable.TiDBTTLDeleteBatchSize, variable.TiDBTTLDeleteRateLimit)
+	if err != nil {
+		return nil, err
+	}
+	vals := map[string]string{
+		variable.TiDBTTLJobEnable:                  variable.BoolToOnOff(variable.DefTiDBTTLJobEnable),
+		variable.TiDBTTLJobInterval:                variable.DefTiDBTTLJobInterval,
+		variable.TiDBTTLJobScheduleWindowStartTime: variable.DefTiDBTTLJobScheduleWindowStartTime,
+		variable.TiDBTTLJobScheduleWindowEndTime:   variable.DefTiDBTTLJobScheduleWindowEndTime,
+		variable.TiDBTTLScanBatchSize:              strconv.Itoa(variable.DefTiDBTTLScanBatchSize),
+		variable.TiDBTTLDeleteBatchSize:            strconv.Itoa(variable.DefTiDBTTLDeleteBatchSize),
+		variable.TiDBTTLDeleteRateLimit:            strconv.Itoa(variable.DefTiDBTTLDeleteRateLimit),
+	}
+	for _, row := range rows {
+		vals[row.GetString(0)] = row.GetString(1)
+	}
+	params := &jobParams{enable: variable.TiDBOptOn(vals[variable.TiDBTTLJobEnable])}
+	if params.interval, err = time.ParseDuration(vals[variable.TiDBTTLJobInterval]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.windowStart, err = time.ParseInLocation(variable.FullDayTimeFormat, vals[variable.TiDBTTLJobScheduleWindowStartTime], time.UTC); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.windowEnd, err = time.ParseInLocation(variable.FullDayTimeFormat, vals[variable.TiDBTTLJobScheduleWindowEndTime], time.UTC); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.scanBatchSize, err = strconv.Atoi(vals[variable.TiDBTTLScanBatchSize]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.deleteBatchSize, err = strconv.Atoi(vals[variable.TiDBTTLDeleteBatchSize]); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if params.deleteRateLimit, err = strconv.ParseInt(vals[variable.TiDBTTLDeleteRateLimit], 10, 64); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return params, nil
+}
+
+// CheckAndRunJobs runs a job for every enabled TTL table whose last job started more than
+// tidb_ttl_job_interval ago. It returns when all the jobs finish, ctx is canceled or the
+// manager isn't the owner any more.
+func (m *JobManager) CheckAndRunJobs(ctx context.Context) error {
+	if !m.owner.IsOwner() {
+		m.wasOwner = false
+		return nil
+	}
+	exec := m.sctx.(sqlexec.RestrictedSQLExecutor)
+	if !m.wasOwner {
+		// The jobs of the manager always finish before it checks again, so the running jobs
+		// are left by the former owner and nobody runs them.
+		_, err := execRestrictedSQL(ctx, exec, "UPDATE mysql.tidb_ttl_job_history SET status = %?, message = %?, finish_time = NOW(3) WHERE status = %?",
+			jobStatusFailed, "the owner is changed", jobStatusRunning)
+		if err != nil {
+			return err
+		}
+		m.wasOwner = true
+	}
+	params, err := loadJobParams(exec)
+	if err != nil {
+		return err
+	}
+	if !params.enable || !timeutil.WithinDayTimePeriod(params.windowStart, params.windowEnd, time.Now()) {
+		return nil
+	}
+	_, err = execRestrictedSQL(ctx, exec, "DELETE FROM mysql.tidb_ttl_job_history WHERE create_time < NOW() - INTERVAL %? SECOND", int64(historyRetention/time.Second))
+	if err != nil {
+		return err
+	}
+	rows, err := execRestrictedSQL(ctx, exec, "SELECT DISTINCT table_id FROM mysql.tidb_ttl_job_history WHERE create_time > NOW() - INTERVAL %? SECOND", int64(params.interval/time.Second))
+	if err != nil {
+		return err
+	}
+	recent := make(map[int64]struct{}, len(rows))
+	for _, row := range rows {
+		recent[row.GetInt64(0)] = struct{}{}
+	}
+
+	limiter := newRateLimiter(params.deleteRateLimit)
+	for _, db := range m.is().AllSchemas() {
+		if util.IsMemOrSysDB(db.Name.L) {
+			continue
+		}
+		for _, tbl := range db.Tables {
+			if tbl.TTLInfo == nil || !tbl.TTLInfo.Enable {
+				continue
+			}
+			if _, ok := recent[tbl.ID]; ok {
+				continue
+			}
+			if ctx.Err() != nil || !m.owner.IsOwner() {
+				return nil
+			}
+			j := newJob(db.Name, tbl, params, limiter)
+			if err := j.run(ctx, exec, m.owner); err != nil {
+				logutil.BgLogger().Warn("[ttl] run job failed", zap.String("jobID", j.id),
+					zap.String("table", db.Name.O+"."+tbl.Name.O), zap.Error(err))
+			}
+		}
+	}
+	return nil
+}
+
+func execRestrictedSQL(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, sql string, args ...interface{}) ([]chunk.Row, error) {
+	stmt, err := exec.ParseWithParams(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	rows, _, err := exec.ExecRestrictedStmt(ctx, stmt)
+	return rows, err
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttl_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/ttl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunJobs(t *testing.T) {
+	store, dom, clean := testkit.CreateMockStoreAndDomain(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set global tidb_ttl_scan_batch_size = 3")
+	tk.MustExec("set global tidb_ttl_delete_batch_size = 2")
+	tk.MustExec("create table t_int (id int primary key, created_at datetime) ttl = created_at + interval 1 day")
+	tk.MustExec("create table t_clustered (a varchar(10), b int, created_at timestamp, primary key (a, b) clustered) ttl = `created_at` + interval 1 day")
+	tk.MustExec("create table t_rowid (a int, created_at date) ttl = created_at + interval 1 day")
+	tk.MustExec("create table t_part (id int primary key, created_at datetime) ttl = created_at + interval 1 day partition by hash(id) partitions 4")
+	tk.MustExec("create table t_disabled (id int primary key, created_at datetime) ttl = created_at + interval 1 day ttl_enable = 'off'")
+	for i := 0; i < 10; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t_int values (%d, now() - interval 2 day), (%d, now())", i, i+100))
+		tk.MustExec(fmt.Sprintf("insert into t_clustered values ('%d', %d, now() - interval 2 day), ('%d', %d, now())", i, i, i+100, i))
+		tk.MustExec("insert into t_rowid values (1, now() - interval 2 day), (2, now())")
+		tk.MustExec(fmt.Sprintf("insert into t_part values (%d, now() - interval 2 day), (%d, now())", i, i+100))
+		tk.MustExec(fmt.Sprintf("insert into t_disabled values (%d, now() - interval 2 day)", i))
+	}
+	// The running jobs left by the former owner are marked failed.
+	tk.MustExec("insert into mysql.tidb_ttl_job_history (job_id, table_id, table_schema, table_name, expire_time, create_time, status) " +
+		"values ('stale', 0, 'test', 't', now(), now() - interval 2 day, 'running')")
+
+	m := ttl.NewJobManager(tk.Session(), dom.InfoSchema, dom.DDL().OwnerManager())
+	require.NoError(t, m.CheckAndRunJobs(context.Background()))
+	tk.MustQuery("select count(*), min(id) from t_int").Check(testkit.Rows("10 100"))
+	tk.MustQuery("select count(*), min(a) from t_clustered").Check(testkit.Rows("10 100"))
+	tk.MustQuery("select count(*), min(a) from t_rowid").Check(testkit.Rows("10 2"))
+	tk.MustQuery("select count(*), min(id) from t_part").Check(testkit.Rows("10 100"))
+	tk.MustQuery("select count(*) from t_disabled").Check(testkit.Rows("10"))
+	tk.MustQuery("select table_name, scanned_rows, deleted_rows, error_delete_rows, status from information_schema.tidb_ttl_job_history order by table_name").Check(testkit.Rows(
+		"t 0 0 0 failed",
+		"t_clustered 10 10 0 finished",
+		"t_int 10 10 0 finished",
+		"t_part 10 10 0 finished",
+		"t_rowid 10 10 0 finished",
+	))
+
+	// The tables whose last job started within tidb_ttl_job_interval are skipped.
+	tk.MustExec("insert into t_int values (1, now() - interval 2 day)")
+	require.NoError(t, m.CheckAndRunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t_int").Check(testkit.Rows("11"))
+	tk.MustExec("update mysql.tidb_ttl_job_history set create_time = now() - interval 2 hour")
+	tk.MustExec("set global tidb_ttl_job_enable = off")
+	require.NoError(t, m.CheckAndRunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t_int").Check(testkit.Rows("11"))
+	tk.MustExec("set global tidb_ttl_job_enable = on")
+	tk.MustExec("set global tidb_ttl_delete_rate_limit = 100")
+	require.NoError(t, m.CheckAndRunJobs(context.Background()))
+	tk.MustQuery("select count(*) from t_int").Check(testkit.Rows("10"))
+	tk.MustQuery("select count(*) from information_schema.tidb_ttl_job_history where table_name = 't_int'").Check(testkit.Rows("2"))
+
+	// Only the jobs of the tables with privileges are shown.
+	tk.MustExec("create user ttl_tester")
+	tk.MustExec("grant select on test.t_int to ttl_tester")
+	tester := testkit.NewTestKit(t, store)
+	require.True(t, tester.Session().Auth(&auth.UserIdentity{Username: "ttl_tester", Hostname: "%"}, nil, nil))
+	tester.MustQuery("select distinct table_name from information_schema.tidb_ttl_job_history").Check(testkit.Rows("t_int"))
+}
@@ -111,6 +111,8 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionAddTablePartition:
 		return job.SchemaState == model.StateNone || job.SchemaState == model.StateReplicaOnly
+	case model.ActionFlashbackTable, model.ActionFlashbackCluster:
+		return job.SchemaState == model.StateNone || job.SchemaState == model.StateWriteOnly
 	case model.ActionDropColumn, model.ActionDropColumns, model.ActionDropTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionTruncateTable, model.ActionAddForeignKey,
@@ -170,6 +172,11 @@ func CancelJobs(txn kv.Transaction, ids []int64) ([]error, error) {
 			}
 
 			job.State = model.JobStateCancelling
+			// The paused job should be run to be rolled back.
+			if err := t.RemoveDDLJobControl(job.ID); err != nil {
+				errs[i] = errors.Trace(err)
+				continue
+			}
 			// Make sure RawArgs isn't overwritten.
 			err := json.Unmarshal(job.RawArgs, &job.Args)
 			if err != nil {
@@ -193,6 +200,109 @@ func CancelJobs(txn kv.Transaction, ids []int64) ([]error, error) {
 	return errs, nil
 }
 
+// PauseJobs pauses the running reorganization DDL jobs. The backfill progress is kept,
+// and the jobs continue from it after they are resumed by ResumeJobs.
+func PauseJobs(txn kv.Transaction, ids []int64) ([]error, error) {
+	return updateJobControls(txn, ids, func(job *model.Job, ctl *meta.DDLJobControl) error {
+		if !isJobControllable(job) {
+			return ErrCannotPauseDDLJob.GenWithStackByArgs(job.ID)
+		}
+		ctl.Paused = true
+		return nil
+	})
+}
+
+// ResumeJobs resumes the paused DDL jobs.
+func ResumeJobs(txn kv.Transaction, ids []int64) ([]error, error) {
+	return updateJobControls(txn, ids, func(job *model.Job, ctl *meta.DDLJobControl) error {
+		if !ctl.Paused {
+			return ErrDDLJobNotPaused.GenWithStackByArgs(job.ID)
+		}
+		ctl.Paused = false
+		return nil
+	})
+}
+
+// AlterJobs changes the count of the backfill workers and the backfill batch size of the running
+// reorganization DDL jobs, the values which are not positive are left unchanged.
+func AlterJobs(txn kv.Transaction, ids []int64, thread, batchSize int) ([]error, error) {
+	return updateJobControls(txn, ids, func(job *model.Job, ctl *meta.DDLJobControl) error {
+		if !isJobControllable(job) {
+			return ErrCannotPauseDDLJob.GenWithStackByArgs(job.ID)
+		}
+		if thread > 0 {
+			ctl.Thread = thread
+		}
+		if batchSize > 0 {
+			ctl.BatchSize = batchSize
+		}
+		return nil
+	})
+}
+
+// GetJobControl gets the control options of a DDL job, it returns nil if there are none.
+func GetJobControl(txn kv.Transaction, id int64) (*meta.DDLJobControl, error) {
+	ctl, err := meta.NewMeta(txn).GetDDLJobControl(id)
+	return ctl, errors.Trace(err)
+}
+
+// isJobControllable checks whether the job is a running reorganization job, only these jobs
+// can be paused or altered.
+func isJobControllable(job *model.Job) bool {
+	if !MayNeedBackfill(job.Type) {
+		return false
+	}
+	return !job.IsFinished() && !job.IsSynced() && !job.IsCancelling() && !job.IsRollingback()
+}
+
+func updateJobControls(txn kv.Transaction, ids []int64, update func(job *model.Job, ctl *meta.DDLJobControl) error) ([]error, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	errs := make([]error, len(ids))
+	t := meta.NewMeta(txn)
+	generalJobs, err := getDDLJobsInQueue(t, meta.DefaultJobListKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addIdxJobs, err := getDDLJobsInQueue(t, meta.AddIndexJobListKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	jobs := append(generalJobs, addIdxJobs...)
+
+	for i, id := range ids {
+		var job *model.Job
+		for _, j := range jobs {
+			if j.ID == id {
+				job = j
+				break
+			}
+		}
+		if job == nil {
+			errs[i] = ErrDDLJobNotFound.GenWithStackByArgs(id)
+			continue
+		}
+		ctl, err := t.GetDDLJobControl(id)
+		if err != nil {
+			errs[i] = errors.Trace(err)
+			continue
+		}
+		if ctl == nil {
+			ctl = &meta.DDLJobControl{}
+		}
+		if err = update(job, ctl); err != nil {
+			errs[i] = err
+			continue
+		}
+		if err = t.SetDDLJobControl(id, ctl); err != nil {
+			errs[i] = errors.Trace(err)
+		}
+	}
+	return errs, nil
+}
+
 func getDDLJobsInQueue(t *meta.Meta, jobListKey meta.JobListKeyType) ([]*model.Job, error) {
 	cnt, err := t.DDLJobQueueLen(jobListKey)
 	if err != nil {
@@ -497,6 +607,10 @@ var (
 	ErrCancelFinishedDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCancelFinishedDDLJob)
 	// ErrCannotCancelDDLJob returns when cancel a almost finished ddl job, because cancel in now may cause data inconsistency.
 	ErrCannotCancelDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCannotCancelDDLJob)
+	// ErrCannotPauseDDLJob returns when pause or alter a ddl job which doesn't do reorganization.
+	ErrCannotPauseDDLJob = dbterror.ClassAdmin.NewStd(errno.ErrCannotPauseDDLJob)
+	// ErrDDLJobNotPaused returns when resume a ddl job which isn't paused.
+	ErrDDLJobNotPaused = dbterror.ClassAdmin.NewStd(errno.ErrDDLJobNotPaused)
 	// ErrAdminCheckTable returns when the table records is inconsistent with the index values.
 	ErrAdminCheckTable = dbterror.ClassAdmin.NewStd(errno.ErrAdminCheckTable)
 )
@@ -279,6 +279,70 @@ func TestCancelJobs(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPauseResumeAlterJobs(t *testing.T) {
+	t.Parallel()
+
+	store, clean := newMockStore(t)
+	defer clean()
+
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, txn.Rollback())
+	}()
+
+	m := meta.NewMeta(txn)
+	addIdxJob := &model.Job{ID: 1, SchemaID: 1, TableID: 2, Type: model.ActionAddIndex, State: model.JobStateRunning}
+	createTblJob := &model.Job{ID: 2, SchemaID: 1, Type: model.ActionCreateTable}
+	rollbackJob := &model.Job{ID: 3, SchemaID: 1, TableID: 2, Type: model.ActionModifyColumn, State: model.JobStateRollingback}
+	require.NoError(t, m.EnQueueDDLJob(addIdxJob, meta.AddIndexJobListKey))
+	require.NoError(t, m.EnQueueDDLJob(createTblJob))
+	require.NoError(t, m.EnQueueDDLJob(rollbackJob))
+
+	errs, err := PauseJobs(txn, []int64{1, 2, 3, -1})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Regexp(t, ".*This job:2 can't be paused or altered", errs[1].Error())
+	require.Regexp(t, ".*This job:3 can't be paused or altered", errs[2].Error())
+	require.Regexp(t, ".*DDL Job:-1 not found", errs[3].Error())
+	ctl, err := GetJobControl(txn, 1)
+	require.NoError(t, err)
+	require.Equal(t, &meta.DDLJobControl{Paused: true}, ctl)
+
+	errs, err = AlterJobs(txn, []int64{1, 2}, 4, 0)
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Error(t, errs[1])
+	errs, err = AlterJobs(txn, []int64{1}, 0, 256)
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	ctl, err = GetJobControl(txn, 1)
+	require.NoError(t, err)
+	require.Equal(t, &meta.DDLJobControl{Paused: true, Thread: 4, BatchSize: 256}, ctl)
+
+	errs, err = ResumeJobs(txn, []int64{1, 2})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	require.Regexp(t, ".*This job:2 is not paused", errs[1].Error())
+	ctl, err = GetJobControl(txn, 1)
+	require.NoError(t, err)
+	require.Equal(t, &meta.DDLJobControl{Thread: 4, BatchSize: 256}, ctl)
+	errs, err = ResumeJobs(txn, []int64{1})
+	require.NoError(t, err)
+	require.True(t, ErrDDLJobNotPaused.Equal(errs[0]))
+
+	// The control options are removed when the job is cancelled.
+	errs, err = PauseJobs(txn, []int64{1})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	errs, err = CancelJobs(txn, []int64{1})
+	require.NoError(t, err)
+	require.NoError(t, errs[0])
+	ctl, err = GetJobControl(txn, 1)
+	require.NoError(t, err)
+	require.Nil(t, ctl)
+}
+
 func TestGetHistoryDDLJobs(t *testing.T) {
 	t.Parallel()
 
@@ -371,6 +435,8 @@ func TestError(t *testing.T) {
 		ErrDDLJobNotFound,
 		ErrCancelFinishedDDLJob,
 		ErrCannotCancelDDLJob,
+		ErrCannotPauseDDLJob,
+		ErrDDLJobNotPaused,
 	}
 	for _, err := range kvErrs {
 		code := terror.ToSQLError(err).Code
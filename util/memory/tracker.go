@@ -552,4 +552,10 @@ const (
 	LabelForSimpleTask int = -18
 	// LabelForCTEStorage represents the label of CTE storage
 	LabelForCTEStorage int = -19
+	// LabelForTemporaryTableData represents the label of the session data of local temporary tables
+	LabelForTemporaryTableData int = -20
+	// LabelForIndexMergeHandles represents the label of the handles collected by the IndexMerge intersection
+	LabelForIndexMergeHandles int = -21
+	// LabelForSession represents the label of the data living across the statements of a session
+	LabelForSession int = -22
 )
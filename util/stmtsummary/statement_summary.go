@@ -367,7 +367,7 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 		func() {
 			ssbd.Lock()
 			defer ssbd.Unlock()
-			if ssbd.initialized && (ssbd.stmtType == "Select" || ssbd.stmtType == "Delete" || ssbd.stmtType == "Update" || ssbd.stmtType == "Insert" || ssbd.stmtType == "Replace") {
+			if ssbd.initialized && isBindableStmtType(ssbd.stmtType) {
 				if ssbd.history.Len() > 0 {
 					ssElement := ssbd.history.Back().Value.(*stmtSummaryByDigestElement)
 					ssElement.Lock()
@@ -396,6 +396,75 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 	return stmts
 }
 
+// BindablePlan is the execution statistics of one plan of a bindable statement, which are summarized
+// in all the intervals in the history.
+type BindablePlan struct {
+	BindableStmt
+	Digest     string
+	PlanDigest string
+	ExecCount  int64
+	SumLatency time.Duration
+	FirstSeen  time.Time
+	LastSeen   time.Time
+}
+
+// GetBindablePlans gets the plans of users' bindable SQLs that executed at least the specified count.
+// Different plans of one SQL are returned separately, so they can be compared with each other.
+func (ssMap *stmtSummaryByDigestMap) GetBindablePlans(cnt int64) []*BindablePlan {
+	ssMap.Lock()
+	values := ssMap.summaryMap.Values()
+	ssMap.Unlock()
+
+	plans := make([]*BindablePlan, 0, len(values))
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		func() {
+			ssbd.Lock()
+			defer ssbd.Unlock()
+			if !ssbd.initialized || ssbd.isInternal || !isBindableStmtType(ssbd.stmtType) || ssbd.history.Len() == 0 {
+				return
+			}
+			plan := &BindablePlan{
+				BindableStmt: BindableStmt{Schema: ssbd.schemaName},
+				Digest:       ssbd.digest,
+				PlanDigest:   ssbd.planDigest,
+			}
+			hasUser := false
+			for e := ssbd.history.Front(); e != nil; e = e.Next() {
+				ssElement := e.Value.(*stmtSummaryByDigestElement)
+				ssElement.Lock()
+				// Empty auth users means that it is an internal queries.
+				hasUser = hasUser || len(ssElement.authUsers) > 0
+				plan.ExecCount += ssElement.execCount
+				plan.SumLatency += ssElement.sumLatency
+				if plan.FirstSeen.IsZero() || ssElement.firstSeen.Before(plan.FirstSeen) {
+					plan.FirstSeen = ssElement.firstSeen
+				}
+				if ssElement.lastSeen.After(plan.LastSeen) {
+					plan.LastSeen = ssElement.lastSeen
+				}
+				// The latest element decides the sample.
+				plan.Query = ssElement.sampleSQL
+				plan.PlanHint = ssElement.planHint
+				plan.Charset = ssElement.charset
+				plan.Collation = ssElement.collation
+				if ssElement.prepared {
+					plan.Query = ssbd.normalizedSQL
+				}
+				ssElement.Unlock()
+			}
+			if hasUser && plan.ExecCount >= cnt && plan.PlanDigest != "" {
+				plans = append(plans, plan)
+			}
+		}()
+	}
+	return plans
+}
+
+func isBindableStmtType(stmtType string) bool {
+	return stmtType == "Select" || stmtType == "Delete" || stmtType == "Update" || stmtType == "Insert" || stmtType == "Replace"
+}
+
 // SetEnabled enables or disables statement summary in global(cluster) or session(server) scope.
 func (ssMap *stmtSummaryByDigestMap) SetEnabled(value string, inSession bool) error {
 	if err := ssMap.sysVars.setVariable(typeEnable, value, inSession); err != nil {
@@ -17,6 +17,7 @@ package stmtsummary
 import (
 	"container/list"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -1156,6 +1157,50 @@ func TestGetMoreThanCntBindableStmt(t *testing.T) {
 	require.Equal(t, 1, len(stmts))
 }
 
+// Test GetBindablePlans.
+func TestGetBindablePlans(t *testing.T) {
+	t.Parallel()
+	ssMap := newStmtSummaryByDigestMap()
+
+	stmtExecInfo1 := generateAnyExecInfo()
+	stmtExecInfo1.NormalizedSQL = "select ?"
+	stmtExecInfo1.StmtCtx.StmtType = "Select"
+	ssMap.AddStatement(stmtExecInfo1)
+	plans := ssMap.GetBindablePlans(2)
+	require.Equal(t, 0, len(plans))
+
+	ssMap.AddStatement(stmtExecInfo1)
+	stmtExecInfo2 := generateAnyExecInfo()
+	stmtExecInfo2.NormalizedSQL = "select ?"
+	stmtExecInfo2.StmtCtx.StmtType = "Select"
+	stmtExecInfo2.PlanDigest = "plan_digest2"
+	stmtExecInfo2.TotalLatency = 30000
+	ssMap.AddStatement(stmtExecInfo2)
+	ssMap.AddStatement(stmtExecInfo2)
+	plans = ssMap.GetBindablePlans(2)
+	require.Equal(t, 2, len(plans))
+	sort.Slice(plans, func(i, j int) bool { return plans[i].PlanDigest < plans[j].PlanDigest })
+	require.Equal(t, "digest", plans[0].Digest)
+	require.Equal(t, "plan_digest", plans[0].PlanDigest)
+	require.Equal(t, int64(2), plans[0].ExecCount)
+	require.Equal(t, 20000*time.Nanosecond, plans[0].SumLatency)
+	require.Equal(t, "digest", plans[1].Digest)
+	require.Equal(t, "plan_digest2", plans[1].PlanDigest)
+	require.Equal(t, 60000*time.Nanosecond, plans[1].SumLatency)
+	require.Equal(t, stmtExecInfo1.OriginalSQL, plans[1].Query)
+
+	// Internal queries are not bindable.
+	stmtExecInfo3 := generateAnyExecInfo()
+	stmtExecInfo3.Digest = "digest3"
+	stmtExecInfo3.StmtCtx.StmtType = "Select"
+	stmtExecInfo3.IsInternal = true
+	stmtExecInfo3.User = ""
+	ssMap.AddStatement(stmtExecInfo3)
+	ssMap.AddStatement(stmtExecInfo3)
+	plans = ssMap.GetBindablePlans(2)
+	require.Equal(t, 2, len(plans))
+}
+
 // Test `formatBackoffTypes`.
 func TestFormatBackoffTypes(t *testing.T) {
 	t.Parallel()